@@ -3,6 +3,7 @@ package main
 import (
 	"fmt"
 	"os"
+	"strconv"
 	"strings"
 
 	"github.com/jmoiron/sqlx"
@@ -17,6 +18,7 @@ func usage() {
 	fmt.Println()
 	fmt.Println("    add, a           Add a new account")
 	fmt.Println("    password, p      Change an existing account's password")
+	fmt.Println("    gm, g            Set an account's GM level")
 	fmt.Println()
 }
 
@@ -28,6 +30,17 @@ func passwordUsage() {
 	fmt.Println("usage:", os.Args[1], "<username> <password>")
 }
 
+func gmUsage() {
+	fmt.Println("usage:", os.Args[1], "<username> <level>")
+	fmt.Println()
+	fmt.Println("levels")
+	fmt.Println()
+	fmt.Println("    0    Player")
+	fmt.Println("    1    Moderator")
+	fmt.Println("    2    Game master")
+	fmt.Println("    3    Administrator")
+}
+
 func main() {
 	db, err := sqlx.Connect(
 		"postgres",
@@ -128,6 +141,40 @@ func main() {
 			os.Exit(1)
 		}
 
+		fmt.Println("success")
+
+	case "gm", "g":
+		args := os.Args[2:]
+
+		if len(args) != 2 {
+			fmt.Println("error: expected 2 arguments")
+			gmUsage()
+			os.Exit(1)
+		}
+
+		username := strings.TrimSpace(args[0])
+		level, err := strconv.Atoi(args[1])
+		if err != nil || level < int(model.GMLevelPlayer) || level > int(model.GMLevelAdministrator) {
+			fmt.Println("error: invalid GM level")
+			gmUsage()
+			os.Exit(1)
+		}
+
+		account, err := accountsDb.Get(&model.AccountGetParams{Username: username})
+		if err != nil {
+			fmt.Println("failed to get account:", err)
+			os.Exit(1)
+		} else if account == nil {
+			fmt.Println("error: no account with that username exists")
+			os.Exit(1)
+		}
+
+		account.GMLevel = model.GMLevel(level)
+		if _, err := accountsDb.Update(account); err != nil {
+			fmt.Println("failed to update account:", err)
+			os.Exit(1)
+		}
+
 		fmt.Println("success")
 	}
 }
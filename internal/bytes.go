@@ -1,5 +1,10 @@
 package internal
 
+import (
+	"io"
+	"strings"
+)
+
 // Pad adds zero-bytes as padding to a byte array if the array is smaller than the given length.
 // If the array doesn't need padding, the original array is returned, otherwise a copy with padding
 // included is returned.
@@ -22,3 +27,24 @@ func Reverse(data []byte) []byte {
 	}
 	return newData
 }
+
+// ReadCString reads a null-terminated string from r. The null byte is consumed but isn't included in the
+// result. Returns io.ErrUnexpectedEOF if r ends before the null byte.
+func ReadCString(r io.ByteReader) (string, error) {
+	sb := strings.Builder{}
+
+	for {
+		b, err := r.ReadByte()
+		if err == io.EOF {
+			return "", io.ErrUnexpectedEOF
+		} else if err != nil {
+			return "", err
+		}
+
+		if b == 0 {
+			return sb.String(), nil
+		}
+
+		sb.WriteByte(b)
+	}
+}
@@ -1,6 +1,8 @@
 package internal
 
 import (
+	"bytes"
+	"io"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -16,3 +18,18 @@ func TestReverse(t *testing.T) {
 	assert.Equal(t, []byte{0, 1}, Reverse([]byte{1, 0}))
 	assert.Equal(t, []byte{0, 1, 2}, Reverse([]byte{2, 1, 0}))
 }
+
+func TestReadCString(t *testing.T) {
+	r := bytes.NewReader([]byte("abc\x00\x00de"))
+
+	s, err := ReadCString(r)
+	assert.NoError(t, err)
+	assert.Equal(t, "abc", s)
+
+	s, err = ReadCString(r)
+	assert.NoError(t, err)
+	assert.Equal(t, "", s)
+
+	_, err = ReadCString(r)
+	assert.ErrorIs(t, err, io.ErrUnexpectedEOF)
+}
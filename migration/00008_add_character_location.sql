@@ -0,0 +1,34 @@
+-- Characters remember where they are in the world and where their hearthstone is bound to.
+-- Existing characters are placed at the human starting location.
+
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE characters
+    ADD map         integer NOT NULL DEFAULT 0,
+    ADD zone        integer NOT NULL DEFAULT 12,
+    ADD position_x  real NOT NULL DEFAULT -8949.95,
+    ADD position_y  real NOT NULL DEFAULT -132.493,
+    ADD position_z  real NOT NULL DEFAULT 83.5312,
+    ADD orientation real NOT NULL DEFAULT 0,
+    ADD home_map    integer NOT NULL DEFAULT 0,
+    ADD home_zone   integer NOT NULL DEFAULT 12,
+    ADD home_x      real NOT NULL DEFAULT -8949.95,
+    ADD home_y      real NOT NULL DEFAULT -132.493,
+    ADD home_z      real NOT NULL DEFAULT 83.5312;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE characters
+    DROP map,
+    DROP zone,
+    DROP position_x,
+    DROP position_y,
+    DROP position_z,
+    DROP orientation,
+    DROP home_map,
+    DROP home_zone,
+    DROP home_x,
+    DROP home_y,
+    DROP home_z;
+-- +goose StatementEnd
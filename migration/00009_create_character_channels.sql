@@ -0,0 +1,16 @@
+-- Custom chat channels a character is a member of, so they can rejoin them on login.
+
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS character_channels (
+    character_id    integer NOT NULL REFERENCES characters ON DELETE CASCADE,
+    name            varchar(31) NOT NULL,
+    password        varchar(31) NOT NULL DEFAULT '',
+    PRIMARY KEY (character_id, name)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS character_channels;
+-- +goose StatementEnd
@@ -0,0 +1,25 @@
+-- Accounts have a GM level which controls what in-game commands they can use. Every command that is
+-- used is recorded in the command log.
+
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE accounts
+    ADD gm_level integer NOT NULL DEFAULT 0;
+
+CREATE TABLE IF NOT EXISTS command_log (
+    id              serial PRIMARY KEY,
+    created_at      timestamp NOT NULL DEFAULT now(),
+    account_id      integer NOT NULL REFERENCES accounts ON DELETE CASCADE,
+    character_id    integer REFERENCES characters ON DELETE SET NULL,
+    command         varchar(255) NOT NULL
+);
+CREATE INDEX IF NOT EXISTS command_log_account_idx ON command_log (account_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS command_log;
+
+ALTER TABLE accounts
+    DROP COLUMN gm_level;
+-- +goose StatementEnd
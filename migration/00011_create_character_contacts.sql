@@ -0,0 +1,18 @@
+-- Friends and ignored players. A character has one row per contact, and the flags say whether the
+-- contact is a friend, ignored or both.
+
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS character_contacts (
+    character_id    integer NOT NULL REFERENCES characters ON DELETE CASCADE,
+    contact_id      integer NOT NULL REFERENCES characters ON DELETE CASCADE,
+    flags           integer NOT NULL DEFAULT 0,
+    note            varchar(48) NOT NULL DEFAULT '',
+    PRIMARY KEY (character_id, contact_id)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS character_contacts;
+-- +goose StatementEnd
@@ -0,0 +1,42 @@
+-- Guilds, their ranks and their members. Each guild has between 5 and 10 ranks, with rank 0 being the
+-- guild master.
+
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS guilds (
+    id          serial PRIMARY KEY,
+    created_at  timestamp NOT NULL DEFAULT now(),
+    realm_id    integer NOT NULL REFERENCES realms (id),
+    name        varchar(24) NOT NULL,
+    leader_id   integer NOT NULL REFERENCES characters (id),
+    motd        varchar(127) NOT NULL DEFAULT '',
+    info        varchar(500) NOT NULL DEFAULT ''
+);
+CREATE UNIQUE INDEX IF NOT EXISTS guilds_name_realm_unique_idx ON guilds (lower(name), realm_id);
+
+CREATE TABLE IF NOT EXISTS guild_ranks (
+    guild_id        integer NOT NULL REFERENCES guilds ON DELETE CASCADE,
+    rank            smallint NOT NULL,
+    name            varchar(15) NOT NULL,
+    rights          integer NOT NULL DEFAULT 0,
+    money_per_day   bigint NOT NULL DEFAULT 0,
+    PRIMARY KEY (guild_id, rank)
+);
+
+CREATE TABLE IF NOT EXISTS guild_members (
+    character_id    integer PRIMARY KEY REFERENCES characters ON DELETE CASCADE,
+    guild_id        integer NOT NULL REFERENCES guilds ON DELETE CASCADE,
+    rank            smallint NOT NULL,
+    public_note     varchar(31) NOT NULL DEFAULT '',
+    officer_note    varchar(31) NOT NULL DEFAULT '',
+    joined_at       timestamp NOT NULL DEFAULT now()
+);
+CREATE INDEX IF NOT EXISTS guild_members_guild_idx ON guild_members (guild_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS guild_members;
+DROP TABLE IF EXISTS guild_ranks;
+DROP TABLE IF EXISTS guilds;
+-- +goose StatementEnd
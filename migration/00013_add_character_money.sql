@@ -0,0 +1,13 @@
+-- Characters keep the money they are carrying, in copper.
+
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE characters
+    ADD money bigint NOT NULL DEFAULT 0;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE characters
+    DROP money;
+-- +goose StatementEnd
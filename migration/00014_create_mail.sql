@@ -0,0 +1,36 @@
+-- Mail sent to characters. Mail is sent by players or by the server, e.g. auctions and GMs, in which case
+-- sender_id is the id of the creature or object that sent it. Items attached to a mail are stored in
+-- mail_items.
+
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS mail (
+    id              serial PRIMARY KEY,
+    created_at      timestamp NOT NULL DEFAULT now(),
+    expires_at      timestamp NOT NULL,
+    receiver_id     integer NOT NULL REFERENCES characters (id) ON DELETE CASCADE,
+    sender_id       integer NOT NULL DEFAULT 0,
+    message_type    smallint NOT NULL DEFAULT 0,
+    stationery      smallint NOT NULL DEFAULT 41,
+    subject         varchar(64) NOT NULL DEFAULT '',
+    body            varchar(500) NOT NULL DEFAULT '',
+    money           bigint NOT NULL DEFAULT 0,
+    cod             bigint NOT NULL DEFAULT 0,
+    flags           integer NOT NULL DEFAULT 0
+);
+CREATE INDEX IF NOT EXISTS mail_receiver_idx ON mail (receiver_id);
+
+CREATE TABLE IF NOT EXISTS mail_items (
+    id          serial PRIMARY KEY,
+    mail_id     integer NOT NULL REFERENCES mail ON DELETE CASCADE,
+    item_id     integer NOT NULL,
+    count       integer NOT NULL DEFAULT 1
+);
+CREATE INDEX IF NOT EXISTS mail_items_mail_idx ON mail_items (mail_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS mail_items;
+DROP TABLE IF EXISTS mail;
+-- +goose StatementEnd
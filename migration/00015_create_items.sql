@@ -0,0 +1,56 @@
+-- Item templates describe every kind of item, and items are the instances of them that characters own.
+-- An item is either in one of its owner's own slots (equipment, bags, backpack, bank, keyring), in which
+-- case container_id is 0, or in the bag with the id container_id.
+
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS item_templates (
+    id              integer PRIMARY KEY,
+    class           smallint NOT NULL DEFAULT 0,
+    subclass        smallint NOT NULL DEFAULT 0,
+    name            varchar(255) NOT NULL,
+    display_id      integer NOT NULL DEFAULT 0,
+    quality         smallint NOT NULL DEFAULT 0,
+    flags           bigint NOT NULL DEFAULT 0,
+    buy_price       bigint NOT NULL DEFAULT 0,
+    sell_price      bigint NOT NULL DEFAULT 0,
+    inventory_type  smallint NOT NULL DEFAULT 0,
+    allowable_class integer NOT NULL DEFAULT -1,
+    allowable_race  integer NOT NULL DEFAULT -1,
+    item_level      smallint NOT NULL DEFAULT 0,
+    required_level  smallint NOT NULL DEFAULT 0,
+    max_count       integer NOT NULL DEFAULT 0,
+    stackable       integer NOT NULL DEFAULT 1,
+    container_slots smallint NOT NULL DEFAULT 0,
+    armor           integer NOT NULL DEFAULT 0,
+    damage_min      real NOT NULL DEFAULT 0,
+    damage_max      real NOT NULL DEFAULT 0,
+    damage_type     smallint NOT NULL DEFAULT 0,
+    delay           integer NOT NULL DEFAULT 0,
+    bonding         smallint NOT NULL DEFAULT 0,
+    description     varchar(255) NOT NULL DEFAULT '',
+    material        integer NOT NULL DEFAULT 0,
+    sheath          smallint NOT NULL DEFAULT 0,
+    max_durability  integer NOT NULL DEFAULT 0,
+    bag_family      integer NOT NULL DEFAULT 0
+);
+
+CREATE TABLE IF NOT EXISTS items (
+    id              serial PRIMARY KEY,
+    created_at      timestamp NOT NULL DEFAULT now(),
+    owner_id        integer NOT NULL REFERENCES characters (id) ON DELETE CASCADE,
+    template_id     integer NOT NULL REFERENCES item_templates (id),
+    container_id    integer NOT NULL DEFAULT 0,
+    slot            smallint NOT NULL,
+    count           integer NOT NULL DEFAULT 1,
+    flags           integer NOT NULL DEFAULT 0,
+    durability      integer NOT NULL DEFAULT 0
+);
+CREATE INDEX IF NOT EXISTS items_owner_idx ON items (owner_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS items;
+DROP TABLE IF EXISTS item_templates;
+-- +goose StatementEnd
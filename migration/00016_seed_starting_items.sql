@@ -0,0 +1,30 @@
+-- The items in the human warrior and mage starting outfits, which new characters are given. Starting items
+-- for other races and classes are skipped until their templates are added.
+
+-- +goose Up
+-- +goose StatementBegin
+INSERT INTO item_templates
+    (id, class, subclass, name, display_id, quality, buy_price, sell_price, inventory_type, item_level,
+     max_count, stackable, armor, damage_min, damage_max, delay, bonding, material, sheath, max_durability)
+VALUES
+    (25,   2,  7,  'Worn Shortsword',         1542,  1, 35, 7, 13, 2, 0, 1,  0, 1, 3, 1900, 0, 1, 3, 20),
+    (35,   2,  10, 'Bent Staff',              472,   1, 47, 9, 17, 2, 0, 1,  0, 3, 5, 2900, 0, 2, 2, 25),
+    (38,   4,  0,  'Recruit''s Shirt',        9891,  1, 1,  1, 4,  1, 0, 1,  0, 0, 0, 0,    0, 7, 0, 0),
+    (39,   4,  1,  'Recruit''s Pants',        10141, 1, 5,  1, 7,  1, 0, 1,  2, 0, 0, 0,    0, 7, 0, 25),
+    (40,   4,  1,  'Recruit''s Boots',        10272, 1, 5,  1, 8,  1, 0, 1,  1, 0, 0, 0,    0, 7, 0, 16),
+    (55,   4,  1,  'Apprentice''s Boots',     9929,  1, 5,  1, 8,  1, 0, 1,  1, 0, 0, 0,    0, 7, 0, 16),
+    (56,   4,  1,  'Apprentice''s Robe',      12647, 1, 5,  1, 20, 1, 0, 1,  3, 0, 0, 0,    0, 7, 0, 35),
+    (117,  0,  0,  'Tough Jerky',             2473,  1, 25, 1, 0,  5, 0, 20, 0, 0, 0, 0,    0, 0, 0, 0),
+    (159,  0,  0,  'Refreshing Spring Water', 18084, 1, 25, 1, 0,  5, 0, 20, 0, 0, 0, 0,    0, 0, 0, 0),
+    (1395, 4,  1,  'Apprentice''s Pants',     9974,  1, 5,  1, 7,  1, 0, 1,  2, 0, 0, 0,    0, 7, 0, 25),
+    (2362, 4,  6,  'Worn Wooden Shield',      18730, 1, 7,  1, 14, 1, 0, 1,  5, 0, 0, 0,    0, 2, 4, 20),
+    (4536, 0,  0,  'Shiny Red Apple',         6410,  1, 25, 1, 0,  5, 0, 20, 0, 0, 0, 0,    0, 0, 0, 0),
+    (6948, 15, 0,  'Hearthstone',             6418,  1, 0,  0, 0,  1, 1, 1,  0, 0, 0, 0,    1, 0, 0, 0)
+ON CONFLICT (id) DO NOTHING;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DELETE FROM item_templates WHERE id IN (25, 35, 38, 39, 40, 55, 56, 117, 159, 1395, 2362, 4536, 6948)
+    AND NOT EXISTS (SELECT 1 FROM items WHERE items.template_id = item_templates.id);
+-- +goose StatementEnd
@@ -0,0 +1,26 @@
+-- The spells each character knows, and the spells that are on cooldown. A cooldown is saved when the
+-- character logs out and is dropped once it expires.
+
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS character_spells (
+    character_id    integer NOT NULL REFERENCES characters ON DELETE CASCADE,
+    spell_id        integer NOT NULL,
+    PRIMARY KEY (character_id, spell_id)
+);
+
+CREATE TABLE IF NOT EXISTS character_spell_cooldowns (
+    character_id        integer NOT NULL REFERENCES characters ON DELETE CASCADE,
+    spell_id            integer NOT NULL,
+    category            integer NOT NULL DEFAULT 0,
+    expires_at          timestamp NOT NULL,
+    category_expires_at timestamp NOT NULL,
+    PRIMARY KEY (character_id, spell_id)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS character_spell_cooldowns;
+DROP TABLE IF EXISTS character_spells;
+-- +goose StatementEnd
@@ -0,0 +1,26 @@
+-- The auras on each character that still have time left when the character logs out. The amounts are
+-- saved since some effects roll a random amount when they're applied.
+
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS character_auras (
+    character_id    integer NOT NULL REFERENCES characters ON DELETE CASCADE,
+    spell_id        integer NOT NULL,
+    caster_guid     bigint NOT NULL,
+    level           integer NOT NULL,
+    positive        boolean NOT NULL,
+    stacks          smallint NOT NULL DEFAULT 1,
+    charges         smallint NOT NULL DEFAULT 0,
+    amount1         integer NOT NULL DEFAULT 0,
+    amount2         integer NOT NULL DEFAULT 0,
+    amount3         integer NOT NULL DEFAULT 0,
+    remaining       integer NOT NULL,
+    max_duration    integer NOT NULL,
+    PRIMARY KEY (character_id, spell_id, caster_guid)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS character_auras;
+-- +goose StatementEnd
@@ -0,0 +1,25 @@
+-- The action bar buttons for each of a character's talent specs, and which of the extra action bars the
+-- character has turned on.
+
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS character_actions (
+    character_id    integer NOT NULL REFERENCES characters ON DELETE CASCADE,
+    spec            smallint NOT NULL DEFAULT 0,
+    button          smallint NOT NULL,
+    action          integer NOT NULL,
+    type            smallint NOT NULL,
+    PRIMARY KEY (character_id, spec, button)
+);
+
+ALTER TABLE characters
+    ADD action_bars smallint NOT NULL DEFAULT 0;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE characters
+    DROP action_bars;
+
+DROP TABLE IF EXISTS character_actions;
+-- +goose StatementEnd
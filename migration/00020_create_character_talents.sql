@@ -0,0 +1,39 @@
+-- The talents and glyphs for each of a character's talent specs, and what the character last paid to reset
+-- their talents.
+
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS character_talents (
+    character_id    integer NOT NULL REFERENCES characters ON DELETE CASCADE,
+    spec            smallint NOT NULL DEFAULT 0,
+    talent_id       integer NOT NULL,
+    rank            smallint NOT NULL,
+    PRIMARY KEY (character_id, spec, talent_id)
+);
+
+CREATE TABLE IF NOT EXISTS character_glyphs (
+    character_id    integer NOT NULL REFERENCES characters ON DELETE CASCADE,
+    spec            smallint NOT NULL DEFAULT 0,
+    slot            smallint NOT NULL,
+    glyph_id        integer NOT NULL,
+    PRIMARY KEY (character_id, spec, slot)
+);
+
+ALTER TABLE characters
+    ADD spec_count smallint NOT NULL DEFAULT 1,
+    ADD active_spec smallint NOT NULL DEFAULT 0,
+    ADD talent_reset_cost integer NOT NULL DEFAULT 0,
+    ADD talent_reset_at timestamptz NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE characters
+    DROP spec_count,
+    DROP active_spec,
+    DROP talent_reset_cost,
+    DROP talent_reset_at;
+
+DROP TABLE IF EXISTS character_glyphs;
+DROP TABLE IF EXISTS character_talents;
+-- +goose StatementEnd
@@ -0,0 +1,18 @@
+-- The reputation each character has with a faction, when it's different from what characters of their race
+-- and class start with. Standing is how much it changed from the starting reputation.
+
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS character_reputations (
+    character_id    integer NOT NULL REFERENCES characters ON DELETE CASCADE,
+    faction_id      integer NOT NULL,
+    standing        integer NOT NULL DEFAULT 0,
+    flags           smallint NOT NULL DEFAULT 0,
+    PRIMARY KEY (character_id, faction_id)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS character_reputations;
+-- +goose StatementEnd
@@ -0,0 +1,18 @@
+-- The skills each character knows, such as weapons, languages and professions, and how far they've raised
+-- them.
+
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS character_skills (
+    character_id    integer NOT NULL REFERENCES characters ON DELETE CASCADE,
+    skill_id        smallint NOT NULL,
+    value           smallint NOT NULL,
+    max             smallint NOT NULL,
+    PRIMARY KEY (character_id, skill_id)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS character_skills;
+-- +goose StatementEnd
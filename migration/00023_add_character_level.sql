@@ -0,0 +1,20 @@
+-- Each character's level, their experience towards the next level and the areas they've explored. Existing
+-- characters start at the level for their class.
+
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE characters
+    ADD level smallint NOT NULL DEFAULT 1,
+    ADD experience integer NOT NULL DEFAULT 0,
+    ADD explored_zones bytea NULL;
+
+UPDATE characters SET level = 55 WHERE class = 6;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE characters
+    DROP level,
+    DROP experience,
+    DROP explored_zones;
+-- +goose StatementEnd
@@ -0,0 +1,15 @@
+-- How long each character has been played, in seconds, in total and at their current level.
+
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE characters
+    ADD played_total integer NOT NULL DEFAULT 0,
+    ADD played_level integer NOT NULL DEFAULT 0;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE characters
+    DROP played_total,
+    DROP played_level;
+-- +goose StatementEnd
@@ -0,0 +1,18 @@
+-- The rested experience each character has, and whether they were resting when they logged out. Characters
+-- keep getting rested experience while they're offline.
+
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE characters
+    ADD rest_bonus real NOT NULL DEFAULT 0,
+    ADD logout_resting boolean NOT NULL DEFAULT false,
+    ADD logout_at timestamptz NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE characters
+    DROP rest_bonus,
+    DROP logout_resting,
+    DROP logout_at;
+-- +goose StatementEnd
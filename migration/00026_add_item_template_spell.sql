@@ -0,0 +1,13 @@
+-- The spell each item casts when it's used, like the glyph a glyph item inscribes.
+
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE item_templates
+    ADD spell_id integer NOT NULL DEFAULT 0;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE item_templates
+    DROP spell_id;
+-- +goose StatementEnd
@@ -0,0 +1,26 @@
+-- Whether each character is dead or a ghost, and where the corpse of a ghost is. Characters that log out
+-- dead are still dead when they log back in.
+
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE characters
+    ADD dead boolean NOT NULL DEFAULT false,
+    ADD ghost boolean NOT NULL DEFAULT false,
+    ADD corpse_map integer NOT NULL DEFAULT 0,
+    ADD corpse_x real NOT NULL DEFAULT 0,
+    ADD corpse_y real NOT NULL DEFAULT 0,
+    ADD corpse_z real NOT NULL DEFAULT 0,
+    ADD corpse_orientation real NOT NULL DEFAULT 0;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE characters
+    DROP dead,
+    DROP ghost,
+    DROP corpse_map,
+    DROP corpse_x,
+    DROP corpse_y,
+    DROP corpse_z,
+    DROP corpse_orientation;
+-- +goose StatementEnd
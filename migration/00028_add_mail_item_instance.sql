@@ -0,0 +1,17 @@
+-- The item that was attached to a mail by a player, so it keeps its durability and flags when it's taken.
+-- Items attached by the server have no instance and are created when they're taken. An attached item is
+-- owned by the character whose mailbox the mail is in, but isn't in their inventory.
+
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE mail_items
+    ADD item_instance_id integer NOT NULL DEFAULT 0;
+CREATE INDEX IF NOT EXISTS mail_items_item_instance_idx ON mail_items (item_instance_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS mail_items_item_instance_idx;
+ALTER TABLE mail_items
+    DROP item_instance_id;
+-- +goose StatementEnd
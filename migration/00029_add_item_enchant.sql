@@ -0,0 +1,14 @@
+-- The permanent enchantment on each item, like one from an enchanter. It's shown on the item and on the
+-- character wearing it.
+
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE items
+    ADD enchant integer NOT NULL DEFAULT 0;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE items
+    DROP enchant;
+-- +goose StatementEnd
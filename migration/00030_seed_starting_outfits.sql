@@ -0,0 +1,76 @@
+-- The rest of the items in the starting outfits of the original eight races, which new characters are given.
+-- Blood elf, draenei and death knight starting items aren't included yet, so they're skipped.
+
+-- +goose Up
+-- +goose StatementBegin
+INSERT INTO item_templates
+    (id, class, subclass, name, display_id, quality, buy_price, sell_price, inventory_type, item_level,
+     max_count, stackable, armor, damage_min, damage_max, delay, bonding, material, sheath, max_durability)
+VALUES
+    (36,    2,  4,  'Worn Mace',                5194,  1, 38, 7, 13, 2, 0, 1,   0, 2, 5, 1900, 0, 2, 3, 20),
+    (37,    2,  0,  'Worn Axe',                 14029, 1, 38, 7, 13, 2, 0, 1,   0, 2, 5, 2000, 0, 1, 3, 20),
+    (43,    4,  1,  'Squire''s Boots',          9938,  1, 5,  1, 8,  1, 0, 1,   1, 0, 0, 0,    0, 7, 0, 16),
+    (44,    4,  1,  'Squire''s Pants',          9937,  1, 5,  1, 7,  1, 0, 1,   2, 0, 0, 0,    0, 7, 0, 25),
+    (45,    4,  0,  'Squire''s Shirt',          3265,  1, 1,  1, 4,  1, 0, 1,   0, 0, 0, 0,    0, 7, 0, 0),
+    (47,    4,  2,  'Footpad''s Shoes',         9915,  1, 5,  1, 8,  1, 0, 1,   2, 0, 0, 0,    0, 8, 0, 20),
+    (48,    4,  2,  'Footpad''s Pants',         9913,  1, 5,  1, 7,  1, 0, 1,   4, 0, 0, 0,    0, 8, 0, 30),
+    (49,    4,  0,  'Footpad''s Shirt',         9906,  1, 1,  1, 4,  1, 0, 1,   0, 0, 0, 0,    0, 7, 0, 0),
+    (51,    4,  1,  'Neophyte''s Boots',        9946,  1, 5,  1, 8,  1, 0, 1,   1, 0, 0, 0,    0, 7, 0, 16),
+    (52,    4,  1,  'Neophyte''s Pants',        9945,  1, 5,  1, 7,  1, 0, 1,   2, 0, 0, 0,    0, 7, 0, 25),
+    (53,    4,  0,  'Neophyte''s Shirt',        9944,  1, 1,  1, 4,  1, 0, 1,   0, 0, 0, 0,    0, 7, 0, 0),
+    (57,    4,  1,  'Acolyte''s Robe',          12650, 1, 5,  1, 20, 1, 0, 1,   3, 0, 0, 0,    0, 7, 0, 35),
+    (59,    4,  1,  'Acolyte''s Shoes',         3261,  1, 5,  1, 8,  1, 0, 1,   1, 0, 0, 0,    0, 7, 0, 16),
+    (127,   4,  0,  'Trapper''s Shirt',         9996,  1, 1,  1, 4,  1, 0, 1,   0, 0, 0, 0,    0, 7, 0, 0),
+    (129,   4,  2,  'Rugged Trapper''s Boots',  9977,  1, 5,  1, 8,  1, 0, 1,   2, 0, 0, 0,    0, 8, 0, 20),
+    (139,   4,  1,  'Brawler''s Pants',         9988,  1, 5,  1, 7,  1, 0, 1,   2, 0, 0, 0,    0, 7, 0, 25),
+    (140,   4,  1,  'Brawler''s Boots',         9989,  1, 5,  1, 8,  1, 0, 1,   1, 0, 0, 0,    0, 7, 0, 16),
+    (147,   4,  2,  'Rugged Trapper''s Pants',  9975,  1, 5,  1, 7,  1, 0, 1,   4, 0, 0, 0,    0, 8, 0, 30),
+    (148,   4,  0,  'Rugged Trapper''s Shirt',  9976,  1, 1,  1, 4,  1, 0, 1,   0, 0, 0, 0,    0, 7, 0, 0),
+    (153,   4,  2,  'Primitive Kilt',           10002, 1, 5,  1, 7,  1, 0, 1,   4, 0, 0, 0,    0, 8, 0, 30),
+    (154,   4,  0,  'Primitive Mantle',         10058, 1, 1,  1, 4,  1, 0, 1,   0, 0, 0, 0,    0, 7, 0, 0),
+    (1396,  4,  1,  'Acolyte''s Pants',         14377, 1, 5,  1, 7,  1, 0, 1,   2, 0, 0, 0,    0, 7, 0, 25),
+    (2070,  0,  0,  'Darnassian Bleu',          6353,  1, 25, 1, 0,  5, 0, 20,  0, 0, 0, 0,    0, 0, 0, 0),
+    (2092,  2,  15, 'Worn Dagger',              6442,  1, 35, 7, 13, 2, 0, 1,   0, 1, 3, 1600, 0, 1, 3, 16),
+    (2361,  2,  5,  'Battleworn Hammer',        8690,  1, 45, 9, 17, 2, 0, 1,   0, 3, 8, 2900, 0, 2, 1, 25),
+    (2504,  2,  2,  'Worn Shortbow',            8106,  1, 29, 5, 15, 2, 0, 1,   0, 2, 4, 2300, 0, 2, 0, 20),
+    (2508,  2,  3,  'Old Blunderbuss',          6606,  1, 27, 5, 26, 2, 0, 1,   0, 2, 4, 2300, 0, 1, 0, 20),
+    (2512,  6,  2,  'Rough Arrow',              5996,  1, 10, 0, 24, 5, 0, 200, 0, 0, 0, 0,    0, 1, 0, 0),
+    (2516,  6,  3,  'Light Shot',               5998,  1, 10, 0, 24, 5, 0, 200, 0, 0, 0, 0,    0, 2, 0, 0),
+    (2947,  2,  16, 'Small Throwing Knife',     16754, 1, 15, 0, 25, 3, 0, 200, 0, 1, 3, 2000, 0, 1, 0, 0),
+    (3111,  2,  16, 'Crude Throwing Axe',       20777, 1, 15, 0, 25, 3, 0, 200, 0, 1, 3, 2000, 0, 1, 0, 0),
+    (3661,  2,  10, 'Handcrafted Staff',        18530, 1, 45, 9, 17, 2, 0, 1,   0, 3, 5, 2900, 0, 2, 2, 25),
+    (4540,  0,  0,  'Tough Hunk of Bread',      6399,  1, 25, 1, 0,  5, 0, 20,  0, 0, 0, 0,    0, 0, 0, 0),
+    (4604,  0,  0,  'Forest Mushroom Cap',      15852, 1, 25, 1, 0,  5, 0, 20,  0, 0, 0, 0,    0, 0, 0, 0),
+    (6096,  4,  0,  'Apprentice''s Shirt',      2163,  1, 1,  1, 4,  1, 0, 1,   0, 0, 0, 0,    0, 7, 0, 0),
+    (6097,  4,  0,  'Acolyte''s Shirt',         2470,  1, 1,  1, 4,  1, 0, 1,   0, 0, 0, 0,    0, 7, 0, 0),
+    (6098,  4,  1,  'Neophyte''s Robe',         12679, 1, 5,  1, 20, 1, 0, 1,   3, 0, 0, 0,    0, 7, 0, 35),
+    (6119,  4,  1,  'Neophyte''s Robe',         12681, 1, 5,  1, 20, 1, 0, 1,   3, 0, 0, 0,    0, 7, 0, 35),
+    (6123,  4,  1,  'Novice''s Robe',           12683, 1, 5,  1, 20, 1, 0, 1,   3, 0, 0, 0,    0, 7, 0, 35),
+    (6124,  4,  1,  'Novice''s Pants',          9987,  1, 5,  1, 7,  1, 0, 1,   2, 0, 0, 0,    0, 7, 0, 25),
+    (6125,  4,  0,  'Brawler''s Harness',       9995,  1, 1,  1, 4,  1, 0, 1,   0, 0, 0, 0,    0, 7, 0, 0),
+    (6126,  4,  2,  'Trapper''s Pants',         9997,  1, 5,  1, 7,  1, 0, 1,   4, 0, 0, 0,    0, 8, 0, 30),
+    (6127,  4,  2,  'Trapper''s Boots',         9998,  1, 5,  1, 8,  1, 0, 1,   2, 0, 0, 0,    0, 8, 0, 20),
+    (6129,  4,  1,  'Acolyte''s Robe',          12654, 1, 5,  1, 20, 1, 0, 1,   3, 0, 0, 0,    0, 7, 0, 35),
+    (6139,  4,  1,  'Novice''s Robe',           12684, 1, 5,  1, 20, 1, 0, 1,   3, 0, 0, 0,    0, 7, 0, 35),
+    (6140,  4,  1,  'Apprentice''s Robe',       12648, 1, 5,  1, 20, 1, 0, 1,   3, 0, 0, 0,    0, 7, 0, 35),
+    (12282, 2,  1,  'Worn Battleaxe',           22291, 1, 43, 8, 17, 2, 0, 1,   0, 3, 8, 2900, 0, 1, 1, 25)
+ON CONFLICT (id) DO NOTHING;
+
+-- Quivers and ammo pouches for hunters
+INSERT INTO item_templates
+    (id, class, subclass, name, display_id, quality, buy_price, sell_price, inventory_type, item_level,
+     container_slots, bag_family)
+VALUES
+    (2101, 11, 2, 'Light Quiver',     21328, 1, 4, 1, 18, 1, 6, 1),
+    (2102, 11, 3, 'Small Ammo Pouch', 1816,  1, 4, 1, 18, 1, 6, 2)
+ON CONFLICT (id) DO NOTHING;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DELETE FROM item_templates
+    WHERE id IN (36, 37, 43, 44, 45, 47, 48, 49, 51, 52, 53, 57, 59, 127, 129, 139, 140, 147, 148, 153, 154,
+                 1396, 2070, 2092, 2101, 2102, 2361, 2504, 2508, 2512, 2516, 2947, 3111, 3661, 4540, 4604,
+                 6096, 6097, 6098, 6119, 6123, 6124, 6125, 6126, 6127, 6129, 6139, 6140, 12282)
+    AND NOT EXISTS (SELECT 1 FROM items WHERE items.template_id = item_templates.id);
+-- +goose StatementEnd
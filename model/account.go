@@ -10,6 +10,16 @@ import (
 	srp "github.com/kangaroux/go-wow-srp6"
 )
 
+// GMLevel is an account's security level. It controls which GM commands the account can use.
+type GMLevel uint8
+
+const (
+	GMLevelPlayer        GMLevel = 0
+	GMLevelModerator     GMLevel = 1
+	GMLevelGameMaster    GMLevel = 2
+	GMLevelAdministrator GMLevel = 3
+)
+
 type Account struct {
 	Id        uint32
 	CreatedAt time.Time    `db:"created_at"`
@@ -17,8 +27,9 @@ type Account struct {
 
 	Username       string
 	Email          string
-	SrpSaltHex     string `db:"srp_salt"`
-	SrpVerifierHex string `db:"srp_verifier"`
+	SrpSaltHex     string  `db:"srp_salt"`
+	SrpVerifierHex string  `db:"srp_verifier"`
+	GMLevel        GMLevel `db:"gm_level"`
 
 	srpSalt     []byte
 	srpVerifier []byte
@@ -88,8 +88,8 @@ func (s *DbAccountService) List() ([]*Account, error) {
 
 func (s *DbAccountService) Create(a *Account) error {
 	q := `
-	INSERT INTO accounts (username, email, srp_verifier, srp_salt)
-	VALUES (:username, :email, :srp_verifier, :srp_salt)
+	INSERT INTO accounts (username, email, srp_verifier, srp_salt, gm_level)
+	VALUES (:username, :email, :srp_verifier, :srp_salt, :gm_level)
 	RETURNING id, created_at`
 	result, err := s.db.NamedQuery(q, a)
 	if err != nil {
@@ -102,7 +102,8 @@ func (s *DbAccountService) Create(a *Account) error {
 func (s *DbAccountService) Update(a *Account) (bool, error) {
 	q := `
 	UPDATE accounts SET
-	username=:username, email=:email, srp_verifier=:srp_verifier, srp_salt=:srp_salt, last_login=:last_login
+	username=:username, email=:email, srp_verifier=:srp_verifier, srp_salt=:srp_salt, last_login=:last_login,
+	gm_level=:gm_level
 	WHERE id=:id`
 	result, err := s.db.NamedExec(q, a)
 	if err != nil {
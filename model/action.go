@@ -0,0 +1,31 @@
+package model
+
+// ActionType is what an action button does when it's clicked.
+// https://gtker.com/wow_messages/docs/actionbuttontype.html
+type ActionType uint8
+
+const (
+	ActionTypeSpell    ActionType = 0x00
+	ActionTypeClick    ActionType = 0x01
+	ActionTypeEquipSet ActionType = 0x20
+	ActionTypeMacro    ActionType = 0x40
+	ActionTypeCMacro   ActionType = 0x41
+	ActionTypeItem     ActionType = 0x80
+)
+
+// ActionButton is a button on one of a character's action bars. Each talent spec has its own buttons.
+type ActionButton struct {
+	CharacterId uint32 `db:"character_id"`
+	Spec        uint8
+	Button      uint8
+
+	// Action is the id of the spell, item, macro, etc. depending on the type.
+	Action uint32
+	Type   ActionType
+}
+
+// Packed returns the action and type in the format that the client uses, which has the type in the upper
+// 8 bits.
+func (b *ActionButton) Packed() uint32 {
+	return b.Action | uint32(b.Type)<<24
+}
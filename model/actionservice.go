@@ -0,0 +1,59 @@
+package model
+
+import (
+	"github.com/jmoiron/sqlx"
+)
+
+type ActionService interface {
+	// List returns the character's action buttons for a talent spec.
+	List(characterId uint32, spec uint8) ([]*ActionButton, error)
+
+	// Set puts an action on a button, replacing whatever was there.
+	Set(*ActionButton) error
+
+	// Remove clears a button and returns if there was anything on it.
+	Remove(characterId uint32, spec uint8, button uint8) (bool, error)
+}
+
+type DbActionService struct {
+	db *sqlx.DB
+}
+
+var _ ActionService = (*DbActionService)(nil)
+
+func NewDbActionService(db *sqlx.DB) ActionService {
+	return &DbActionService{db}
+}
+
+func (s *DbActionService) List(characterId uint32, spec uint8) ([]*ActionButton, error) {
+	var result []*ActionButton
+	q := `SELECT * FROM character_actions WHERE character_id = $1 AND spec = $2 ORDER BY button ASC`
+	if err := s.db.Select(&result, q, characterId, spec); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (s *DbActionService) Set(button *ActionButton) error {
+	return setAction(s.db, button)
+}
+
+// setAction is shared with the character service, which sets a new character's default action buttons.
+func setAction(db updater, button *ActionButton) error {
+	q := `
+	INSERT INTO character_actions (character_id, spec, button, action, type)
+	VALUES (:character_id, :spec, :button, :action, :type)
+	ON CONFLICT (character_id, spec, button) DO UPDATE SET action = :action, type = :type`
+	_, err := db.NamedExec(q, button)
+	return err
+}
+
+func (s *DbActionService) Remove(characterId uint32, spec uint8, button uint8) (bool, error) {
+	q := `DELETE FROM character_actions WHERE character_id = $1 AND spec = $2 AND button = $3`
+	result, err := s.db.Exec(q, characterId, spec, button)
+	if err != nil {
+		return false, err
+	}
+	n, _ := result.RowsAffected()
+	return n > 0, err
+}
@@ -0,0 +1,10 @@
+package model
+
+// CharacterChannel is a custom chat channel that a character has joined.
+type CharacterChannel struct {
+	CharacterId uint32 `db:"character_id"`
+	Name        string
+
+	// Password is the password the character joined with, if the channel had one.
+	Password string
+}
@@ -0,0 +1,57 @@
+package model
+
+import (
+	"github.com/jmoiron/sqlx"
+)
+
+type CharacterChannelService interface {
+	// List returns the channels the character has joined, ordered by name.
+	List(uint32) ([]*CharacterChannel, error)
+
+	// Add saves the channel for the character. If the character is already in the channel, the password
+	// is updated.
+	Add(*CharacterChannel) error
+
+	// Remove deletes the channel with the given name for the character. Names are case-insensitive.
+	// Remove reports whether the channel was deleted.
+	Remove(uint32, string) (bool, error)
+}
+
+type DbCharacterChannelService struct {
+	db *sqlx.DB
+}
+
+var _ CharacterChannelService = (*DbCharacterChannelService)(nil)
+
+func NewDbCharacterChannelService(db *sqlx.DB) CharacterChannelService {
+	return &DbCharacterChannelService{db}
+}
+
+func (s *DbCharacterChannelService) List(characterID uint32) ([]*CharacterChannel, error) {
+	var result []*CharacterChannel
+	q := `SELECT * FROM character_channels WHERE character_id = $1 ORDER BY name ASC`
+	if err := s.db.Select(&result, q, characterID); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (s *DbCharacterChannelService) Add(c *CharacterChannel) error {
+	q := `
+	INSERT INTO character_channels (character_id, name, password)
+	VALUES (:character_id, :name, :password)
+	ON CONFLICT (character_id, name) DO UPDATE SET password = EXCLUDED.password`
+	_, err := s.db.NamedExec(q, c)
+	return err
+}
+
+func (s *DbCharacterChannelService) Remove(characterID uint32, name string) (bool, error) {
+	q := `DELETE FROM character_channels WHERE character_id = $1 AND lower(name) = lower($2)`
+	result, err := s.db.Exec(q, characterID, name)
+	if err != nil {
+		return false, err
+	}
+
+	n, _ := result.RowsAffected()
+	return n > 0, err
+}
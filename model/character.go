@@ -25,6 +25,62 @@ type Character struct {
 	// Facial hair, piercings, etc.
 	ExtraCosmetic byte `db:"extra_cosmetic"`
 	OutfitId      byte `db:"outfit_id"`
+
+	// Where the character is in the world
+	Map         uint32
+	Zone        uint32
+	X           float32 `db:"position_x"`
+	Y           float32 `db:"position_y"`
+	Z           float32 `db:"position_z"`
+	Orientation float32
+
+	// Level is the character's level, and Experience is how much they have towards the next one.
+	Level      uint8
+	Experience uint32
+
+	// ExploredZones has a bit for each area the character has explored.
+	ExploredZones []byte `db:"explored_zones"`
+
+	// How many seconds the character has been played, in total and at their current level
+	PlayedTotal uint32 `db:"played_total"`
+	PlayedLevel uint32 `db:"played_level"`
+
+	// RestBonus is how much rested experience the character has. They keep getting more while they're
+	// offline, faster if they were resting when they logged out.
+	RestBonus     float32      `db:"rest_bonus"`
+	LogoutResting bool         `db:"logout_resting"`
+	LogoutAt      sql.NullTime `db:"logout_at"`
+
+	// Money is how much copper the character is carrying.
+	Money uint32
+
+	// ActionBars is a mask of the extra action bars the character has turned on.
+	ActionBars uint8 `db:"action_bars"`
+
+	// SpecCount is how many talent specs the character has, and ActiveSpec is the one they're using.
+	SpecCount  uint8 `db:"spec_count"`
+	ActiveSpec uint8 `db:"active_spec"`
+
+	// What the character paid the last time they reset their talents, and when
+	TalentResetCost uint32       `db:"talent_reset_cost"`
+	TalentResetAt   sql.NullTime `db:"talent_reset_at"`
+
+	// Where the character's hearthstone is bound to
+	HomeMap  uint32  `db:"home_map"`
+	HomeZone uint32  `db:"home_zone"`
+	HomeX    float32 `db:"home_x"`
+	HomeY    float32 `db:"home_y"`
+	HomeZ    float32 `db:"home_z"`
+
+	// Dead is true if the character is dead, and Ghost is true if they've also released their spirit. A
+	// ghost's corpse is where they died.
+	Dead              bool
+	Ghost             bool
+	CorpseMap         uint32  `db:"corpse_map"`
+	CorpseX           float32 `db:"corpse_x"`
+	CorpseY           float32 `db:"corpse_y"`
+	CorpseZ           float32 `db:"corpse_z"`
+	CorpseOrientation float32 `db:"corpse_orientation"`
 }
 
 func (c *Character) String() string {
@@ -19,6 +19,7 @@ const (
 type CharacterListParams struct {
 	AccountId uint32
 	RealmId   uint32
+	GuildId   uint32
 	Sort      CharacterSort
 }
 
@@ -36,6 +37,11 @@ type CharacterService interface {
 	// Create creates a new character and sets the Id and CreatedAt fields.
 	Create(*Character) error
 
+	// CreateNew creates a new character along with its starting items and action buttons in one
+	// transaction. It sets the character's Id and CreatedAt fields, the items' OwnerId, Id and CreatedAt
+	// fields, and the action buttons' CharacterId.
+	CreateNew(c *Character, items []*Item, actions []*ActionButton) error
+
 	// Update tries to update an existing character and returns if it was updated.
 	Update(*Character) (bool, error)
 
@@ -89,6 +95,10 @@ func (s *DbCharacterService) List(params *CharacterListParams) ([]*Character, er
 		cond = append(cond, `realm_id = ?`)
 		args = append(args, params.RealmId)
 	}
+	if params.GuildId > 0 {
+		cond = append(cond, `id IN (SELECT character_id FROM guild_members WHERE guild_id = ?)`)
+		args = append(args, params.GuildId)
+	}
 	if len(cond) > 0 {
 		q += " WHERE " + strings.Join(cond, " AND ")
 		q = s.db.Rebind(q)
@@ -111,6 +121,40 @@ func (s *DbCharacterService) List(params *CharacterListParams) ([]*Character, er
 }
 
 func (s *DbCharacterService) Create(c *Character) error {
+	return createCharacter(s.db, c)
+}
+
+func (s *DbCharacterService) CreateNew(c *Character, items []*Item, actions []*ActionButton) error {
+	tx, err := s.db.Beginx()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		tx.Rollback()
+	}()
+
+	if err := createCharacter(tx, c); err != nil {
+		return err
+	}
+
+	for _, item := range items {
+		item.OwnerId = c.Id
+		if err := createItem(tx, item); err != nil {
+			return err
+		}
+	}
+
+	for _, button := range actions {
+		button.CharacterId = c.Id
+		if err := setAction(tx, button); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+func createCharacter(db creater, c *Character) error {
 	q := `
 	INSERT INTO characters (
 		name,
@@ -119,12 +163,37 @@ func (s *DbCharacterService) Create(c *Character) error {
 		race,
 		class,
 		gender,
+		level,
+		experience,
+		explored_zones,
+		played_total,
+		played_level,
+		rest_bonus,
+		logout_resting,
+		logout_at,
 		skin_color,
 		face,
 		hair_style,
 		hair_color,
 		extra_cosmetic,
-		outfit_id
+		outfit_id,
+		map,
+		zone,
+		position_x,
+		position_y,
+		position_z,
+		orientation,
+		home_map,
+		home_zone,
+		home_x,
+		home_y,
+		home_z,
+		money,
+		action_bars,
+		spec_count,
+		active_spec,
+		talent_reset_cost,
+		talent_reset_at
 	) VALUES (
 		:name,
 		:account_id,
@@ -132,17 +201,43 @@ func (s *DbCharacterService) Create(c *Character) error {
 		:race,
 		:class,
 		:gender,
+		:level,
+		:experience,
+		:explored_zones,
+		:played_total,
+		:played_level,
+		:rest_bonus,
+		:logout_resting,
+		:logout_at,
 		:skin_color,
 		:face,
 		:hair_style,
 		:hair_color,
 		:extra_cosmetic,
-		:outfit_id
+		:outfit_id,
+		:map,
+		:zone,
+		:position_x,
+		:position_y,
+		:position_z,
+		:orientation,
+		:home_map,
+		:home_zone,
+		:home_x,
+		:home_y,
+		:home_z,
+		:money,
+		:action_bars,
+		:spec_count,
+		:active_spec,
+		:talent_reset_cost,
+		:talent_reset_at
 	) RETURNING id, created_at`
-	result, err := s.db.NamedQuery(q, c)
+	result, err := db.NamedQuery(q, c)
 	if err != nil {
 		return err
 	}
+	defer result.Close()
 	result.Next()
 	return result.StructScan(c)
 }
@@ -155,12 +250,44 @@ func (s *DbCharacterService) Update(c *Character) (bool, error) {
 		race=:race,
 		class=:class,
 		gender=:gender,
+		level=:level,
+		experience=:experience,
+		explored_zones=:explored_zones,
+		played_total=:played_total,
+		played_level=:played_level,
+		rest_bonus=:rest_bonus,
+		logout_resting=:logout_resting,
+		logout_at=:logout_at,
 		skin_color=:skin_color,
 		face=:face,
 		hair_style=:hair_style,
 		hair_color=:hair_color,
 		extra_cosmetic=:extra_cosmetic,
-		outfit_id=:outfit_id
+		outfit_id=:outfit_id,
+		map=:map,
+		zone=:zone,
+		position_x=:position_x,
+		position_y=:position_y,
+		position_z=:position_z,
+		orientation=:orientation,
+		home_map=:home_map,
+		home_zone=:home_zone,
+		home_x=:home_x,
+		home_y=:home_y,
+		home_z=:home_z,
+		money=:money,
+		action_bars=:action_bars,
+		spec_count=:spec_count,
+		active_spec=:active_spec,
+		talent_reset_cost=:talent_reset_cost,
+		talent_reset_at=:talent_reset_at,
+		dead=:dead,
+		ghost=:ghost,
+		corpse_map=:corpse_map,
+		corpse_x=:corpse_x,
+		corpse_y=:corpse_y,
+		corpse_z=:corpse_z,
+		corpse_orientation=:corpse_orientation
 	WHERE
 		id=:id`
 	result, err := s.db.NamedExec(q, c)
@@ -0,0 +1,17 @@
+package model
+
+import (
+	"database/sql"
+	"time"
+)
+
+// CommandLog is a record of a GM command that was used in game.
+type CommandLog struct {
+	Id          uint32
+	CreatedAt   time.Time     `db:"created_at"`
+	AccountId   uint32        `db:"account_id"`
+	CharacterId sql.NullInt32 `db:"character_id"`
+
+	// Command is the full command, including the arguments.
+	Command string
+}
@@ -0,0 +1,45 @@
+package model
+
+import (
+	"github.com/jmoiron/sqlx"
+)
+
+type CommandLogService interface {
+	// List returns the commands used by the account, newest first.
+	List(uint32) ([]*CommandLog, error)
+
+	// Create records a command and sets the Id and CreatedAt fields.
+	Create(*CommandLog) error
+}
+
+type DbCommandLogService struct {
+	db *sqlx.DB
+}
+
+var _ CommandLogService = (*DbCommandLogService)(nil)
+
+func NewDbCommandLogService(db *sqlx.DB) CommandLogService {
+	return &DbCommandLogService{db}
+}
+
+func (s *DbCommandLogService) List(accountID uint32) ([]*CommandLog, error) {
+	var result []*CommandLog
+	q := `SELECT * FROM command_log WHERE account_id = $1 ORDER BY created_at DESC`
+	if err := s.db.Select(&result, q, accountID); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (s *DbCommandLogService) Create(c *CommandLog) error {
+	q := `
+	INSERT INTO command_log (account_id, character_id, command)
+	VALUES (:account_id, :character_id, :command)
+	RETURNING id, created_at`
+	result, err := s.db.NamedQuery(q, c)
+	if err != nil {
+		return err
+	}
+	result.Next()
+	return result.StructScan(c)
+}
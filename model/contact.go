@@ -0,0 +1,20 @@
+package model
+
+// https://gtker.com/wow_messages/docs/relationtype.html
+type ContactFlags uint32
+
+const (
+	ContactFlagFriend  ContactFlags = 0x01
+	ContactFlagIgnored ContactFlags = 0x02
+	ContactFlagMuted   ContactFlags = 0x04
+)
+
+// Contact is another character that is on a character's friend or ignore list.
+type Contact struct {
+	CharacterId uint32 `db:"character_id"`
+	ContactId   uint32 `db:"contact_id"`
+	Flags       ContactFlags
+
+	// Note is shown next to friends in the friend list.
+	Note string
+}
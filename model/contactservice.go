@@ -0,0 +1,54 @@
+package model
+
+import (
+	"github.com/jmoiron/sqlx"
+)
+
+type ContactService interface {
+	// List returns the character's contacts.
+	List(uint32) ([]*Contact, error)
+
+	// Save creates the contact, or updates it if it already exists.
+	Save(*Contact) error
+
+	// Delete removes a contact from the character's contacts and returns if it was deleted.
+	Delete(characterId uint32, contactId uint32) (bool, error)
+}
+
+type DbContactService struct {
+	db *sqlx.DB
+}
+
+var _ ContactService = (*DbContactService)(nil)
+
+func NewDbContactService(db *sqlx.DB) ContactService {
+	return &DbContactService{db}
+}
+
+func (s *DbContactService) List(characterID uint32) ([]*Contact, error) {
+	var result []*Contact
+	q := `SELECT * FROM character_contacts WHERE character_id = $1`
+	if err := s.db.Select(&result, q, characterID); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (s *DbContactService) Save(c *Contact) error {
+	q := `
+	INSERT INTO character_contacts (character_id, contact_id, flags, note)
+	VALUES (:character_id, :contact_id, :flags, :note)
+	ON CONFLICT (character_id, contact_id) DO UPDATE SET flags = EXCLUDED.flags, note = EXCLUDED.note`
+	_, err := s.db.NamedExec(q, c)
+	return err
+}
+
+func (s *DbContactService) Delete(characterID uint32, contactID uint32) (bool, error) {
+	q := `DELETE FROM character_contacts WHERE character_id = $1 AND contact_id = $2`
+	result, err := s.db.Exec(q, characterID, contactID)
+	if err != nil {
+		return false, err
+	}
+	n, _ := result.RowsAffected()
+	return n > 0, err
+}
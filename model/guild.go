@@ -0,0 +1,62 @@
+package model
+
+import "time"
+
+// GuildRights are what members of a guild rank are allowed to do.
+// https://gtker.com/wow_messages/docs/guildrights.html
+type GuildRights uint32
+
+const (
+	GuildRightChatListen        GuildRights = 0x00000001
+	GuildRightChatSpeak         GuildRights = 0x00000002
+	GuildRightOfficerChatListen GuildRights = 0x00000004
+	GuildRightOfficerChatSpeak  GuildRights = 0x00000008
+	GuildRightInvite            GuildRights = 0x00000010
+	GuildRightRemove            GuildRights = 0x00000020
+	GuildRightEmpty             GuildRights = 0x00000040
+	GuildRightPromote           GuildRights = 0x00000080
+	GuildRightDemote            GuildRights = 0x00000100
+	GuildRightSetMOTD           GuildRights = 0x00001000
+	GuildRightEditPublicNote    GuildRights = 0x00002000
+	GuildRightViewOfficerNote   GuildRights = 0x00004000
+	GuildRightEditOfficerNote   GuildRights = 0x00008000
+	GuildRightModifyGuildInfo   GuildRights = 0x00010000
+
+	GuildRightsMember = GuildRightEmpty | GuildRightChatListen | GuildRightChatSpeak
+	GuildRightsAll    = GuildRightsMember | GuildRightOfficerChatListen | GuildRightOfficerChatSpeak |
+		GuildRightInvite | GuildRightRemove | GuildRightPromote | GuildRightDemote | GuildRightSetMOTD |
+		GuildRightEditPublicNote | GuildRightViewOfficerNote | GuildRightEditOfficerNote |
+		GuildRightModifyGuildInfo
+)
+
+type Guild struct {
+	Id        uint32
+	CreatedAt time.Time `db:"created_at"`
+
+	RealmId  uint32 `db:"realm_id"`
+	Name     string
+	LeaderId uint32 `db:"leader_id"`
+
+	// MOTD is shown to members when they log in. Info is shown in the guild info window.
+	MOTD string `db:"motd"`
+	Info string
+}
+
+// GuildRank is one of a guild's ranks. Rank 0 is the guild master, and higher ranks have fewer rights.
+type GuildRank struct {
+	GuildId     uint32 `db:"guild_id"`
+	Rank        uint8
+	Name        string
+	Rights      GuildRights
+	MoneyPerDay uint32 `db:"money_per_day"`
+}
+
+// GuildMember is a character's membership in a guild. A character can only be in one guild.
+type GuildMember struct {
+	CharacterId uint32 `db:"character_id"`
+	GuildId     uint32 `db:"guild_id"`
+	Rank        uint8
+	PublicNote  string    `db:"public_note"`
+	OfficerNote string    `db:"officer_note"`
+	JoinedAt    time.Time `db:"joined_at"`
+}
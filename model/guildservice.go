@@ -0,0 +1,214 @@
+package model
+
+import (
+	"database/sql"
+	"strings"
+
+	"github.com/jmoiron/sqlx"
+)
+
+type GuildService interface {
+	// Get returns a guild by id, or nil if it doesn't exist.
+	Get(uint32) (*Guild, error)
+
+	// GetName returns a guild by its name and realm id, or nil if it doesn't exist.
+	GetName(name string, realmId uint32) (*Guild, error)
+
+	// Create creates a new guild and sets the Id and CreatedAt fields.
+	Create(*Guild) error
+
+	// Update tries to update an existing guild and returns if it was updated.
+	Update(*Guild) (bool, error)
+
+	// Delete tries to delete an existing guild by id, along with its ranks and members, and returns if it
+	// was deleted.
+	Delete(uint32) (bool, error)
+
+	// ListRanks returns the guild's ranks, ordered from highest to lowest.
+	ListRanks(guildId uint32) ([]*GuildRank, error)
+
+	// SaveRank creates the rank, or updates it if it already exists.
+	SaveRank(*GuildRank) error
+
+	// DeleteRank removes one of the guild's ranks and returns if it was deleted.
+	DeleteRank(guildId uint32, rank uint8) (bool, error)
+
+	// GetMember returns the character's guild membership, or nil if they aren't in a guild.
+	GetMember(characterId uint32) (*GuildMember, error)
+
+	// ListCharacterMembers returns the guild memberships of the characters. Characters that aren't in a
+	// guild are skipped.
+	ListCharacterMembers(characterIds []uint32) ([]*GuildMember, error)
+
+	// ListMembers returns the guild's members.
+	ListMembers(guildId uint32) ([]*GuildMember, error)
+
+	// SaveMember creates the membership, or updates it if the character is already in the guild.
+	SaveMember(*GuildMember) error
+
+	// DeleteMember removes the character from their guild and returns if they were removed.
+	DeleteMember(characterId uint32) (bool, error)
+}
+
+type DbGuildService struct {
+	db *sqlx.DB
+}
+
+var _ GuildService = (*DbGuildService)(nil)
+
+func NewDbGuildService(db *sqlx.DB) GuildService {
+	return &DbGuildService{db}
+}
+
+func (s *DbGuildService) Get(id uint32) (*Guild, error) {
+	result := &Guild{}
+	if err := s.db.Get(result, `SELECT * FROM guilds WHERE id = $1`, id); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return result, nil
+}
+
+func (s *DbGuildService) GetName(name string, realmId uint32) (*Guild, error) {
+	q := `SELECT * FROM guilds WHERE lower(name) = $1 AND realm_id = $2`
+	result := &Guild{}
+	if err := s.db.Get(result, q, strings.ToLower(name), realmId); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return result, nil
+}
+
+func (s *DbGuildService) Create(g *Guild) error {
+	q := `
+	INSERT INTO guilds (realm_id, name, leader_id, motd, info)
+	VALUES (:realm_id, :name, :leader_id, :motd, :info)
+	RETURNING id, created_at`
+	result, err := s.db.NamedQuery(q, g)
+	if err != nil {
+		return err
+	}
+	result.Next()
+	return result.StructScan(g)
+}
+
+func (s *DbGuildService) Update(g *Guild) (bool, error) {
+	q := `
+	UPDATE guilds SET
+		name=:name,
+		leader_id=:leader_id,
+		motd=:motd,
+		info=:info
+	WHERE
+		id=:id`
+	result, err := s.db.NamedExec(q, g)
+	if err != nil {
+		return false, err
+	}
+	n, _ := result.RowsAffected()
+	return n > 0, err
+}
+
+func (s *DbGuildService) Delete(id uint32) (bool, error) {
+	result, err := s.db.Exec(`DELETE FROM guilds WHERE id = $1`, id)
+	if err != nil {
+		return false, err
+	}
+	n, _ := result.RowsAffected()
+	return n > 0, err
+}
+
+func (s *DbGuildService) ListRanks(guildId uint32) ([]*GuildRank, error) {
+	var result []*GuildRank
+	q := `SELECT * FROM guild_ranks WHERE guild_id = $1 ORDER BY rank ASC`
+	if err := s.db.Select(&result, q, guildId); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (s *DbGuildService) SaveRank(r *GuildRank) error {
+	q := `
+	INSERT INTO guild_ranks (guild_id, rank, name, rights, money_per_day)
+	VALUES (:guild_id, :rank, :name, :rights, :money_per_day)
+	ON CONFLICT (guild_id, rank) DO UPDATE SET
+		name = EXCLUDED.name,
+		rights = EXCLUDED.rights,
+		money_per_day = EXCLUDED.money_per_day`
+	_, err := s.db.NamedExec(q, r)
+	return err
+}
+
+func (s *DbGuildService) DeleteRank(guildId uint32, rank uint8) (bool, error) {
+	q := `DELETE FROM guild_ranks WHERE guild_id = $1 AND rank = $2`
+	result, err := s.db.Exec(q, guildId, rank)
+	if err != nil {
+		return false, err
+	}
+	n, _ := result.RowsAffected()
+	return n > 0, err
+}
+
+func (s *DbGuildService) GetMember(characterId uint32) (*GuildMember, error) {
+	result := &GuildMember{}
+	q := `SELECT * FROM guild_members WHERE character_id = $1`
+	if err := s.db.Get(result, q, characterId); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return result, nil
+}
+
+func (s *DbGuildService) ListCharacterMembers(characterIds []uint32) ([]*GuildMember, error) {
+	var result []*GuildMember
+	if len(characterIds) == 0 {
+		return result, nil
+	}
+
+	q, args, err := sqlx.In(`SELECT * FROM guild_members WHERE character_id IN (?)`, characterIds)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.db.Select(&result, s.db.Rebind(q), args...); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (s *DbGuildService) ListMembers(guildId uint32) ([]*GuildMember, error) {
+	var result []*GuildMember
+	q := `SELECT * FROM guild_members WHERE guild_id = $1`
+	if err := s.db.Select(&result, q, guildId); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (s *DbGuildService) SaveMember(m *GuildMember) error {
+	q := `
+	INSERT INTO guild_members (character_id, guild_id, rank, public_note, officer_note)
+	VALUES (:character_id, :guild_id, :rank, :public_note, :officer_note)
+	ON CONFLICT (character_id) DO UPDATE SET
+		guild_id = EXCLUDED.guild_id,
+		rank = EXCLUDED.rank,
+		public_note = EXCLUDED.public_note,
+		officer_note = EXCLUDED.officer_note`
+	_, err := s.db.NamedExec(q, m)
+	return err
+}
+
+func (s *DbGuildService) DeleteMember(characterId uint32) (bool, error) {
+	result, err := s.db.Exec(`DELETE FROM guild_members WHERE character_id = $1`, characterId)
+	if err != nil {
+		return false, err
+	}
+	n, _ := result.RowsAffected()
+	return n > 0, err
+}
@@ -0,0 +1,172 @@
+package model
+
+import "time"
+
+// https://gtker.com/wow_messages/docs/itemclass.html
+type ItemClass uint8
+
+const (
+	ItemClassConsumable ItemClass = 0
+	ItemClassContainer  ItemClass = 1
+	ItemClassWeapon     ItemClass = 2
+	ItemClassGem        ItemClass = 3
+	ItemClassArmor      ItemClass = 4
+	ItemClassReagent    ItemClass = 5
+	ItemClassProjectile ItemClass = 6
+	ItemClassTradeGoods ItemClass = 7
+	ItemClassGeneric    ItemClass = 8
+	ItemClassRecipe     ItemClass = 9
+	ItemClassMoney      ItemClass = 10
+	ItemClassQuiver     ItemClass = 11
+	ItemClassQuest      ItemClass = 12
+	ItemClassKey        ItemClass = 13
+	ItemClassPermanent  ItemClass = 14
+	ItemClassMisc       ItemClass = 15
+	ItemClassGlyph      ItemClass = 16
+)
+
+// https://gtker.com/wow_messages/docs/itemquality.html
+type ItemQuality uint8
+
+const (
+	ItemQualityPoor      ItemQuality = 0
+	ItemQualityCommon    ItemQuality = 1
+	ItemQualityUncommon  ItemQuality = 2
+	ItemQualityRare      ItemQuality = 3
+	ItemQualityEpic      ItemQuality = 4
+	ItemQualityLegendary ItemQuality = 5
+	ItemQualityArtifact  ItemQuality = 6
+	ItemQualityHeirloom  ItemQuality = 7
+)
+
+// InventoryType is where an item can be equipped.
+// https://gtker.com/wow_messages/docs/inventorytype.html
+type InventoryType uint8
+
+const (
+	InventoryTypeNonEquip       InventoryType = 0
+	InventoryTypeHead           InventoryType = 1
+	InventoryTypeNeck           InventoryType = 2
+	InventoryTypeShoulders      InventoryType = 3
+	InventoryTypeBody           InventoryType = 4
+	InventoryTypeChest          InventoryType = 5
+	InventoryTypeWaist          InventoryType = 6
+	InventoryTypeLegs           InventoryType = 7
+	InventoryTypeFeet           InventoryType = 8
+	InventoryTypeWrists         InventoryType = 9
+	InventoryTypeHands          InventoryType = 10
+	InventoryTypeFinger         InventoryType = 11
+	InventoryTypeTrinket        InventoryType = 12
+	InventoryTypeWeapon         InventoryType = 13
+	InventoryTypeShield         InventoryType = 14
+	InventoryTypeRanged         InventoryType = 15
+	InventoryTypeCloak          InventoryType = 16
+	InventoryTypeTwoHandWeapon  InventoryType = 17
+	InventoryTypeBag            InventoryType = 18
+	InventoryTypeTabard         InventoryType = 19
+	InventoryTypeRobe           InventoryType = 20
+	InventoryTypeWeaponMainHand InventoryType = 21
+	InventoryTypeWeaponOffHand  InventoryType = 22
+	InventoryTypeHoldable       InventoryType = 23
+	InventoryTypeAmmo           InventoryType = 24
+	InventoryTypeThrown         InventoryType = 25
+	InventoryTypeRangedRight    InventoryType = 26
+	InventoryTypeQuiver         InventoryType = 27
+	InventoryTypeRelic          InventoryType = 28
+)
+
+// https://gtker.com/wow_messages/docs/bonding.html
+type ItemBonding uint8
+
+const (
+	ItemBondingNone       ItemBonding = 0
+	ItemBondingPickUp     ItemBonding = 1
+	ItemBondingEquip      ItemBonding = 2
+	ItemBondingUse        ItemBonding = 3
+	ItemBondingQuestItem  ItemBonding = 4
+	ItemBondingQuestItem2 ItemBonding = 5
+)
+
+// ItemFlags are the flags of an item instance.
+type ItemFlags uint32
+
+const (
+	ItemFlagSoulbound ItemFlags = 0x001
+	ItemFlagUnlocked  ItemFlags = 0x004
+	ItemFlagWrapped   ItemFlags = 0x008
+	ItemFlagReadable  ItemFlags = 0x200
+)
+
+// ItemTemplate describes a kind of item. Templates are static data and aren't changed by the server.
+type ItemTemplate struct {
+	Id       uint32
+	Class    ItemClass
+	Subclass uint32
+	Name     string
+
+	DisplayId uint32 `db:"display_id"`
+	Quality   ItemQuality
+	Flags     uint32
+
+	// BuyPrice and SellPrice are in copper.
+	BuyPrice  uint32 `db:"buy_price"`
+	SellPrice uint32 `db:"sell_price"`
+
+	InventoryType InventoryType `db:"inventory_type"`
+
+	// AllowableClass and AllowableRace are bitmasks of the classes and races that can use the item, or
+	// -1 if anyone can use it.
+	AllowableClass int32 `db:"allowable_class"`
+	AllowableRace  int32 `db:"allowable_race"`
+
+	ItemLevel     uint32 `db:"item_level"`
+	RequiredLevel uint32 `db:"required_level"`
+
+	// MaxCount is how many of the item a character can have, or 0 if there's no limit. Stackable is the
+	// most items that fit in one stack.
+	MaxCount  uint32 `db:"max_count"`
+	Stackable uint32
+
+	// ContainerSlots is how many slots a bag has.
+	ContainerSlots uint8 `db:"container_slots"`
+
+	Armor      uint32
+	DamageMin  float32 `db:"damage_min"`
+	DamageMax  float32 `db:"damage_max"`
+	DamageType uint32  `db:"damage_type"`
+
+	// Delay is the weapon's attack speed in milliseconds.
+	Delay uint32
+
+	Bonding       ItemBonding
+	Description   string
+	Material      int32
+	Sheath        uint32
+	MaxDurability uint32 `db:"max_durability"`
+	BagFamily     uint32 `db:"bag_family"`
+
+	// SpellId is the spell that's cast when the item is used, or 0 if the item can't be used.
+	SpellId uint32 `db:"spell_id"`
+}
+
+// Item is an instance of an item template that a character owns.
+type Item struct {
+	Id        uint32
+	CreatedAt time.Time `db:"created_at"`
+
+	OwnerId    uint32 `db:"owner_id"`
+	TemplateId uint32 `db:"template_id"`
+
+	// ContainerId is the id of the bag the item is in, or 0 if the item is in one of the owner's own
+	// slots. Slot is the slot in the bag or in the owner's inventory.
+	ContainerId uint32 `db:"container_id"`
+	Slot        uint8
+
+	Count      uint32
+	Flags      ItemFlags
+	Durability uint32
+
+	// Enchant is the item's permanent enchantment, or 0 if it doesn't have one. Temporary enchantments
+	// like poisons aren't saved.
+	Enchant uint32
+}
@@ -0,0 +1,183 @@
+package model
+
+import (
+	"database/sql"
+
+	"github.com/jmoiron/sqlx"
+)
+
+type ItemService interface {
+	// GetTemplate returns an item template by id, or nil if it doesn't exist.
+	GetTemplate(uint32) (*ItemTemplate, error)
+
+	// ListTemplates returns the item templates with the given ids. Ids that don't exist are skipped.
+	ListTemplates(ids []uint32) ([]*ItemTemplate, error)
+
+	// Get returns an item by id, or nil if it doesn't exist.
+	Get(uint32) (*Item, error)
+
+	// List returns the items that a character owns. Items attached to mail aren't included.
+	List(ownerId uint32) ([]*Item, error)
+
+	// ListOwners returns the items that the characters own, like List does for one character.
+	ListOwners(ownerIds []uint32) ([]*Item, error)
+
+	// Create creates a new item and sets the Id and CreatedAt fields.
+	Create(*Item) error
+
+	// Update tries to update an existing item and returns if it was updated.
+	Update(*Item) (bool, error)
+
+	// Delete tries to delete an existing item by id and returns if it was deleted.
+	Delete(uint32) (bool, error)
+}
+
+type DbItemService struct {
+	db *sqlx.DB
+}
+
+var _ ItemService = (*DbItemService)(nil)
+
+func NewDbItemService(db *sqlx.DB) ItemService {
+	return &DbItemService{db}
+}
+
+func (s *DbItemService) GetTemplate(id uint32) (*ItemTemplate, error) {
+	result := &ItemTemplate{}
+	if err := s.db.Get(result, `SELECT * FROM item_templates WHERE id = $1`, id); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return result, nil
+}
+
+func (s *DbItemService) ListTemplates(ids []uint32) ([]*ItemTemplate, error) {
+	var result []*ItemTemplate
+	if len(ids) == 0 {
+		return result, nil
+	}
+
+	q, args, err := sqlx.In(`SELECT * FROM item_templates WHERE id IN (?)`, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.db.Select(&result, s.db.Rebind(q), args...); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (s *DbItemService) Get(id uint32) (*Item, error) {
+	result := &Item{}
+	if err := s.db.Get(result, `SELECT * FROM items WHERE id = $1`, id); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return result, nil
+}
+
+func (s *DbItemService) List(ownerId uint32) ([]*Item, error) {
+	var result []*Item
+	q := `
+	SELECT * FROM items
+	WHERE owner_id = $1 AND id NOT IN (SELECT item_instance_id FROM mail_items)
+	ORDER BY container_id ASC, slot ASC`
+	if err := s.db.Select(&result, q, ownerId); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (s *DbItemService) ListOwners(ownerIds []uint32) ([]*Item, error) {
+	var result []*Item
+	if len(ownerIds) == 0 {
+		return result, nil
+	}
+
+	q, args, err := sqlx.In(`
+	SELECT * FROM items
+	WHERE owner_id IN (?) AND id NOT IN (SELECT item_instance_id FROM mail_items)
+	ORDER BY owner_id ASC, container_id ASC, slot ASC`, ownerIds)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.db.Select(&result, s.db.Rebind(q), args...); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (s *DbItemService) Create(item *Item) error {
+	return createItem(s.db, item)
+}
+
+// createItem is shared with the mail service, which creates items when they're taken from a mail, and the
+// character service, which creates a new character's starting items.
+func createItem(db creater, item *Item) error {
+	q := `
+	INSERT INTO items (
+		owner_id,
+		template_id,
+		container_id,
+		slot,
+		count,
+		flags,
+		durability,
+		enchant
+	) VALUES (
+		:owner_id,
+		:template_id,
+		:container_id,
+		:slot,
+		:count,
+		:flags,
+		:durability,
+		:enchant
+	) RETURNING id, created_at`
+	result, err := db.NamedQuery(q, item)
+	if err != nil {
+		return err
+	}
+	defer result.Close()
+	result.Next()
+	return result.StructScan(item)
+}
+
+func (s *DbItemService) Update(item *Item) (bool, error) {
+	return updateItem(s.db, item)
+}
+
+func updateItem(db updater, item *Item) (bool, error) {
+	q := `
+	UPDATE items SET
+		owner_id=:owner_id,
+		container_id=:container_id,
+		slot=:slot,
+		count=:count,
+		flags=:flags,
+		durability=:durability,
+		enchant=:enchant
+	WHERE
+		id=:id`
+	result, err := db.NamedExec(q, item)
+	if err != nil {
+		return false, err
+	}
+	n, _ := result.RowsAffected()
+	return n > 0, err
+}
+
+func (s *DbItemService) Delete(id uint32) (bool, error) {
+	result, err := s.db.Exec(`DELETE FROM items WHERE id = $1`, id)
+	if err != nil {
+		return false, err
+	}
+	n, _ := result.RowsAffected()
+	return n > 0, err
+}
@@ -0,0 +1,91 @@
+package model
+
+import "time"
+
+// MailType is who sent a mail. Normal mail is sent by players, other mail is sent by the server on
+// behalf of a creature or object.
+// https://gtker.com/wow_messages/docs/mailtype.html
+type MailType uint8
+
+const (
+	MailTypeNormal     MailType = 0
+	MailTypeAuction    MailType = 2
+	MailTypeCreature   MailType = 3
+	MailTypeGameObject MailType = 4
+	MailTypeItem       MailType = 5
+)
+
+// MailStationery is the background of a mail.
+type MailStationery uint8
+
+const (
+	MailStationeryTest    MailStationery = 1
+	MailStationeryDefault MailStationery = 41
+	MailStationeryGM      MailStationery = 61
+	MailStationeryAuction MailStationery = 62
+)
+
+type MailFlags uint32
+
+const (
+	MailFlagRead       MailFlags = 0x01
+	MailFlagReturned   MailFlags = 0x02
+	MailFlagCopied     MailFlags = 0x04
+	MailFlagCODPayment MailFlags = 0x08
+	MailFlagHasBody    MailFlags = 0x10
+)
+
+// Mail is a message in a character's mailbox.
+type Mail struct {
+	Id        uint32
+	CreatedAt time.Time `db:"created_at"`
+	ExpiresAt time.Time `db:"expires_at"`
+
+	ReceiverId uint32 `db:"receiver_id"`
+
+	// SenderId is the id of the character that sent the mail for normal mail, otherwise it's the id of the
+	// creature or object that sent it.
+	SenderId    uint32   `db:"sender_id"`
+	MessageType MailType `db:"message_type"`
+	Stationery  MailStationery
+
+	Subject string
+	Body    string
+
+	// Money is copper that is attached to the mail. COD is how much copper the receiver has to pay to take
+	// the attached items.
+	Money uint32
+	COD   uint32 `db:"cod"`
+
+	Flags MailFlags
+
+	Items []*MailItem `db:"-"`
+}
+
+// MailItem is an item attached to a mail. ItemId is the item's template.
+type MailItem struct {
+	Id     uint32
+	MailId uint32 `db:"mail_id"`
+	ItemId uint32 `db:"item_id"`
+	Count  uint32
+
+	// InstanceId is the id of the item that a player attached, or 0 if the item is created when it's taken.
+	// The item is owned by the mail's receiver but isn't in their inventory.
+	InstanceId uint32 `db:"item_instance_id"`
+}
+
+// MailTake is an item being taken from a mail and everything that changes when it's taken.
+type MailTake struct {
+	Mail       *Mail
+	Attachment *MailItem
+
+	// Updated are the receiver's items that changed to make room for the attachment, including the attached
+	// item if it has an instance. Created are the new items.
+	Updated []*Item
+	Created []*Item
+
+	// Payment is the COD being mailed to the sender, or nil if the mail doesn't have COD. Money is how much
+	// money the receiver has left after paying it.
+	Payment *Mail
+	Money   uint32
+}
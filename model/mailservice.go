@@ -0,0 +1,333 @@
+package model
+
+import (
+	"database/sql"
+
+	"github.com/jmoiron/sqlx"
+)
+
+type MailService interface {
+	// Get returns a mail and its items by id, or nil if it doesn't exist.
+	Get(uint32) (*Mail, error)
+
+	// List returns the mail in a character's mailbox and the mail's items, ordered from newest to oldest.
+	List(receiverId uint32) ([]*Mail, error)
+
+	// Create creates a new mail and its items, and sets the Id and CreatedAt fields. Attached items with an
+	// instance are given to the receiver.
+	Create(*Mail) error
+
+	// Send creates a new mail like Create, and saves the money the sender has left after paying for it in the
+	// same transaction.
+	Send(m *Mail, senderMoney uint32) error
+
+	// Update tries to update an existing mail and returns if it was updated. Attached items with an instance
+	// are given to the receiver, in case the mail was returned.
+	Update(*Mail) (bool, error)
+
+	// Delete tries to delete an existing mail and its items by id, and returns if it was deleted.
+	Delete(uint32) (bool, error)
+
+	// TakeMoney updates a mail that the receiver took the money from, and saves the receiver's money in the
+	// same transaction.
+	TakeMoney(m *Mail, receiverMoney uint32) error
+
+	// TakeItem removes an item from a mail and saves the receiver's items that changed to make room for it,
+	// all in one transaction. If the mail has COD, the receiver's money, the mail and the payment are saved
+	// too.
+	TakeItem(*MailTake) error
+}
+
+type DbMailService struct {
+	db *sqlx.DB
+}
+
+var _ MailService = (*DbMailService)(nil)
+
+func NewDbMailService(db *sqlx.DB) MailService {
+	return &DbMailService{db}
+}
+
+func (s *DbMailService) Get(id uint32) (*Mail, error) {
+	result := &Mail{}
+	if err := s.db.Get(result, `SELECT * FROM mail WHERE id = $1`, id); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	q := `SELECT * FROM mail_items WHERE mail_id = $1 ORDER BY id ASC`
+	if err := s.db.Select(&result.Items, q, id); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (s *DbMailService) List(receiverId uint32) ([]*Mail, error) {
+	var result []*Mail
+	q := `SELECT * FROM mail WHERE receiver_id = $1 ORDER BY created_at DESC, id DESC`
+	if err := s.db.Select(&result, q, receiverId); err != nil {
+		return nil, err
+	}
+
+	if len(result) == 0 {
+		return result, nil
+	}
+
+	byId := make(map[uint32]*Mail, len(result))
+	ids := make([]uint32, len(result))
+	for i, m := range result {
+		byId[m.Id] = m
+		ids[i] = m.Id
+	}
+
+	q, args, err := sqlx.In(`SELECT * FROM mail_items WHERE mail_id IN (?) ORDER BY id ASC`, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	var items []*MailItem
+	if err := s.db.Select(&items, s.db.Rebind(q), args...); err != nil {
+		return nil, err
+	}
+
+	for _, item := range items {
+		m := byId[item.MailId]
+		m.Items = append(m.Items, item)
+	}
+
+	return result, nil
+}
+
+func (s *DbMailService) Create(m *Mail) error {
+	tx, err := s.db.Beginx()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		tx.Rollback()
+	}()
+
+	if err := s.create(tx, m); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (s *DbMailService) Send(m *Mail, senderMoney uint32) error {
+	tx, err := s.db.Beginx()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		tx.Rollback()
+	}()
+
+	if _, err := tx.Exec(`UPDATE characters SET money = $1 WHERE id = $2`, senderMoney, m.SenderId); err != nil {
+		return err
+	}
+
+	if err := s.create(tx, m); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (s *DbMailService) create(tx *sqlx.Tx, m *Mail) error {
+	q := `
+	INSERT INTO mail (
+		expires_at,
+		receiver_id,
+		sender_id,
+		message_type,
+		stationery,
+		subject,
+		body,
+		money,
+		cod,
+		flags
+	) VALUES (
+		:expires_at,
+		:receiver_id,
+		:sender_id,
+		:message_type,
+		:stationery,
+		:subject,
+		:body,
+		:money,
+		:cod,
+		:flags
+	) RETURNING id, created_at`
+	result, err := tx.NamedQuery(q, m)
+	if err != nil {
+		return err
+	}
+	result.Next()
+	if err := result.StructScan(m); err != nil {
+		return err
+	}
+	result.Close()
+
+	for _, item := range m.Items {
+		item.MailId = m.Id
+
+		q := `
+		INSERT INTO mail_items (mail_id, item_id, count, item_instance_id)
+		VALUES (:mail_id, :item_id, :count, :item_instance_id)
+		RETURNING id`
+		result, err := tx.NamedQuery(q, item)
+		if err != nil {
+			return err
+		}
+		result.Next()
+		if err := result.StructScan(item); err != nil {
+			return err
+		}
+		result.Close()
+	}
+
+	return s.giveItems(tx, m)
+}
+
+// giveItems gives the items attached to m that have an instance to the mail's receiver.
+func (s *DbMailService) giveItems(tx *sqlx.Tx, m *Mail) error {
+	q := `
+	UPDATE items SET owner_id = $1
+	WHERE id IN (SELECT item_instance_id FROM mail_items WHERE mail_id = $2)`
+	_, err := tx.Exec(q, m.ReceiverId, m.Id)
+	return err
+}
+
+func (s *DbMailService) Update(m *Mail) (bool, error) {
+	tx, err := s.db.Beginx()
+	if err != nil {
+		return false, err
+	}
+	defer func() {
+		tx.Rollback()
+	}()
+
+	ok, err := s.update(tx, m)
+	if err != nil || !ok {
+		return false, err
+	}
+
+	if err := s.giveItems(tx, m); err != nil {
+		return false, err
+	}
+
+	return true, tx.Commit()
+}
+
+func (s *DbMailService) update(db updater, m *Mail) (bool, error) {
+	q := `
+	UPDATE mail SET
+		expires_at=:expires_at,
+		receiver_id=:receiver_id,
+		sender_id=:sender_id,
+		money=:money,
+		cod=:cod,
+		flags=:flags
+	WHERE
+		id=:id`
+	result, err := db.NamedExec(q, m)
+	if err != nil {
+		return false, err
+	}
+	n, _ := result.RowsAffected()
+	return n > 0, err
+}
+
+func (s *DbMailService) Delete(id uint32) (bool, error) {
+	tx, err := s.db.Beginx()
+	if err != nil {
+		return false, err
+	}
+	defer func() {
+		tx.Rollback()
+	}()
+
+	// The attached items aren't in anyone's inventory, so they're deleted with the mail
+	q := `DELETE FROM items WHERE id IN (SELECT item_instance_id FROM mail_items WHERE mail_id = $1)`
+	if _, err := tx.Exec(q, id); err != nil {
+		return false, err
+	}
+
+	result, err := tx.Exec(`DELETE FROM mail WHERE id = $1`, id)
+	if err != nil {
+		return false, err
+	}
+	n, _ := result.RowsAffected()
+	if n == 0 {
+		return false, nil
+	}
+
+	return true, tx.Commit()
+}
+
+func (s *DbMailService) TakeMoney(m *Mail, receiverMoney uint32) error {
+	tx, err := s.db.Beginx()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		tx.Rollback()
+	}()
+
+	if _, err := tx.Exec(`UPDATE characters SET money = $1 WHERE id = $2`, receiverMoney, m.ReceiverId); err != nil {
+		return err
+	}
+
+	if _, err := s.update(tx, m); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (s *DbMailService) TakeItem(t *MailTake) error {
+	tx, err := s.db.Beginx()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		tx.Rollback()
+	}()
+
+	if _, err := tx.Exec(`DELETE FROM mail_items WHERE id = $1`, t.Attachment.Id); err != nil {
+		return err
+	}
+
+	for _, item := range t.Updated {
+		if _, err := updateItem(tx, item); err != nil {
+			return err
+		}
+	}
+
+	for _, item := range t.Created {
+		if err := createItem(tx, item); err != nil {
+			return err
+		}
+	}
+
+	if t.Payment != nil {
+		q := `UPDATE characters SET money = $1 WHERE id = $2`
+		if _, err := tx.Exec(q, t.Money, t.Mail.ReceiverId); err != nil {
+			return err
+		}
+
+		if _, err := s.update(tx, t.Mail); err != nil {
+			return err
+		}
+
+		if err := s.create(tx, t.Payment); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
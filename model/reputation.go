@@ -0,0 +1,10 @@
+package model
+
+// CharacterReputation is a character's reputation with a faction. Standing is added to the reputation that
+// the character started with.
+type CharacterReputation struct {
+	CharacterId uint32 `db:"character_id"`
+	FactionId   uint32 `db:"faction_id"`
+	Standing    int32
+	Flags       uint8
+}
@@ -0,0 +1,57 @@
+package model
+
+import (
+	"github.com/jmoiron/sqlx"
+)
+
+type ReputationService interface {
+	// List returns the character's reputations that changed from what they started with.
+	List(characterId uint32) ([]*CharacterReputation, error)
+
+	// Save replaces the character's reputations.
+	Save(characterId uint32, reputations []*CharacterReputation) error
+}
+
+type DbReputationService struct {
+	db *sqlx.DB
+}
+
+var _ ReputationService = (*DbReputationService)(nil)
+
+func NewDbReputationService(db *sqlx.DB) ReputationService {
+	return &DbReputationService{db}
+}
+
+func (s *DbReputationService) List(characterId uint32) ([]*CharacterReputation, error) {
+	var result []*CharacterReputation
+	q := `SELECT * FROM character_reputations WHERE character_id = $1`
+	if err := s.db.Select(&result, q, characterId); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (s *DbReputationService) Save(characterId uint32, reputations []*CharacterReputation) error {
+	tx, err := s.db.Beginx()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		tx.Rollback()
+	}()
+
+	if _, err := tx.Exec(`DELETE FROM character_reputations WHERE character_id = $1`, characterId); err != nil {
+		return err
+	}
+
+	q := `
+	INSERT INTO character_reputations (character_id, faction_id, standing, flags)
+	VALUES (:character_id, :faction_id, :standing, :flags)`
+	for _, rep := range reputations {
+		if _, err := tx.NamedExec(q, rep); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
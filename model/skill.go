@@ -0,0 +1,9 @@
+package model
+
+// CharacterSkill is a skill the character knows. Value is how far they've raised it, up to Max.
+type CharacterSkill struct {
+	CharacterId uint32 `db:"character_id"`
+	SkillId     uint16 `db:"skill_id"`
+	Value       uint16
+	Max         uint16
+}
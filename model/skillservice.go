@@ -0,0 +1,57 @@
+package model
+
+import (
+	"github.com/jmoiron/sqlx"
+)
+
+type SkillService interface {
+	// List returns the skills the character knows.
+	List(characterId uint32) ([]*CharacterSkill, error)
+
+	// Save replaces the character's skills.
+	Save(characterId uint32, skills []*CharacterSkill) error
+}
+
+type DbSkillService struct {
+	db *sqlx.DB
+}
+
+var _ SkillService = (*DbSkillService)(nil)
+
+func NewDbSkillService(db *sqlx.DB) SkillService {
+	return &DbSkillService{db}
+}
+
+func (s *DbSkillService) List(characterId uint32) ([]*CharacterSkill, error) {
+	var result []*CharacterSkill
+	q := `SELECT * FROM character_skills WHERE character_id = $1 ORDER BY skill_id`
+	if err := s.db.Select(&result, q, characterId); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (s *DbSkillService) Save(characterId uint32, skills []*CharacterSkill) error {
+	tx, err := s.db.Beginx()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		tx.Rollback()
+	}()
+
+	if _, err := tx.Exec(`DELETE FROM character_skills WHERE character_id = $1`, characterId); err != nil {
+		return err
+	}
+
+	q := `
+	INSERT INTO character_skills (character_id, skill_id, value, max)
+	VALUES (:character_id, :skill_id, :value, :max)`
+	for _, skill := range skills {
+		if _, err := tx.NamedExec(q, skill); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
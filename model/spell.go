@@ -0,0 +1,37 @@
+package model
+
+import "time"
+
+// CharacterSpell is a spell that a character knows.
+type CharacterSpell struct {
+	CharacterId uint32 `db:"character_id"`
+	SpellId     uint32 `db:"spell_id"`
+}
+
+// SpellCooldown is a spell that a character can't cast again until the cooldown expires. Spells in the
+// same category share CategoryExpiresAt.
+type SpellCooldown struct {
+	CharacterId uint32 `db:"character_id"`
+	SpellId     uint32 `db:"spell_id"`
+	Category    uint32
+
+	ExpiresAt         time.Time `db:"expires_at"`
+	CategoryExpiresAt time.Time `db:"category_expires_at"`
+}
+
+// CharacterAura is an aura that was on a character when they logged out. Remaining and MaxDuration are in
+// milliseconds.
+type CharacterAura struct {
+	CharacterId uint32 `db:"character_id"`
+	SpellId     uint32 `db:"spell_id"`
+	CasterGuid  uint64 `db:"caster_guid"`
+	Level       uint32
+	Positive    bool
+	Stacks      uint8
+	Charges     uint8
+	Amount1     int32
+	Amount2     int32
+	Amount3     int32
+	Remaining   int32
+	MaxDuration int32 `db:"max_duration"`
+}
@@ -0,0 +1,138 @@
+package model
+
+import (
+	"github.com/jmoiron/sqlx"
+)
+
+type SpellService interface {
+	// List returns the spells the character knows.
+	List(uint32) ([]*CharacterSpell, error)
+
+	// Add adds the spell to the character's spells. Does nothing if they already know it.
+	Add(*CharacterSpell) error
+
+	// Remove removes a spell from the character's spells and returns if it was removed.
+	Remove(characterId uint32, spellId uint32) (bool, error)
+
+	// ListCooldowns returns the character's cooldowns that haven't expired.
+	ListCooldowns(uint32) ([]*SpellCooldown, error)
+
+	// SaveCooldowns replaces the character's cooldowns.
+	SaveCooldowns(characterId uint32, cooldowns []*SpellCooldown) error
+
+	// ListAuras returns the auras the character had when they logged out.
+	ListAuras(uint32) ([]*CharacterAura, error)
+
+	// SaveAuras replaces the character's auras.
+	SaveAuras(characterId uint32, auras []*CharacterAura) error
+}
+
+type DbSpellService struct {
+	db *sqlx.DB
+}
+
+var _ SpellService = (*DbSpellService)(nil)
+
+func NewDbSpellService(db *sqlx.DB) SpellService {
+	return &DbSpellService{db}
+}
+
+func (s *DbSpellService) List(characterId uint32) ([]*CharacterSpell, error) {
+	var result []*CharacterSpell
+	q := `SELECT * FROM character_spells WHERE character_id = $1 ORDER BY spell_id ASC`
+	if err := s.db.Select(&result, q, characterId); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (s *DbSpellService) Add(spell *CharacterSpell) error {
+	q := `
+	INSERT INTO character_spells (character_id, spell_id)
+	VALUES (:character_id, :spell_id)
+	ON CONFLICT (character_id, spell_id) DO NOTHING`
+	_, err := s.db.NamedExec(q, spell)
+	return err
+}
+
+func (s *DbSpellService) Remove(characterId uint32, spellId uint32) (bool, error) {
+	q := `DELETE FROM character_spells WHERE character_id = $1 AND spell_id = $2`
+	result, err := s.db.Exec(q, characterId, spellId)
+	if err != nil {
+		return false, err
+	}
+	n, _ := result.RowsAffected()
+	return n > 0, err
+}
+
+func (s *DbSpellService) ListCooldowns(characterId uint32) ([]*SpellCooldown, error) {
+	var result []*SpellCooldown
+	q := `
+	SELECT * FROM character_spell_cooldowns
+	WHERE character_id = $1 AND (expires_at > now() OR category_expires_at > now())`
+	if err := s.db.Select(&result, q, characterId); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (s *DbSpellService) SaveCooldowns(characterId uint32, cooldowns []*SpellCooldown) error {
+	tx, err := s.db.Beginx()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		tx.Rollback()
+	}()
+
+	if _, err := tx.Exec(`DELETE FROM character_spell_cooldowns WHERE character_id = $1`, characterId); err != nil {
+		return err
+	}
+
+	q := `
+	INSERT INTO character_spell_cooldowns (character_id, spell_id, category, expires_at, category_expires_at)
+	VALUES (:character_id, :spell_id, :category, :expires_at, :category_expires_at)`
+	for _, cd := range cooldowns {
+		if _, err := tx.NamedExec(q, cd); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+func (s *DbSpellService) ListAuras(characterId uint32) ([]*CharacterAura, error) {
+	var result []*CharacterAura
+	q := `SELECT * FROM character_auras WHERE character_id = $1`
+	if err := s.db.Select(&result, q, characterId); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (s *DbSpellService) SaveAuras(characterId uint32, auras []*CharacterAura) error {
+	tx, err := s.db.Beginx()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		tx.Rollback()
+	}()
+
+	if _, err := tx.Exec(`DELETE FROM character_auras WHERE character_id = $1`, characterId); err != nil {
+		return err
+	}
+
+	q := `
+	INSERT INTO character_auras (character_id, spell_id, caster_guid, level, positive, stacks, charges,
+		amount1, amount2, amount3, remaining, max_duration)
+	VALUES (:character_id, :spell_id, :caster_guid, :level, :positive, :stacks, :charges,
+		:amount1, :amount2, :amount3, :remaining, :max_duration)`
+	for _, aura := range auras {
+		if _, err := tx.NamedExec(q, aura); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
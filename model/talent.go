@@ -0,0 +1,18 @@
+package model
+
+// CharacterTalent is a talent that a character has learned in one of their specs. Rank starts at 0 for the
+// first rank.
+type CharacterTalent struct {
+	CharacterId uint32 `db:"character_id"`
+	Spec        uint8
+	TalentId    uint32 `db:"talent_id"`
+	Rank        uint8
+}
+
+// CharacterGlyph is a glyph in one of the glyph slots of a character's spec.
+type CharacterGlyph struct {
+	CharacterId uint32 `db:"character_id"`
+	Spec        uint8
+	Slot        uint8
+	GlyphId     uint32 `db:"glyph_id"`
+}
@@ -0,0 +1,111 @@
+package model
+
+import (
+	"github.com/jmoiron/sqlx"
+)
+
+type TalentService interface {
+	// List returns the talents the character has learned in all of their specs.
+	List(characterId uint32) ([]*CharacterTalent, error)
+
+	// Set saves the rank of a talent, replacing the rank that was learned before.
+	Set(*CharacterTalent) error
+
+	// Reset removes all of the talents in one of the character's specs.
+	Reset(characterId uint32, spec uint8) error
+
+	// ListGlyphs returns the character's glyphs in all of their specs.
+	ListGlyphs(characterId uint32) ([]*CharacterGlyph, error)
+
+	// SetGlyph puts a glyph in a slot, replacing whatever was there.
+	SetGlyph(*CharacterGlyph) error
+
+	// RemoveGlyph clears a glyph slot.
+	RemoveGlyph(characterId uint32, spec uint8, slot uint8) error
+
+	// SaveGlyphs replaces all of the character's glyphs.
+	SaveGlyphs(characterId uint32, glyphs []*CharacterGlyph) error
+}
+
+type DbTalentService struct {
+	db *sqlx.DB
+}
+
+var _ TalentService = (*DbTalentService)(nil)
+
+func NewDbTalentService(db *sqlx.DB) TalentService {
+	return &DbTalentService{db}
+}
+
+func (s *DbTalentService) List(characterId uint32) ([]*CharacterTalent, error) {
+	var result []*CharacterTalent
+	q := `SELECT * FROM character_talents WHERE character_id = $1 ORDER BY spec ASC, talent_id ASC`
+	if err := s.db.Select(&result, q, characterId); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (s *DbTalentService) Set(talent *CharacterTalent) error {
+	q := `
+	INSERT INTO character_talents (character_id, spec, talent_id, rank)
+	VALUES (:character_id, :spec, :talent_id, :rank)
+	ON CONFLICT (character_id, spec, talent_id) DO UPDATE SET rank = :rank`
+	_, err := s.db.NamedExec(q, talent)
+	return err
+}
+
+func (s *DbTalentService) Reset(characterId uint32, spec uint8) error {
+	q := `DELETE FROM character_talents WHERE character_id = $1 AND spec = $2`
+	_, err := s.db.Exec(q, characterId, spec)
+	return err
+}
+
+func (s *DbTalentService) ListGlyphs(characterId uint32) ([]*CharacterGlyph, error) {
+	var result []*CharacterGlyph
+	q := `SELECT * FROM character_glyphs WHERE character_id = $1 ORDER BY spec ASC, slot ASC`
+	if err := s.db.Select(&result, q, characterId); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (s *DbTalentService) SetGlyph(glyph *CharacterGlyph) error {
+	q := `
+	INSERT INTO character_glyphs (character_id, spec, slot, glyph_id)
+	VALUES (:character_id, :spec, :slot, :glyph_id)
+	ON CONFLICT (character_id, spec, slot) DO UPDATE SET glyph_id = :glyph_id`
+	_, err := s.db.NamedExec(q, glyph)
+	return err
+}
+
+func (s *DbTalentService) RemoveGlyph(characterId uint32, spec uint8, slot uint8) error {
+	q := `DELETE FROM character_glyphs WHERE character_id = $1 AND spec = $2 AND slot = $3`
+	_, err := s.db.Exec(q, characterId, spec, slot)
+	return err
+}
+
+func (s *DbTalentService) SaveGlyphs(characterId uint32, glyphs []*CharacterGlyph) error {
+	tx, err := s.db.Beginx()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		tx.Rollback()
+	}()
+
+	if _, err := tx.Exec(`DELETE FROM character_glyphs WHERE character_id = $1`, characterId); err != nil {
+		return err
+	}
+
+	q := `
+	INSERT INTO character_glyphs (character_id, spec, slot, glyph_id)
+	VALUES (:character_id, :spec, :slot, :glyph_id)`
+	for _, glyph := range glyphs {
+		if _, err := tx.NamedExec(q, glyph); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
@@ -0,0 +1,94 @@
+package chat
+
+import (
+	"bytes"
+	"encoding/binary"
+)
+
+const (
+	// MaxChannelNameLength is the longest name a custom channel can have.
+	MaxChannelNameLength = 31
+)
+
+// Notice tells the player about something that happened in a channel.
+// https://gtker.com/wow_messages/docs/chatnotify.html#client-version-335
+type Notice uint8
+
+const (
+	NoticeJoined              Notice = 0x00
+	NoticeLeft                Notice = 0x01
+	NoticeYouJoined           Notice = 0x02
+	NoticeYouLeft             Notice = 0x03
+	NoticeWrongPassword       Notice = 0x04
+	NoticeNotMember           Notice = 0x05
+	NoticeNotModerator        Notice = 0x06
+	NoticePasswordChanged     Notice = 0x07
+	NoticeOwnerChanged        Notice = 0x08
+	NoticePlayerNotFound      Notice = 0x09
+	NoticeNotOwner            Notice = 0x0A
+	NoticeChannelOwner        Notice = 0x0B
+	NoticeModeChange          Notice = 0x0C
+	NoticeAnnouncementsOn     Notice = 0x0D
+	NoticeAnnouncementsOff    Notice = 0x0E
+	NoticeModerationOn        Notice = 0x0F
+	NoticeModerationOff       Notice = 0x10
+	NoticeMuted               Notice = 0x11
+	NoticePlayerKicked        Notice = 0x12
+	NoticeBanned              Notice = 0x13
+	NoticePlayerBanned        Notice = 0x14
+	NoticePlayerUnbanned      Notice = 0x15
+	NoticePlayerNotBanned     Notice = 0x16
+	NoticePlayerAlreadyMember Notice = 0x17
+	NoticeInvite              Notice = 0x18
+	NoticeInviteWrongFaction  Notice = 0x19
+	NoticeWrongFaction        Notice = 0x1A
+	NoticeInvalidName         Notice = 0x1B
+	NoticeNotModerated        Notice = 0x1C
+	NoticePlayerInvited       Notice = 0x1D
+	NoticePlayerInviteBanned  Notice = 0x1E
+)
+
+// ChannelFlags describe what kind of channel it is.
+type ChannelFlags uint8
+
+const (
+	ChannelFlagCustom  ChannelFlags = 0x01
+	ChannelFlagTrade   ChannelFlags = 0x04
+	ChannelFlagNotLFG  ChannelFlags = 0x08
+	ChannelFlagGeneral ChannelFlags = 0x10
+	ChannelFlagCity    ChannelFlags = 0x20
+)
+
+// MemberFlags describe a player's role in a channel.
+type MemberFlags uint8
+
+const (
+	MemberFlagNone      MemberFlags = 0x00
+	MemberFlagOwner     MemberFlags = 0x01
+	MemberFlagModerator MemberFlags = 0x02
+	MemberFlagVoiced    MemberFlags = 0x04
+	MemberFlagMuted     MemberFlags = 0x08
+)
+
+// NoticeBytes returns the SMSG_CHANNEL_NOTIFY payload for a notice. args are written after the channel
+// name in order. Strings are written as null terminated strings, anything else is written as little
+// endian binary.
+// https://gtker.com/wow_messages/docs/smsg_channel_notify.html#client-version-335
+func NoticeBytes(notice Notice, channel string, args ...interface{}) []byte {
+	buf := bytes.Buffer{}
+	buf.WriteByte(byte(notice))
+	buf.WriteString(channel)
+	buf.WriteByte(0)
+
+	for _, arg := range args {
+		switch v := arg.(type) {
+		case string:
+			buf.WriteString(v)
+			buf.WriteByte(0)
+		default:
+			binary.Write(&buf, binary.LittleEndian, v)
+		}
+	}
+
+	return buf.Bytes()
+}
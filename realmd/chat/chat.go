@@ -0,0 +1,138 @@
+// Package chat contains the chat message types and languages, and builds chat packets.
+package chat
+
+import (
+	"bytes"
+	"encoding/binary"
+
+	"github.com/kangaroux/gomaggus/realmd"
+	"github.com/kangaroux/gomaggus/realmd/player"
+)
+
+// https://gtker.com/wow_messages/docs/chattype.html#client-version-335
+type MessageType uint8
+
+const (
+	MessageTypeSystem             MessageType = 0x00
+	MessageTypeSay                MessageType = 0x01
+	MessageTypeParty              MessageType = 0x02
+	MessageTypeRaid               MessageType = 0x03
+	MessageTypeGuild              MessageType = 0x04
+	MessageTypeOfficer            MessageType = 0x05
+	MessageTypeYell               MessageType = 0x06
+	MessageTypeWhisper            MessageType = 0x07
+	MessageTypeWhisperForeign     MessageType = 0x08
+	MessageTypeWhisperInform      MessageType = 0x09
+	MessageTypeEmote              MessageType = 0x0A
+	MessageTypeTextEmote          MessageType = 0x0B
+	MessageTypeChannel            MessageType = 0x11
+	MessageTypeChannelJoin        MessageType = 0x12
+	MessageTypeChannelLeave       MessageType = 0x13
+	MessageTypeChannelList        MessageType = 0x14
+	MessageTypeChannelNotice      MessageType = 0x15
+	MessageTypeChannelNoticeUser  MessageType = 0x16
+	MessageTypeAFK                MessageType = 0x17
+	MessageTypeDND                MessageType = 0x18
+	MessageTypeIgnored            MessageType = 0x19
+	MessageTypeRaidLeader         MessageType = 0x27
+	MessageTypeRaidWarning        MessageType = 0x28
+	MessageTypeBattleground       MessageType = 0x2C
+	MessageTypeBattlegroundLeader MessageType = 0x2D
+	MessageTypePartyLeader        MessageType = 0x33
+)
+
+// https://gtker.com/wow_messages/docs/language.html#client-version-335
+type Language uint32
+
+const (
+	LanguageUniversal   Language = 0
+	LanguageOrcish      Language = 1
+	LanguageDarnassian  Language = 2
+	LanguageTaurahe     Language = 3
+	LanguageDwarvish    Language = 6
+	LanguageCommon      Language = 7
+	LanguageDemonic     Language = 8
+	LanguageTitan       Language = 9
+	LanguageThalassian  Language = 10
+	LanguageDraconic    Language = 11
+	LanguageKalimag     Language = 12
+	LanguageGnomish     Language = 13
+	LanguageTroll       Language = 14
+	LanguageGutterspeak Language = 33
+	LanguageDraenei     Language = 35
+
+	// Addon messages are sent over whispers and channels using this language. They are never shown to
+	// the player.
+	LanguageAddon Language = 0xFFFFFFFF
+)
+
+var languageSkills = map[Language]uint16{
+	LanguageOrcish:      player.SkillLanguageOrcish,
+	LanguageDarnassian:  player.SkillLanguageDarnassian,
+	LanguageTaurahe:     player.SkillLanguageTaurahe,
+	LanguageDwarvish:    player.SkillLanguageDwarven,
+	LanguageCommon:      player.SkillLanguageCommon,
+	LanguageThalassian:  player.SkillLanguageThalassian,
+	LanguageGnomish:     player.SkillLanguageGnomish,
+	LanguageTroll:       player.SkillLanguageTroll,
+	LanguageGutterspeak: player.SkillLanguageGutterspeak,
+	LanguageDraenei:     player.SkillLanguageDraenei,
+}
+
+// Skill returns the skill needed to speak and understand the language. ok is false if the language
+// doesn't require a skill.
+func (l Language) Skill() (skill uint16, ok bool) {
+	skill, ok = languageSkills[l]
+	return
+}
+
+// Tag is shown next to the sender's name.
+type Tag uint8
+
+const (
+	TagNone Tag = 0
+	TagAFK  Tag = 1
+	TagDND  Tag = 2
+	TagGM   Tag = 4
+)
+
+// Message is a chat message that is sent to a player.
+type Message struct {
+	Type     MessageType
+	Language Language
+	Sender   realmd.Guid
+
+	// Target is who the message is for. For most messages this is the sender.
+	Target realmd.Guid
+
+	// Channel is the channel the message was sent in, and is only used for channel messages.
+	Channel string
+
+	Text string
+	Tag  Tag
+}
+
+// Bytes returns the SMSG_MESSAGECHAT payload for the message.
+// https://gtker.com/wow_messages/docs/smsg_messagechat.html#client-version-335
+func (m *Message) Bytes() []byte {
+	buf := bytes.Buffer{}
+	buf.WriteByte(byte(m.Type))
+	binary.Write(&buf, binary.LittleEndian, m.Language)
+	binary.Write(&buf, binary.LittleEndian, m.Sender)
+	binary.Write(&buf, binary.LittleEndian, uint32(0)) // flags
+
+	if m.Type == MessageTypeChannel {
+		buf.WriteString(m.Channel)
+		buf.WriteByte(0)
+	}
+
+	binary.Write(&buf, binary.LittleEndian, m.Target)
+
+	// The length includes the null terminator
+	binary.Write(&buf, binary.LittleEndian, uint32(len(m.Text)+1))
+	buf.WriteString(m.Text)
+	buf.WriteByte(0)
+	buf.WriteByte(byte(m.Tag))
+
+	return buf.Bytes()
+}
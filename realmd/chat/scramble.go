@@ -0,0 +1,78 @@
+package chat
+
+import (
+	"hash/fnv"
+	"strings"
+	"unicode"
+)
+
+// Syllables that scrambled words are built from. Languages without their own list use the default list.
+var (
+	defaultSyllables = []string{"a", "ko", "ra", "mo", "den", "lo", "ash", "gar", "nu", "thi", "ve", "zu"}
+
+	languageSyllables = map[Language][]string{
+		LanguageCommon:     {"a", "an", "ash", "bor", "dana", "e", "gol", "lo", "mod", "nud", "o", "ras", "ru", "thor", "ver", "yes"},
+		LanguageOrcish:     {"a", "ag", "gor", "kaz", "lok", "mog", "nar", "ogg", "ruk", "tar", "thok", "u", "zug", "grom", "ka", "dae"},
+		LanguageDarnassian: {"a", "al", "dor", "el", "fal", "ilth", "ish", "lah", "nor", "quel", "shan", "thero", "u", "dal"},
+		LanguageDwarvish:   {"a", "ai", "bur", "dum", "gar", "hor", "kha", "mor", "ok", "thon", "ruk", "zan", "grum"},
+		LanguageTaurahe:    {"a", "aki", "ba", "chi", "ish", "mu", "nahe", "o", "po", "shu", "ta", "uku", "wa"},
+		LanguageGnomish:    {"a", "bil", "dink", "fiz", "gal", "ick", "nog", "ob", "pik", "tik", "wik", "zap"},
+		LanguageTroll:      {"a", "di", "fu", "ga", "jin", "ka", "mon", "sa", "tu", "wi", "zan", "zu"},
+		LanguageThalassian: {"a", "al", "bel", "dor", "el", "ash", "quel", "nor", "shal", "sin", "thar", "ro"},
+		LanguageDraenei:    {"a", "ar", "ash", "el", "gar", "kir", "mar", "nar", "ruum", "tor", "zen", "ta"},
+	}
+)
+
+// Scramble replaces every word in text with a word made from the language's syllables. Punctuation and
+// spacing are kept, and the same word is always scrambled the same way.
+func Scramble(lang Language, text string) string {
+	syllables, ok := languageSyllables[lang]
+	if !ok {
+		syllables = defaultSyllables
+	}
+
+	result := strings.Builder{}
+	word := strings.Builder{}
+
+	flush := func() {
+		if word.Len() > 0 {
+			result.WriteString(scrambleWord(syllables, word.String()))
+			word.Reset()
+		}
+	}
+
+	for _, r := range text {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			word.WriteRune(r)
+		} else {
+			flush()
+			result.WriteRune(r)
+		}
+	}
+	flush()
+
+	return result.String()
+}
+
+// scrambleWord returns a word with the same length as word. The first letter is capitalized if the
+// original word was.
+func scrambleWord(syllables []string, word string) string {
+	h := fnv.New32a()
+	h.Write([]byte(strings.ToLower(word)))
+	seed := h.Sum32()
+
+	length := len([]rune(word))
+	sb := strings.Builder{}
+
+	for sb.Len() < length {
+		sb.WriteString(syllables[seed%uint32(len(syllables))])
+		seed = seed*1103515245 + 12345
+	}
+
+	result := []rune(sb.String())[:length]
+	if unicode.IsUpper([]rune(word)[0]) {
+		result[0] = unicode.ToUpper(result[0])
+	}
+
+	return string(result)
+}
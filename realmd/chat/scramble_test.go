@@ -0,0 +1,23 @@
+package chat
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScramble(t *testing.T) {
+	text := "Hello there, friend!"
+	scrambled := Scramble(LanguageCommon, text)
+
+	assert.NotEqual(t, text, scrambled)
+	assert.Len(t, scrambled, len(text))
+	assert.Equal(t, byte(','), scrambled[11])
+	assert.Equal(t, byte('!'), scrambled[len(scrambled)-1])
+	assert.Equal(t, scrambled, Scramble(LanguageCommon, text), "scrambling is deterministic")
+	assert.NotEqual(t, scrambled, Scramble(LanguageOrcish, text))
+
+	lower := Scramble(LanguageCommon, "word")
+	assert.Equal(t, strings.ToUpper(lower[:1])+lower[1:], Scramble(LanguageCommon, "Word"), "capitalization is kept")
+}
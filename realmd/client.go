@@ -8,6 +8,7 @@ import (
 	"encoding/hex"
 	"fmt"
 	"net"
+	"sync"
 	"sync/atomic"
 
 	"github.com/kangaroux/go-wow-srp6/header"
@@ -40,6 +41,11 @@ type Client struct {
 	// Header manages packet header encryption/decryption as well as encoding server headers.
 	Header *header.WrathHeader
 
+	// sendLock ensures packets are encoded and written one at a time. Packets can be sent to a client
+	// from other goroutines (e.g. when another player moves nearby), and the header cipher requires
+	// that headers are written in the same order they were encrypted.
+	sendLock sync.Mutex
+
 	// Cancels a pending logout, if there is one. This func is safe to call when there is no pending logout.
 	CancelPendingLogout context.CancelFunc
 	LogoutPending       bool
@@ -115,6 +121,9 @@ func (c *Client) SendPacket(opcode ServerOpcode, data interface{}) error {
 // SendPacketBytes generates a header and sends a packet containing the header + data. In most cases,
 // SendPacket should be used instead.
 func (c *Client) SendPacketBytes(opcode ServerOpcode, data []byte) error {
+	c.sendLock.Lock()
+	defer c.sendLock.Unlock()
+
 	header, err := c.Header.Encode(uint16(opcode), uint32(len(data)))
 	if err != nil {
 		return err
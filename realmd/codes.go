@@ -35,6 +35,112 @@ const (
 	OpServerTime                  ServerOpcode = 0x1CF // SMSG_QUERY_TIME_RESPONSE
 	OpServerPlayedTime            ServerOpcode = 0x1CD // SMSG_PLAYED_TIME
 	OpServerPlayerTalents         ServerOpcode = 0x4C0 // SMSG_TALENTS_INFO
+	OpServerMessageChat           ServerOpcode = 0x96  // SMSG_MESSAGECHAT
+	OpServerChatPlayerNotFound    ServerOpcode = 0x2A9 // SMSG_CHAT_PLAYER_NOT_FOUND
+	OpServerChannelNotify         ServerOpcode = 0x99  // SMSG_CHANNEL_NOTIFY
+	OpServerChannelList           ServerOpcode = 0x9B  // SMSG_CHANNEL_LIST
+	OpServerLearnedSpell          ServerOpcode = 0x12B // SMSG_LEARNED_SPELL
+	OpServerTransferPending       ServerOpcode = 0x3F  // SMSG_TRANSFER_PENDING
+	OpServerNewWorld              ServerOpcode = 0x3E  // SMSG_NEW_WORLD
+	OpServerWho                   ServerOpcode = 0x63  // SMSG_WHO
+	OpServerContactList           ServerOpcode = 0x67  // SMSG_CONTACT_LIST
+	OpServerFriendStatus          ServerOpcode = 0x68  // SMSG_FRIEND_STATUS
+
+	OpServerGroupInvite            ServerOpcode = 0x6F  // SMSG_GROUP_INVITE
+	OpServerGroupDecline           ServerOpcode = 0x74  // SMSG_GROUP_DECLINE
+	OpServerGroupUninvite          ServerOpcode = 0x77  // SMSG_GROUP_UNINVITE
+	OpServerGroupSetLeader         ServerOpcode = 0x79  // SMSG_GROUP_SET_LEADER
+	OpServerGroupDestroyed         ServerOpcode = 0x7C  // SMSG_GROUP_DESTROYED
+	OpServerGroupList              ServerOpcode = 0x7D  // SMSG_GROUP_LIST
+	OpServerPartyMemberStats       ServerOpcode = 0x7E  // SMSG_PARTY_MEMBER_STATS
+	OpServerPartyCommandResult     ServerOpcode = 0x7F  // SMSG_PARTY_COMMAND_RESULT
+	OpServerPartyMemberStatsFull   ServerOpcode = 0x2F2 // SMSG_PARTY_MEMBER_STATS_FULL
+	OpServerRaidTargetUpdate       ServerOpcode = 0x321 // MSG_RAID_TARGET_UPDATE
+	OpServerRaidReadyCheck         ServerOpcode = 0x322 // MSG_RAID_READY_CHECK
+	OpServerRaidReadyCheckConfirm  ServerOpcode = 0x3AE // MSG_RAID_READY_CHECK_CONFIRM
+	OpServerRaidReadyCheckFinished ServerOpcode = 0x3C6 // MSG_RAID_READY_CHECK_FINISHED
+
+	OpServerGuildQueryResponse ServerOpcode = 0x55  // SMSG_GUILD_QUERY_RESPONSE
+	OpServerGuildInvite        ServerOpcode = 0x83  // SMSG_GUILD_INVITE
+	OpServerGuildDecline       ServerOpcode = 0x86  // SMSG_GUILD_DECLINE
+	OpServerGuildInfo          ServerOpcode = 0x88  // SMSG_GUILD_INFO
+	OpServerGuildRoster        ServerOpcode = 0x8A  // SMSG_GUILD_ROSTER
+	OpServerGuildEvent         ServerOpcode = 0x92  // SMSG_GUILD_EVENT
+	OpServerGuildCommandResult ServerOpcode = 0x93  // SMSG_GUILD_COMMAND_RESULT
+	OpServerGuildPermissions   ServerOpcode = 0x3FD // MSG_GUILD_PERMISSIONS
+
+	OpServerItemQueryResponse   ServerOpcode = 0x58  // SMSG_ITEM_QUERY_SINGLE_RESPONSE
+	OpServerInventoryChangeFail ServerOpcode = 0x112 // SMSG_INVENTORY_CHANGE_FAILURE
+	OpServerItemPushResult      ServerOpcode = 0x166 // SMSG_ITEM_PUSH_RESULT
+
+	OpServerCastFailed       ServerOpcode = 0x130 // SMSG_CAST_FAILED
+	OpServerSpellStart       ServerOpcode = 0x131 // SMSG_SPELL_START
+	OpServerSpellGo          ServerOpcode = 0x132 // SMSG_SPELL_GO
+	OpServerSpellFailure     ServerOpcode = 0x133 // SMSG_SPELL_FAILURE
+	OpServerChannelStart     ServerOpcode = 0x139 // MSG_CHANNEL_START
+	OpServerChannelUpdate    ServerOpcode = 0x13A // MSG_CHANNEL_UPDATE
+	OpServerSpellFailedOther ServerOpcode = 0x2A6 // SMSG_SPELL_FAILED_OTHER
+	OpServerPeriodicAuraLog  ServerOpcode = 0x24E // SMSG_PERIODICAURALOG
+	OpServerAuraUpdateAll    ServerOpcode = 0x495 // SMSG_AURA_UPDATE_ALL
+	OpServerAuraUpdate       ServerOpcode = 0x496 // SMSG_AURA_UPDATE
+
+	OpServerRemovedSpell      ServerOpcode = 0x203 // SMSG_REMOVED_SPELL
+	OpServerTalentWipeConfirm ServerOpcode = 0x2AA // MSG_TALENT_WIPE_CONFIRM
+
+	OpServerSetFactionVisible  ServerOpcode = 0x123 // SMSG_SET_FACTION_VISIBLE
+	OpServerSetFactionStanding ServerOpcode = 0x124 // SMSG_SET_FACTION_STANDING
+
+	OpServerLogXPGain             ServerOpcode = 0x1D0 // SMSG_LOG_XPGAIN
+	OpServerLevelUpInfo           ServerOpcode = 0x1D4 // SMSG_LEVELUP_INFO
+	OpServerExplorationExperience ServerOpcode = 0x1F8 // SMSG_EXPLORATION_EXPERIENCE
+
+	OpServerSendMailResult    ServerOpcode = 0x239 // SMSG_SEND_MAIL_RESULT
+	OpServerMailListResult    ServerOpcode = 0x23B // SMSG_MAIL_LIST_RESULT
+	OpServerQueryNextMailTime ServerOpcode = 0x284 // MSG_QUERY_NEXT_MAIL_TIME
+	OpServerReceivedMail      ServerOpcode = 0x285 // SMSG_RECEIVED_MAIL
+
+	OpServerMoveStartForward     ServerOpcode = 0xB5  // MSG_MOVE_START_FORWARD
+	OpServerMoveStartBackward    ServerOpcode = 0xB6  // MSG_MOVE_START_BACKWARD
+	OpServerMoveStop             ServerOpcode = 0xB7  // MSG_MOVE_STOP
+	OpServerMoveStartStrafeLeft  ServerOpcode = 0xB8  // MSG_MOVE_START_STRAFE_LEFT
+	OpServerMoveStartStrafeRight ServerOpcode = 0xB9  // MSG_MOVE_START_STRAFE_RIGHT
+	OpServerMoveStopStrafe       ServerOpcode = 0xBA  // MSG_MOVE_STOP_STRAFE
+	OpServerMoveJump             ServerOpcode = 0xBB  // MSG_MOVE_JUMP
+	OpServerMoveStartTurnLeft    ServerOpcode = 0xBC  // MSG_MOVE_START_TURN_LEFT
+	OpServerMoveStartTurnRight   ServerOpcode = 0xBD  // MSG_MOVE_START_TURN_RIGHT
+	OpServerMoveStopTurn         ServerOpcode = 0xBE  // MSG_MOVE_STOP_TURN
+	OpServerMoveStartPitchUp     ServerOpcode = 0xBF  // MSG_MOVE_START_PITCH_UP
+	OpServerMoveStartPitchDown   ServerOpcode = 0xC0  // MSG_MOVE_START_PITCH_DOWN
+	OpServerMoveStopPitch        ServerOpcode = 0xC1  // MSG_MOVE_STOP_PITCH
+	OpServerMoveSetRunMode       ServerOpcode = 0xC2  // MSG_MOVE_SET_RUN_MODE
+	OpServerMoveSetWalkMode      ServerOpcode = 0xC3  // MSG_MOVE_SET_WALK_MODE
+	OpServerMoveFallLand         ServerOpcode = 0xC9  // MSG_MOVE_FALL_LAND
+	OpServerMoveStartSwim        ServerOpcode = 0xCA  // MSG_MOVE_START_SWIM
+	OpServerMoveStopSwim         ServerOpcode = 0xCB  // MSG_MOVE_STOP_SWIM
+	OpServerMoveSetFacing        ServerOpcode = 0xDA  // MSG_MOVE_SET_FACING
+	OpServerMoveSetPitch         ServerOpcode = 0xDB  // MSG_MOVE_SET_PITCH
+	OpServerMoveHeartbeat        ServerOpcode = 0xEE  // MSG_MOVE_HEARTBEAT
+	OpServerMoveStartAscend      ServerOpcode = 0x359 // MSG_MOVE_START_ASCEND
+	OpServerMoveStopAscend       ServerOpcode = 0x35A // MSG_MOVE_STOP_ASCEND
+	OpServerMoveStartDescend     ServerOpcode = 0x3A7 // MSG_MOVE_START_DESCEND
+	OpServerMoveTeleportAck      ServerOpcode = 0xC7  // MSG_MOVE_TELEPORT_ACK
+
+	OpServerForceRunSpeedChange    ServerOpcode = 0xE2  // SMSG_FORCE_RUN_SPEED_CHANGE
+	OpServerForceSwimSpeedChange   ServerOpcode = 0xE6  // SMSG_FORCE_SWIM_SPEED_CHANGE
+	OpServerForceFlightSpeedChange ServerOpcode = 0x381 // SMSG_FORCE_FLIGHT_SPEED_CHANGE
+	OpServerSplineSetRunSpeed      ServerOpcode = 0x2FE // SMSG_SPLINE_SET_RUN_SPEED
+	OpServerSplineSetSwimSpeed     ServerOpcode = 0x300 // SMSG_SPLINE_SET_SWIM_SPEED
+	OpServerSplineSetFlightSpeed   ServerOpcode = 0x385 // SMSG_SPLINE_SET_FLIGHT_SPEED
+
+	OpServerAttackStart           ServerOpcode = 0x143 // SMSG_ATTACKSTART
+	OpServerAttackStop            ServerOpcode = 0x144 // SMSG_ATTACKSTOP
+	OpServerAttackSwingNotInRange ServerOpcode = 0x145 // SMSG_ATTACKSWING_NOTINRANGE
+	OpServerAttackSwingBadFacing  ServerOpcode = 0x146 // SMSG_ATTACKSWING_BADFACING
+	OpServerAttackSwingDeadTarget ServerOpcode = 0x148 // SMSG_ATTACKSWING_DEADTARGET
+	OpServerAttackSwingCantAttack ServerOpcode = 0x149 // SMSG_ATTACKSWING_CANT_ATTACK
+	OpServerAttackerStateUpdate   ServerOpcode = 0x14A // SMSG_ATTACKERSTATEUPDATE
+	OpServerCorpseQuery           ServerOpcode = 0x216 // MSG_CORPSE_QUERY
+	OpServerDeathReleaseLoc       ServerOpcode = 0x378 // SMSG_DEATH_RELEASE_LOC
 )
 
 type ClientOpcode uint32
@@ -58,22 +164,157 @@ const (
 	OpClientGetPlayedTime            ClientOpcode = 0x1CC // TODO CMSG_PLAYED_TIME
 	OpClientGetPlayerName            ClientOpcode = 0x50  // TODO CMSG_NAME_QUERY
 	OpClientSetActiveMover           ClientOpcode = 0x26A // TODO
-	OpClientSetActionBarToggles      ClientOpcode = 0x2BF // TODO
+	OpClientSetActionBarToggles      ClientOpcode = 0x2BF // CMSG_SET_ACTIONBAR_TOGGLES
 	OpClientGetRaidInfo              ClientOpcode = 0x2CD // TODO
 	OpClientGetTicketStatus          ClientOpcode = 0x211 // TODO CMSG_GMTICKET_GETTICKET
 	OpClientGetTime                  ClientOpcode = 0x1CE
-	OpClientGetItemInfo              ClientOpcode = 0x56  // TODO
-	OpClientGetNextMailArrival       ClientOpcode = 0x284 // TODO
+	OpClientGetItemInfo              ClientOpcode = 0x56  // CMSG_ITEM_QUERY_SINGLE
 	OpClientGetBattlefieldStatus     ClientOpcode = 0x2D3 // TODO
 	OpClientGetLFGStatus             ClientOpcode = 0x296 // TODO
 	OpClientGetLFGDungeonList        ClientOpcode = 0x36E // TODO CMSG_LFD_PLAYER_LOCK_INFO_REQUEST
 	OpClientGetGuildBankMoney        ClientOpcode = 0x3FE // TODO MSG_GUILD_BANK_MONEY_WITHDRAWN_Client
 	OpClientGetNumPendingEvents      ClientOpcode = 0x447 // TODO CMSG_CALENDAR_GET_NUM_PENDING
 	OpClientSetVoiceEnabled          ClientOpcode = 0x3AF // TODO CMSG_VOICE_SESSION_ENABLE
-	OpClientEnteredZone              ClientOpcode = 0x1F4 // TODO CMSG_ZONEUPDATE
+	OpClientEnteredZone              ClientOpcode = 0x1F4 // CMSG_ZONEUPDATE
+	OpClientAreaTrigger              ClientOpcode = 0xB4  // CMSG_AREATRIGGER
 	OpClientSetVoiceChannel          ClientOpcode = 0x3D3 // TODO CMSG_SET_ACTIVE_VOICE_CHANNEL
 	OpClientListBattlegrounds        ClientOpcode = 0x23C // TODO CMSG_BATTLEFIELD_LIST
 	OpClientCancelTrade              ClientOpcode = 0x11C // TODO CMSG_CANCEL_TRADE
+	OpClientMessageChat              ClientOpcode = 0x95  // CMSG_MESSAGECHAT
+	OpClientWho                      ClientOpcode = 0x62  // CMSG_WHO
+	OpClientContactList              ClientOpcode = 0x66  // CMSG_CONTACT_LIST
+	OpClientAddFriend                ClientOpcode = 0x69  // CMSG_ADD_FRIEND
+	OpClientDelFriend                ClientOpcode = 0x6A  // CMSG_DEL_FRIEND
+	OpClientSetContactNotes          ClientOpcode = 0x6B  // CMSG_SET_CONTACT_NOTES
+	OpClientAddIgnore                ClientOpcode = 0x6C  // CMSG_ADD_IGNORE
+	OpClientDelIgnore                ClientOpcode = 0x6D  // CMSG_DEL_IGNORE
+
+	OpClientGroupInvite             ClientOpcode = 0x6E  // CMSG_GROUP_INVITE
+	OpClientGroupAccept             ClientOpcode = 0x72  // CMSG_GROUP_ACCEPT
+	OpClientGroupDecline            ClientOpcode = 0x73  // CMSG_GROUP_DECLINE
+	OpClientGroupUninvite           ClientOpcode = 0x75  // CMSG_GROUP_UNINVITE
+	OpClientGroupUninviteGuid       ClientOpcode = 0x76  // CMSG_GROUP_UNINVITE_GUID
+	OpClientGroupSetLeader          ClientOpcode = 0x78  // CMSG_GROUP_SET_LEADER
+	OpClientLootMethod              ClientOpcode = 0x7A  // CMSG_LOOT_METHOD
+	OpClientGroupDisband            ClientOpcode = 0x7B  // CMSG_GROUP_DISBAND
+	OpClientGroupChangeSubGroup     ClientOpcode = 0x27E // CMSG_GROUP_CHANGE_SUB_GROUP
+	OpClientRequestPartyMemberStats ClientOpcode = 0x27F // CMSG_REQUEST_PARTY_MEMBER_STATS
+	OpClientGroupSwapSubGroup       ClientOpcode = 0x280 // CMSG_GROUP_SWAP_SUB_GROUP
+	OpClientGroupRaidConvert        ClientOpcode = 0x28E // CMSG_GROUP_RAID_CONVERT
+	OpClientGroupAssistantLeader    ClientOpcode = 0x28F // CMSG_GROUP_ASSISTANT_LEADER
+	OpClientRaidTargetUpdate        ClientOpcode = 0x321 // MSG_RAID_TARGET_UPDATE
+	OpClientRaidReadyCheck          ClientOpcode = 0x322 // MSG_RAID_READY_CHECK
+	OpClientRaidReadyCheckFinished  ClientOpcode = 0x3C6 // MSG_RAID_READY_CHECK_FINISHED
+
+	OpClientGuildQuery          ClientOpcode = 0x54  // CMSG_GUILD_QUERY
+	OpClientGuildInvite         ClientOpcode = 0x82  // CMSG_GUILD_INVITE
+	OpClientGuildAccept         ClientOpcode = 0x84  // CMSG_GUILD_ACCEPT
+	OpClientGuildDecline        ClientOpcode = 0x85  // CMSG_GUILD_DECLINE
+	OpClientGuildInfo           ClientOpcode = 0x87  // CMSG_GUILD_INFO
+	OpClientGuildRoster         ClientOpcode = 0x89  // CMSG_GUILD_ROSTER
+	OpClientGuildPromote        ClientOpcode = 0x8B  // CMSG_GUILD_PROMOTE
+	OpClientGuildDemote         ClientOpcode = 0x8C  // CMSG_GUILD_DEMOTE
+	OpClientGuildLeave          ClientOpcode = 0x8D  // CMSG_GUILD_LEAVE
+	OpClientGuildRemove         ClientOpcode = 0x8E  // CMSG_GUILD_REMOVE
+	OpClientGuildDisband        ClientOpcode = 0x8F  // CMSG_GUILD_DISBAND
+	OpClientGuildLeader         ClientOpcode = 0x90  // CMSG_GUILD_LEADER
+	OpClientGuildMOTD           ClientOpcode = 0x91  // CMSG_GUILD_MOTD
+	OpClientGuildRank           ClientOpcode = 0x231 // CMSG_GUILD_RANK
+	OpClientGuildAddRank        ClientOpcode = 0x232 // CMSG_GUILD_ADD_RANK
+	OpClientGuildDelRank        ClientOpcode = 0x233 // CMSG_GUILD_DEL_RANK
+	OpClientGuildSetPublicNote  ClientOpcode = 0x234 // CMSG_GUILD_SET_PUBLIC_NOTE
+	OpClientGuildSetOfficerNote ClientOpcode = 0x235 // CMSG_GUILD_SET_OFFICER_NOTE
+	OpClientGuildInfoText       ClientOpcode = 0x2FC // CMSG_GUILD_INFO_TEXT
+	OpClientGuildPermissions    ClientOpcode = 0x3FD // MSG_GUILD_PERMISSIONS
+
+	OpClientAutoEquipItem    ClientOpcode = 0x10A // CMSG_AUTOEQUIP_ITEM
+	OpClientAutoStoreBagItem ClientOpcode = 0x10B // CMSG_AUTOSTORE_BAG_ITEM
+	OpClientSwapItem         ClientOpcode = 0x10C // CMSG_SWAP_ITEM
+	OpClientSwapInvItem      ClientOpcode = 0x10D // CMSG_SWAP_INV_ITEM
+	OpClientSplitItem        ClientOpcode = 0x10E // CMSG_SPLIT_ITEM
+	OpClientDestroyItem      ClientOpcode = 0x111 // CMSG_DESTROYITEM
+	OpClientUseItem          ClientOpcode = 0xAB  // CMSG_USE_ITEM
+
+	OpClientSetActionButton   ClientOpcode = 0x128 // CMSG_SET_ACTION_BUTTON
+	OpClientCastSpell         ClientOpcode = 0x12E // CMSG_CAST_SPELL
+	OpClientCancelCast        ClientOpcode = 0x12F // CMSG_CANCEL_CAST
+	OpClientCancelAura        ClientOpcode = 0x136 // CMSG_CANCEL_AURA
+	OpClientCancelChannelling ClientOpcode = 0x13B // CMSG_CANCEL_CHANNELLING
+
+	OpClientLearnTalent         ClientOpcode = 0x251 // CMSG_LEARN_TALENT
+	OpClientTalentWipeConfirm   ClientOpcode = 0x2AA // MSG_TALENT_WIPE_CONFIRM
+	OpClientRemoveGlyph         ClientOpcode = 0x48A // CMSG_REMOVE_GLYPH
+	OpClientLearnPreviewTalents ClientOpcode = 0x4C1 // CMSG_LEARN_PREVIEW_TALENTS
+
+	OpClientSetFactionAtWar    ClientOpcode = 0x125 // CMSG_SET_FACTION_ATWAR
+	OpClientSetFactionInactive ClientOpcode = 0x317 // CMSG_SET_FACTION_INACTIVE
+
+	OpClientSendMail           ClientOpcode = 0x238 // CMSG_SEND_MAIL
+	OpClientGetMailList        ClientOpcode = 0x23A // CMSG_GET_MAIL_LIST
+	OpClientMailTakeMoney      ClientOpcode = 0x245 // CMSG_MAIL_TAKE_MONEY
+	OpClientMailTakeItem       ClientOpcode = 0x246 // CMSG_MAIL_TAKE_ITEM
+	OpClientMailMarkAsRead     ClientOpcode = 0x247 // CMSG_MAIL_MARK_AS_READ
+	OpClientMailReturnToSender ClientOpcode = 0x248 // CMSG_MAIL_RETURN_TO_SENDER
+	OpClientMailDelete         ClientOpcode = 0x249 // CMSG_MAIL_DELETE
+	OpClientQueryNextMailTime  ClientOpcode = 0x284 // MSG_QUERY_NEXT_MAIL_TIME
+
+	OpClientJoinChannel          ClientOpcode = 0x97  // CMSG_JOIN_CHANNEL
+	OpClientLeaveChannel         ClientOpcode = 0x98  // CMSG_LEAVE_CHANNEL
+	OpClientChannelList          ClientOpcode = 0x9A  // CMSG_CHANNEL_LIST
+	OpClientChannelPassword      ClientOpcode = 0x9C  // CMSG_CHANNEL_PASSWORD
+	OpClientChannelSetOwner      ClientOpcode = 0x9D  // CMSG_CHANNEL_SET_OWNER
+	OpClientChannelOwner         ClientOpcode = 0x9E  // CMSG_CHANNEL_OWNER
+	OpClientChannelModerator     ClientOpcode = 0x9F  // CMSG_CHANNEL_MODERATOR
+	OpClientChannelUnmoderator   ClientOpcode = 0xA0  // CMSG_CHANNEL_UNMODERATOR
+	OpClientChannelMute          ClientOpcode = 0xA1  // CMSG_CHANNEL_MUTE
+	OpClientChannelUnmute        ClientOpcode = 0xA2  // CMSG_CHANNEL_UNMUTE
+	OpClientChannelInvite        ClientOpcode = 0xA3  // CMSG_CHANNEL_INVITE
+	OpClientChannelKick          ClientOpcode = 0xA4  // CMSG_CHANNEL_KICK
+	OpClientChannelBan           ClientOpcode = 0xA5  // CMSG_CHANNEL_BAN
+	OpClientChannelUnban         ClientOpcode = 0xA6  // CMSG_CHANNEL_UNBAN
+	OpClientChannelAnnouncements ClientOpcode = 0xA7  // CMSG_CHANNEL_ANNOUNCEMENTS
+	OpClientChannelModerate      ClientOpcode = 0xA8  // CMSG_CHANNEL_MODERATE
+	OpClientChannelDisplayList   ClientOpcode = 0x3EE // CMSG_CHANNEL_DISPLAY_LIST
+
+	OpClientMoveStartForward     ClientOpcode = 0xB5  // MSG_MOVE_START_FORWARD
+	OpClientMoveStartBackward    ClientOpcode = 0xB6  // MSG_MOVE_START_BACKWARD
+	OpClientMoveStop             ClientOpcode = 0xB7  // MSG_MOVE_STOP
+	OpClientMoveStartStrafeLeft  ClientOpcode = 0xB8  // MSG_MOVE_START_STRAFE_LEFT
+	OpClientMoveStartStrafeRight ClientOpcode = 0xB9  // MSG_MOVE_START_STRAFE_RIGHT
+	OpClientMoveStopStrafe       ClientOpcode = 0xBA  // MSG_MOVE_STOP_STRAFE
+	OpClientMoveJump             ClientOpcode = 0xBB  // MSG_MOVE_JUMP
+	OpClientMoveStartTurnLeft    ClientOpcode = 0xBC  // MSG_MOVE_START_TURN_LEFT
+	OpClientMoveStartTurnRight   ClientOpcode = 0xBD  // MSG_MOVE_START_TURN_RIGHT
+	OpClientMoveStopTurn         ClientOpcode = 0xBE  // MSG_MOVE_STOP_TURN
+	OpClientMoveStartPitchUp     ClientOpcode = 0xBF  // MSG_MOVE_START_PITCH_UP
+	OpClientMoveStartPitchDown   ClientOpcode = 0xC0  // MSG_MOVE_START_PITCH_DOWN
+	OpClientMoveStopPitch        ClientOpcode = 0xC1  // MSG_MOVE_STOP_PITCH
+	OpClientMoveSetRunMode       ClientOpcode = 0xC2  // MSG_MOVE_SET_RUN_MODE
+	OpClientMoveSetWalkMode      ClientOpcode = 0xC3  // MSG_MOVE_SET_WALK_MODE
+	OpClientMoveFallLand         ClientOpcode = 0xC9  // MSG_MOVE_FALL_LAND
+	OpClientMoveStartSwim        ClientOpcode = 0xCA  // MSG_MOVE_START_SWIM
+	OpClientMoveStopSwim         ClientOpcode = 0xCB  // MSG_MOVE_STOP_SWIM
+	OpClientMoveSetFacing        ClientOpcode = 0xDA  // MSG_MOVE_SET_FACING
+	OpClientMoveSetPitch         ClientOpcode = 0xDB  // MSG_MOVE_SET_PITCH
+	OpClientMoveHeartbeat        ClientOpcode = 0xEE  // MSG_MOVE_HEARTBEAT
+	OpClientMoveStartAscend      ClientOpcode = 0x359 // MSG_MOVE_START_ASCEND
+	OpClientMoveStopAscend       ClientOpcode = 0x35A // MSG_MOVE_STOP_ASCEND
+	OpClientMoveStartDescend     ClientOpcode = 0x3A7 // MSG_MOVE_START_DESCEND
+	OpClientMoveTeleportAck      ClientOpcode = 0xC7  // MSG_MOVE_TELEPORT_ACK
+	OpClientMoveFallReset        ClientOpcode = 0x2CA // CMSG_MOVE_FALL_RESET
+	OpClientMoveTimeSkipped      ClientOpcode = 0x2CE // CMSG_MOVE_TIME_SKIPPED
+	OpClientMoveWorldportAck     ClientOpcode = 0xDC  // MSG_MOVE_WORLDPORT_ACK
+
+	OpClientForceRunSpeedChangeAck    ClientOpcode = 0xE3  // CMSG_FORCE_RUN_SPEED_CHANGE_ACK
+	OpClientForceSwimSpeedChangeAck   ClientOpcode = 0xE7  // CMSG_FORCE_SWIM_SPEED_CHANGE_ACK
+	OpClientForceFlightSpeedChangeAck ClientOpcode = 0x382 // CMSG_FORCE_FLIGHT_SPEED_CHANGE_ACK
+
+	OpClientAttackSwing          ClientOpcode = 0x141 // CMSG_ATTACKSWING
+	OpClientAttackStop           ClientOpcode = 0x142 // CMSG_ATTACKSTOP
+	OpClientRepopRequest         ClientOpcode = 0x15A // CMSG_REPOP_REQUEST
+	OpClientReclaimCorpse        ClientOpcode = 0x1D2 // CMSG_RECLAIM_CORPSE
+	OpClientCorpseQuery          ClientOpcode = 0x216 // MSG_CORPSE_QUERY
+	OpClientSpiritHealerActivate ClientOpcode = 0x21C // CMSG_SPIRIT_HEALER_ACTIVATE
 )
 
 type ResponseCode byte
@@ -0,0 +1,180 @@
+// Package command parses and runs the GM commands that players type in chat.
+package command
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/kangaroux/gomaggus/model"
+	"github.com/kangaroux/gomaggus/realmd"
+	"github.com/kangaroux/gomaggus/realmd/world"
+)
+
+const (
+	// Prefix is what chat messages start with to be treated as a command.
+	Prefix = "."
+)
+
+// ErrUsage is returned by a handler when the command's arguments are wrong. The player is shown how to
+// use the command.
+var ErrUsage = errors.New("command: incorrect usage")
+
+type Handler func(ctx *Context, args []string) error
+
+// Command is a command, or a group of subcommands. Commands can have both a handler and subcommands, in
+// which case the handler is used when none of the subcommands match.
+type Command struct {
+	Name string
+
+	// Usage describes the command's arguments, e.g. "<name> [count]".
+	Usage string
+	Help  string
+
+	// Level is the GM level an account needs to use the command.
+	Level model.GMLevel
+
+	Handler     Handler
+	Subcommands []*Command
+}
+
+// Context is what a command is running for.
+type Context struct {
+	Service *realmd.Service
+	World   *world.World
+
+	// Player is who used the command.
+	Player *world.Player
+}
+
+// Level returns the GM level of the player's account.
+func (ctx *Context) Level() model.GMLevel {
+	return ctx.Player.GMLevel()
+}
+
+// Reply sends a system message to the player that used the command.
+func (ctx *Context) Reply(format string, args ...interface{}) error {
+	return ctx.Player.SendSystemMessage(fmt.Sprintf(format, args...))
+}
+
+// commands is the root of the command tree. It's set in init since the help command needs to read it.
+var commands []*Command
+
+// lookup returns the command with the given name that an account with level can use, or nil if there
+// isn't one. Names are case-insensitive.
+func lookup(cmds []*Command, level model.GMLevel, name string) *Command {
+	for _, cmd := range cmds {
+		if cmd.Level <= level && strings.EqualFold(cmd.Name, name) {
+			return cmd
+		}
+	}
+	return nil
+}
+
+// find walks the command tree using args. It returns the deepest command that matched, the path that
+// was used to get there (e.g. ".gm on") and the remaining args.
+func find(cmds []*Command, level model.GMLevel, args []string) (*Command, string, []string) {
+	var cmd *Command
+	var path []string
+
+	for len(args) > 0 {
+		next := lookup(cmds, level, args[0])
+		if next == nil {
+			break
+		}
+
+		cmd = next
+		path = append(path, next.Name)
+		args = args[1:]
+		cmds = next.Subcommands
+	}
+
+	return cmd, Prefix + strings.Join(path, " "), args
+}
+
+// Run runs the command in text. handled is false if text isn't a command the player can use, in which
+// case it should be treated as a regular chat message. Commands that need a GM level are recorded in the
+// command log.
+func Run(ctx *Context, text string) (handled bool, err error) {
+	if !strings.HasPrefix(text, Prefix) {
+		return false, nil
+	}
+
+	args := strings.Fields(strings.TrimPrefix(text, Prefix))
+	if len(args) == 0 {
+		return false, nil
+	}
+
+	cmd, path, rest := find(commands, ctx.Level(), args)
+	if cmd == nil {
+		// Players can't use commands, so this is likely a normal message that happens to start with a dot
+		if ctx.Level() == model.GMLevelPlayer {
+			return false, nil
+		}
+
+		return true, ctx.Reply("Unknown command %s%s. Type .help to see the commands you can use.", Prefix, args[0])
+	}
+
+	if cmd.Level > model.GMLevelPlayer {
+		if err := audit(ctx, text); err != nil {
+			return true, err
+		}
+	}
+
+	if cmd.Handler == nil {
+		return true, sendHelp(ctx, cmd, path)
+	}
+
+	err = cmd.Handler(ctx, rest)
+	if err == ErrUsage {
+		return true, ctx.Reply("Usage: %s", strings.TrimSpace(path+" "+cmd.Usage))
+	}
+
+	return true, err
+}
+
+// audit records that the player used a command.
+func audit(ctx *Context, text string) error {
+	p := ctx.Player
+
+	p.Client.Log.Info().
+		Str("char", p.Character.String()).
+		Uint8("gm", uint8(ctx.Level())).
+		Str("cmd", text).
+		Msg("command used")
+
+	entry := &model.CommandLog{
+		CharacterId: sql.NullInt32{Int32: int32(p.Character.Id), Valid: true},
+		Command:     text,
+	}
+	if p.Client.Account != nil {
+		entry.AccountId = p.Client.Account.Id
+	}
+
+	return ctx.Service.CommandLog.Create(entry)
+}
+
+// sendHelp tells the player how to use cmd and lists any subcommands they can use.
+func sendHelp(ctx *Context, cmd *Command, path string) error {
+	if err := ctx.Reply("%s", describe(path, cmd)); err != nil {
+		return err
+	}
+
+	for _, sub := range cmd.Subcommands {
+		if sub.Level > ctx.Level() {
+			continue
+		}
+
+		if err := ctx.Reply("  %s", describe(path+" "+sub.Name, sub)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// describe returns a line containing the command's usage and help text.
+func describe(path string, cmd *Command) string {
+	return strings.TrimSpace(path+" "+cmd.Usage) + " - " + cmd.Help
+}
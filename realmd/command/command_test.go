@@ -0,0 +1,169 @@
+package command
+
+import (
+	"bytes"
+	"net"
+	"testing"
+
+	"github.com/kangaroux/gomaggus/model"
+	"github.com/kangaroux/gomaggus/realmd"
+	"github.com/kangaroux/gomaggus/realmd/dbc"
+	"github.com/kangaroux/gomaggus/realmd/world"
+	"github.com/phuslu/log"
+	"github.com/stretchr/testify/assert"
+)
+
+type testConn struct {
+	net.Conn
+	last []byte
+}
+
+func (c *testConn) Write(p []byte) (int, error) {
+	c.last = p
+	return len(p), nil
+}
+
+type testCommandLog struct {
+	entries []*model.CommandLog
+}
+
+func (l *testCommandLog) List(uint32) ([]*model.CommandLog, error) {
+	return l.entries, nil
+}
+
+func (l *testCommandLog) Create(c *model.CommandLog) error {
+	l.entries = append(l.entries, c)
+	return nil
+}
+
+func newTestContext(t *testing.T, level model.GMLevel) (*Context, *testConn, *testCommandLog) {
+	data, err := dbc.Load("../dbc/testdata")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	conn := &testConn{}
+	client, _ := realmd.NewClient(conn)
+	client.Log = &log.Logger{Writer: log.WriterFunc(func(e *log.Entry) (int, error) { return 0, nil })}
+	client.Account = &model.Account{Id: 1, GMLevel: level}
+	client.Character = &model.Character{Id: 1, Name: "Alice", Race: model.RaceHuman, Class: model.ClassWarrior}
+
+	p, err := world.NewPlayer(client, client.Character, data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	w := world.New(nil)
+	if err := w.AddPlayer(p); err != nil {
+		t.Fatal(err)
+	}
+
+	commandLog := &testCommandLog{}
+	ctx := &Context{
+		Service: &realmd.Service{CommandLog: commandLog, DBC: data},
+		World:   w,
+		Player:  p,
+	}
+
+	return ctx, conn, commandLog
+}
+
+func TestRun(t *testing.T) {
+	t.Run("player", func(t *testing.T) {
+		ctx, _, commandLog := newTestContext(t, model.GMLevelPlayer)
+
+		tests := []struct {
+			text    string
+			handled bool
+		}{
+			{"hello", false},
+			{".", false},
+			{"...", false},
+			{".gps", false},
+			{".help", true},
+		}
+
+		for _, tt := range tests {
+			handled, err := Run(ctx, tt.text)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.handled, handled, tt.text)
+		}
+
+		assert.Empty(t, commandLog.entries, "commands everyone can use aren't logged")
+	})
+
+	t.Run("gm", func(t *testing.T) {
+		ctx, conn, commandLog := newTestContext(t, model.GMLevelGameMaster)
+
+		handled, err := Run(ctx, ".nope")
+		assert.True(t, handled)
+		assert.NoError(t, err)
+		assert.True(t, bytes.Contains(conn.last, []byte("Unknown command")))
+		assert.Empty(t, commandLog.entries, "unknown commands aren't logged")
+
+		handled, err = Run(ctx, ".GPS")
+		assert.True(t, handled)
+		assert.NoError(t, err)
+		assert.True(t, bytes.Contains(conn.last, []byte("Map: 0")))
+
+		if assert.Len(t, commandLog.entries, 1) {
+			assert.Equal(t, uint32(1), commandLog.entries[0].AccountId)
+			assert.Equal(t, ".GPS", commandLog.entries[0].Command)
+		}
+	})
+
+	t.Run("usage", func(t *testing.T) {
+		ctx, conn, _ := newTestContext(t, model.GMLevelGameMaster)
+
+		handled, err := Run(ctx, ".speed fast")
+		assert.True(t, handled)
+		assert.NoError(t, err)
+		assert.True(t, bytes.Contains(conn.last, []byte("Usage: .speed <rate>")))
+	})
+}
+
+func TestCommands(t *testing.T) {
+	ctx, _, _ := newTestContext(t, model.GMLevelAdministrator)
+	p := ctx.Player
+
+	run := func(text string) {
+		handled, err := Run(ctx, text)
+		assert.True(t, handled, text)
+		assert.NoError(t, err, text)
+	}
+
+	t.Run("gm", func(t *testing.T) {
+		run(".gm on")
+		assert.True(t, p.Values.GM())
+
+		run(".gm off")
+		assert.False(t, p.Values.GM())
+	})
+
+	t.Run("levelup", func(t *testing.T) {
+		run(".levelup 4")
+		assert.Equal(t, uint32(5), p.Values.Level())
+		assert.Equal(t, p.Values.MaxHealth(), p.Values.Health())
+
+		run(".levelup -100")
+		assert.Equal(t, uint32(1), p.Values.Level())
+	})
+
+	t.Run("speed", func(t *testing.T) {
+		run(".speed 2")
+		assert.Equal(t, world.DefaultSpeeds.Run*2, p.Speeds.Run)
+		assert.Equal(t, world.DefaultSpeeds.Walk, p.Speeds.Walk)
+	})
+
+	t.Run("tele", func(t *testing.T) {
+		run(".tele 1 2 3")
+		assert.Equal(t, float32(2), p.Position().Y)
+
+		run(".tele 10 20 30 1")
+		assert.Equal(t, uint32(1), p.MapID)
+		assert.Equal(t, float32(30), p.Position().Z)
+
+		run(".tele 1 2 3 9999")
+		assert.Equal(t, uint32(1), p.MapID, "unknown map")
+	})
+}
@@ -0,0 +1,697 @@
+package command
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/kangaroux/gomaggus/model"
+	"github.com/kangaroux/gomaggus/realmd"
+	"github.com/kangaroux/gomaggus/realmd/item"
+	"github.com/kangaroux/gomaggus/realmd/mail"
+	"github.com/kangaroux/gomaggus/realmd/player"
+	"github.com/kangaroux/gomaggus/realmd/spell"
+	"github.com/kangaroux/gomaggus/realmd/world"
+)
+
+const (
+	minSpeedRate = 0.1
+	maxSpeedRate = 10.0
+)
+
+func init() {
+	commands = []*Command{
+		{
+			Name:    "help",
+			Usage:   "[command]",
+			Help:    "Lists the commands you can use, or shows how to use a command.",
+			Level:   model.GMLevelPlayer,
+			Handler: helpHandler,
+		},
+		{
+			Name:    "gps",
+			Help:    "Shows your current location.",
+			Level:   model.GMLevelModerator,
+			Handler: gpsHandler,
+		},
+		{
+			Name:    "played",
+			Usage:   "<name>",
+			Help:    "Shows how long a character has been played, even if they are offline.",
+			Level:   model.GMLevelModerator,
+			Handler: playedHandler,
+		},
+		{
+			Name:    "kick",
+			Usage:   "<name>",
+			Help:    "Disconnects a player.",
+			Level:   model.GMLevelModerator,
+			Handler: kickHandler,
+		},
+		{
+			Name:    "announce",
+			Usage:   "<message>",
+			Help:    "Sends a message to every player.",
+			Level:   model.GMLevelModerator,
+			Handler: announceHandler,
+		},
+		{
+			Name:    "tele",
+			Usage:   "<location> | <x> <y> <z> [map]",
+			Help:    "Teleports you to a named location or to coordinates.",
+			Level:   model.GMLevelGameMaster,
+			Handler: teleHandler,
+		},
+		{
+			Name:    "gm",
+			Usage:   "[on|off]",
+			Help:    "Shows whether GM mode is on.",
+			Level:   model.GMLevelGameMaster,
+			Handler: gmHandler,
+			Subcommands: []*Command{
+				{
+					Name:    "on",
+					Help:    "Turns on GM mode.",
+					Level:   model.GMLevelGameMaster,
+					Handler: gmOnHandler,
+				},
+				{
+					Name:    "off",
+					Help:    "Turns off GM mode.",
+					Level:   model.GMLevelGameMaster,
+					Handler: gmOffHandler,
+				},
+			},
+		},
+		{
+			Name:    "speed",
+			Usage:   "<rate>",
+			Help:    fmt.Sprintf("Changes your movement speed (%g-%g).", minSpeedRate, maxSpeedRate),
+			Level:   model.GMLevelGameMaster,
+			Handler: speedHandler,
+		},
+		{
+			Name:    "revive",
+			Usage:   "[name]",
+			Help:    "Brings you or another player back to life, and restores them to full health.",
+			Level:   model.GMLevelGameMaster,
+			Handler: reviveHandler,
+		},
+		{
+			Name:    "levelup",
+			Usage:   "[levels]",
+			Help:    "Raises your level, or lowers it if levels is negative.",
+			Level:   model.GMLevelGameMaster,
+			Handler: levelUpHandler,
+		},
+		{
+			Name:    "learn",
+			Usage:   "<spell id>",
+			Help:    "Teaches you a spell.",
+			Level:   model.GMLevelGameMaster,
+			Handler: learnHandler,
+		},
+		{
+			Name:    "resettalents",
+			Help:    "Offers to reset your talents, as a trainer would.",
+			Level:   model.GMLevelGameMaster,
+			Handler: resetTalentsHandler,
+		},
+		{
+			Name:    "reputation",
+			Usage:   "<faction id> <amount>",
+			Help:    "Changes your reputation with a faction.",
+			Level:   model.GMLevelGameMaster,
+			Handler: reputationHandler,
+		},
+		{
+			Name:    "setskill",
+			Usage:   "<skill id> <value> [max]",
+			Help:    "Sets the value and max of one of your skills, learning it if you don't know it.",
+			Level:   model.GMLevelGameMaster,
+			Handler: setSkillHandler,
+		},
+		{
+			Name:    "additem",
+			Usage:   "<item id> [count]",
+			Help:    "Adds an item to your bags.",
+			Level:   model.GMLevelGameMaster,
+			Handler: addItemHandler,
+		},
+		{
+			Name:    "enchant",
+			Usage:   "<enchant id>",
+			Help:    "Permanently enchants your main hand weapon, or removes its enchant if the id is 0.",
+			Level:   model.GMLevelGameMaster,
+			Handler: enchantHandler,
+		},
+		{
+			Name:    "sendmail",
+			Usage:   "<name> <copper> <subject>",
+			Help:    "Sends mail with money attached to a character, even if they are offline.",
+			Level:   model.GMLevelGameMaster,
+			Handler: sendMailHandler,
+		},
+		{
+			Name:  "guild",
+			Help:  "Manages guilds.",
+			Level: model.GMLevelGameMaster,
+			Subcommands: []*Command{
+				{
+					Name:    "create",
+					Usage:   "<name>",
+					Help:    "Creates a guild with you as its guild master.",
+					Level:   model.GMLevelGameMaster,
+					Handler: guildCreateHandler,
+				},
+			},
+		},
+	}
+}
+
+func helpHandler(ctx *Context, args []string) error {
+	if len(args) > 0 {
+		cmd, path, _ := find(commands, ctx.Level(), args)
+		if cmd == nil {
+			return ctx.Reply("Unknown command %s%s.", Prefix, args[0])
+		}
+		return sendHelp(ctx, cmd, path)
+	}
+
+	if err := ctx.Reply("Commands:"); err != nil {
+		return err
+	}
+
+	for _, cmd := range commands {
+		if cmd.Level > ctx.Level() {
+			continue
+		}
+
+		if err := ctx.Reply("  %s", describe(Prefix+cmd.Name, cmd)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func gpsHandler(ctx *Context, args []string) error {
+	p := ctx.Player
+	pos := p.Position()
+
+	zone := "Unknown"
+	if area, ok := ctx.Service.DBC.Areas[p.Zone]; ok {
+		zone = area.Name
+	}
+
+	return ctx.Reply(
+		"Map: %d Zone: %d (%s) X: %.3f Y: %.3f Z: %.3f O: %.3f",
+		p.MapID, p.Zone, zone, pos.X, pos.Y, pos.Z, pos.Rotation,
+	)
+}
+
+func playedHandler(ctx *Context, args []string) error {
+	if len(args) != 1 {
+		return ErrUsage
+	}
+
+	var total, level time.Duration
+	if target := ctx.World.PlayerByName(args[0]); target != nil {
+		total, level = target.PlayedTime()
+	} else {
+		char, err := ctx.Service.Characters.GetName(args[0], ctx.Player.Character.RealmId)
+		if err != nil {
+			return err
+		} else if char == nil {
+			return ctx.Reply("Character %s doesn't exist.", args[0])
+		}
+		total = time.Duration(char.PlayedTotal) * time.Second
+		level = time.Duration(char.PlayedLevel) * time.Second
+	}
+
+	return ctx.Reply(
+		"%s has played for %s in total and %s at their level.",
+		args[0], total.Truncate(time.Second), level.Truncate(time.Second),
+	)
+}
+
+func kickHandler(ctx *Context, args []string) error {
+	if len(args) != 1 {
+		return ErrUsage
+	}
+
+	target := ctx.World.PlayerByName(args[0])
+	if target == nil {
+		return ctx.Reply("%s isn't online.", args[0])
+	}
+
+	if target.GMLevel() > ctx.Level() {
+		return ctx.Reply("You can't kick %s.", target.Character.Name)
+	}
+
+	target.Client.Log.Warn().Str("by", ctx.Player.Character.String()).Msg("kicked by GM")
+	ctx.World.KickPlayer(target)
+
+	return ctx.Reply("Kicked %s.", target.Character.Name)
+}
+
+func announceHandler(ctx *Context, args []string) error {
+	if len(args) == 0 {
+		return ErrUsage
+	}
+
+	ctx.World.Announce(fmt.Sprintf("[%s]: %s", ctx.Player.Character.Name, strings.Join(args, " ")))
+
+	return nil
+}
+
+// locations are the named places that players can teleport to.
+var locations = map[string]player.Location{
+	"stormwind":    {Map: 0, Zone: 1519, Position: realmd.Vector4{X: -8833.38, Y: 628.628, Z: 94.0066, Rotation: 1.06535}},
+	"ironforge":    {Map: 0, Zone: 1537, Position: realmd.Vector4{X: -4918.88, Y: -940.406, Z: 501.564, Rotation: 5.42347}},
+	"darnassus":    {Map: 1, Zone: 1657, Position: realmd.Vector4{X: 9949.56, Y: 2284.21, Z: 1341.4, Rotation: 1.59587}},
+	"exodar":       {Map: 530, Zone: 3557, Position: realmd.Vector4{X: -3965.7, Y: -11653.6, Z: -138.844, Rotation: 0.852154}},
+	"orgrimmar":    {Map: 1, Zone: 1637, Position: realmd.Vector4{X: 1629.36, Y: -4373.39, Z: 31.2564, Rotation: 3.54839}},
+	"undercity":    {Map: 0, Zone: 1497, Position: realmd.Vector4{X: 1584.07, Y: 241.987, Z: -52.1534, Rotation: 0.049647}},
+	"thunderbluff": {Map: 1, Zone: 1638, Position: realmd.Vector4{X: -1277.37, Y: 124.804, Z: 131.287, Rotation: 5.22274}},
+	"silvermoon":   {Map: 530, Zone: 3487, Position: realmd.Vector4{X: 9487.69, Y: -7279.2, Z: 14.2866, Rotation: 6.16478}},
+	"shattrath":    {Map: 530, Zone: 3703, Position: realmd.Vector4{X: -1838.16, Y: 5301.79, Z: -12.428, Rotation: 5.9517}},
+	"dalaran":      {Map: 571, Zone: 4395, Position: realmd.Vector4{X: 5804.15, Y: 624.771, Z: 647.767, Rotation: 1.64}},
+	"gmisland":     {Map: 1, Zone: 876, Position: realmd.Vector4{X: 16222.1, Y: 16252.1, Z: 12.5872, Rotation: 0}},
+}
+
+func teleHandler(ctx *Context, args []string) error {
+	p := ctx.Player
+	var loc player.Location
+
+	switch len(args) {
+	case 1:
+		named, ok := locations[strings.ToLower(args[0])]
+		if !ok {
+			var names []string
+			for name := range locations {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+
+			return ctx.Reply("Unknown location %s. Locations: %s", args[0], strings.Join(names, ", "))
+		}
+		loc = named
+
+	case 3, 4:
+		var coords [3]float32
+		for i := range coords {
+			v, err := strconv.ParseFloat(args[i], 32)
+			if err != nil {
+				return ErrUsage
+			}
+			coords[i] = float32(v)
+		}
+
+		loc = player.Location{
+			Map: p.MapID,
+			Position: realmd.Vector4{
+				X:        coords[0],
+				Y:        coords[1],
+				Z:        coords[2],
+				Rotation: p.Position().Rotation,
+			},
+		}
+
+		if len(args) == 4 {
+			id, err := strconv.ParseUint(args[3], 10, 32)
+			if err != nil {
+				return ErrUsage
+			}
+			loc.Map = uint32(id)
+		}
+
+	default:
+		return ErrUsage
+	}
+
+	if _, ok := ctx.Service.DBC.Maps[loc.Map]; !ok {
+		return ctx.Reply("Map %d doesn't exist.", loc.Map)
+	}
+
+	return ctx.World.TransferPlayer(p, loc.Map, loc.Position)
+}
+
+func gmHandler(ctx *Context, args []string) error {
+	if len(args) > 0 {
+		return ErrUsage
+	}
+
+	if ctx.Player.Values.GM() {
+		return ctx.Reply("GM mode is on.")
+	}
+	return ctx.Reply("GM mode is off.")
+}
+
+func gmOnHandler(ctx *Context, args []string) error {
+	ctx.Player.Values.SetGM(true)
+	return ctx.Reply("GM mode is now on.")
+}
+
+func gmOffHandler(ctx *Context, args []string) error {
+	ctx.Player.Values.SetGM(false)
+	return ctx.Reply("GM mode is now off.")
+}
+
+func speedHandler(ctx *Context, args []string) error {
+	if len(args) != 1 {
+		return ErrUsage
+	}
+
+	rate, err := strconv.ParseFloat(args[0], 32)
+	if err != nil {
+		return ErrUsage
+	}
+
+	if rate < minSpeedRate || rate > maxSpeedRate {
+		return ctx.Reply("Speed must be between %g and %g.", minSpeedRate, maxSpeedRate)
+	}
+
+	if err := ctx.World.SetSpeedRate(ctx.Player, float32(rate)); err != nil {
+		return err
+	}
+
+	return ctx.Reply("Speed set to %g.", rate)
+}
+
+func reviveHandler(ctx *Context, args []string) error {
+	target := ctx.Player
+
+	switch len(args) {
+	case 0:
+	case 1:
+		target = ctx.World.PlayerByName(args[0])
+		if target == nil {
+			return ctx.Reply("%s isn't online.", args[0])
+		}
+	default:
+		return ErrUsage
+	}
+
+	ctx.World.Resurrect(target)
+
+	return ctx.Reply("Revived %s.", target.Character.Name)
+}
+
+func levelUpHandler(ctx *Context, args []string) error {
+	levels := 1
+
+	switch len(args) {
+	case 0:
+	case 1:
+		n, err := strconv.Atoi(args[0])
+		if err != nil {
+			return ErrUsage
+		}
+		levels = n
+	default:
+		return ErrUsage
+	}
+
+	level := int(ctx.Player.Values.Level()) + levels
+	if start := int(player.StartLevel(ctx.Player.Character.Class)); level < start {
+		level = start
+	} else if level > player.MaxLevel {
+		level = player.MaxLevel
+	}
+
+	if err := ctx.World.GiveLevel(ctx.Player, uint32(level)); err != nil {
+		return err
+	}
+	ctx.Player.Values.SetExperience(0)
+
+	return ctx.Reply("You are now level %d.", level)
+}
+
+func learnHandler(ctx *Context, args []string) error {
+	if len(args) != 1 {
+		return ErrUsage
+	}
+
+	id, err := strconv.ParseUint(args[0], 10, 32)
+	if err != nil {
+		return ErrUsage
+	}
+
+	s, ok := ctx.Service.DBC.Spells[uint32(id)]
+	if !ok {
+		return ctx.Reply("Spell %d doesn't exist.", id)
+	}
+
+	learned, err := spell.Learn(ctx.Service, ctx.Player, s.ID)
+	if err != nil {
+		return err
+	}
+	if !learned {
+		return ctx.Reply("You already know %s (%d).", s.Name, s.ID)
+	}
+
+	return ctx.Reply("Learned %s (%d).", s.Name, s.ID)
+}
+
+func resetTalentsHandler(ctx *Context, args []string) error {
+	if len(args) != 0 {
+		return ErrUsage
+	}
+
+	ctx.World.ConfirmTalentReset(ctx.Player, ctx.Player.GUID())
+	return nil
+}
+
+func reputationHandler(ctx *Context, args []string) error {
+	if len(args) != 2 {
+		return ErrUsage
+	}
+
+	id, err := strconv.ParseUint(args[0], 10, 32)
+	if err != nil {
+		return ErrUsage
+	}
+	amount, err := strconv.ParseInt(args[1], 10, 32)
+	if err != nil {
+		return ErrUsage
+	}
+
+	if !ctx.World.ModifyReputation(ctx.Player, uint32(id), int32(amount)) {
+		return ctx.Reply("Faction %d doesn't have a reputation.", id)
+	}
+
+	r := ctx.Player.Reputation(uint32(id))
+	return ctx.Reply("Your reputation with %s is now %d.", r.Faction.Name, r.Value())
+}
+
+func setSkillHandler(ctx *Context, args []string) error {
+	if len(args) < 2 || len(args) > 3 {
+		return ErrUsage
+	}
+
+	var nums [3]uint64
+	for i, arg := range args {
+		n, err := strconv.ParseUint(arg, 10, 16)
+		if err != nil {
+			return ErrUsage
+		}
+		nums[i] = n
+	}
+
+	skill := player.Skill{ID: uint16(nums[0]), Value: uint16(nums[1]), Max: uint16(nums[2])}
+	if len(args) == 2 {
+		skill.Max = skill.Value
+		if current, ok := ctx.Player.Skill(skill.ID); ok && current.Max > skill.Max {
+			skill.Max = current.Max
+		}
+	}
+
+	if !ctx.Player.SetSkill(skill) {
+		return ctx.Reply("You can't learn any more skills.")
+	}
+
+	skill, _ = ctx.Player.Skill(skill.ID)
+	return ctx.Reply("Skill %d is now %d/%d.", skill.ID, skill.Value, skill.Max)
+}
+
+func addItemHandler(ctx *Context, args []string) error {
+	if len(args) < 1 || len(args) > 2 {
+		return ErrUsage
+	}
+
+	id, err := strconv.ParseUint(args[0], 10, 32)
+	if err != nil {
+		return ErrUsage
+	}
+
+	count := uint64(1)
+	if len(args) == 2 {
+		if count, err = strconv.ParseUint(args[1], 10, 32); err != nil || count == 0 {
+			return ErrUsage
+		}
+	}
+
+	t, err := ctx.Service.Items.GetTemplate(uint32(id))
+	if err != nil {
+		return err
+	} else if t == nil {
+		return ctx.Reply("Item %d doesn't exist.", id)
+	}
+
+	result, err := item.Give(ctx.Service, ctx.World, ctx.Player, t, uint32(count))
+	if err != nil {
+		return err
+	}
+
+	switch result {
+	case world.InventoryResultOK:
+		return ctx.Reply("Added %dx %s.", count, t.Name)
+	case world.InventoryResultCantCarryMoreOfThis:
+		return ctx.Reply("You can't carry more than %d of %s.", t.MaxCount, t.Name)
+	default:
+		return ctx.Reply("You don't have room for %dx %s.", count, t.Name)
+	}
+}
+
+func enchantHandler(ctx *Context, args []string) error {
+	if len(args) != 1 {
+		return ErrUsage
+	}
+
+	id, err := strconv.ParseUint(args[0], 10, 32)
+	if err != nil {
+		return ErrUsage
+	}
+
+	weapon := ctx.Player.Item(world.InventorySlotBag0, world.EquipmentSlotMainHand)
+	if weapon == nil {
+		return ctx.Reply("You don't have a main hand weapon.")
+	}
+
+	var name string
+	if id != 0 {
+		e, ok := ctx.Service.DBC.ItemEnchantments[uint32(id)]
+		if !ok {
+			return ctx.Reply("Enchant %d doesn't exist.", id)
+		}
+		name = e.Name
+	}
+
+	ctx.World.EnchantItem(ctx.Player, weapon, uint32(id))
+	if _, err := ctx.Service.Items.Update(weapon.Item); err != nil {
+		return err
+	}
+
+	if id == 0 {
+		return ctx.Reply("Removed the enchant from %s.", weapon.Template.Name)
+	}
+	return ctx.Reply("Enchanted %s with %s (%d).", weapon.Template.Name, name, id)
+}
+
+func sendMailHandler(ctx *Context, args []string) error {
+	if len(args) < 3 {
+		return ErrUsage
+	}
+
+	money, err := strconv.ParseUint(args[1], 10, 32)
+	if err != nil || money > world.MaxMoney {
+		return ErrUsage
+	}
+
+	receiver, err := ctx.Service.Characters.GetName(args[0], ctx.Player.Character.RealmId)
+	if err != nil {
+		return err
+	} else if receiver == nil {
+		return ctx.Reply("Character %s doesn't exist.", args[0])
+	}
+
+	subject := strings.Join(args[2:], " ")
+	if len(subject) > mail.MaxSubjectLength {
+		subject = subject[:mail.MaxSubjectLength]
+	}
+
+	m := &model.Mail{
+		ReceiverId:  receiver.Id,
+		MessageType: model.MailTypeNormal,
+		Stationery:  model.MailStationeryGM,
+		Subject:     subject,
+		Money:       uint32(money),
+	}
+	if err := mail.Send(ctx.Service, ctx.World, m); err != nil {
+		return err
+	}
+
+	return ctx.Reply("Sent mail to %s.", receiver.Name)
+}
+
+// validGuildName reports whether name can be used for a guild. Guild names are letters and spaces.
+func validGuildName(name string) bool {
+	if name == "" || len(name) > world.MaxGuildNameLength {
+		return false
+	}
+
+	for _, c := range name {
+		if c != ' ' && (c < 'A' || c > 'Z') && (c < 'a' || c > 'z') {
+			return false
+		}
+	}
+
+	return true
+}
+
+func guildCreateHandler(ctx *Context, args []string) error {
+	if len(args) == 0 {
+		return ErrUsage
+	}
+
+	p := ctx.Player
+	name := strings.Join(args, " ")
+
+	if p.Guild() != nil {
+		return ctx.Reply("You are already in a guild.")
+	}
+
+	if !validGuildName(name) {
+		return ctx.Reply("Guild names can only have letters and spaces, and can't be longer than %d characters.", world.MaxGuildNameLength)
+	}
+
+	existing, err := ctx.Service.Guilds.GetName(name, p.Character.RealmId)
+	if err != nil {
+		return err
+	} else if existing != nil {
+		return ctx.Reply("There is already a guild named %s.", existing.Name)
+	}
+
+	g := &model.Guild{
+		RealmId:  p.Character.RealmId,
+		Name:     name,
+		LeaderId: p.Character.Id,
+	}
+	if err := ctx.Service.Guilds.Create(g); err != nil {
+		return err
+	}
+
+	ranks := world.DefaultGuildRanks(g.Id)
+	for _, r := range ranks {
+		if err := ctx.Service.Guilds.SaveRank(r); err != nil {
+			return err
+		}
+	}
+
+	leader := &model.GuildMember{
+		CharacterId: p.Character.Id,
+		GuildId:     g.Id,
+		Rank:        0,
+	}
+	if err := ctx.Service.Guilds.SaveMember(leader); err != nil {
+		return err
+	}
+
+	loaded := ctx.World.LoadGuild(g, ranks, []*model.GuildMember{leader}, []*model.Character{p.Character})
+	ctx.World.GuildSignOn(p, loaded)
+
+	return ctx.Reply("Created guild %s.", g.Name)
+}
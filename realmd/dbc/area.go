@@ -0,0 +1,236 @@
+package dbc
+
+import "math"
+
+// https://wowdev.wiki/DB/Map
+type Map struct {
+	ID           uint32
+	Directory    string
+	InstanceType uint32
+	Flags        uint32
+	PVP          bool
+	Name         string
+	AreaTable    uint32
+	CorpseMap    int32
+	CorpseX      float32
+	CorpseY      float32
+	Expansion    uint32
+	MaxPlayers   uint32
+}
+
+const (
+	MapInstanceTypeNone  = 0
+	MapInstanceTypeParty = 1
+	MapInstanceTypeRaid  = 2
+	MapInstanceTypePVP   = 3
+	MapInstanceTypeArena = 4
+)
+
+const mapRecordSize = 66 * 4
+
+func loadMaps(path string) (map[uint32]*Map, error) {
+	f, err := loadFile(path, mapRecordSize)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[uint32]*Map, len(f.records))
+
+	for _, r := range f.records {
+		row := &Map{
+			ID:           r.Uint32(0),
+			Directory:    r.String(1),
+			InstanceType: r.Uint32(2),
+			Flags:        r.Uint32(3),
+			PVP:          r.Uint32(4) == 1,
+			Name:         r.LocString(5),
+			AreaTable:    r.Uint32(22),
+			CorpseMap:    r.Int32(59),
+			CorpseX:      r.Float(60),
+			CorpseY:      r.Float(61),
+			Expansion:    r.Uint32(63),
+			MaxPlayers:   r.Uint32(65),
+		}
+		result[row.ID] = row
+	}
+
+	return result, nil
+}
+
+// https://wowdev.wiki/DB/AreaTable
+type Area struct {
+	ID     uint32
+	Map    uint32
+	Parent uint32
+
+	// AreaBit is the bit in the explored zones field that is set when the area is explored.
+	AreaBit          int32
+	Flags            uint32
+	ExplorationLevel int32
+	Name             string
+	FactionGroupMask uint32
+}
+
+const (
+	AreaFlagCapital   = 0x100
+	AreaFlagSanctuary = 0x800
+)
+
+const areaTableRecordSize = 36 * 4
+
+func loadAreas(path string) (map[uint32]*Area, error) {
+	f, err := loadFile(path, areaTableRecordSize)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[uint32]*Area, len(f.records))
+
+	for _, r := range f.records {
+		row := &Area{
+			ID:               r.Uint32(0),
+			Map:              r.Uint32(1),
+			Parent:           r.Uint32(2),
+			AreaBit:          r.Int32(3),
+			Flags:            r.Uint32(4),
+			ExplorationLevel: r.Int32(10),
+			Name:             r.LocString(11),
+			FactionGroupMask: r.Uint32(28),
+		}
+		result[row.ID] = row
+	}
+
+	return result, nil
+}
+
+// https://wowdev.wiki/DB/AreaTrigger
+type AreaTrigger struct {
+	ID  uint32
+	Map uint32
+	X   float32
+	Y   float32
+	Z   float32
+
+	// Triggers with a radius are spheres, otherwise they're a box centered on the position and turned by
+	// BoxYaw.
+	Radius    float32
+	BoxLength float32
+	BoxWidth  float32
+	BoxHeight float32
+	BoxYaw    float32
+}
+
+// The client's position can be a little out of date, so it can be this far outside of a trigger and still be
+// counted as inside it.
+const areaTriggerTolerance = 5
+
+// Contains reports whether a position on mapID is inside the trigger.
+func (t *AreaTrigger) Contains(mapID uint32, x, y, z float32) bool {
+	if mapID != t.Map {
+		return false
+	}
+
+	dx, dy, dz := float64(x-t.X), float64(y-t.Y), float64(z-t.Z)
+
+	if t.Radius > 0 {
+		return math.Sqrt(dx*dx+dy*dy+dz*dz) <= float64(t.Radius)+areaTriggerTolerance
+	}
+
+	// Turn the position the other way around the center so the box lines up with the axes
+	sin, cos := math.Sincos(-float64(t.BoxYaw))
+	rx := dx*cos - dy*sin
+	ry := dx*sin + dy*cos
+
+	return math.Abs(rx) <= float64(t.BoxLength)/2+areaTriggerTolerance &&
+		math.Abs(ry) <= float64(t.BoxWidth)/2+areaTriggerTolerance &&
+		math.Abs(dz) <= float64(t.BoxHeight)/2+areaTriggerTolerance
+}
+
+const areaTriggerRecordSize = 10 * 4
+
+func loadAreaTriggers(path string) (map[uint32]*AreaTrigger, error) {
+	f, err := loadFile(path, areaTriggerRecordSize)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[uint32]*AreaTrigger, len(f.records))
+
+	for _, r := range f.records {
+		row := &AreaTrigger{
+			ID:        r.Uint32(0),
+			Map:       r.Uint32(1),
+			X:         r.Float(2),
+			Y:         r.Float(3),
+			Z:         r.Float(4),
+			Radius:    r.Float(5),
+			BoxLength: r.Float(6),
+			BoxWidth:  r.Float(7),
+			BoxHeight: r.Float(8),
+			BoxYaw:    r.Float(9),
+		}
+		result[row.ID] = row
+	}
+
+	return result, nil
+}
+
+// https://wowdev.wiki/DB/WorldMapArea
+type WorldMapArea struct {
+	ID   uint32
+	Map  uint32
+	Area uint32
+
+	// The bounds of the zone's map. Left and Right are Y coordinates, Top and Bottom are X coordinates.
+	Left   float32
+	Right  float32
+	Top    float32
+	Bottom float32
+}
+
+// Contains reports whether a position on mapID is inside the zone's map.
+func (a *WorldMapArea) Contains(mapID uint32, x, y float32) bool {
+	return mapID == a.Map && x <= a.Top && x >= a.Bottom && y <= a.Left && y >= a.Right
+}
+
+const worldMapAreaRecordSize = 11 * 4
+
+// loadWorldMapAreas returns the maps of zones by area id. Maps of whole continents aren't included.
+func loadWorldMapAreas(path string) (map[uint32]*WorldMapArea, error) {
+	f, err := loadFile(path, worldMapAreaRecordSize)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[uint32]*WorldMapArea, len(f.records))
+
+	for _, r := range f.records {
+		row := &WorldMapArea{
+			ID:     r.Uint32(0),
+			Map:    r.Uint32(1),
+			Area:   r.Uint32(2),
+			Left:   r.Float(4),
+			Right:  r.Float(5),
+			Top:    r.Float(6),
+			Bottom: r.Float(7),
+		}
+		if _, ok := result[row.Area]; row.Area != 0 && !ok {
+			result[row.Area] = row
+		}
+	}
+
+	return result, nil
+}
+
+// ZoneMap returns the map of the zone the area is in, or nil if the zone doesn't have one. Subzones use their
+// parent zone's map.
+func (s *Store) ZoneMap(area *Area) *WorldMapArea {
+	// The parents are followed a few times at most, in case an area is its own parent
+	for i := 0; area != nil && i < 4; i++ {
+		if m, ok := s.WorldMapAreas[area.ID]; ok {
+			return m
+		}
+		area = s.Areas[area.Parent]
+	}
+	return nil
+}
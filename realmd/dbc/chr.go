@@ -0,0 +1,152 @@
+package dbc
+
+import (
+	"github.com/kangaroux/gomaggus/model"
+)
+
+// https://wowdev.wiki/DB/ChrRaces
+type ChrRace struct {
+	ID                model.Race
+	Flags             uint32
+	FactionTemplate   uint32
+	MaleDisplayID     uint32
+	FemaleDisplayID   uint32
+	ClientPrefix      string
+	BaseLanguage      uint32
+	CreatureType      uint32
+	CinematicSequence uint32
+	Horde             bool
+	Name              string
+	Expansion         uint32
+}
+
+// DisplayID returns the race's model for gender.
+func (r *ChrRace) DisplayID(gender model.Gender) uint32 {
+	if gender == model.GenderFemale {
+		return r.FemaleDisplayID
+	}
+	return r.MaleDisplayID
+}
+
+const chrRacesRecordSize = 69 * 4
+
+func loadChrRaces(path string) (map[model.Race]*ChrRace, error) {
+	f, err := loadFile(path, chrRacesRecordSize)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[model.Race]*ChrRace, len(f.records))
+
+	for _, r := range f.records {
+		row := &ChrRace{
+			ID:                model.Race(r.Uint32(0)),
+			Flags:             r.Uint32(1),
+			FactionTemplate:   r.Uint32(2),
+			MaleDisplayID:     r.Uint32(4),
+			FemaleDisplayID:   r.Uint32(5),
+			ClientPrefix:      r.String(6),
+			BaseLanguage:      r.Uint32(7),
+			CreatureType:      r.Uint32(8),
+			CinematicSequence: r.Uint32(12),
+			Horde:             r.Uint32(13) == 1,
+			Name:              r.LocString(14),
+			Expansion:         r.Uint32(68),
+		}
+		result[row.ID] = row
+	}
+
+	return result, nil
+}
+
+// https://wowdev.wiki/DB/ChrClasses
+type ChrClass struct {
+	ID                model.Class
+	DisplayPower      uint32
+	Name              string
+	SpellClassSet     uint32
+	Flags             uint32
+	CinematicSequence uint32
+	Expansion         uint32
+}
+
+const chrClassesRecordSize = 60 * 4
+
+func loadChrClasses(path string) (map[model.Class]*ChrClass, error) {
+	f, err := loadFile(path, chrClassesRecordSize)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[model.Class]*ChrClass, len(f.records))
+
+	for _, r := range f.records {
+		row := &ChrClass{
+			ID:                model.Class(r.Uint32(0)),
+			DisplayPower:      r.Uint32(2),
+			Name:              r.LocString(4),
+			SpellClassSet:     r.Uint32(56),
+			Flags:             r.Uint32(57),
+			CinematicSequence: r.Uint32(58),
+			Expansion:         r.Uint32(59),
+		}
+		result[row.ID] = row
+	}
+
+	return result, nil
+}
+
+// NumOutfitItems is the number of items in a starting outfit.
+const NumOutfitItems = 24
+
+// https://wowdev.wiki/DB/CharStartOutfit
+type CharStartOutfit struct {
+	ID     uint32
+	Race   model.Race
+	Class  model.Class
+	Gender model.Gender
+	Outfit uint8
+
+	// Items that aren't used are -1 or 0
+	ItemID        [NumOutfitItems]int32
+	DisplayItemID [NumOutfitItems]int32
+	InventoryType [NumOutfitItems]int32
+}
+
+// The race, class, gender and outfit are packed into a single field.
+const charStartOutfitRecordSize = 74 * 4
+
+type outfitKey struct {
+	Race   model.Race
+	Class  model.Class
+	Gender model.Gender
+}
+
+func loadCharStartOutfits(path string) (map[outfitKey]*CharStartOutfit, error) {
+	f, err := loadFile(path, charStartOutfitRecordSize)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[outfitKey]*CharStartOutfit, len(f.records))
+
+	for _, r := range f.records {
+		row := &CharStartOutfit{
+			ID:     r.Uint32(0),
+			Race:   model.Race(r.Byte(1, 0)),
+			Class:  model.Class(r.Byte(1, 1)),
+			Gender: model.Gender(r.Byte(1, 2)),
+			Outfit: r.Byte(1, 3),
+		}
+
+		for i := 0; i < NumOutfitItems; i++ {
+			row.ItemID[i] = r.Int32(2 + i)
+			row.DisplayItemID[i] = r.Int32(2 + NumOutfitItems + i)
+			row.InventoryType[i] = r.Int32(2 + NumOutfitItems*2 + i)
+		}
+
+		result[outfitKey{row.Race, row.Class, row.Gender}] = row
+	}
+
+	return result, nil
+}
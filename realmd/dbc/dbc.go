@@ -0,0 +1,165 @@
+// Package dbc reads the client database (DBC) files that ship with the 3.3.5 client.
+//
+// https://wowdev.wiki/DBC
+package dbc
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+)
+
+const (
+	// A localized string is 16 string offsets (one for each locale) followed by a flags field.
+	numLocales = 16
+)
+
+var (
+	ErrBadMagic         = errors.New("dbc: file is not a DBC file")
+	ErrTruncated        = errors.New("dbc: file is truncated")
+	ErrUnexpectedLayout = errors.New("dbc: record layout doesn't match the expected layout")
+
+	dbcMagic = [4]byte{'W', 'D', 'B', 'C'}
+)
+
+type header struct {
+	Magic           [4]byte
+	RecordCount     uint32
+	FieldCount      uint32
+	RecordSize      uint32
+	StringBlockSize uint32
+}
+
+// file is the raw contents of a DBC file.
+type file struct {
+	fieldCount uint32
+	recordSize uint32
+	records    []record
+}
+
+// record is a single row in a DBC file. Fields are accessed by their index, where every field is 4 bytes.
+type record struct {
+	data    []byte
+	strings []byte
+}
+
+// readFile parses a DBC file from r.
+func readFile(r io.Reader) (*file, error) {
+	h := header{}
+	if err := binary.Read(r, binary.LittleEndian, &h); err != nil {
+		if errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, io.EOF) {
+			return nil, ErrTruncated
+		}
+		return nil, err
+	}
+
+	if h.Magic != dbcMagic {
+		return nil, ErrBadMagic
+	}
+
+	data := make([]byte, int(h.RecordCount)*int(h.RecordSize)+int(h.StringBlockSize))
+	if _, err := io.ReadFull(r, data); err != nil {
+		if errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, io.EOF) {
+			return nil, ErrTruncated
+		}
+		return nil, err
+	}
+
+	strings := data[h.RecordCount*h.RecordSize:]
+	f := &file{
+		fieldCount: h.FieldCount,
+		recordSize: h.RecordSize,
+		records:    make([]record, h.RecordCount),
+	}
+
+	for i := range f.records {
+		start := uint32(i) * h.RecordSize
+		f.records[i] = record{data: data[start : start+h.RecordSize], strings: strings}
+	}
+
+	return f, nil
+}
+
+// loadFile reads the DBC file at path. recordSize is the expected size of each record, in bytes.
+func loadFile(path string, recordSize uint32) (*file, error) {
+	fp, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer fp.Close()
+
+	f, err := readFile(fp)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	if f.recordSize != recordSize {
+		return nil, fmt.Errorf("%s: %w: record size is %d, expected %d",
+			path, ErrUnexpectedLayout, f.recordSize, recordSize)
+	}
+
+	return f, nil
+}
+
+// field returns the bytes of field i. The record size is checked when the file is loaded, so the
+// field is always inside the record.
+func (r record) field(i int) []byte {
+	return r.data[i*4 : i*4+4]
+}
+
+func (r record) Uint32(i int) uint32 {
+	return binary.LittleEndian.Uint32(r.field(i))
+}
+
+func (r record) Int32(i int) int32 {
+	return int32(r.Uint32(i))
+}
+
+func (r record) Float(i int) float32 {
+	return math.Float32frombits(r.Uint32(i))
+}
+
+// Byte returns the nth byte of field i. Some files pack several byte-sized values into a single field.
+func (r record) Byte(i int, n int) byte {
+	return r.field(i)[n]
+}
+
+// Uint32s returns n consecutive fields starting at field i.
+func (r record) Uint32s(i int, n int) []uint32 {
+	result := make([]uint32, n)
+	for j := range result {
+		result[j] = r.Uint32(i + j)
+	}
+	return result
+}
+
+// String returns the string that field i points to in the string block. An offset outside of the string
+// block is treated as an empty string.
+func (r record) String(i int) string {
+	offset := r.Uint32(i)
+	if offset >= uint32(len(r.strings)) {
+		return ""
+	}
+
+	s := r.strings[offset:]
+	if end := bytes.IndexByte(s, 0); end >= 0 {
+		s = s[:end]
+	}
+
+	return string(s)
+}
+
+// LocString returns the localized string that starts at field i. The first locale that has a value is
+// used, since a client only contains the strings for its own locale.
+func (r record) LocString(i int) string {
+	for j := 0; j < numLocales; j++ {
+		if s := r.String(i + j); s != "" {
+			return s
+		}
+	}
+	return ""
+}
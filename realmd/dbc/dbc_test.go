@@ -0,0 +1,65 @@
+package dbc
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func makeFile(fields []uint32, strings string) []byte {
+	buf := bytes.Buffer{}
+	buf.WriteString("WDBC")
+	binary.Write(&buf, binary.LittleEndian, []uint32{1, uint32(len(fields)), uint32(len(fields) * 4), uint32(len(strings))})
+	binary.Write(&buf, binary.LittleEndian, fields)
+	buf.WriteString(strings)
+	return buf.Bytes()
+}
+
+func TestReadFile(t *testing.T) {
+	data := makeFile([]uint32{7, 0xFFFFFFFF, 0x3FC00000, 0x04030201, 1, 0, 5}, "\x00abc\x00de\x00")
+
+	f, err := readFile(bytes.NewReader(data))
+	assert.NoError(t, err)
+	assert.Len(t, f.records, 1)
+
+	r := f.records[0]
+	assert.Equal(t, uint32(7), r.Uint32(0))
+	assert.Equal(t, int32(-1), r.Int32(1))
+	assert.Equal(t, float32(1.5), r.Float(2))
+	assert.Equal(t, byte(3), r.Byte(3, 2))
+	assert.Equal(t, []uint32{7, 0xFFFFFFFF}, r.Uint32s(0, 2))
+	assert.Equal(t, "abc", r.String(4))
+	assert.Equal(t, "", r.String(5))
+	assert.Equal(t, "", r.String(1), "offset outside the string block")
+	assert.Equal(t, "de", r.String(6))
+	assert.Equal(t, "abc", r.LocString(4))
+}
+
+func TestReadFileErrors(t *testing.T) {
+	data := makeFile([]uint32{1, 2, 3}, "\x00")
+
+	tests := []struct {
+		name     string
+		data     []byte
+		expected error
+	}{
+		{"empty", nil, ErrTruncated},
+		{"bad magic", append([]byte("WDBX"), data[4:]...), ErrBadMagic},
+		{"truncated header", data[:10], ErrTruncated},
+		{"truncated records", data[:len(data)-5], ErrTruncated},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := readFile(bytes.NewReader(tt.data))
+			assert.ErrorIs(t, err, tt.expected)
+		})
+	}
+}
+
+func TestLoadFileUnexpectedLayout(t *testing.T) {
+	_, err := loadFile("testdata/Talent.dbc", talentTabRecordSize)
+	assert.ErrorIs(t, err, ErrUnexpectedLayout)
+}
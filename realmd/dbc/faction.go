@@ -0,0 +1,79 @@
+package dbc
+
+import (
+	"github.com/kangaroux/gomaggus/model"
+)
+
+// NumFactionReputations is the number of race/class combinations that a faction can define a base
+// reputation for.
+const NumFactionReputations = 4
+
+// https://wowdev.wiki/DB/Faction
+type Faction struct {
+	ID uint32
+
+	// ReputationIndex is the faction's index in the client's reputation list, or -1 if the faction has
+	// no reputation.
+	ReputationIndex int32
+
+	// A character uses the base reputation and flags of the first entry that matches their race and
+	// class. An empty mask matches anything.
+	RaceMask  [NumFactionReputations]uint32
+	ClassMask [NumFactionReputations]uint32
+	Base      [NumFactionReputations]int32
+	Flags     [NumFactionReputations]uint32
+
+	Parent uint32
+	Name   string
+}
+
+const factionRecordSize = 57 * 4
+
+func loadFactions(path string) (map[uint32]*Faction, error) {
+	f, err := loadFile(path, factionRecordSize)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[uint32]*Faction, len(f.records))
+
+	for _, r := range f.records {
+		row := &Faction{
+			ID:              r.Uint32(0),
+			ReputationIndex: r.Int32(1),
+			Parent:          r.Uint32(18),
+			Name:            r.LocString(23),
+		}
+
+		for i := 0; i < NumFactionReputations; i++ {
+			row.RaceMask[i] = r.Uint32(2 + i)
+			row.ClassMask[i] = r.Uint32(6 + i)
+			row.Base[i] = r.Int32(10 + i)
+			row.Flags[i] = r.Uint32(14 + i)
+		}
+
+		result[row.ID] = row
+	}
+
+	return result, nil
+}
+
+// BaseReputation returns the starting reputation and flags for a character with the given race and
+// class. ok is false if the faction doesn't define a reputation for them.
+func (f *Faction) BaseReputation(race model.Race, class model.Class) (standing int32, flags uint32, ok bool) {
+	raceMask := uint32(1) << (race - 1)
+	classMask := uint32(1) << (class - 1)
+
+	for i := 0; i < NumFactionReputations; i++ {
+		if f.RaceMask[i] == 0 && f.ClassMask[i] == 0 {
+			continue
+		}
+
+		if (f.RaceMask[i] == 0 || f.RaceMask[i]&raceMask > 0) &&
+			(f.ClassMask[i] == 0 || f.ClassMask[i]&classMask > 0) {
+			return f.Base[i], f.Flags[i], true
+		}
+	}
+
+	return 0, 0, false
+}
@@ -0,0 +1,60 @@
+package dbc
+
+// https://wowdev.wiki/DB/WorldSafeLocs
+type WorldSafeLoc struct {
+	ID   uint32
+	Map  uint32
+	X    float32
+	Y    float32
+	Z    float32
+	Name string
+}
+
+const worldSafeLocRecordSize = 22 * 4
+
+func loadWorldSafeLocs(path string) (map[uint32]*WorldSafeLoc, error) {
+	f, err := loadFile(path, worldSafeLocRecordSize)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[uint32]*WorldSafeLoc, len(f.records))
+
+	for _, r := range f.records {
+		row := &WorldSafeLoc{
+			ID:   r.Uint32(0),
+			Map:  r.Uint32(1),
+			X:    r.Float(2),
+			Y:    r.Float(3),
+			Z:    r.Float(4),
+			Name: r.LocString(5),
+		}
+		result[row.ID] = row
+	}
+
+	return result, nil
+}
+
+// NearestGraveyard returns the safe location closest to x, y on mapID, or nil if the map doesn't have any.
+// Dungeons send their dead to the graveyard closest to the dungeon's entrance.
+func (s *Store) NearestGraveyard(mapID uint32, x, y float32) *WorldSafeLoc {
+	if m, ok := s.Maps[mapID]; ok && m.CorpseMap >= 0 {
+		mapID, x, y = uint32(m.CorpseMap), m.CorpseX, m.CorpseY
+	}
+
+	var nearest *WorldSafeLoc
+	var nearestDist float32
+
+	for _, loc := range s.WorldSafeLocs {
+		if loc.Map != mapID {
+			continue
+		}
+
+		dx, dy := loc.X-x, loc.Y-y
+		if dist := dx*dx + dy*dy; nearest == nil || dist < nearestDist {
+			nearest, nearestDist = loc, dist
+		}
+	}
+
+	return nearest
+}
@@ -0,0 +1,32 @@
+package dbc
+
+// https://wowdev.wiki/DB/SpellItemEnchantment
+type SpellItemEnchantment struct {
+	ID   uint32
+	Name string
+
+	// ItemVisual is the glow shown on an item with the enchantment, or 0 if it doesn't have one.
+	ItemVisual uint32
+}
+
+const spellItemEnchantmentRecordSize = 38 * 4
+
+func loadSpellItemEnchantments(path string) (map[uint32]*SpellItemEnchantment, error) {
+	f, err := loadFile(path, spellItemEnchantmentRecordSize)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[uint32]*SpellItemEnchantment, len(f.records))
+
+	for _, r := range f.records {
+		row := &SpellItemEnchantment{
+			ID:         r.Uint32(0),
+			Name:       r.LocString(14),
+			ItemVisual: r.Uint32(31),
+		}
+		result[row.ID] = row
+	}
+
+	return result, nil
+}
@@ -0,0 +1,333 @@
+package dbc
+
+// NumSpellEffects is the number of effects a spell can have.
+const NumSpellEffects = 3
+
+// SpellEffect is one of the effects of a spell.
+type SpellEffect struct {
+	Effect             uint32
+	DieSides           int32
+	BasePoints         int32
+	ImplicitTargetA    uint32
+	ImplicitTargetB    uint32
+	RadiusIndex        uint32
+	ApplyAuraName      uint32
+	Amplitude          uint32
+	ChainTarget        uint32
+	ItemType           uint32
+	MiscValue          int32
+	MiscValueB         int32
+	TriggerSpell       uint32
+	RealPointsPerLevel float32
+}
+
+// https://wowdev.wiki/DB/Spell
+type Spell struct {
+	ID                     uint32
+	Category               uint32
+	Dispel                 uint32
+	Mechanic               uint32
+	Attributes             uint32
+	AttributesEx           [7]uint32
+	Targets                uint32
+	CastingTimeIndex       uint32
+	RecoveryTime           uint32
+	CategoryRecoveryTime   uint32
+	InterruptFlags         uint32
+	AuraInterruptFlags     uint32
+	ChannelInterruptFlags  uint32
+	ProcFlags              uint32
+	ProcChance             uint32
+	ProcCharges            uint32
+	MaxLevel               uint32
+	BaseLevel              uint32
+	SpellLevel             uint32
+	DurationIndex          uint32
+	PowerType              int32
+	ManaCost               uint32
+	ManaCostPerLevel       uint32
+	ManaPerSecond          uint32
+	RangeIndex             uint32
+	Speed                  float32
+	StackAmount            uint32
+	Effects                [NumSpellEffects]SpellEffect
+	SpellIconID            uint32
+	Name                   string
+	Rank                   string
+	ManaCostPercentage     uint32
+	StartRecoveryCategory  uint32
+	StartRecoveryTime      uint32
+	SpellFamilyName        uint32
+	SpellFamilyFlags       [3]uint32
+	MaxAffectedTargets     uint32
+	DmgClass               uint32
+	PreventionType         uint32
+	SchoolMask             uint32
+	EffectBonusMultipliers [NumSpellEffects]float32
+}
+
+// Spell attributes that the server checks.
+const (
+	SpellAttrPassive      = 0x40
+	SpellAttrEx1Channeled = 0x04
+	SpellAttrEx1Channel2  = 0x40
+)
+
+// Flags for what interrupts a spell. InterruptFlags are checked while the spell is being cast, and
+// ChannelInterruptFlags are checked while it's being channeled.
+const (
+	SpellInterruptMovement    = 0x01
+	SpellChannelInterruptMove = 0x08
+)
+
+// Channeled reports whether the spell is channeled after it's cast.
+func (s *Spell) Channeled() bool {
+	return s.AttributesEx[0]&(SpellAttrEx1Channeled|SpellAttrEx1Channel2) != 0
+}
+
+// Passive reports whether the spell is always active and can't be cast.
+func (s *Spell) Passive() bool {
+	return s.Attributes&SpellAttrPassive != 0
+}
+
+const spellRecordSize = 234 * 4
+
+func loadSpells(path string) (map[uint32]*Spell, error) {
+	f, err := loadFile(path, spellRecordSize)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[uint32]*Spell, len(f.records))
+
+	for _, r := range f.records {
+		row := &Spell{
+			ID:                    r.Uint32(0),
+			Category:              r.Uint32(1),
+			Dispel:                r.Uint32(2),
+			Mechanic:              r.Uint32(3),
+			Attributes:            r.Uint32(4),
+			Targets:               r.Uint32(16),
+			CastingTimeIndex:      r.Uint32(28),
+			RecoveryTime:          r.Uint32(29),
+			CategoryRecoveryTime:  r.Uint32(30),
+			InterruptFlags:        r.Uint32(31),
+			AuraInterruptFlags:    r.Uint32(32),
+			ChannelInterruptFlags: r.Uint32(33),
+			ProcFlags:             r.Uint32(34),
+			ProcChance:            r.Uint32(35),
+			ProcCharges:           r.Uint32(36),
+			MaxLevel:              r.Uint32(37),
+			BaseLevel:             r.Uint32(38),
+			SpellLevel:            r.Uint32(39),
+			DurationIndex:         r.Uint32(40),
+			PowerType:             r.Int32(41),
+			ManaCost:              r.Uint32(42),
+			ManaCostPerLevel:      r.Uint32(43),
+			ManaPerSecond:         r.Uint32(44),
+			RangeIndex:            r.Uint32(46),
+			Speed:                 r.Float(47),
+			StackAmount:           r.Uint32(49),
+			SpellIconID:           r.Uint32(133),
+			Name:                  r.LocString(136),
+			Rank:                  r.LocString(153),
+			ManaCostPercentage:    r.Uint32(204),
+			StartRecoveryCategory: r.Uint32(205),
+			StartRecoveryTime:     r.Uint32(206),
+			SpellFamilyName:       r.Uint32(208),
+			MaxAffectedTargets:    r.Uint32(212),
+			DmgClass:              r.Uint32(213),
+			PreventionType:        r.Uint32(214),
+			SchoolMask:            r.Uint32(225),
+		}
+
+		copy(row.AttributesEx[:], r.Uint32s(5, len(row.AttributesEx)))
+		copy(row.SpellFamilyFlags[:], r.Uint32s(209, len(row.SpellFamilyFlags)))
+
+		for i := 0; i < NumSpellEffects; i++ {
+			row.Effects[i] = SpellEffect{
+				Effect:             r.Uint32(71 + i),
+				DieSides:           r.Int32(74 + i),
+				RealPointsPerLevel: r.Float(77 + i),
+				BasePoints:         r.Int32(80 + i),
+				ImplicitTargetA:    r.Uint32(86 + i),
+				ImplicitTargetB:    r.Uint32(89 + i),
+				RadiusIndex:        r.Uint32(92 + i),
+				ApplyAuraName:      r.Uint32(95 + i),
+				Amplitude:          r.Uint32(98 + i),
+				ChainTarget:        r.Uint32(104 + i),
+				ItemType:           r.Uint32(107 + i),
+				MiscValue:          r.Int32(110 + i),
+				MiscValueB:         r.Int32(113 + i),
+				TriggerSpell:       r.Uint32(116 + i),
+			}
+			row.EffectBonusMultipliers[i] = r.Float(229 + i)
+		}
+
+		result[row.ID] = row
+	}
+
+	return result, nil
+}
+
+// https://wowdev.wiki/DB/SkillLineAbility
+type SkillLineAbility struct {
+	ID           uint32
+	SkillLine    uint32
+	Spell        uint32
+	RaceMask     uint32
+	ClassMask    uint32
+	ExcludeRace  uint32
+	ExcludeClass uint32
+	MinSkillRank uint32
+
+	// SupercededBy is the spell that replaces this one, e.g. the next rank.
+	SupercededBy  uint32
+	AcquireMethod uint32
+	TrivialHigh   uint32
+	TrivialLow    uint32
+}
+
+const (
+	// The spell is learned when the skill is learned
+	SkillLineAbilityAcquireOnSkillLearn = 1
+	// The spell is learned when the skill value changes
+	SkillLineAbilityAcquireOnSkillValue = 2
+)
+
+const skillLineAbilityRecordSize = 14 * 4
+
+func loadSkillLineAbilities(path string) (map[uint32]*SkillLineAbility, error) {
+	f, err := loadFile(path, skillLineAbilityRecordSize)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[uint32]*SkillLineAbility, len(f.records))
+
+	for _, r := range f.records {
+		row := &SkillLineAbility{
+			ID:            r.Uint32(0),
+			SkillLine:     r.Uint32(1),
+			Spell:         r.Uint32(2),
+			RaceMask:      r.Uint32(3),
+			ClassMask:     r.Uint32(4),
+			ExcludeRace:   r.Uint32(5),
+			ExcludeClass:  r.Uint32(6),
+			MinSkillRank:  r.Uint32(7),
+			SupercededBy:  r.Uint32(8),
+			AcquireMethod: r.Uint32(9),
+			TrivialHigh:   r.Uint32(10),
+			TrivialLow:    r.Uint32(11),
+		}
+		result[row.ID] = row
+	}
+
+	return result, nil
+}
+
+// https://wowdev.wiki/DB/SpellCastTimes
+type SpellCastTime struct {
+	ID uint32
+
+	// Base is the cast time in milliseconds, which changes by PerLevel for each level of the spell. The cast
+	// time is never shorter than Minimum.
+	Base     int32
+	PerLevel int32
+	Minimum  int32
+}
+
+const spellCastTimeRecordSize = 4 * 4
+
+func loadSpellCastTimes(path string) (map[uint32]*SpellCastTime, error) {
+	f, err := loadFile(path, spellCastTimeRecordSize)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[uint32]*SpellCastTime, len(f.records))
+
+	for _, r := range f.records {
+		row := &SpellCastTime{
+			ID:       r.Uint32(0),
+			Base:     r.Int32(1),
+			PerLevel: r.Int32(2),
+			Minimum:  r.Int32(3),
+		}
+		result[row.ID] = row
+	}
+
+	return result, nil
+}
+
+// https://wowdev.wiki/DB/SpellRange
+type SpellRange struct {
+	ID uint32
+
+	// The ranges are in yards, and are different for hostile and friendly targets.
+	MinRangeHostile float32
+	MinRangeFriend  float32
+	MaxRangeHostile float32
+	MaxRangeFriend  float32
+	Flags           uint32
+}
+
+// The display names are two localized strings at the end of the record.
+const spellRangeRecordSize = 40 * 4
+
+func loadSpellRanges(path string) (map[uint32]*SpellRange, error) {
+	f, err := loadFile(path, spellRangeRecordSize)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[uint32]*SpellRange, len(f.records))
+
+	for _, r := range f.records {
+		row := &SpellRange{
+			ID:              r.Uint32(0),
+			MinRangeHostile: r.Float(1),
+			MinRangeFriend:  r.Float(2),
+			MaxRangeHostile: r.Float(3),
+			MaxRangeFriend:  r.Float(4),
+			Flags:           r.Uint32(5),
+		}
+		result[row.ID] = row
+	}
+
+	return result, nil
+}
+
+// https://wowdev.wiki/DB/SpellDuration
+type SpellDuration struct {
+	ID uint32
+
+	// Duration is in milliseconds, or -1 if the spell lasts until it's cancelled. It changes by PerLevel
+	// for each level of the caster, up to Max.
+	Duration int32
+	PerLevel int32
+	Max      int32
+}
+
+const spellDurationRecordSize = 4 * 4
+
+func loadSpellDurations(path string) (map[uint32]*SpellDuration, error) {
+	f, err := loadFile(path, spellDurationRecordSize)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[uint32]*SpellDuration, len(f.records))
+
+	for _, r := range f.records {
+		row := &SpellDuration{
+			ID:       r.Uint32(0),
+			Duration: r.Int32(1),
+			PerLevel: r.Int32(2),
+			Max:      r.Int32(3),
+		}
+		result[row.ID] = row
+	}
+
+	return result, nil
+}
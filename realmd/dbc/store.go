@@ -0,0 +1,255 @@
+package dbc
+
+import (
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/kangaroux/gomaggus/model"
+)
+
+// Store contains the tables loaded from the client's DBC files. The tables are indexed by ID and must
+// not be modified once loaded.
+type Store struct {
+	ChrRaces           map[model.Race]*ChrRace
+	ChrClasses         map[model.Class]*ChrClass
+	Maps               map[uint32]*Map
+	Areas              map[uint32]*Area
+	AreaTriggers       map[uint32]*AreaTrigger
+	WorldMapAreas      map[uint32]*WorldMapArea
+	WorldSafeLocs      map[uint32]*WorldSafeLoc
+	SkillLineAbilities map[uint32]*SkillLineAbility
+	Spells             map[uint32]*Spell
+	SpellCastTimes     map[uint32]*SpellCastTime
+	SpellRanges        map[uint32]*SpellRange
+	SpellDurations     map[uint32]*SpellDuration
+	Factions           map[uint32]*Faction
+	Talents            map[uint32]*Talent
+	TalentTabs         map[uint32]*TalentTab
+	GlyphProperties    map[uint32]*GlyphProperties
+	ItemEnchantments   map[uint32]*SpellItemEnchantment
+
+	outfits          map[outfitKey]*CharStartOutfit
+	abilitiesBySpell map[uint32][]*SkillLineAbility
+	abilitiesBySkill map[uint32][]*SkillLineAbility
+	talentsByTab     map[uint32][]*Talent
+}
+
+// Load reads all of the tables from the DBC files in dir.
+func Load(dir string) (*Store, error) {
+	var err error
+	s := &Store{}
+	path := func(name string) string { return filepath.Join(dir, name+".dbc") }
+
+	if s.ChrRaces, err = loadChrRaces(path("ChrRaces")); err != nil {
+		return nil, err
+	}
+	if s.ChrClasses, err = loadChrClasses(path("ChrClasses")); err != nil {
+		return nil, err
+	}
+	if s.outfits, err = loadCharStartOutfits(path("CharStartOutfit")); err != nil {
+		return nil, err
+	}
+	if s.Maps, err = loadMaps(path("Map")); err != nil {
+		return nil, err
+	}
+	if s.Areas, err = loadAreas(path("AreaTable")); err != nil {
+		return nil, err
+	}
+	if s.AreaTriggers, err = loadAreaTriggers(path("AreaTrigger")); err != nil {
+		return nil, err
+	}
+	if s.WorldMapAreas, err = loadWorldMapAreas(path("WorldMapArea")); err != nil {
+		return nil, err
+	}
+	if s.WorldSafeLocs, err = loadWorldSafeLocs(path("WorldSafeLocs")); err != nil {
+		return nil, err
+	}
+	if s.SkillLineAbilities, err = loadSkillLineAbilities(path("SkillLineAbility")); err != nil {
+		return nil, err
+	}
+	if s.Spells, err = loadSpells(path("Spell")); err != nil {
+		return nil, err
+	}
+	if s.SpellCastTimes, err = loadSpellCastTimes(path("SpellCastTimes")); err != nil {
+		return nil, err
+	}
+	if s.SpellRanges, err = loadSpellRanges(path("SpellRange")); err != nil {
+		return nil, err
+	}
+	if s.SpellDurations, err = loadSpellDurations(path("SpellDuration")); err != nil {
+		return nil, err
+	}
+	if s.Factions, err = loadFactions(path("Faction")); err != nil {
+		return nil, err
+	}
+	if s.Talents, err = loadTalents(path("Talent")); err != nil {
+		return nil, err
+	}
+	if s.TalentTabs, err = loadTalentTabs(path("TalentTab")); err != nil {
+		return nil, err
+	}
+	if s.GlyphProperties, err = loadGlyphProperties(path("GlyphProperties")); err != nil {
+		return nil, err
+	}
+	if s.ItemEnchantments, err = loadSpellItemEnchantments(path("SpellItemEnchantment")); err != nil {
+		return nil, err
+	}
+
+	s.buildIndexes()
+
+	return s, nil
+}
+
+func (s *Store) buildIndexes() {
+	s.abilitiesBySpell = make(map[uint32][]*SkillLineAbility)
+	s.abilitiesBySkill = make(map[uint32][]*SkillLineAbility)
+	for _, a := range s.SkillLineAbilities {
+		s.abilitiesBySpell[a.Spell] = append(s.abilitiesBySpell[a.Spell], a)
+		s.abilitiesBySkill[a.SkillLine] = append(s.abilitiesBySkill[a.SkillLine], a)
+	}
+
+	s.talentsByTab = make(map[uint32][]*Talent)
+	for _, t := range s.Talents {
+		s.talentsByTab[t.Tab] = append(s.talentsByTab[t.Tab], t)
+	}
+
+	// Sort the indexes so the results don't depend on map ordering
+	for _, abilities := range s.abilitiesBySpell {
+		sortAbilities(abilities)
+	}
+	for _, abilities := range s.abilitiesBySkill {
+		sortAbilities(abilities)
+	}
+	for _, talents := range s.talentsByTab {
+		sort.Slice(talents, func(i, j int) bool {
+			if talents[i].Tier != talents[j].Tier {
+				return talents[i].Tier < talents[j].Tier
+			}
+			return talents[i].Column < talents[j].Column
+		})
+	}
+}
+
+func sortAbilities(abilities []*SkillLineAbility) {
+	sort.Slice(abilities, func(i, j int) bool {
+		return abilities[i].ID < abilities[j].ID
+	})
+}
+
+// StartOutfit returns the starting outfit for a new character, or nil if there isn't one.
+func (s *Store) StartOutfit(race model.Race, class model.Class, gender model.Gender) *CharStartOutfit {
+	return s.outfits[outfitKey{race, class, gender}]
+}
+
+// SkillLineAbilitiesForSpell returns the skill line abilities that teach spell.
+func (s *Store) SkillLineAbilitiesForSpell(spell uint32) []*SkillLineAbility {
+	return s.abilitiesBySpell[spell]
+}
+
+// SkillLineAbilitiesForSkill returns the abilities that belong to the skill line.
+func (s *Store) SkillLineAbilitiesForSkill(skill uint32) []*SkillLineAbility {
+	return s.abilitiesBySkill[skill]
+}
+
+// TalentsForTab returns the talents in a talent tree, sorted by their tier and column.
+func (s *Store) TalentsForTab(tab uint32) []*Talent {
+	return s.talentsByTab[tab]
+}
+
+// TalentTabsForClass returns the talent trees for class in the order they appear in the talent window.
+func (s *Store) TalentTabsForClass(class model.Class) []*TalentTab {
+	mask := uint32(1) << (class - 1)
+
+	var result []*TalentTab
+	for _, tab := range s.TalentTabs {
+		if tab.ClassMask&mask > 0 {
+			result = append(result, tab)
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].OrderIndex < result[j].OrderIndex
+	})
+
+	return result
+}
+
+// CastTime returns how long it takes to cast the spell. Spells with an unknown cast time are instant.
+func (s *Store) CastTime(spell *Spell) time.Duration {
+	ct, ok := s.SpellCastTimes[spell.CastingTimeIndex]
+	if !ok {
+		return 0
+	}
+
+	ms := ct.Base + ct.PerLevel*int32(spell.SpellLevel)
+	if ms < ct.Minimum {
+		ms = ct.Minimum
+	}
+	if ms < 0 {
+		return 0
+	}
+	return time.Duration(ms) * time.Millisecond
+}
+
+// Duration returns how long the spell lasts when cast by a caster of level, or -1 if it lasts until it's
+// cancelled. Spells with an unknown duration last 0.
+func (s *Store) Duration(spell *Spell, level uint32) time.Duration {
+	d, ok := s.SpellDurations[spell.DurationIndex]
+	if !ok {
+		return 0
+	}
+	if d.Duration < 0 {
+		return -1
+	}
+
+	ms := d.Duration
+	if level > spell.SpellLevel {
+		ms += d.PerLevel * int32(level-spell.SpellLevel)
+	}
+	if d.Max > 0 && ms > d.Max {
+		ms = d.Max
+	}
+	return time.Duration(ms) * time.Millisecond
+}
+
+// StartingSpells returns the ids of the spells that a character of race and class knows when they're
+// created, in ascending order. These are the spells that come with their race and class skills, up to
+// the character's level.
+func (s *Store) StartingSpells(race model.Race, class model.Class, level uint32) []uint32 {
+	raceMask := uint32(1) << (race - 1)
+	classMask := uint32(1) << (class - 1)
+
+	seen := make(map[uint32]bool)
+	var result []uint32
+
+	for _, a := range s.SkillLineAbilities {
+		if a.AcquireMethod != SkillLineAbilityAcquireOnSkillLearn {
+			continue
+		}
+
+		// Abilities that aren't tied to a race or class are for skills like professions, which are
+		// learned later
+		if a.RaceMask == 0 && a.ClassMask == 0 {
+			continue
+		}
+		if a.RaceMask != 0 && a.RaceMask&raceMask == 0 || a.ClassMask != 0 && a.ClassMask&classMask == 0 {
+			continue
+		}
+		if a.ExcludeRace&raceMask > 0 || a.ExcludeClass&classMask > 0 {
+			continue
+		}
+
+		spell, ok := s.Spells[a.Spell]
+		if !ok || spell.SpellLevel > level || seen[spell.ID] {
+			continue
+		}
+
+		seen[spell.ID] = true
+		result = append(result, spell.ID)
+	}
+
+	sort.Slice(result, func(i, j int) bool { return result[i] < result[j] })
+
+	return result
+}
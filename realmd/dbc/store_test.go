@@ -0,0 +1,201 @@
+package dbc
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kangaroux/gomaggus/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoad(t *testing.T) {
+	s, err := Load("testdata")
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	t.Run("ChrRaces", func(t *testing.T) {
+		human := s.ChrRaces[model.RaceHuman]
+		assert.Equal(t, "Human", human.Name)
+		assert.Equal(t, "Hu", human.ClientPrefix)
+		assert.Equal(t, uint32(49), human.DisplayID(model.GenderMale))
+		assert.Equal(t, uint32(50), human.DisplayID(model.GenderFemale))
+		assert.Equal(t, uint32(81), human.CinematicSequence)
+		assert.False(t, human.Horde)
+		assert.True(t, s.ChrRaces[model.RaceOrc].Horde)
+		assert.Equal(t, uint32(1), s.ChrRaces[model.RaceBloodElf].Expansion)
+	})
+
+	t.Run("ChrClasses", func(t *testing.T) {
+		assert.Equal(t, uint32(1), s.ChrClasses[model.ClassWarrior].DisplayPower)
+		assert.Equal(t, "Death Knight", s.ChrClasses[model.ClassDeathKnight].Name)
+		assert.Equal(t, uint32(165), s.ChrClasses[model.ClassDeathKnight].CinematicSequence)
+	})
+
+	t.Run("CharStartOutfit", func(t *testing.T) {
+		outfit := s.StartOutfit(model.RaceHuman, model.ClassWarrior, model.GenderFemale)
+		if assert.NotNil(t, outfit) {
+			assert.Equal(t, int32(38), outfit.ItemID[0])
+			assert.Equal(t, int32(9891), outfit.DisplayItemID[0])
+			assert.Equal(t, int32(4), outfit.InventoryType[0])
+			assert.Equal(t, int32(-1), outfit.ItemID[NumOutfitItems-1])
+		}
+		assert.Nil(t, s.StartOutfit(model.RaceOrc, model.ClassWarrior, model.GenderMale))
+	})
+
+	t.Run("Map", func(t *testing.T) {
+		dm := s.Maps[36]
+		assert.Equal(t, "Deadmines", dm.Name)
+		assert.Equal(t, uint32(MapInstanceTypeParty), dm.InstanceType)
+		assert.Equal(t, float32(-11208.6), dm.CorpseX)
+		assert.Equal(t, int32(-1), s.Maps[0].CorpseMap)
+	})
+
+	t.Run("AreaTable", func(t *testing.T) {
+		assert.Equal(t, "Northshire Valley", s.Areas[9].Name)
+		assert.Equal(t, uint32(12), s.Areas[9].Parent)
+		assert.NotZero(t, s.Areas[1519].Flags&AreaFlagCapital)
+	})
+
+	t.Run("AreaTrigger", func(t *testing.T) {
+		inn := s.AreaTriggers[562]
+		if assert.NotNil(t, inn) {
+			assert.Equal(t, uint32(0), inn.Map)
+			assert.Equal(t, float32(-9462.66), inn.X)
+			assert.True(t, inn.Contains(0, -9465, 15, 70))
+			assert.False(t, inn.Contains(1, -9465, 15, 70), "different map")
+			assert.False(t, inn.Contains(0, -9500, 15, 70))
+		}
+
+		box := s.AreaTriggers[71]
+		if assert.NotNil(t, box) {
+			assert.True(t, box.Contains(0, box.X+10, box.Y+1, box.Z))
+			assert.False(t, box.Contains(0, box.X+1, box.Y+10, box.Z), "the box is turned")
+		}
+	})
+
+	t.Run("WorldMapArea", func(t *testing.T) {
+		assert.Len(t, s.WorldMapAreas, 3, "continents are skipped")
+
+		elwynn := s.ZoneMap(s.Areas[9])
+		if assert.NotNil(t, elwynn, "subzones use their zone's map") {
+			assert.Equal(t, uint32(12), elwynn.Area)
+			assert.True(t, elwynn.Contains(0, -8900, 0))
+			assert.False(t, elwynn.Contains(1, -8900, 0), "different map")
+			assert.False(t, elwynn.Contains(0, -8900, 2000))
+		}
+		assert.Nil(t, s.ZoneMap(nil))
+	})
+
+	t.Run("WorldSafeLocs", func(t *testing.T) {
+		assert.Equal(t, "Elwynn Forest, Northshire Abbey", s.WorldSafeLocs[4].Name)
+		assert.Equal(t, uint32(1), s.WorldSafeLocs[10].Map)
+
+		assert.Equal(t, uint32(4), s.NearestGraveyard(0, -8900, -100).ID)
+		assert.Equal(t, uint32(2), s.NearestGraveyard(0, -9400, 200).ID)
+		assert.Equal(t, uint32(10), s.NearestGraveyard(1, 0, 0).ID)
+		assert.Equal(t, uint32(2), s.NearestGraveyard(36, 0, 0).ID, "dungeons use the graveyard by the entrance")
+		assert.Nil(t, s.NearestGraveyard(530, 0, 0))
+	})
+
+	t.Run("SkillLineAbility", func(t *testing.T) {
+		abilities := s.SkillLineAbilitiesForSpell(133)
+		if assert.Len(t, abilities, 1) {
+			assert.Equal(t, uint32(6), abilities[0].SkillLine)
+			assert.Equal(t, uint32(143), abilities[0].SupercededBy)
+		}
+		assert.Len(t, s.SkillLineAbilitiesForSkill(6), 2)
+	})
+
+	t.Run("Spell", func(t *testing.T) {
+		fireball := s.Spells[133]
+		assert.Equal(t, "Fireball", fireball.Name)
+		assert.Equal(t, "Rank 1", fireball.Rank)
+		assert.Equal(t, uint32(30), fireball.ManaCost)
+		assert.Equal(t, float32(24), fireball.Speed)
+		assert.Equal(t, uint32(1500), fireball.StartRecoveryTime)
+		assert.Equal(t, uint32(2), fireball.Effects[0].Effect)
+		assert.Equal(t, int32(13), fireball.Effects[0].BasePoints)
+		assert.Equal(t, uint32(3), fireball.Effects[1].ApplyAuraName)
+		assert.Equal(t, uint32(2000), fireball.Effects[1].Amplitude)
+		assert.Equal(t, uint32(4), fireball.SchoolMask)
+	})
+
+	t.Run("StartingSpells", func(t *testing.T) {
+		assert.Equal(t, []uint32{668}, s.StartingSpells(model.RaceHuman, model.ClassMage, 1))
+		assert.Equal(t, []uint32{669}, s.StartingSpells(model.RaceOrc, model.ClassWarrior, 1))
+	})
+
+	t.Run("SpellCastTimes", func(t *testing.T) {
+		assert.Equal(t, int32(1500), s.SpellCastTimes[19].Base)
+		assert.Equal(t, 1500*time.Millisecond, s.CastTime(s.Spells[133]))
+		assert.Equal(t, 2*time.Second, s.CastTime(s.Spells[143]))
+		assert.Zero(t, s.CastTime(s.Spells[668]))
+	})
+
+	t.Run("SpellRange", func(t *testing.T) {
+		assert.Equal(t, float32(30), s.SpellRanges[s.Spells[133].RangeIndex].MaxRangeHostile)
+		assert.Equal(t, float32(5), s.SpellRanges[s.Spells[78].RangeIndex].MaxRangeFriend)
+	})
+
+	t.Run("SpellDuration", func(t *testing.T) {
+		assert.Equal(t, 8*time.Second, s.Duration(s.Spells[133], 1))
+		assert.Equal(t, time.Duration(-1), s.Duration(s.Spells[668], 1))
+		assert.Zero(t, s.Duration(s.Spells[143], 1))
+	})
+
+	t.Run("Faction", func(t *testing.T) {
+		standing, _, ok := s.Factions[72].BaseReputation(model.RaceHuman, model.ClassWarrior)
+		assert.True(t, ok)
+		assert.Equal(t, int32(0), standing)
+
+		standing, _, ok = s.Factions[72].BaseReputation(model.RaceOrc, model.ClassWarrior)
+		assert.True(t, ok)
+		assert.Equal(t, int32(-42000), standing)
+
+		standing, _, _ = s.Factions[529].BaseReputation(model.RaceHuman, model.ClassDeathKnight)
+		assert.Equal(t, int32(3100), standing)
+
+		standing, _, _ = s.Factions[529].BaseReputation(model.RaceHuman, model.ClassMage)
+		assert.Equal(t, int32(0), standing)
+	})
+
+	t.Run("Talent", func(t *testing.T) {
+		talents := s.TalentsForTab(41)
+		if assert.Len(t, talents, 3) {
+			assert.Equal(t, uint32(26), talents[0].ID)
+			assert.Equal(t, 5, talents[0].Ranks())
+			assert.Equal(t, 2, talents[1].Ranks())
+			assert.Equal(t, uint32(26), talents[2].PrereqTalent[0])
+		}
+	})
+
+	t.Run("TalentTab", func(t *testing.T) {
+		tabs := s.TalentTabsForClass(model.ClassMage)
+		if assert.Len(t, tabs, 3) {
+			assert.Equal(t, "Arcane", tabs[0].Name)
+			assert.Equal(t, "Fire", tabs[1].Name)
+			assert.Equal(t, "Frost", tabs[2].Name)
+		}
+	})
+
+	t.Run("GlyphProperties", func(t *testing.T) {
+		assert.Equal(t, uint32(56368), s.GlyphProperties[311].Spell)
+		assert.False(t, s.GlyphProperties[311].Minor())
+		assert.True(t, s.GlyphProperties[445].Minor())
+	})
+
+	t.Run("SpellItemEnchantment", func(t *testing.T) {
+		crusader := s.ItemEnchantments[1900]
+		if assert.NotNil(t, crusader) {
+			assert.Equal(t, "Crusader", crusader.Name)
+			assert.Equal(t, uint32(4), crusader.ItemVisual)
+		}
+		assert.Zero(t, s.ItemEnchantments[2564].ItemVisual)
+	})
+}
+
+func TestLoadMissingFile(t *testing.T) {
+	_, err := Load("does-not-exist")
+	assert.Error(t, err)
+}
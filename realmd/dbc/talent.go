@@ -0,0 +1,140 @@
+package dbc
+
+// MaxTalentRanks is the most ranks a talent can have.
+const MaxTalentRanks = 5
+
+// https://wowdev.wiki/DB/Talent
+type Talent struct {
+	ID     uint32
+	Tab    uint32
+	Tier   uint32
+	Column uint32
+
+	// SpellRank is the spell for each rank of the talent. Ranks the talent doesn't have are 0.
+	SpellRank [MaxTalentRanks]uint32
+
+	// The talent requires PrereqRank+1 points in each PrereqTalent, if it's not 0.
+	PrereqTalent [3]uint32
+	PrereqRank   [3]uint32
+
+	Flags uint32
+}
+
+// Ranks returns the number of ranks the talent has.
+func (t *Talent) Ranks() int {
+	for i, spell := range t.SpellRank {
+		if spell == 0 {
+			return i
+		}
+	}
+	return MaxTalentRanks
+}
+
+const talentRecordSize = 23 * 4
+
+func loadTalents(path string) (map[uint32]*Talent, error) {
+	f, err := loadFile(path, talentRecordSize)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[uint32]*Talent, len(f.records))
+
+	for _, r := range f.records {
+		row := &Talent{
+			ID:     r.Uint32(0),
+			Tab:    r.Uint32(1),
+			Tier:   r.Uint32(2),
+			Column: r.Uint32(3),
+			Flags:  r.Uint32(19),
+		}
+
+		copy(row.SpellRank[:], r.Uint32s(4, MaxTalentRanks))
+		copy(row.PrereqTalent[:], r.Uint32s(13, len(row.PrereqTalent)))
+		copy(row.PrereqRank[:], r.Uint32s(16, len(row.PrereqRank)))
+
+		result[row.ID] = row
+	}
+
+	return result, nil
+}
+
+// https://wowdev.wiki/DB/TalentTab
+type TalentTab struct {
+	ID        uint32
+	Name      string
+	SpellIcon uint32
+	RaceMask  uint32
+	ClassMask uint32
+
+	// PetTalentMask is set instead of ClassMask for pet talent trees.
+	PetTalentMask uint32
+
+	// OrderIndex is the position of the tab in the talent window.
+	OrderIndex     uint32
+	BackgroundFile string
+}
+
+const talentTabRecordSize = 24 * 4
+
+func loadTalentTabs(path string) (map[uint32]*TalentTab, error) {
+	f, err := loadFile(path, talentTabRecordSize)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[uint32]*TalentTab, len(f.records))
+
+	for _, r := range f.records {
+		row := &TalentTab{
+			ID:             r.Uint32(0),
+			Name:           r.LocString(1),
+			SpellIcon:      r.Uint32(18),
+			RaceMask:       r.Uint32(19),
+			ClassMask:      r.Uint32(20),
+			PetTalentMask:  r.Uint32(21),
+			OrderIndex:     r.Uint32(22),
+			BackgroundFile: r.String(23),
+		}
+		result[row.ID] = row
+	}
+
+	return result, nil
+}
+
+// GlyphFlagMinor is set on the glyphs that go in minor glyph slots. The rest go in major slots.
+const GlyphFlagMinor = 0x1
+
+// https://wowdev.wiki/DB/GlyphProperties
+type GlyphProperties struct {
+	ID    uint32
+	Spell uint32
+	Flags uint32
+}
+
+// Minor reports whether the glyph goes in a minor glyph slot.
+func (g *GlyphProperties) Minor() bool {
+	return g.Flags&GlyphFlagMinor != 0
+}
+
+const glyphPropertiesRecordSize = 4 * 4
+
+func loadGlyphProperties(path string) (map[uint32]*GlyphProperties, error) {
+	f, err := loadFile(path, glyphPropertiesRecordSize)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[uint32]*GlyphProperties, len(f.records))
+
+	for _, r := range f.records {
+		row := &GlyphProperties{
+			ID:    r.Uint32(0),
+			Spell: r.Uint32(1),
+			Flags: r.Uint32(2),
+		}
+		result[row.ID] = row
+	}
+
+	return result, nil
+}
@@ -7,8 +7,8 @@ import (
 	"strings"
 )
 
-const _ClientOpcodeName = "ClientCharCreateClientCharListClientCharDeleteClientPlayerLoginClientLogoutForceClientLogoutRequestClientLogoutCancelClientGetPlayerNameClientGetItemInfoClientStandStateChangeClientCancelTradeClientGetPlayedTimeClientGetTimeClientPingClientAuthSessionClientEnteredZoneClientGetStorageClientPutStorageClientGetTicketStatusClientListBattlegroundsClientSetActiveMoverClientGetNextMailArrivalClientGetLFGStatusClientSetActionBarTogglesClientGetRaidInfoClientGetBattlefieldStatusClientGetLFGDungeonListClientRealmSplitClientSetVoiceEnabledClientSetVoiceChannelClientGetGuildBankMoneyClientGetNumPendingEventsClientGetUITimeClientReadyForAccountDataTimes"
-const _ClientOpcodeLowerName = "clientcharcreateclientcharlistclientchardeleteclientplayerloginclientlogoutforceclientlogoutrequestclientlogoutcancelclientgetplayernameclientgetiteminfoclientstandstatechangeclientcanceltradeclientgetplayedtimeclientgettimeclientpingclientauthsessioncliententeredzoneclientgetstorageclientputstorageclientgetticketstatusclientlistbattlegroundsclientsetactivemoverclientgetnextmailarrivalclientgetlfgstatusclientsetactionbartogglesclientgetraidinfoclientgetbattlefieldstatusclientgetlfgdungeonlistclientrealmsplitclientsetvoiceenabledclientsetvoicechannelclientgetguildbankmoneyclientgetnumpendingeventsclientgetuitimeclientreadyforaccountdatatimes"
+const _ClientOpcodeName = "ClientCharCreateClientCharListClientCharDeleteClientPlayerLoginClientLogoutForceClientLogoutRequestClientLogoutCancelClientGetPlayerNameClientGuildQueryClientGetItemInfoClientWhoClientContactListClientAddFriendClientDelFriendClientSetContactNotesClientAddIgnoreClientDelIgnoreClientGroupInviteClientGroupAcceptClientGroupDeclineClientGroupUninviteClientGroupUninviteGuidClientGroupSetLeaderClientLootMethodClientGroupDisbandClientGuildInviteClientGuildAcceptClientGuildDeclineClientGuildInfoClientGuildRosterClientGuildPromoteClientGuildDemoteClientGuildLeaveClientGuildRemoveClientGuildDisbandClientGuildLeaderClientGuildMOTDClientMessageChatClientJoinChannelClientLeaveChannelClientChannelListClientChannelPasswordClientChannelSetOwnerClientChannelOwnerClientChannelModeratorClientChannelUnmoderatorClientChannelMuteClientChannelUnmuteClientChannelInviteClientChannelKickClientChannelBanClientChannelUnbanClientChannelAnnouncementsClientChannelModerateClientUseItemClientAreaTriggerClientMoveStartForwardClientMoveStartBackwardClientMoveStopClientMoveStartStrafeLeftClientMoveStartStrafeRightClientMoveStopStrafeClientMoveJumpClientMoveStartTurnLeftClientMoveStartTurnRightClientMoveStopTurnClientMoveStartPitchUpClientMoveStartPitchDownClientMoveStopPitchClientMoveSetRunModeClientMoveSetWalkModeClientMoveTeleportAckClientMoveFallLandClientMoveStartSwimClientMoveStopSwimClientMoveSetFacingClientMoveSetPitchClientMoveWorldportAckClientForceRunSpeedChangeAckClientForceSwimSpeedChangeAckClientMoveHeartbeatClientStandStateChangeClientAutoEquipItemClientAutoStoreBagItemClientSwapItemClientSwapInvItemClientSplitItemClientDestroyItemClientCancelTradeClientSetFactionAtWarClientSetActionButtonClientCastSpellClientCancelCastClientCancelAuraClientCancelChannellingClientAttackSwingClientAttackStopClientRepopRequestClientGetPlayedTimeClientGetTimeClientReclaimCorpseClientPingClientAuthSessionClientEnteredZoneClientGetStorageClientPutStorageClientGetTicketStatusClientCorpseQueryClientSpiritHealerActivateClientGuildRankClientGuildAddRankClientGuildDelRankClientGuildSetPublicNoteClientGuildSetOfficerNoteClientSendMailClientGetMailListClientListBattlegroundsClientMailTakeMoneyClientMailTakeItemClientMailMarkAsReadClientMailReturnToSenderClientMailDeleteClientLearnTalentClientSetActiveMoverClientGroupChangeSubGroupClientRequestPartyMemberStatsClientGroupSwapSubGroupClientQueryNextMailTimeClientGroupRaidConvertClientGroupAssistantLeaderClientGetLFGStatusClientTalentWipeConfirmClientSetActionBarTogglesClientMoveFallResetClientGetRaidInfoClientMoveTimeSkippedClientGetBattlefieldStatusClientGuildInfoTextClientSetFactionInactiveClientRaidTargetUpdateClientRaidReadyCheckClientMoveStartAscendClientMoveStopAscendClientGetLFGDungeonListClientForceFlightSpeedChangeAckClientRealmSplitClientMoveStartDescendClientSetVoiceEnabledClientRaidReadyCheckFinishedClientSetVoiceChannelClientChannelDisplayListClientGuildPermissionsClientGetGuildBankMoneyClientGetNumPendingEventsClientRemoveGlyphClientLearnPreviewTalentsClientGetUITimeClientReadyForAccountDataTimes"
+const _ClientOpcodeLowerName = "clientcharcreateclientcharlistclientchardeleteclientplayerloginclientlogoutforceclientlogoutrequestclientlogoutcancelclientgetplayernameclientguildqueryclientgetiteminfoclientwhoclientcontactlistclientaddfriendclientdelfriendclientsetcontactnotesclientaddignoreclientdelignoreclientgroupinviteclientgroupacceptclientgroupdeclineclientgroupuninviteclientgroupuninviteguidclientgroupsetleaderclientlootmethodclientgroupdisbandclientguildinviteclientguildacceptclientguilddeclineclientguildinfoclientguildrosterclientguildpromoteclientguilddemoteclientguildleaveclientguildremoveclientguilddisbandclientguildleaderclientguildmotdclientmessagechatclientjoinchannelclientleavechannelclientchannellistclientchannelpasswordclientchannelsetownerclientchannelownerclientchannelmoderatorclientchannelunmoderatorclientchannelmuteclientchannelunmuteclientchannelinviteclientchannelkickclientchannelbanclientchannelunbanclientchannelannouncementsclientchannelmoderateclientuseitemclientareatriggerclientmovestartforwardclientmovestartbackwardclientmovestopclientmovestartstrafeleftclientmovestartstraferightclientmovestopstrafeclientmovejumpclientmovestartturnleftclientmovestartturnrightclientmovestopturnclientmovestartpitchupclientmovestartpitchdownclientmovestoppitchclientmovesetrunmodeclientmovesetwalkmodeclientmoveteleportackclientmovefalllandclientmovestartswimclientmovestopswimclientmovesetfacingclientmovesetpitchclientmoveworldportackclientforcerunspeedchangeackclientforceswimspeedchangeackclientmoveheartbeatclientstandstatechangeclientautoequipitemclientautostorebagitemclientswapitemclientswapinvitemclientsplititemclientdestroyitemclientcanceltradeclientsetfactionatwarclientsetactionbuttonclientcastspellclientcancelcastclientcancelauraclientcancelchannellingclientattackswingclientattackstopclientrepoprequestclientgetplayedtimeclientgettimeclientreclaimcorpseclientpingclientauthsessioncliententeredzoneclientgetstorageclientputstorageclientgetticketstatusclientcorpsequeryclientspirithealeractivateclientguildrankclientguildaddrankclientguilddelrankclientguildsetpublicnoteclientguildsetofficernoteclientsendmailclientgetmaillistclientlistbattlegroundsclientmailtakemoneyclientmailtakeitemclientmailmarkasreadclientmailreturntosenderclientmaildeleteclientlearntalentclientsetactivemoverclientgroupchangesubgroupclientrequestpartymemberstatsclientgroupswapsubgroupclientquerynextmailtimeclientgroupraidconvertclientgroupassistantleaderclientgetlfgstatusclienttalentwipeconfirmclientsetactionbartogglesclientmovefallresetclientgetraidinfoclientmovetimeskippedclientgetbattlefieldstatusclientguildinfotextclientsetfactioninactiveclientraidtargetupdateclientraidreadycheckclientmovestartascendclientmovestopascendclientgetlfgdungeonlistclientforceflightspeedchangeackclientrealmsplitclientmovestartdescendclientsetvoiceenabledclientraidreadycheckfinishedclientsetvoicechannelclientchanneldisplaylistclientguildpermissionsclientgetguildbankmoneyclientgetnumpendingeventsclientremoveglyphclientlearnpreviewtalentsclientgetuitimeclientreadyforaccountdatatimes"
 
 var _ClientOpcodeMap = map[ClientOpcode]string{
 	54:   _ClientOpcodeName[0:16],
@@ -19,32 +19,156 @@ var _ClientOpcodeMap = map[ClientOpcode]string{
 	75:   _ClientOpcodeName[80:99],
 	78:   _ClientOpcodeName[99:117],
 	80:   _ClientOpcodeName[117:136],
-	86:   _ClientOpcodeName[136:153],
-	257:  _ClientOpcodeName[153:175],
-	284:  _ClientOpcodeName[175:192],
-	460:  _ClientOpcodeName[192:211],
-	462:  _ClientOpcodeName[211:224],
-	476:  _ClientOpcodeName[224:234],
-	493:  _ClientOpcodeName[234:251],
-	500:  _ClientOpcodeName[251:268],
-	522:  _ClientOpcodeName[268:284],
-	523:  _ClientOpcodeName[284:300],
-	529:  _ClientOpcodeName[300:321],
-	572:  _ClientOpcodeName[321:344],
-	618:  _ClientOpcodeName[344:364],
-	644:  _ClientOpcodeName[364:388],
-	662:  _ClientOpcodeName[388:406],
-	703:  _ClientOpcodeName[406:431],
-	717:  _ClientOpcodeName[431:448],
-	723:  _ClientOpcodeName[448:474],
-	878:  _ClientOpcodeName[474:497],
-	908:  _ClientOpcodeName[497:513],
-	943:  _ClientOpcodeName[513:534],
-	979:  _ClientOpcodeName[534:555],
-	1022: _ClientOpcodeName[555:578],
-	1095: _ClientOpcodeName[578:603],
-	1270: _ClientOpcodeName[603:618],
-	1279: _ClientOpcodeName[618:648],
+	84:   _ClientOpcodeName[136:152],
+	86:   _ClientOpcodeName[152:169],
+	98:   _ClientOpcodeName[169:178],
+	102:  _ClientOpcodeName[178:195],
+	105:  _ClientOpcodeName[195:210],
+	106:  _ClientOpcodeName[210:225],
+	107:  _ClientOpcodeName[225:246],
+	108:  _ClientOpcodeName[246:261],
+	109:  _ClientOpcodeName[261:276],
+	110:  _ClientOpcodeName[276:293],
+	114:  _ClientOpcodeName[293:310],
+	115:  _ClientOpcodeName[310:328],
+	117:  _ClientOpcodeName[328:347],
+	118:  _ClientOpcodeName[347:370],
+	120:  _ClientOpcodeName[370:390],
+	122:  _ClientOpcodeName[390:406],
+	123:  _ClientOpcodeName[406:424],
+	130:  _ClientOpcodeName[424:441],
+	132:  _ClientOpcodeName[441:458],
+	133:  _ClientOpcodeName[458:476],
+	135:  _ClientOpcodeName[476:491],
+	137:  _ClientOpcodeName[491:508],
+	139:  _ClientOpcodeName[508:526],
+	140:  _ClientOpcodeName[526:543],
+	141:  _ClientOpcodeName[543:559],
+	142:  _ClientOpcodeName[559:576],
+	143:  _ClientOpcodeName[576:594],
+	144:  _ClientOpcodeName[594:611],
+	145:  _ClientOpcodeName[611:626],
+	149:  _ClientOpcodeName[626:643],
+	151:  _ClientOpcodeName[643:660],
+	152:  _ClientOpcodeName[660:678],
+	154:  _ClientOpcodeName[678:695],
+	156:  _ClientOpcodeName[695:716],
+	157:  _ClientOpcodeName[716:737],
+	158:  _ClientOpcodeName[737:755],
+	159:  _ClientOpcodeName[755:777],
+	160:  _ClientOpcodeName[777:801],
+	161:  _ClientOpcodeName[801:818],
+	162:  _ClientOpcodeName[818:837],
+	163:  _ClientOpcodeName[837:856],
+	164:  _ClientOpcodeName[856:873],
+	165:  _ClientOpcodeName[873:889],
+	166:  _ClientOpcodeName[889:907],
+	167:  _ClientOpcodeName[907:933],
+	168:  _ClientOpcodeName[933:954],
+	171:  _ClientOpcodeName[954:967],
+	180:  _ClientOpcodeName[967:984],
+	181:  _ClientOpcodeName[984:1006],
+	182:  _ClientOpcodeName[1006:1029],
+	183:  _ClientOpcodeName[1029:1043],
+	184:  _ClientOpcodeName[1043:1068],
+	185:  _ClientOpcodeName[1068:1094],
+	186:  _ClientOpcodeName[1094:1114],
+	187:  _ClientOpcodeName[1114:1128],
+	188:  _ClientOpcodeName[1128:1151],
+	189:  _ClientOpcodeName[1151:1175],
+	190:  _ClientOpcodeName[1175:1193],
+	191:  _ClientOpcodeName[1193:1215],
+	192:  _ClientOpcodeName[1215:1239],
+	193:  _ClientOpcodeName[1239:1258],
+	194:  _ClientOpcodeName[1258:1278],
+	195:  _ClientOpcodeName[1278:1299],
+	199:  _ClientOpcodeName[1299:1320],
+	201:  _ClientOpcodeName[1320:1338],
+	202:  _ClientOpcodeName[1338:1357],
+	203:  _ClientOpcodeName[1357:1375],
+	218:  _ClientOpcodeName[1375:1394],
+	219:  _ClientOpcodeName[1394:1412],
+	220:  _ClientOpcodeName[1412:1434],
+	227:  _ClientOpcodeName[1434:1462],
+	231:  _ClientOpcodeName[1462:1491],
+	238:  _ClientOpcodeName[1491:1510],
+	257:  _ClientOpcodeName[1510:1532],
+	266:  _ClientOpcodeName[1532:1551],
+	267:  _ClientOpcodeName[1551:1573],
+	268:  _ClientOpcodeName[1573:1587],
+	269:  _ClientOpcodeName[1587:1604],
+	270:  _ClientOpcodeName[1604:1619],
+	273:  _ClientOpcodeName[1619:1636],
+	284:  _ClientOpcodeName[1636:1653],
+	293:  _ClientOpcodeName[1653:1674],
+	296:  _ClientOpcodeName[1674:1695],
+	302:  _ClientOpcodeName[1695:1710],
+	303:  _ClientOpcodeName[1710:1726],
+	310:  _ClientOpcodeName[1726:1742],
+	315:  _ClientOpcodeName[1742:1765],
+	321:  _ClientOpcodeName[1765:1782],
+	322:  _ClientOpcodeName[1782:1798],
+	346:  _ClientOpcodeName[1798:1816],
+	460:  _ClientOpcodeName[1816:1835],
+	462:  _ClientOpcodeName[1835:1848],
+	466:  _ClientOpcodeName[1848:1867],
+	476:  _ClientOpcodeName[1867:1877],
+	493:  _ClientOpcodeName[1877:1894],
+	500:  _ClientOpcodeName[1894:1911],
+	522:  _ClientOpcodeName[1911:1927],
+	523:  _ClientOpcodeName[1927:1943],
+	529:  _ClientOpcodeName[1943:1964],
+	534:  _ClientOpcodeName[1964:1981],
+	540:  _ClientOpcodeName[1981:2007],
+	561:  _ClientOpcodeName[2007:2022],
+	562:  _ClientOpcodeName[2022:2040],
+	563:  _ClientOpcodeName[2040:2058],
+	564:  _ClientOpcodeName[2058:2082],
+	565:  _ClientOpcodeName[2082:2107],
+	568:  _ClientOpcodeName[2107:2121],
+	570:  _ClientOpcodeName[2121:2138],
+	572:  _ClientOpcodeName[2138:2161],
+	581:  _ClientOpcodeName[2161:2180],
+	582:  _ClientOpcodeName[2180:2198],
+	583:  _ClientOpcodeName[2198:2218],
+	584:  _ClientOpcodeName[2218:2242],
+	585:  _ClientOpcodeName[2242:2258],
+	593:  _ClientOpcodeName[2258:2275],
+	618:  _ClientOpcodeName[2275:2295],
+	638:  _ClientOpcodeName[2295:2320],
+	639:  _ClientOpcodeName[2320:2349],
+	640:  _ClientOpcodeName[2349:2372],
+	644:  _ClientOpcodeName[2372:2395],
+	654:  _ClientOpcodeName[2395:2417],
+	655:  _ClientOpcodeName[2417:2443],
+	662:  _ClientOpcodeName[2443:2461],
+	682:  _ClientOpcodeName[2461:2484],
+	703:  _ClientOpcodeName[2484:2509],
+	714:  _ClientOpcodeName[2509:2528],
+	717:  _ClientOpcodeName[2528:2545],
+	718:  _ClientOpcodeName[2545:2566],
+	723:  _ClientOpcodeName[2566:2592],
+	764:  _ClientOpcodeName[2592:2611],
+	791:  _ClientOpcodeName[2611:2635],
+	801:  _ClientOpcodeName[2635:2657],
+	802:  _ClientOpcodeName[2657:2677],
+	857:  _ClientOpcodeName[2677:2698],
+	858:  _ClientOpcodeName[2698:2718],
+	878:  _ClientOpcodeName[2718:2741],
+	898:  _ClientOpcodeName[2741:2772],
+	908:  _ClientOpcodeName[2772:2788],
+	935:  _ClientOpcodeName[2788:2810],
+	943:  _ClientOpcodeName[2810:2831],
+	966:  _ClientOpcodeName[2831:2859],
+	979:  _ClientOpcodeName[2859:2880],
+	1006: _ClientOpcodeName[2880:2904],
+	1021: _ClientOpcodeName[2904:2926],
+	1022: _ClientOpcodeName[2926:2949],
+	1095: _ClientOpcodeName[2949:2974],
+	1162: _ClientOpcodeName[2974:2991],
+	1217: _ClientOpcodeName[2991:3016],
+	1270: _ClientOpcodeName[3016:3031],
+	1279: _ClientOpcodeName[3031:3061],
 }
 
 func (i ClientOpcode) String() string {
@@ -66,105 +190,477 @@ func _ClientOpcodeNoOp() {
 	_ = x[OpClientLogoutRequest-(75)]
 	_ = x[OpClientLogoutCancel-(78)]
 	_ = x[OpClientGetPlayerName-(80)]
+	_ = x[OpClientGuildQuery-(84)]
 	_ = x[OpClientGetItemInfo-(86)]
+	_ = x[OpClientWho-(98)]
+	_ = x[OpClientContactList-(102)]
+	_ = x[OpClientAddFriend-(105)]
+	_ = x[OpClientDelFriend-(106)]
+	_ = x[OpClientSetContactNotes-(107)]
+	_ = x[OpClientAddIgnore-(108)]
+	_ = x[OpClientDelIgnore-(109)]
+	_ = x[OpClientGroupInvite-(110)]
+	_ = x[OpClientGroupAccept-(114)]
+	_ = x[OpClientGroupDecline-(115)]
+	_ = x[OpClientGroupUninvite-(117)]
+	_ = x[OpClientGroupUninviteGuid-(118)]
+	_ = x[OpClientGroupSetLeader-(120)]
+	_ = x[OpClientLootMethod-(122)]
+	_ = x[OpClientGroupDisband-(123)]
+	_ = x[OpClientGuildInvite-(130)]
+	_ = x[OpClientGuildAccept-(132)]
+	_ = x[OpClientGuildDecline-(133)]
+	_ = x[OpClientGuildInfo-(135)]
+	_ = x[OpClientGuildRoster-(137)]
+	_ = x[OpClientGuildPromote-(139)]
+	_ = x[OpClientGuildDemote-(140)]
+	_ = x[OpClientGuildLeave-(141)]
+	_ = x[OpClientGuildRemove-(142)]
+	_ = x[OpClientGuildDisband-(143)]
+	_ = x[OpClientGuildLeader-(144)]
+	_ = x[OpClientGuildMOTD-(145)]
+	_ = x[OpClientMessageChat-(149)]
+	_ = x[OpClientJoinChannel-(151)]
+	_ = x[OpClientLeaveChannel-(152)]
+	_ = x[OpClientChannelList-(154)]
+	_ = x[OpClientChannelPassword-(156)]
+	_ = x[OpClientChannelSetOwner-(157)]
+	_ = x[OpClientChannelOwner-(158)]
+	_ = x[OpClientChannelModerator-(159)]
+	_ = x[OpClientChannelUnmoderator-(160)]
+	_ = x[OpClientChannelMute-(161)]
+	_ = x[OpClientChannelUnmute-(162)]
+	_ = x[OpClientChannelInvite-(163)]
+	_ = x[OpClientChannelKick-(164)]
+	_ = x[OpClientChannelBan-(165)]
+	_ = x[OpClientChannelUnban-(166)]
+	_ = x[OpClientChannelAnnouncements-(167)]
+	_ = x[OpClientChannelModerate-(168)]
+	_ = x[OpClientUseItem-(171)]
+	_ = x[OpClientAreaTrigger-(180)]
+	_ = x[OpClientMoveStartForward-(181)]
+	_ = x[OpClientMoveStartBackward-(182)]
+	_ = x[OpClientMoveStop-(183)]
+	_ = x[OpClientMoveStartStrafeLeft-(184)]
+	_ = x[OpClientMoveStartStrafeRight-(185)]
+	_ = x[OpClientMoveStopStrafe-(186)]
+	_ = x[OpClientMoveJump-(187)]
+	_ = x[OpClientMoveStartTurnLeft-(188)]
+	_ = x[OpClientMoveStartTurnRight-(189)]
+	_ = x[OpClientMoveStopTurn-(190)]
+	_ = x[OpClientMoveStartPitchUp-(191)]
+	_ = x[OpClientMoveStartPitchDown-(192)]
+	_ = x[OpClientMoveStopPitch-(193)]
+	_ = x[OpClientMoveSetRunMode-(194)]
+	_ = x[OpClientMoveSetWalkMode-(195)]
+	_ = x[OpClientMoveTeleportAck-(199)]
+	_ = x[OpClientMoveFallLand-(201)]
+	_ = x[OpClientMoveStartSwim-(202)]
+	_ = x[OpClientMoveStopSwim-(203)]
+	_ = x[OpClientMoveSetFacing-(218)]
+	_ = x[OpClientMoveSetPitch-(219)]
+	_ = x[OpClientMoveWorldportAck-(220)]
+	_ = x[OpClientForceRunSpeedChangeAck-(227)]
+	_ = x[OpClientForceSwimSpeedChangeAck-(231)]
+	_ = x[OpClientMoveHeartbeat-(238)]
 	_ = x[OpClientStandStateChange-(257)]
+	_ = x[OpClientAutoEquipItem-(266)]
+	_ = x[OpClientAutoStoreBagItem-(267)]
+	_ = x[OpClientSwapItem-(268)]
+	_ = x[OpClientSwapInvItem-(269)]
+	_ = x[OpClientSplitItem-(270)]
+	_ = x[OpClientDestroyItem-(273)]
 	_ = x[OpClientCancelTrade-(284)]
+	_ = x[OpClientSetFactionAtWar-(293)]
+	_ = x[OpClientSetActionButton-(296)]
+	_ = x[OpClientCastSpell-(302)]
+	_ = x[OpClientCancelCast-(303)]
+	_ = x[OpClientCancelAura-(310)]
+	_ = x[OpClientCancelChannelling-(315)]
+	_ = x[OpClientAttackSwing-(321)]
+	_ = x[OpClientAttackStop-(322)]
+	_ = x[OpClientRepopRequest-(346)]
 	_ = x[OpClientGetPlayedTime-(460)]
 	_ = x[OpClientGetTime-(462)]
+	_ = x[OpClientReclaimCorpse-(466)]
 	_ = x[OpClientPing-(476)]
 	_ = x[OpClientAuthSession-(493)]
 	_ = x[OpClientEnteredZone-(500)]
 	_ = x[OpClientGetStorage-(522)]
 	_ = x[OpClientPutStorage-(523)]
 	_ = x[OpClientGetTicketStatus-(529)]
+	_ = x[OpClientCorpseQuery-(534)]
+	_ = x[OpClientSpiritHealerActivate-(540)]
+	_ = x[OpClientGuildRank-(561)]
+	_ = x[OpClientGuildAddRank-(562)]
+	_ = x[OpClientGuildDelRank-(563)]
+	_ = x[OpClientGuildSetPublicNote-(564)]
+	_ = x[OpClientGuildSetOfficerNote-(565)]
+	_ = x[OpClientSendMail-(568)]
+	_ = x[OpClientGetMailList-(570)]
 	_ = x[OpClientListBattlegrounds-(572)]
+	_ = x[OpClientMailTakeMoney-(581)]
+	_ = x[OpClientMailTakeItem-(582)]
+	_ = x[OpClientMailMarkAsRead-(583)]
+	_ = x[OpClientMailReturnToSender-(584)]
+	_ = x[OpClientMailDelete-(585)]
+	_ = x[OpClientLearnTalent-(593)]
 	_ = x[OpClientSetActiveMover-(618)]
-	_ = x[OpClientGetNextMailArrival-(644)]
+	_ = x[OpClientGroupChangeSubGroup-(638)]
+	_ = x[OpClientRequestPartyMemberStats-(639)]
+	_ = x[OpClientGroupSwapSubGroup-(640)]
+	_ = x[OpClientQueryNextMailTime-(644)]
+	_ = x[OpClientGroupRaidConvert-(654)]
+	_ = x[OpClientGroupAssistantLeader-(655)]
 	_ = x[OpClientGetLFGStatus-(662)]
+	_ = x[OpClientTalentWipeConfirm-(682)]
 	_ = x[OpClientSetActionBarToggles-(703)]
+	_ = x[OpClientMoveFallReset-(714)]
 	_ = x[OpClientGetRaidInfo-(717)]
+	_ = x[OpClientMoveTimeSkipped-(718)]
 	_ = x[OpClientGetBattlefieldStatus-(723)]
+	_ = x[OpClientGuildInfoText-(764)]
+	_ = x[OpClientSetFactionInactive-(791)]
+	_ = x[OpClientRaidTargetUpdate-(801)]
+	_ = x[OpClientRaidReadyCheck-(802)]
+	_ = x[OpClientMoveStartAscend-(857)]
+	_ = x[OpClientMoveStopAscend-(858)]
 	_ = x[OpClientGetLFGDungeonList-(878)]
+	_ = x[OpClientForceFlightSpeedChangeAck-(898)]
 	_ = x[OpClientRealmSplit-(908)]
+	_ = x[OpClientMoveStartDescend-(935)]
 	_ = x[OpClientSetVoiceEnabled-(943)]
+	_ = x[OpClientRaidReadyCheckFinished-(966)]
 	_ = x[OpClientSetVoiceChannel-(979)]
+	_ = x[OpClientChannelDisplayList-(1006)]
+	_ = x[OpClientGuildPermissions-(1021)]
 	_ = x[OpClientGetGuildBankMoney-(1022)]
 	_ = x[OpClientGetNumPendingEvents-(1095)]
+	_ = x[OpClientRemoveGlyph-(1162)]
+	_ = x[OpClientLearnPreviewTalents-(1217)]
 	_ = x[OpClientGetUITime-(1270)]
 	_ = x[OpClientReadyForAccountDataTimes-(1279)]
 }
 
-var _ClientOpcodeValues = []ClientOpcode{OpClientCharCreate, OpClientCharList, OpClientCharDelete, OpClientPlayerLogin, OpClientLogoutForce, OpClientLogoutRequest, OpClientLogoutCancel, OpClientGetPlayerName, OpClientGetItemInfo, OpClientStandStateChange, OpClientCancelTrade, OpClientGetPlayedTime, OpClientGetTime, OpClientPing, OpClientAuthSession, OpClientEnteredZone, OpClientGetStorage, OpClientPutStorage, OpClientGetTicketStatus, OpClientListBattlegrounds, OpClientSetActiveMover, OpClientGetNextMailArrival, OpClientGetLFGStatus, OpClientSetActionBarToggles, OpClientGetRaidInfo, OpClientGetBattlefieldStatus, OpClientGetLFGDungeonList, OpClientRealmSplit, OpClientSetVoiceEnabled, OpClientSetVoiceChannel, OpClientGetGuildBankMoney, OpClientGetNumPendingEvents, OpClientGetUITime, OpClientReadyForAccountDataTimes}
+var _ClientOpcodeValues = []ClientOpcode{OpClientCharCreate, OpClientCharList, OpClientCharDelete, OpClientPlayerLogin, OpClientLogoutForce, OpClientLogoutRequest, OpClientLogoutCancel, OpClientGetPlayerName, OpClientGuildQuery, OpClientGetItemInfo, OpClientWho, OpClientContactList, OpClientAddFriend, OpClientDelFriend, OpClientSetContactNotes, OpClientAddIgnore, OpClientDelIgnore, OpClientGroupInvite, OpClientGroupAccept, OpClientGroupDecline, OpClientGroupUninvite, OpClientGroupUninviteGuid, OpClientGroupSetLeader, OpClientLootMethod, OpClientGroupDisband, OpClientGuildInvite, OpClientGuildAccept, OpClientGuildDecline, OpClientGuildInfo, OpClientGuildRoster, OpClientGuildPromote, OpClientGuildDemote, OpClientGuildLeave, OpClientGuildRemove, OpClientGuildDisband, OpClientGuildLeader, OpClientGuildMOTD, OpClientMessageChat, OpClientJoinChannel, OpClientLeaveChannel, OpClientChannelList, OpClientChannelPassword, OpClientChannelSetOwner, OpClientChannelOwner, OpClientChannelModerator, OpClientChannelUnmoderator, OpClientChannelMute, OpClientChannelUnmute, OpClientChannelInvite, OpClientChannelKick, OpClientChannelBan, OpClientChannelUnban, OpClientChannelAnnouncements, OpClientChannelModerate, OpClientUseItem, OpClientAreaTrigger, OpClientMoveStartForward, OpClientMoveStartBackward, OpClientMoveStop, OpClientMoveStartStrafeLeft, OpClientMoveStartStrafeRight, OpClientMoveStopStrafe, OpClientMoveJump, OpClientMoveStartTurnLeft, OpClientMoveStartTurnRight, OpClientMoveStopTurn, OpClientMoveStartPitchUp, OpClientMoveStartPitchDown, OpClientMoveStopPitch, OpClientMoveSetRunMode, OpClientMoveSetWalkMode, OpClientMoveTeleportAck, OpClientMoveFallLand, OpClientMoveStartSwim, OpClientMoveStopSwim, OpClientMoveSetFacing, OpClientMoveSetPitch, OpClientMoveWorldportAck, OpClientForceRunSpeedChangeAck, OpClientForceSwimSpeedChangeAck, OpClientMoveHeartbeat, OpClientStandStateChange, OpClientAutoEquipItem, OpClientAutoStoreBagItem, OpClientSwapItem, OpClientSwapInvItem, OpClientSplitItem, OpClientDestroyItem, OpClientCancelTrade, OpClientSetFactionAtWar, OpClientSetActionButton, OpClientCastSpell, OpClientCancelCast, OpClientCancelAura, OpClientCancelChannelling, OpClientAttackSwing, OpClientAttackStop, OpClientRepopRequest, OpClientGetPlayedTime, OpClientGetTime, OpClientReclaimCorpse, OpClientPing, OpClientAuthSession, OpClientEnteredZone, OpClientGetStorage, OpClientPutStorage, OpClientGetTicketStatus, OpClientCorpseQuery, OpClientSpiritHealerActivate, OpClientGuildRank, OpClientGuildAddRank, OpClientGuildDelRank, OpClientGuildSetPublicNote, OpClientGuildSetOfficerNote, OpClientSendMail, OpClientGetMailList, OpClientListBattlegrounds, OpClientMailTakeMoney, OpClientMailTakeItem, OpClientMailMarkAsRead, OpClientMailReturnToSender, OpClientMailDelete, OpClientLearnTalent, OpClientSetActiveMover, OpClientGroupChangeSubGroup, OpClientRequestPartyMemberStats, OpClientGroupSwapSubGroup, OpClientQueryNextMailTime, OpClientGroupRaidConvert, OpClientGroupAssistantLeader, OpClientGetLFGStatus, OpClientTalentWipeConfirm, OpClientSetActionBarToggles, OpClientMoveFallReset, OpClientGetRaidInfo, OpClientMoveTimeSkipped, OpClientGetBattlefieldStatus, OpClientGuildInfoText, OpClientSetFactionInactive, OpClientRaidTargetUpdate, OpClientRaidReadyCheck, OpClientMoveStartAscend, OpClientMoveStopAscend, OpClientGetLFGDungeonList, OpClientForceFlightSpeedChangeAck, OpClientRealmSplit, OpClientMoveStartDescend, OpClientSetVoiceEnabled, OpClientRaidReadyCheckFinished, OpClientSetVoiceChannel, OpClientChannelDisplayList, OpClientGuildPermissions, OpClientGetGuildBankMoney, OpClientGetNumPendingEvents, OpClientRemoveGlyph, OpClientLearnPreviewTalents, OpClientGetUITime, OpClientReadyForAccountDataTimes}
 
 var _ClientOpcodeNameToValueMap = map[string]ClientOpcode{
-	_ClientOpcodeName[0:16]:         OpClientCharCreate,
-	_ClientOpcodeLowerName[0:16]:    OpClientCharCreate,
-	_ClientOpcodeName[16:30]:        OpClientCharList,
-	_ClientOpcodeLowerName[16:30]:   OpClientCharList,
-	_ClientOpcodeName[30:46]:        OpClientCharDelete,
-	_ClientOpcodeLowerName[30:46]:   OpClientCharDelete,
-	_ClientOpcodeName[46:63]:        OpClientPlayerLogin,
-	_ClientOpcodeLowerName[46:63]:   OpClientPlayerLogin,
-	_ClientOpcodeName[63:80]:        OpClientLogoutForce,
-	_ClientOpcodeLowerName[63:80]:   OpClientLogoutForce,
-	_ClientOpcodeName[80:99]:        OpClientLogoutRequest,
-	_ClientOpcodeLowerName[80:99]:   OpClientLogoutRequest,
-	_ClientOpcodeName[99:117]:       OpClientLogoutCancel,
-	_ClientOpcodeLowerName[99:117]:  OpClientLogoutCancel,
-	_ClientOpcodeName[117:136]:      OpClientGetPlayerName,
-	_ClientOpcodeLowerName[117:136]: OpClientGetPlayerName,
-	_ClientOpcodeName[136:153]:      OpClientGetItemInfo,
-	_ClientOpcodeLowerName[136:153]: OpClientGetItemInfo,
-	_ClientOpcodeName[153:175]:      OpClientStandStateChange,
-	_ClientOpcodeLowerName[153:175]: OpClientStandStateChange,
-	_ClientOpcodeName[175:192]:      OpClientCancelTrade,
-	_ClientOpcodeLowerName[175:192]: OpClientCancelTrade,
-	_ClientOpcodeName[192:211]:      OpClientGetPlayedTime,
-	_ClientOpcodeLowerName[192:211]: OpClientGetPlayedTime,
-	_ClientOpcodeName[211:224]:      OpClientGetTime,
-	_ClientOpcodeLowerName[211:224]: OpClientGetTime,
-	_ClientOpcodeName[224:234]:      OpClientPing,
-	_ClientOpcodeLowerName[224:234]: OpClientPing,
-	_ClientOpcodeName[234:251]:      OpClientAuthSession,
-	_ClientOpcodeLowerName[234:251]: OpClientAuthSession,
-	_ClientOpcodeName[251:268]:      OpClientEnteredZone,
-	_ClientOpcodeLowerName[251:268]: OpClientEnteredZone,
-	_ClientOpcodeName[268:284]:      OpClientGetStorage,
-	_ClientOpcodeLowerName[268:284]: OpClientGetStorage,
-	_ClientOpcodeName[284:300]:      OpClientPutStorage,
-	_ClientOpcodeLowerName[284:300]: OpClientPutStorage,
-	_ClientOpcodeName[300:321]:      OpClientGetTicketStatus,
-	_ClientOpcodeLowerName[300:321]: OpClientGetTicketStatus,
-	_ClientOpcodeName[321:344]:      OpClientListBattlegrounds,
-	_ClientOpcodeLowerName[321:344]: OpClientListBattlegrounds,
-	_ClientOpcodeName[344:364]:      OpClientSetActiveMover,
-	_ClientOpcodeLowerName[344:364]: OpClientSetActiveMover,
-	_ClientOpcodeName[364:388]:      OpClientGetNextMailArrival,
-	_ClientOpcodeLowerName[364:388]: OpClientGetNextMailArrival,
-	_ClientOpcodeName[388:406]:      OpClientGetLFGStatus,
-	_ClientOpcodeLowerName[388:406]: OpClientGetLFGStatus,
-	_ClientOpcodeName[406:431]:      OpClientSetActionBarToggles,
-	_ClientOpcodeLowerName[406:431]: OpClientSetActionBarToggles,
-	_ClientOpcodeName[431:448]:      OpClientGetRaidInfo,
-	_ClientOpcodeLowerName[431:448]: OpClientGetRaidInfo,
-	_ClientOpcodeName[448:474]:      OpClientGetBattlefieldStatus,
-	_ClientOpcodeLowerName[448:474]: OpClientGetBattlefieldStatus,
-	_ClientOpcodeName[474:497]:      OpClientGetLFGDungeonList,
-	_ClientOpcodeLowerName[474:497]: OpClientGetLFGDungeonList,
-	_ClientOpcodeName[497:513]:      OpClientRealmSplit,
-	_ClientOpcodeLowerName[497:513]: OpClientRealmSplit,
-	_ClientOpcodeName[513:534]:      OpClientSetVoiceEnabled,
-	_ClientOpcodeLowerName[513:534]: OpClientSetVoiceEnabled,
-	_ClientOpcodeName[534:555]:      OpClientSetVoiceChannel,
-	_ClientOpcodeLowerName[534:555]: OpClientSetVoiceChannel,
-	_ClientOpcodeName[555:578]:      OpClientGetGuildBankMoney,
-	_ClientOpcodeLowerName[555:578]: OpClientGetGuildBankMoney,
-	_ClientOpcodeName[578:603]:      OpClientGetNumPendingEvents,
-	_ClientOpcodeLowerName[578:603]: OpClientGetNumPendingEvents,
-	_ClientOpcodeName[603:618]:      OpClientGetUITime,
-	_ClientOpcodeLowerName[603:618]: OpClientGetUITime,
-	_ClientOpcodeName[618:648]:      OpClientReadyForAccountDataTimes,
-	_ClientOpcodeLowerName[618:648]: OpClientReadyForAccountDataTimes,
+	_ClientOpcodeName[0:16]:           OpClientCharCreate,
+	_ClientOpcodeLowerName[0:16]:      OpClientCharCreate,
+	_ClientOpcodeName[16:30]:          OpClientCharList,
+	_ClientOpcodeLowerName[16:30]:     OpClientCharList,
+	_ClientOpcodeName[30:46]:          OpClientCharDelete,
+	_ClientOpcodeLowerName[30:46]:     OpClientCharDelete,
+	_ClientOpcodeName[46:63]:          OpClientPlayerLogin,
+	_ClientOpcodeLowerName[46:63]:     OpClientPlayerLogin,
+	_ClientOpcodeName[63:80]:          OpClientLogoutForce,
+	_ClientOpcodeLowerName[63:80]:     OpClientLogoutForce,
+	_ClientOpcodeName[80:99]:          OpClientLogoutRequest,
+	_ClientOpcodeLowerName[80:99]:     OpClientLogoutRequest,
+	_ClientOpcodeName[99:117]:         OpClientLogoutCancel,
+	_ClientOpcodeLowerName[99:117]:    OpClientLogoutCancel,
+	_ClientOpcodeName[117:136]:        OpClientGetPlayerName,
+	_ClientOpcodeLowerName[117:136]:   OpClientGetPlayerName,
+	_ClientOpcodeName[136:152]:        OpClientGuildQuery,
+	_ClientOpcodeLowerName[136:152]:   OpClientGuildQuery,
+	_ClientOpcodeName[152:169]:        OpClientGetItemInfo,
+	_ClientOpcodeLowerName[152:169]:   OpClientGetItemInfo,
+	_ClientOpcodeName[169:178]:        OpClientWho,
+	_ClientOpcodeLowerName[169:178]:   OpClientWho,
+	_ClientOpcodeName[178:195]:        OpClientContactList,
+	_ClientOpcodeLowerName[178:195]:   OpClientContactList,
+	_ClientOpcodeName[195:210]:        OpClientAddFriend,
+	_ClientOpcodeLowerName[195:210]:   OpClientAddFriend,
+	_ClientOpcodeName[210:225]:        OpClientDelFriend,
+	_ClientOpcodeLowerName[210:225]:   OpClientDelFriend,
+	_ClientOpcodeName[225:246]:        OpClientSetContactNotes,
+	_ClientOpcodeLowerName[225:246]:   OpClientSetContactNotes,
+	_ClientOpcodeName[246:261]:        OpClientAddIgnore,
+	_ClientOpcodeLowerName[246:261]:   OpClientAddIgnore,
+	_ClientOpcodeName[261:276]:        OpClientDelIgnore,
+	_ClientOpcodeLowerName[261:276]:   OpClientDelIgnore,
+	_ClientOpcodeName[276:293]:        OpClientGroupInvite,
+	_ClientOpcodeLowerName[276:293]:   OpClientGroupInvite,
+	_ClientOpcodeName[293:310]:        OpClientGroupAccept,
+	_ClientOpcodeLowerName[293:310]:   OpClientGroupAccept,
+	_ClientOpcodeName[310:328]:        OpClientGroupDecline,
+	_ClientOpcodeLowerName[310:328]:   OpClientGroupDecline,
+	_ClientOpcodeName[328:347]:        OpClientGroupUninvite,
+	_ClientOpcodeLowerName[328:347]:   OpClientGroupUninvite,
+	_ClientOpcodeName[347:370]:        OpClientGroupUninviteGuid,
+	_ClientOpcodeLowerName[347:370]:   OpClientGroupUninviteGuid,
+	_ClientOpcodeName[370:390]:        OpClientGroupSetLeader,
+	_ClientOpcodeLowerName[370:390]:   OpClientGroupSetLeader,
+	_ClientOpcodeName[390:406]:        OpClientLootMethod,
+	_ClientOpcodeLowerName[390:406]:   OpClientLootMethod,
+	_ClientOpcodeName[406:424]:        OpClientGroupDisband,
+	_ClientOpcodeLowerName[406:424]:   OpClientGroupDisband,
+	_ClientOpcodeName[424:441]:        OpClientGuildInvite,
+	_ClientOpcodeLowerName[424:441]:   OpClientGuildInvite,
+	_ClientOpcodeName[441:458]:        OpClientGuildAccept,
+	_ClientOpcodeLowerName[441:458]:   OpClientGuildAccept,
+	_ClientOpcodeName[458:476]:        OpClientGuildDecline,
+	_ClientOpcodeLowerName[458:476]:   OpClientGuildDecline,
+	_ClientOpcodeName[476:491]:        OpClientGuildInfo,
+	_ClientOpcodeLowerName[476:491]:   OpClientGuildInfo,
+	_ClientOpcodeName[491:508]:        OpClientGuildRoster,
+	_ClientOpcodeLowerName[491:508]:   OpClientGuildRoster,
+	_ClientOpcodeName[508:526]:        OpClientGuildPromote,
+	_ClientOpcodeLowerName[508:526]:   OpClientGuildPromote,
+	_ClientOpcodeName[526:543]:        OpClientGuildDemote,
+	_ClientOpcodeLowerName[526:543]:   OpClientGuildDemote,
+	_ClientOpcodeName[543:559]:        OpClientGuildLeave,
+	_ClientOpcodeLowerName[543:559]:   OpClientGuildLeave,
+	_ClientOpcodeName[559:576]:        OpClientGuildRemove,
+	_ClientOpcodeLowerName[559:576]:   OpClientGuildRemove,
+	_ClientOpcodeName[576:594]:        OpClientGuildDisband,
+	_ClientOpcodeLowerName[576:594]:   OpClientGuildDisband,
+	_ClientOpcodeName[594:611]:        OpClientGuildLeader,
+	_ClientOpcodeLowerName[594:611]:   OpClientGuildLeader,
+	_ClientOpcodeName[611:626]:        OpClientGuildMOTD,
+	_ClientOpcodeLowerName[611:626]:   OpClientGuildMOTD,
+	_ClientOpcodeName[626:643]:        OpClientMessageChat,
+	_ClientOpcodeLowerName[626:643]:   OpClientMessageChat,
+	_ClientOpcodeName[643:660]:        OpClientJoinChannel,
+	_ClientOpcodeLowerName[643:660]:   OpClientJoinChannel,
+	_ClientOpcodeName[660:678]:        OpClientLeaveChannel,
+	_ClientOpcodeLowerName[660:678]:   OpClientLeaveChannel,
+	_ClientOpcodeName[678:695]:        OpClientChannelList,
+	_ClientOpcodeLowerName[678:695]:   OpClientChannelList,
+	_ClientOpcodeName[695:716]:        OpClientChannelPassword,
+	_ClientOpcodeLowerName[695:716]:   OpClientChannelPassword,
+	_ClientOpcodeName[716:737]:        OpClientChannelSetOwner,
+	_ClientOpcodeLowerName[716:737]:   OpClientChannelSetOwner,
+	_ClientOpcodeName[737:755]:        OpClientChannelOwner,
+	_ClientOpcodeLowerName[737:755]:   OpClientChannelOwner,
+	_ClientOpcodeName[755:777]:        OpClientChannelModerator,
+	_ClientOpcodeLowerName[755:777]:   OpClientChannelModerator,
+	_ClientOpcodeName[777:801]:        OpClientChannelUnmoderator,
+	_ClientOpcodeLowerName[777:801]:   OpClientChannelUnmoderator,
+	_ClientOpcodeName[801:818]:        OpClientChannelMute,
+	_ClientOpcodeLowerName[801:818]:   OpClientChannelMute,
+	_ClientOpcodeName[818:837]:        OpClientChannelUnmute,
+	_ClientOpcodeLowerName[818:837]:   OpClientChannelUnmute,
+	_ClientOpcodeName[837:856]:        OpClientChannelInvite,
+	_ClientOpcodeLowerName[837:856]:   OpClientChannelInvite,
+	_ClientOpcodeName[856:873]:        OpClientChannelKick,
+	_ClientOpcodeLowerName[856:873]:   OpClientChannelKick,
+	_ClientOpcodeName[873:889]:        OpClientChannelBan,
+	_ClientOpcodeLowerName[873:889]:   OpClientChannelBan,
+	_ClientOpcodeName[889:907]:        OpClientChannelUnban,
+	_ClientOpcodeLowerName[889:907]:   OpClientChannelUnban,
+	_ClientOpcodeName[907:933]:        OpClientChannelAnnouncements,
+	_ClientOpcodeLowerName[907:933]:   OpClientChannelAnnouncements,
+	_ClientOpcodeName[933:954]:        OpClientChannelModerate,
+	_ClientOpcodeLowerName[933:954]:   OpClientChannelModerate,
+	_ClientOpcodeName[954:967]:        OpClientUseItem,
+	_ClientOpcodeLowerName[954:967]:   OpClientUseItem,
+	_ClientOpcodeName[967:984]:        OpClientAreaTrigger,
+	_ClientOpcodeLowerName[967:984]:   OpClientAreaTrigger,
+	_ClientOpcodeName[984:1006]:       OpClientMoveStartForward,
+	_ClientOpcodeLowerName[984:1006]:  OpClientMoveStartForward,
+	_ClientOpcodeName[1006:1029]:      OpClientMoveStartBackward,
+	_ClientOpcodeLowerName[1006:1029]: OpClientMoveStartBackward,
+	_ClientOpcodeName[1029:1043]:      OpClientMoveStop,
+	_ClientOpcodeLowerName[1029:1043]: OpClientMoveStop,
+	_ClientOpcodeName[1043:1068]:      OpClientMoveStartStrafeLeft,
+	_ClientOpcodeLowerName[1043:1068]: OpClientMoveStartStrafeLeft,
+	_ClientOpcodeName[1068:1094]:      OpClientMoveStartStrafeRight,
+	_ClientOpcodeLowerName[1068:1094]: OpClientMoveStartStrafeRight,
+	_ClientOpcodeName[1094:1114]:      OpClientMoveStopStrafe,
+	_ClientOpcodeLowerName[1094:1114]: OpClientMoveStopStrafe,
+	_ClientOpcodeName[1114:1128]:      OpClientMoveJump,
+	_ClientOpcodeLowerName[1114:1128]: OpClientMoveJump,
+	_ClientOpcodeName[1128:1151]:      OpClientMoveStartTurnLeft,
+	_ClientOpcodeLowerName[1128:1151]: OpClientMoveStartTurnLeft,
+	_ClientOpcodeName[1151:1175]:      OpClientMoveStartTurnRight,
+	_ClientOpcodeLowerName[1151:1175]: OpClientMoveStartTurnRight,
+	_ClientOpcodeName[1175:1193]:      OpClientMoveStopTurn,
+	_ClientOpcodeLowerName[1175:1193]: OpClientMoveStopTurn,
+	_ClientOpcodeName[1193:1215]:      OpClientMoveStartPitchUp,
+	_ClientOpcodeLowerName[1193:1215]: OpClientMoveStartPitchUp,
+	_ClientOpcodeName[1215:1239]:      OpClientMoveStartPitchDown,
+	_ClientOpcodeLowerName[1215:1239]: OpClientMoveStartPitchDown,
+	_ClientOpcodeName[1239:1258]:      OpClientMoveStopPitch,
+	_ClientOpcodeLowerName[1239:1258]: OpClientMoveStopPitch,
+	_ClientOpcodeName[1258:1278]:      OpClientMoveSetRunMode,
+	_ClientOpcodeLowerName[1258:1278]: OpClientMoveSetRunMode,
+	_ClientOpcodeName[1278:1299]:      OpClientMoveSetWalkMode,
+	_ClientOpcodeLowerName[1278:1299]: OpClientMoveSetWalkMode,
+	_ClientOpcodeName[1299:1320]:      OpClientMoveTeleportAck,
+	_ClientOpcodeLowerName[1299:1320]: OpClientMoveTeleportAck,
+	_ClientOpcodeName[1320:1338]:      OpClientMoveFallLand,
+	_ClientOpcodeLowerName[1320:1338]: OpClientMoveFallLand,
+	_ClientOpcodeName[1338:1357]:      OpClientMoveStartSwim,
+	_ClientOpcodeLowerName[1338:1357]: OpClientMoveStartSwim,
+	_ClientOpcodeName[1357:1375]:      OpClientMoveStopSwim,
+	_ClientOpcodeLowerName[1357:1375]: OpClientMoveStopSwim,
+	_ClientOpcodeName[1375:1394]:      OpClientMoveSetFacing,
+	_ClientOpcodeLowerName[1375:1394]: OpClientMoveSetFacing,
+	_ClientOpcodeName[1394:1412]:      OpClientMoveSetPitch,
+	_ClientOpcodeLowerName[1394:1412]: OpClientMoveSetPitch,
+	_ClientOpcodeName[1412:1434]:      OpClientMoveWorldportAck,
+	_ClientOpcodeLowerName[1412:1434]: OpClientMoveWorldportAck,
+	_ClientOpcodeName[1434:1462]:      OpClientForceRunSpeedChangeAck,
+	_ClientOpcodeLowerName[1434:1462]: OpClientForceRunSpeedChangeAck,
+	_ClientOpcodeName[1462:1491]:      OpClientForceSwimSpeedChangeAck,
+	_ClientOpcodeLowerName[1462:1491]: OpClientForceSwimSpeedChangeAck,
+	_ClientOpcodeName[1491:1510]:      OpClientMoveHeartbeat,
+	_ClientOpcodeLowerName[1491:1510]: OpClientMoveHeartbeat,
+	_ClientOpcodeName[1510:1532]:      OpClientStandStateChange,
+	_ClientOpcodeLowerName[1510:1532]: OpClientStandStateChange,
+	_ClientOpcodeName[1532:1551]:      OpClientAutoEquipItem,
+	_ClientOpcodeLowerName[1532:1551]: OpClientAutoEquipItem,
+	_ClientOpcodeName[1551:1573]:      OpClientAutoStoreBagItem,
+	_ClientOpcodeLowerName[1551:1573]: OpClientAutoStoreBagItem,
+	_ClientOpcodeName[1573:1587]:      OpClientSwapItem,
+	_ClientOpcodeLowerName[1573:1587]: OpClientSwapItem,
+	_ClientOpcodeName[1587:1604]:      OpClientSwapInvItem,
+	_ClientOpcodeLowerName[1587:1604]: OpClientSwapInvItem,
+	_ClientOpcodeName[1604:1619]:      OpClientSplitItem,
+	_ClientOpcodeLowerName[1604:1619]: OpClientSplitItem,
+	_ClientOpcodeName[1619:1636]:      OpClientDestroyItem,
+	_ClientOpcodeLowerName[1619:1636]: OpClientDestroyItem,
+	_ClientOpcodeName[1636:1653]:      OpClientCancelTrade,
+	_ClientOpcodeLowerName[1636:1653]: OpClientCancelTrade,
+	_ClientOpcodeName[1653:1674]:      OpClientSetFactionAtWar,
+	_ClientOpcodeLowerName[1653:1674]: OpClientSetFactionAtWar,
+	_ClientOpcodeName[1674:1695]:      OpClientSetActionButton,
+	_ClientOpcodeLowerName[1674:1695]: OpClientSetActionButton,
+	_ClientOpcodeName[1695:1710]:      OpClientCastSpell,
+	_ClientOpcodeLowerName[1695:1710]: OpClientCastSpell,
+	_ClientOpcodeName[1710:1726]:      OpClientCancelCast,
+	_ClientOpcodeLowerName[1710:1726]: OpClientCancelCast,
+	_ClientOpcodeName[1726:1742]:      OpClientCancelAura,
+	_ClientOpcodeLowerName[1726:1742]: OpClientCancelAura,
+	_ClientOpcodeName[1742:1765]:      OpClientCancelChannelling,
+	_ClientOpcodeLowerName[1742:1765]: OpClientCancelChannelling,
+	_ClientOpcodeName[1765:1782]:      OpClientAttackSwing,
+	_ClientOpcodeLowerName[1765:1782]: OpClientAttackSwing,
+	_ClientOpcodeName[1782:1798]:      OpClientAttackStop,
+	_ClientOpcodeLowerName[1782:1798]: OpClientAttackStop,
+	_ClientOpcodeName[1798:1816]:      OpClientRepopRequest,
+	_ClientOpcodeLowerName[1798:1816]: OpClientRepopRequest,
+	_ClientOpcodeName[1816:1835]:      OpClientGetPlayedTime,
+	_ClientOpcodeLowerName[1816:1835]: OpClientGetPlayedTime,
+	_ClientOpcodeName[1835:1848]:      OpClientGetTime,
+	_ClientOpcodeLowerName[1835:1848]: OpClientGetTime,
+	_ClientOpcodeName[1848:1867]:      OpClientReclaimCorpse,
+	_ClientOpcodeLowerName[1848:1867]: OpClientReclaimCorpse,
+	_ClientOpcodeName[1867:1877]:      OpClientPing,
+	_ClientOpcodeLowerName[1867:1877]: OpClientPing,
+	_ClientOpcodeName[1877:1894]:      OpClientAuthSession,
+	_ClientOpcodeLowerName[1877:1894]: OpClientAuthSession,
+	_ClientOpcodeName[1894:1911]:      OpClientEnteredZone,
+	_ClientOpcodeLowerName[1894:1911]: OpClientEnteredZone,
+	_ClientOpcodeName[1911:1927]:      OpClientGetStorage,
+	_ClientOpcodeLowerName[1911:1927]: OpClientGetStorage,
+	_ClientOpcodeName[1927:1943]:      OpClientPutStorage,
+	_ClientOpcodeLowerName[1927:1943]: OpClientPutStorage,
+	_ClientOpcodeName[1943:1964]:      OpClientGetTicketStatus,
+	_ClientOpcodeLowerName[1943:1964]: OpClientGetTicketStatus,
+	_ClientOpcodeName[1964:1981]:      OpClientCorpseQuery,
+	_ClientOpcodeLowerName[1964:1981]: OpClientCorpseQuery,
+	_ClientOpcodeName[1981:2007]:      OpClientSpiritHealerActivate,
+	_ClientOpcodeLowerName[1981:2007]: OpClientSpiritHealerActivate,
+	_ClientOpcodeName[2007:2022]:      OpClientGuildRank,
+	_ClientOpcodeLowerName[2007:2022]: OpClientGuildRank,
+	_ClientOpcodeName[2022:2040]:      OpClientGuildAddRank,
+	_ClientOpcodeLowerName[2022:2040]: OpClientGuildAddRank,
+	_ClientOpcodeName[2040:2058]:      OpClientGuildDelRank,
+	_ClientOpcodeLowerName[2040:2058]: OpClientGuildDelRank,
+	_ClientOpcodeName[2058:2082]:      OpClientGuildSetPublicNote,
+	_ClientOpcodeLowerName[2058:2082]: OpClientGuildSetPublicNote,
+	_ClientOpcodeName[2082:2107]:      OpClientGuildSetOfficerNote,
+	_ClientOpcodeLowerName[2082:2107]: OpClientGuildSetOfficerNote,
+	_ClientOpcodeName[2107:2121]:      OpClientSendMail,
+	_ClientOpcodeLowerName[2107:2121]: OpClientSendMail,
+	_ClientOpcodeName[2121:2138]:      OpClientGetMailList,
+	_ClientOpcodeLowerName[2121:2138]: OpClientGetMailList,
+	_ClientOpcodeName[2138:2161]:      OpClientListBattlegrounds,
+	_ClientOpcodeLowerName[2138:2161]: OpClientListBattlegrounds,
+	_ClientOpcodeName[2161:2180]:      OpClientMailTakeMoney,
+	_ClientOpcodeLowerName[2161:2180]: OpClientMailTakeMoney,
+	_ClientOpcodeName[2180:2198]:      OpClientMailTakeItem,
+	_ClientOpcodeLowerName[2180:2198]: OpClientMailTakeItem,
+	_ClientOpcodeName[2198:2218]:      OpClientMailMarkAsRead,
+	_ClientOpcodeLowerName[2198:2218]: OpClientMailMarkAsRead,
+	_ClientOpcodeName[2218:2242]:      OpClientMailReturnToSender,
+	_ClientOpcodeLowerName[2218:2242]: OpClientMailReturnToSender,
+	_ClientOpcodeName[2242:2258]:      OpClientMailDelete,
+	_ClientOpcodeLowerName[2242:2258]: OpClientMailDelete,
+	_ClientOpcodeName[2258:2275]:      OpClientLearnTalent,
+	_ClientOpcodeLowerName[2258:2275]: OpClientLearnTalent,
+	_ClientOpcodeName[2275:2295]:      OpClientSetActiveMover,
+	_ClientOpcodeLowerName[2275:2295]: OpClientSetActiveMover,
+	_ClientOpcodeName[2295:2320]:      OpClientGroupChangeSubGroup,
+	_ClientOpcodeLowerName[2295:2320]: OpClientGroupChangeSubGroup,
+	_ClientOpcodeName[2320:2349]:      OpClientRequestPartyMemberStats,
+	_ClientOpcodeLowerName[2320:2349]: OpClientRequestPartyMemberStats,
+	_ClientOpcodeName[2349:2372]:      OpClientGroupSwapSubGroup,
+	_ClientOpcodeLowerName[2349:2372]: OpClientGroupSwapSubGroup,
+	_ClientOpcodeName[2372:2395]:      OpClientQueryNextMailTime,
+	_ClientOpcodeLowerName[2372:2395]: OpClientQueryNextMailTime,
+	_ClientOpcodeName[2395:2417]:      OpClientGroupRaidConvert,
+	_ClientOpcodeLowerName[2395:2417]: OpClientGroupRaidConvert,
+	_ClientOpcodeName[2417:2443]:      OpClientGroupAssistantLeader,
+	_ClientOpcodeLowerName[2417:2443]: OpClientGroupAssistantLeader,
+	_ClientOpcodeName[2443:2461]:      OpClientGetLFGStatus,
+	_ClientOpcodeLowerName[2443:2461]: OpClientGetLFGStatus,
+	_ClientOpcodeName[2461:2484]:      OpClientTalentWipeConfirm,
+	_ClientOpcodeLowerName[2461:2484]: OpClientTalentWipeConfirm,
+	_ClientOpcodeName[2484:2509]:      OpClientSetActionBarToggles,
+	_ClientOpcodeLowerName[2484:2509]: OpClientSetActionBarToggles,
+	_ClientOpcodeName[2509:2528]:      OpClientMoveFallReset,
+	_ClientOpcodeLowerName[2509:2528]: OpClientMoveFallReset,
+	_ClientOpcodeName[2528:2545]:      OpClientGetRaidInfo,
+	_ClientOpcodeLowerName[2528:2545]: OpClientGetRaidInfo,
+	_ClientOpcodeName[2545:2566]:      OpClientMoveTimeSkipped,
+	_ClientOpcodeLowerName[2545:2566]: OpClientMoveTimeSkipped,
+	_ClientOpcodeName[2566:2592]:      OpClientGetBattlefieldStatus,
+	_ClientOpcodeLowerName[2566:2592]: OpClientGetBattlefieldStatus,
+	_ClientOpcodeName[2592:2611]:      OpClientGuildInfoText,
+	_ClientOpcodeLowerName[2592:2611]: OpClientGuildInfoText,
+	_ClientOpcodeName[2611:2635]:      OpClientSetFactionInactive,
+	_ClientOpcodeLowerName[2611:2635]: OpClientSetFactionInactive,
+	_ClientOpcodeName[2635:2657]:      OpClientRaidTargetUpdate,
+	_ClientOpcodeLowerName[2635:2657]: OpClientRaidTargetUpdate,
+	_ClientOpcodeName[2657:2677]:      OpClientRaidReadyCheck,
+	_ClientOpcodeLowerName[2657:2677]: OpClientRaidReadyCheck,
+	_ClientOpcodeName[2677:2698]:      OpClientMoveStartAscend,
+	_ClientOpcodeLowerName[2677:2698]: OpClientMoveStartAscend,
+	_ClientOpcodeName[2698:2718]:      OpClientMoveStopAscend,
+	_ClientOpcodeLowerName[2698:2718]: OpClientMoveStopAscend,
+	_ClientOpcodeName[2718:2741]:      OpClientGetLFGDungeonList,
+	_ClientOpcodeLowerName[2718:2741]: OpClientGetLFGDungeonList,
+	_ClientOpcodeName[2741:2772]:      OpClientForceFlightSpeedChangeAck,
+	_ClientOpcodeLowerName[2741:2772]: OpClientForceFlightSpeedChangeAck,
+	_ClientOpcodeName[2772:2788]:      OpClientRealmSplit,
+	_ClientOpcodeLowerName[2772:2788]: OpClientRealmSplit,
+	_ClientOpcodeName[2788:2810]:      OpClientMoveStartDescend,
+	_ClientOpcodeLowerName[2788:2810]: OpClientMoveStartDescend,
+	_ClientOpcodeName[2810:2831]:      OpClientSetVoiceEnabled,
+	_ClientOpcodeLowerName[2810:2831]: OpClientSetVoiceEnabled,
+	_ClientOpcodeName[2831:2859]:      OpClientRaidReadyCheckFinished,
+	_ClientOpcodeLowerName[2831:2859]: OpClientRaidReadyCheckFinished,
+	_ClientOpcodeName[2859:2880]:      OpClientSetVoiceChannel,
+	_ClientOpcodeLowerName[2859:2880]: OpClientSetVoiceChannel,
+	_ClientOpcodeName[2880:2904]:      OpClientChannelDisplayList,
+	_ClientOpcodeLowerName[2880:2904]: OpClientChannelDisplayList,
+	_ClientOpcodeName[2904:2926]:      OpClientGuildPermissions,
+	_ClientOpcodeLowerName[2904:2926]: OpClientGuildPermissions,
+	_ClientOpcodeName[2926:2949]:      OpClientGetGuildBankMoney,
+	_ClientOpcodeLowerName[2926:2949]: OpClientGetGuildBankMoney,
+	_ClientOpcodeName[2949:2974]:      OpClientGetNumPendingEvents,
+	_ClientOpcodeLowerName[2949:2974]: OpClientGetNumPendingEvents,
+	_ClientOpcodeName[2974:2991]:      OpClientRemoveGlyph,
+	_ClientOpcodeLowerName[2974:2991]: OpClientRemoveGlyph,
+	_ClientOpcodeName[2991:3016]:      OpClientLearnPreviewTalents,
+	_ClientOpcodeLowerName[2991:3016]: OpClientLearnPreviewTalents,
+	_ClientOpcodeName[3016:3031]:      OpClientGetUITime,
+	_ClientOpcodeLowerName[3016:3031]: OpClientGetUITime,
+	_ClientOpcodeName[3031:3061]:      OpClientReadyForAccountDataTimes,
+	_ClientOpcodeLowerName[3031:3061]: OpClientReadyForAccountDataTimes,
 }
 
 var _ClientOpcodeNames = []string{
@@ -176,32 +672,156 @@ var _ClientOpcodeNames = []string{
 	_ClientOpcodeName[80:99],
 	_ClientOpcodeName[99:117],
 	_ClientOpcodeName[117:136],
-	_ClientOpcodeName[136:153],
-	_ClientOpcodeName[153:175],
-	_ClientOpcodeName[175:192],
-	_ClientOpcodeName[192:211],
-	_ClientOpcodeName[211:224],
-	_ClientOpcodeName[224:234],
-	_ClientOpcodeName[234:251],
-	_ClientOpcodeName[251:268],
-	_ClientOpcodeName[268:284],
-	_ClientOpcodeName[284:300],
-	_ClientOpcodeName[300:321],
-	_ClientOpcodeName[321:344],
-	_ClientOpcodeName[344:364],
-	_ClientOpcodeName[364:388],
-	_ClientOpcodeName[388:406],
-	_ClientOpcodeName[406:431],
-	_ClientOpcodeName[431:448],
-	_ClientOpcodeName[448:474],
-	_ClientOpcodeName[474:497],
-	_ClientOpcodeName[497:513],
-	_ClientOpcodeName[513:534],
-	_ClientOpcodeName[534:555],
-	_ClientOpcodeName[555:578],
-	_ClientOpcodeName[578:603],
-	_ClientOpcodeName[603:618],
-	_ClientOpcodeName[618:648],
+	_ClientOpcodeName[136:152],
+	_ClientOpcodeName[152:169],
+	_ClientOpcodeName[169:178],
+	_ClientOpcodeName[178:195],
+	_ClientOpcodeName[195:210],
+	_ClientOpcodeName[210:225],
+	_ClientOpcodeName[225:246],
+	_ClientOpcodeName[246:261],
+	_ClientOpcodeName[261:276],
+	_ClientOpcodeName[276:293],
+	_ClientOpcodeName[293:310],
+	_ClientOpcodeName[310:328],
+	_ClientOpcodeName[328:347],
+	_ClientOpcodeName[347:370],
+	_ClientOpcodeName[370:390],
+	_ClientOpcodeName[390:406],
+	_ClientOpcodeName[406:424],
+	_ClientOpcodeName[424:441],
+	_ClientOpcodeName[441:458],
+	_ClientOpcodeName[458:476],
+	_ClientOpcodeName[476:491],
+	_ClientOpcodeName[491:508],
+	_ClientOpcodeName[508:526],
+	_ClientOpcodeName[526:543],
+	_ClientOpcodeName[543:559],
+	_ClientOpcodeName[559:576],
+	_ClientOpcodeName[576:594],
+	_ClientOpcodeName[594:611],
+	_ClientOpcodeName[611:626],
+	_ClientOpcodeName[626:643],
+	_ClientOpcodeName[643:660],
+	_ClientOpcodeName[660:678],
+	_ClientOpcodeName[678:695],
+	_ClientOpcodeName[695:716],
+	_ClientOpcodeName[716:737],
+	_ClientOpcodeName[737:755],
+	_ClientOpcodeName[755:777],
+	_ClientOpcodeName[777:801],
+	_ClientOpcodeName[801:818],
+	_ClientOpcodeName[818:837],
+	_ClientOpcodeName[837:856],
+	_ClientOpcodeName[856:873],
+	_ClientOpcodeName[873:889],
+	_ClientOpcodeName[889:907],
+	_ClientOpcodeName[907:933],
+	_ClientOpcodeName[933:954],
+	_ClientOpcodeName[954:967],
+	_ClientOpcodeName[967:984],
+	_ClientOpcodeName[984:1006],
+	_ClientOpcodeName[1006:1029],
+	_ClientOpcodeName[1029:1043],
+	_ClientOpcodeName[1043:1068],
+	_ClientOpcodeName[1068:1094],
+	_ClientOpcodeName[1094:1114],
+	_ClientOpcodeName[1114:1128],
+	_ClientOpcodeName[1128:1151],
+	_ClientOpcodeName[1151:1175],
+	_ClientOpcodeName[1175:1193],
+	_ClientOpcodeName[1193:1215],
+	_ClientOpcodeName[1215:1239],
+	_ClientOpcodeName[1239:1258],
+	_ClientOpcodeName[1258:1278],
+	_ClientOpcodeName[1278:1299],
+	_ClientOpcodeName[1299:1320],
+	_ClientOpcodeName[1320:1338],
+	_ClientOpcodeName[1338:1357],
+	_ClientOpcodeName[1357:1375],
+	_ClientOpcodeName[1375:1394],
+	_ClientOpcodeName[1394:1412],
+	_ClientOpcodeName[1412:1434],
+	_ClientOpcodeName[1434:1462],
+	_ClientOpcodeName[1462:1491],
+	_ClientOpcodeName[1491:1510],
+	_ClientOpcodeName[1510:1532],
+	_ClientOpcodeName[1532:1551],
+	_ClientOpcodeName[1551:1573],
+	_ClientOpcodeName[1573:1587],
+	_ClientOpcodeName[1587:1604],
+	_ClientOpcodeName[1604:1619],
+	_ClientOpcodeName[1619:1636],
+	_ClientOpcodeName[1636:1653],
+	_ClientOpcodeName[1653:1674],
+	_ClientOpcodeName[1674:1695],
+	_ClientOpcodeName[1695:1710],
+	_ClientOpcodeName[1710:1726],
+	_ClientOpcodeName[1726:1742],
+	_ClientOpcodeName[1742:1765],
+	_ClientOpcodeName[1765:1782],
+	_ClientOpcodeName[1782:1798],
+	_ClientOpcodeName[1798:1816],
+	_ClientOpcodeName[1816:1835],
+	_ClientOpcodeName[1835:1848],
+	_ClientOpcodeName[1848:1867],
+	_ClientOpcodeName[1867:1877],
+	_ClientOpcodeName[1877:1894],
+	_ClientOpcodeName[1894:1911],
+	_ClientOpcodeName[1911:1927],
+	_ClientOpcodeName[1927:1943],
+	_ClientOpcodeName[1943:1964],
+	_ClientOpcodeName[1964:1981],
+	_ClientOpcodeName[1981:2007],
+	_ClientOpcodeName[2007:2022],
+	_ClientOpcodeName[2022:2040],
+	_ClientOpcodeName[2040:2058],
+	_ClientOpcodeName[2058:2082],
+	_ClientOpcodeName[2082:2107],
+	_ClientOpcodeName[2107:2121],
+	_ClientOpcodeName[2121:2138],
+	_ClientOpcodeName[2138:2161],
+	_ClientOpcodeName[2161:2180],
+	_ClientOpcodeName[2180:2198],
+	_ClientOpcodeName[2198:2218],
+	_ClientOpcodeName[2218:2242],
+	_ClientOpcodeName[2242:2258],
+	_ClientOpcodeName[2258:2275],
+	_ClientOpcodeName[2275:2295],
+	_ClientOpcodeName[2295:2320],
+	_ClientOpcodeName[2320:2349],
+	_ClientOpcodeName[2349:2372],
+	_ClientOpcodeName[2372:2395],
+	_ClientOpcodeName[2395:2417],
+	_ClientOpcodeName[2417:2443],
+	_ClientOpcodeName[2443:2461],
+	_ClientOpcodeName[2461:2484],
+	_ClientOpcodeName[2484:2509],
+	_ClientOpcodeName[2509:2528],
+	_ClientOpcodeName[2528:2545],
+	_ClientOpcodeName[2545:2566],
+	_ClientOpcodeName[2566:2592],
+	_ClientOpcodeName[2592:2611],
+	_ClientOpcodeName[2611:2635],
+	_ClientOpcodeName[2635:2657],
+	_ClientOpcodeName[2657:2677],
+	_ClientOpcodeName[2677:2698],
+	_ClientOpcodeName[2698:2718],
+	_ClientOpcodeName[2718:2741],
+	_ClientOpcodeName[2741:2772],
+	_ClientOpcodeName[2772:2788],
+	_ClientOpcodeName[2788:2810],
+	_ClientOpcodeName[2810:2831],
+	_ClientOpcodeName[2831:2859],
+	_ClientOpcodeName[2859:2880],
+	_ClientOpcodeName[2880:2904],
+	_ClientOpcodeName[2904:2926],
+	_ClientOpcodeName[2926:2949],
+	_ClientOpcodeName[2949:2974],
+	_ClientOpcodeName[2974:2991],
+	_ClientOpcodeName[2991:3016],
+	_ClientOpcodeName[3016:3031],
+	_ClientOpcodeName[3031:3061],
 }
 
 // ClientOpcodeString retrieves an enum value from the enum constants string name.
@@ -235,42 +855,137 @@ func (i ClientOpcode) IsAClientOpcode() bool {
 	return ok
 }
 
-const _ServerOpcodeName = "ServerCharCreateServerCharListServerCharDeleteServerCharLoginFailedServerSetTimeSpeedServerLogoutServerLogoutCompleteServerLogoutCancelACKServerGetPlayerNameResponseServerUpdateObjectServerPlayCinematicServerTutorialFlagsServerFactionReputationServerActionButtonsServerInitialSpellsServerHearthLocationServerPlayedTimeServerTimeServerPongServerAuthChallengeServerAuthResponseServerClientStorageTimesServerGetStorageServerCharLoginVerifyWorldServerStandStateServerInitialWorldStatesServerMOTDServerRealmSplitServerSystemFeaturesServerPutStorageOKServerPlayerTalentsServerUITime"
-const _ServerOpcodeLowerName = "servercharcreateservercharlistserverchardeleteservercharloginfailedserversettimespeedserverlogoutserverlogoutcompleteserverlogoutcancelackservergetplayernameresponseserverupdateobjectserverplaycinematicservertutorialflagsserverfactionreputationserveractionbuttonsserverinitialspellsserverhearthlocationserverplayedtimeservertimeserverpongserverauthchallengeserverauthresponseserverclientstoragetimesservergetstorageservercharloginverifyworldserverstandstateserverinitialworldstatesservermotdserverrealmsplitserversystemfeaturesserverputstorageokserverplayertalentsserveruitime"
+const _ServerOpcodeName = "ServerCharCreateServerCharListServerCharDeleteServerNewWorldServerTransferPendingServerCharLoginFailedServerSetTimeSpeedServerLogoutServerLogoutCompleteServerLogoutCancelACKServerGetPlayerNameResponseServerGuildQueryResponseServerItemQueryResponseServerWhoServerContactListServerFriendStatusServerGroupInviteServerGroupDeclineServerGroupUninviteServerGroupSetLeaderServerGroupDestroyedServerGroupListServerPartyMemberStatsServerPartyCommandResultServerGuildInviteServerGuildDeclineServerGuildInfoServerGuildRosterServerGuildEventServerGuildCommandResultServerMessageChatServerChannelNotifyServerChannelListServerUpdateObjectServerMoveStartForwardServerMoveStartBackwardServerMoveStopServerMoveStartStrafeLeftServerMoveStartStrafeRightServerMoveStopStrafeServerMoveJumpServerMoveStartTurnLeftServerMoveStartTurnRightServerMoveStopTurnServerMoveStartPitchUpServerMoveStartPitchDownServerMoveStopPitchServerMoveSetRunModeServerMoveSetWalkModeServerMoveTeleportAckServerMoveFallLandServerMoveStartSwimServerMoveStopSwimServerMoveSetFacingServerMoveSetPitchServerForceRunSpeedChangeServerForceSwimSpeedChangeServerMoveHeartbeatServerPlayCinematicServerTutorialFlagsServerInventoryChangeFailServerFactionReputationServerSetFactionVisibleServerSetFactionStandingServerActionButtonsServerInitialSpellsServerLearnedSpellServerCastFailedServerSpellStartServerSpellGoServerSpellFailureServerChannelStartServerChannelUpdateServerAttackStartServerAttackStopServerAttackSwingNotInRangeServerAttackSwingBadFacingServerAttackSwingDeadTargetServerAttackSwingCantAttackServerAttackerStateUpdateServerHearthLocationServerItemPushResultServerPlayedTimeServerTimeServerLogXPGainServerLevelUpInfoServerPongServerAuthChallengeServerAuthResponseServerExplorationExperienceServerRemovedSpellServerClientStorageTimesServerGetStorageServerCorpseQueryServerCharLoginVerifyWorldServerSendMailResultServerMailListResultServerPeriodicAuraLogServerQueryNextMailTimeServerReceivedMailServerStandStateServerSpellFailedOtherServerChatPlayerNotFoundServerTalentWipeConfirmServerInitialWorldStatesServerPartyMemberStatsFullServerSplineSetRunSpeedServerSplineSetSwimSpeedServerRaidTargetUpdateServerRaidReadyCheckServerMOTDServerMoveStartAscendServerMoveStopAscendServerDeathReleaseLocServerForceFlightSpeedChangeServerSplineSetFlightSpeedServerRealmSplitServerMoveStartDescendServerRaidReadyCheckConfirmServerRaidReadyCheckFinishedServerSystemFeaturesServerGuildPermissionsServerPutStorageOKServerAuraUpdateAllServerAuraUpdateServerPlayerTalentsServerUITime"
+const _ServerOpcodeLowerName = "servercharcreateservercharlistserverchardeleteservernewworldservertransferpendingservercharloginfailedserversettimespeedserverlogoutserverlogoutcompleteserverlogoutcancelackservergetplayernameresponseserverguildqueryresponseserveritemqueryresponseserverwhoservercontactlistserverfriendstatusservergroupinviteservergroupdeclineservergroupuninviteservergroupsetleaderservergroupdestroyedservergrouplistserverpartymemberstatsserverpartycommandresultserverguildinviteserverguilddeclineserverguildinfoserverguildrosterserverguildeventserverguildcommandresultservermessagechatserverchannelnotifyserverchannellistserverupdateobjectservermovestartforwardservermovestartbackwardservermovestopservermovestartstrafeleftservermovestartstraferightservermovestopstrafeservermovejumpservermovestartturnleftservermovestartturnrightservermovestopturnservermovestartpitchupservermovestartpitchdownservermovestoppitchservermovesetrunmodeservermovesetwalkmodeservermoveteleportackservermovefalllandservermovestartswimservermovestopswimservermovesetfacingservermovesetpitchserverforcerunspeedchangeserverforceswimspeedchangeservermoveheartbeatserverplaycinematicservertutorialflagsserverinventorychangefailserverfactionreputationserversetfactionvisibleserversetfactionstandingserveractionbuttonsserverinitialspellsserverlearnedspellservercastfailedserverspellstartserverspellgoserverspellfailureserverchannelstartserverchannelupdateserverattackstartserverattackstopserverattackswingnotinrangeserverattackswingbadfacingserverattackswingdeadtargetserverattackswingcantattackserverattackerstateupdateserverhearthlocationserveritempushresultserverplayedtimeservertimeserverlogxpgainserverlevelupinfoserverpongserverauthchallengeserverauthresponseserverexplorationexperienceserverremovedspellserverclientstoragetimesservergetstorageservercorpsequeryservercharloginverifyworldserversendmailresultservermaillistresultserverperiodicauralogserverquerynextmailtimeserverreceivedmailserverstandstateserverspellfailedotherserverchatplayernotfoundservertalentwipeconfirmserverinitialworldstatesserverpartymemberstatsfullserversplinesetrunspeedserversplinesetswimspeedserverraidtargetupdateserverraidreadycheckservermotdservermovestartascendservermovestopascendserverdeathreleaselocserverforceflightspeedchangeserversplinesetflightspeedserverrealmsplitservermovestartdescendserverraidreadycheckconfirmserverraidreadycheckfinishedserversystemfeaturesserverguildpermissionsserverputstorageokserverauraupdateallserverauraupdateserverplayertalentsserveruitime"
 
 var _ServerOpcodeMap = map[ServerOpcode]string{
 	58:   _ServerOpcodeName[0:16],
 	59:   _ServerOpcodeName[16:30],
 	60:   _ServerOpcodeName[30:46],
-	65:   _ServerOpcodeName[46:67],
-	66:   _ServerOpcodeName[67:85],
-	76:   _ServerOpcodeName[85:97],
-	77:   _ServerOpcodeName[97:117],
-	79:   _ServerOpcodeName[117:138],
-	81:   _ServerOpcodeName[138:165],
-	169:  _ServerOpcodeName[165:183],
-	250:  _ServerOpcodeName[183:202],
-	253:  _ServerOpcodeName[202:221],
-	290:  _ServerOpcodeName[221:244],
-	297:  _ServerOpcodeName[244:263],
-	298:  _ServerOpcodeName[263:282],
-	341:  _ServerOpcodeName[282:302],
-	461:  _ServerOpcodeName[302:318],
-	463:  _ServerOpcodeName[318:328],
-	477:  _ServerOpcodeName[328:338],
-	492:  _ServerOpcodeName[338:357],
-	494:  _ServerOpcodeName[357:375],
-	521:  _ServerOpcodeName[375:399],
-	524:  _ServerOpcodeName[399:415],
-	566:  _ServerOpcodeName[415:441],
-	669:  _ServerOpcodeName[441:457],
-	706:  _ServerOpcodeName[457:481],
-	829:  _ServerOpcodeName[481:491],
-	907:  _ServerOpcodeName[491:507],
-	969:  _ServerOpcodeName[507:527],
-	1123: _ServerOpcodeName[527:545],
-	1216: _ServerOpcodeName[545:564],
-	1271: _ServerOpcodeName[564:576],
+	62:   _ServerOpcodeName[46:60],
+	63:   _ServerOpcodeName[60:81],
+	65:   _ServerOpcodeName[81:102],
+	66:   _ServerOpcodeName[102:120],
+	76:   _ServerOpcodeName[120:132],
+	77:   _ServerOpcodeName[132:152],
+	79:   _ServerOpcodeName[152:173],
+	81:   _ServerOpcodeName[173:200],
+	85:   _ServerOpcodeName[200:224],
+	88:   _ServerOpcodeName[224:247],
+	99:   _ServerOpcodeName[247:256],
+	103:  _ServerOpcodeName[256:273],
+	104:  _ServerOpcodeName[273:291],
+	111:  _ServerOpcodeName[291:308],
+	116:  _ServerOpcodeName[308:326],
+	119:  _ServerOpcodeName[326:345],
+	121:  _ServerOpcodeName[345:365],
+	124:  _ServerOpcodeName[365:385],
+	125:  _ServerOpcodeName[385:400],
+	126:  _ServerOpcodeName[400:422],
+	127:  _ServerOpcodeName[422:446],
+	131:  _ServerOpcodeName[446:463],
+	134:  _ServerOpcodeName[463:481],
+	136:  _ServerOpcodeName[481:496],
+	138:  _ServerOpcodeName[496:513],
+	146:  _ServerOpcodeName[513:529],
+	147:  _ServerOpcodeName[529:553],
+	150:  _ServerOpcodeName[553:570],
+	153:  _ServerOpcodeName[570:589],
+	155:  _ServerOpcodeName[589:606],
+	169:  _ServerOpcodeName[606:624],
+	181:  _ServerOpcodeName[624:646],
+	182:  _ServerOpcodeName[646:669],
+	183:  _ServerOpcodeName[669:683],
+	184:  _ServerOpcodeName[683:708],
+	185:  _ServerOpcodeName[708:734],
+	186:  _ServerOpcodeName[734:754],
+	187:  _ServerOpcodeName[754:768],
+	188:  _ServerOpcodeName[768:791],
+	189:  _ServerOpcodeName[791:815],
+	190:  _ServerOpcodeName[815:833],
+	191:  _ServerOpcodeName[833:855],
+	192:  _ServerOpcodeName[855:879],
+	193:  _ServerOpcodeName[879:898],
+	194:  _ServerOpcodeName[898:918],
+	195:  _ServerOpcodeName[918:939],
+	199:  _ServerOpcodeName[939:960],
+	201:  _ServerOpcodeName[960:978],
+	202:  _ServerOpcodeName[978:997],
+	203:  _ServerOpcodeName[997:1015],
+	218:  _ServerOpcodeName[1015:1034],
+	219:  _ServerOpcodeName[1034:1052],
+	226:  _ServerOpcodeName[1052:1077],
+	230:  _ServerOpcodeName[1077:1103],
+	238:  _ServerOpcodeName[1103:1122],
+	250:  _ServerOpcodeName[1122:1141],
+	253:  _ServerOpcodeName[1141:1160],
+	274:  _ServerOpcodeName[1160:1185],
+	290:  _ServerOpcodeName[1185:1208],
+	291:  _ServerOpcodeName[1208:1231],
+	292:  _ServerOpcodeName[1231:1255],
+	297:  _ServerOpcodeName[1255:1274],
+	298:  _ServerOpcodeName[1274:1293],
+	299:  _ServerOpcodeName[1293:1311],
+	304:  _ServerOpcodeName[1311:1327],
+	305:  _ServerOpcodeName[1327:1343],
+	306:  _ServerOpcodeName[1343:1356],
+	307:  _ServerOpcodeName[1356:1374],
+	313:  _ServerOpcodeName[1374:1392],
+	314:  _ServerOpcodeName[1392:1411],
+	323:  _ServerOpcodeName[1411:1428],
+	324:  _ServerOpcodeName[1428:1444],
+	325:  _ServerOpcodeName[1444:1471],
+	326:  _ServerOpcodeName[1471:1497],
+	328:  _ServerOpcodeName[1497:1524],
+	329:  _ServerOpcodeName[1524:1551],
+	330:  _ServerOpcodeName[1551:1576],
+	341:  _ServerOpcodeName[1576:1596],
+	358:  _ServerOpcodeName[1596:1616],
+	461:  _ServerOpcodeName[1616:1632],
+	463:  _ServerOpcodeName[1632:1642],
+	464:  _ServerOpcodeName[1642:1657],
+	468:  _ServerOpcodeName[1657:1674],
+	477:  _ServerOpcodeName[1674:1684],
+	492:  _ServerOpcodeName[1684:1703],
+	494:  _ServerOpcodeName[1703:1721],
+	504:  _ServerOpcodeName[1721:1748],
+	515:  _ServerOpcodeName[1748:1766],
+	521:  _ServerOpcodeName[1766:1790],
+	524:  _ServerOpcodeName[1790:1806],
+	534:  _ServerOpcodeName[1806:1823],
+	566:  _ServerOpcodeName[1823:1849],
+	569:  _ServerOpcodeName[1849:1869],
+	571:  _ServerOpcodeName[1869:1889],
+	590:  _ServerOpcodeName[1889:1910],
+	644:  _ServerOpcodeName[1910:1933],
+	645:  _ServerOpcodeName[1933:1951],
+	669:  _ServerOpcodeName[1951:1967],
+	678:  _ServerOpcodeName[1967:1989],
+	681:  _ServerOpcodeName[1989:2013],
+	682:  _ServerOpcodeName[2013:2036],
+	706:  _ServerOpcodeName[2036:2060],
+	754:  _ServerOpcodeName[2060:2086],
+	766:  _ServerOpcodeName[2086:2109],
+	768:  _ServerOpcodeName[2109:2133],
+	801:  _ServerOpcodeName[2133:2155],
+	802:  _ServerOpcodeName[2155:2175],
+	829:  _ServerOpcodeName[2175:2185],
+	857:  _ServerOpcodeName[2185:2206],
+	858:  _ServerOpcodeName[2206:2226],
+	888:  _ServerOpcodeName[2226:2247],
+	897:  _ServerOpcodeName[2247:2275],
+	901:  _ServerOpcodeName[2275:2301],
+	907:  _ServerOpcodeName[2301:2317],
+	935:  _ServerOpcodeName[2317:2339],
+	942:  _ServerOpcodeName[2339:2366],
+	966:  _ServerOpcodeName[2366:2394],
+	969:  _ServerOpcodeName[2394:2414],
+	1021: _ServerOpcodeName[2414:2436],
+	1123: _ServerOpcodeName[2436:2454],
+	1173: _ServerOpcodeName[2454:2473],
+	1174: _ServerOpcodeName[2473:2489],
+	1216: _ServerOpcodeName[2489:2508],
+	1271: _ServerOpcodeName[2508:2520],
 }
 
 func (i ServerOpcode) String() string {
@@ -287,139 +1002,519 @@ func _ServerOpcodeNoOp() {
 	_ = x[OpServerCharCreate-(58)]
 	_ = x[OpServerCharList-(59)]
 	_ = x[OpServerCharDelete-(60)]
+	_ = x[OpServerNewWorld-(62)]
+	_ = x[OpServerTransferPending-(63)]
 	_ = x[OpServerCharLoginFailed-(65)]
 	_ = x[OpServerSetTimeSpeed-(66)]
 	_ = x[OpServerLogout-(76)]
 	_ = x[OpServerLogoutComplete-(77)]
 	_ = x[OpServerLogoutCancelACK-(79)]
 	_ = x[OpServerGetPlayerNameResponse-(81)]
+	_ = x[OpServerGuildQueryResponse-(85)]
+	_ = x[OpServerItemQueryResponse-(88)]
+	_ = x[OpServerWho-(99)]
+	_ = x[OpServerContactList-(103)]
+	_ = x[OpServerFriendStatus-(104)]
+	_ = x[OpServerGroupInvite-(111)]
+	_ = x[OpServerGroupDecline-(116)]
+	_ = x[OpServerGroupUninvite-(119)]
+	_ = x[OpServerGroupSetLeader-(121)]
+	_ = x[OpServerGroupDestroyed-(124)]
+	_ = x[OpServerGroupList-(125)]
+	_ = x[OpServerPartyMemberStats-(126)]
+	_ = x[OpServerPartyCommandResult-(127)]
+	_ = x[OpServerGuildInvite-(131)]
+	_ = x[OpServerGuildDecline-(134)]
+	_ = x[OpServerGuildInfo-(136)]
+	_ = x[OpServerGuildRoster-(138)]
+	_ = x[OpServerGuildEvent-(146)]
+	_ = x[OpServerGuildCommandResult-(147)]
+	_ = x[OpServerMessageChat-(150)]
+	_ = x[OpServerChannelNotify-(153)]
+	_ = x[OpServerChannelList-(155)]
 	_ = x[OpServerUpdateObject-(169)]
+	_ = x[OpServerMoveStartForward-(181)]
+	_ = x[OpServerMoveStartBackward-(182)]
+	_ = x[OpServerMoveStop-(183)]
+	_ = x[OpServerMoveStartStrafeLeft-(184)]
+	_ = x[OpServerMoveStartStrafeRight-(185)]
+	_ = x[OpServerMoveStopStrafe-(186)]
+	_ = x[OpServerMoveJump-(187)]
+	_ = x[OpServerMoveStartTurnLeft-(188)]
+	_ = x[OpServerMoveStartTurnRight-(189)]
+	_ = x[OpServerMoveStopTurn-(190)]
+	_ = x[OpServerMoveStartPitchUp-(191)]
+	_ = x[OpServerMoveStartPitchDown-(192)]
+	_ = x[OpServerMoveStopPitch-(193)]
+	_ = x[OpServerMoveSetRunMode-(194)]
+	_ = x[OpServerMoveSetWalkMode-(195)]
+	_ = x[OpServerMoveTeleportAck-(199)]
+	_ = x[OpServerMoveFallLand-(201)]
+	_ = x[OpServerMoveStartSwim-(202)]
+	_ = x[OpServerMoveStopSwim-(203)]
+	_ = x[OpServerMoveSetFacing-(218)]
+	_ = x[OpServerMoveSetPitch-(219)]
+	_ = x[OpServerForceRunSpeedChange-(226)]
+	_ = x[OpServerForceSwimSpeedChange-(230)]
+	_ = x[OpServerMoveHeartbeat-(238)]
 	_ = x[OpServerPlayCinematic-(250)]
 	_ = x[OpServerTutorialFlags-(253)]
+	_ = x[OpServerInventoryChangeFail-(274)]
 	_ = x[OpServerFactionReputation-(290)]
+	_ = x[OpServerSetFactionVisible-(291)]
+	_ = x[OpServerSetFactionStanding-(292)]
 	_ = x[OpServerActionButtons-(297)]
 	_ = x[OpServerInitialSpells-(298)]
+	_ = x[OpServerLearnedSpell-(299)]
+	_ = x[OpServerCastFailed-(304)]
+	_ = x[OpServerSpellStart-(305)]
+	_ = x[OpServerSpellGo-(306)]
+	_ = x[OpServerSpellFailure-(307)]
+	_ = x[OpServerChannelStart-(313)]
+	_ = x[OpServerChannelUpdate-(314)]
+	_ = x[OpServerAttackStart-(323)]
+	_ = x[OpServerAttackStop-(324)]
+	_ = x[OpServerAttackSwingNotInRange-(325)]
+	_ = x[OpServerAttackSwingBadFacing-(326)]
+	_ = x[OpServerAttackSwingDeadTarget-(328)]
+	_ = x[OpServerAttackSwingCantAttack-(329)]
+	_ = x[OpServerAttackerStateUpdate-(330)]
 	_ = x[OpServerHearthLocation-(341)]
+	_ = x[OpServerItemPushResult-(358)]
 	_ = x[OpServerPlayedTime-(461)]
 	_ = x[OpServerTime-(463)]
+	_ = x[OpServerLogXPGain-(464)]
+	_ = x[OpServerLevelUpInfo-(468)]
 	_ = x[OpServerPong-(477)]
 	_ = x[OpServerAuthChallenge-(492)]
 	_ = x[OpServerAuthResponse-(494)]
+	_ = x[OpServerExplorationExperience-(504)]
+	_ = x[OpServerRemovedSpell-(515)]
 	_ = x[OpServerClientStorageTimes-(521)]
 	_ = x[OpServerGetStorage-(524)]
+	_ = x[OpServerCorpseQuery-(534)]
 	_ = x[OpServerCharLoginVerifyWorld-(566)]
+	_ = x[OpServerSendMailResult-(569)]
+	_ = x[OpServerMailListResult-(571)]
+	_ = x[OpServerPeriodicAuraLog-(590)]
+	_ = x[OpServerQueryNextMailTime-(644)]
+	_ = x[OpServerReceivedMail-(645)]
 	_ = x[OpServerStandState-(669)]
+	_ = x[OpServerSpellFailedOther-(678)]
+	_ = x[OpServerChatPlayerNotFound-(681)]
+	_ = x[OpServerTalentWipeConfirm-(682)]
 	_ = x[OpServerInitialWorldStates-(706)]
+	_ = x[OpServerPartyMemberStatsFull-(754)]
+	_ = x[OpServerSplineSetRunSpeed-(766)]
+	_ = x[OpServerSplineSetSwimSpeed-(768)]
+	_ = x[OpServerRaidTargetUpdate-(801)]
+	_ = x[OpServerRaidReadyCheck-(802)]
 	_ = x[OpServerMOTD-(829)]
+	_ = x[OpServerMoveStartAscend-(857)]
+	_ = x[OpServerMoveStopAscend-(858)]
+	_ = x[OpServerDeathReleaseLoc-(888)]
+	_ = x[OpServerForceFlightSpeedChange-(897)]
+	_ = x[OpServerSplineSetFlightSpeed-(901)]
 	_ = x[OpServerRealmSplit-(907)]
+	_ = x[OpServerMoveStartDescend-(935)]
+	_ = x[OpServerRaidReadyCheckConfirm-(942)]
+	_ = x[OpServerRaidReadyCheckFinished-(966)]
 	_ = x[OpServerSystemFeatures-(969)]
+	_ = x[OpServerGuildPermissions-(1021)]
 	_ = x[OpServerPutStorageOK-(1123)]
+	_ = x[OpServerAuraUpdateAll-(1173)]
+	_ = x[OpServerAuraUpdate-(1174)]
 	_ = x[OpServerPlayerTalents-(1216)]
 	_ = x[OpServerUITime-(1271)]
 }
 
-var _ServerOpcodeValues = []ServerOpcode{OpServerCharCreate, OpServerCharList, OpServerCharDelete, OpServerCharLoginFailed, OpServerSetTimeSpeed, OpServerLogout, OpServerLogoutComplete, OpServerLogoutCancelACK, OpServerGetPlayerNameResponse, OpServerUpdateObject, OpServerPlayCinematic, OpServerTutorialFlags, OpServerFactionReputation, OpServerActionButtons, OpServerInitialSpells, OpServerHearthLocation, OpServerPlayedTime, OpServerTime, OpServerPong, OpServerAuthChallenge, OpServerAuthResponse, OpServerClientStorageTimes, OpServerGetStorage, OpServerCharLoginVerifyWorld, OpServerStandState, OpServerInitialWorldStates, OpServerMOTD, OpServerRealmSplit, OpServerSystemFeatures, OpServerPutStorageOK, OpServerPlayerTalents, OpServerUITime}
+var _ServerOpcodeValues = []ServerOpcode{OpServerCharCreate, OpServerCharList, OpServerCharDelete, OpServerNewWorld, OpServerTransferPending, OpServerCharLoginFailed, OpServerSetTimeSpeed, OpServerLogout, OpServerLogoutComplete, OpServerLogoutCancelACK, OpServerGetPlayerNameResponse, OpServerGuildQueryResponse, OpServerItemQueryResponse, OpServerWho, OpServerContactList, OpServerFriendStatus, OpServerGroupInvite, OpServerGroupDecline, OpServerGroupUninvite, OpServerGroupSetLeader, OpServerGroupDestroyed, OpServerGroupList, OpServerPartyMemberStats, OpServerPartyCommandResult, OpServerGuildInvite, OpServerGuildDecline, OpServerGuildInfo, OpServerGuildRoster, OpServerGuildEvent, OpServerGuildCommandResult, OpServerMessageChat, OpServerChannelNotify, OpServerChannelList, OpServerUpdateObject, OpServerMoveStartForward, OpServerMoveStartBackward, OpServerMoveStop, OpServerMoveStartStrafeLeft, OpServerMoveStartStrafeRight, OpServerMoveStopStrafe, OpServerMoveJump, OpServerMoveStartTurnLeft, OpServerMoveStartTurnRight, OpServerMoveStopTurn, OpServerMoveStartPitchUp, OpServerMoveStartPitchDown, OpServerMoveStopPitch, OpServerMoveSetRunMode, OpServerMoveSetWalkMode, OpServerMoveTeleportAck, OpServerMoveFallLand, OpServerMoveStartSwim, OpServerMoveStopSwim, OpServerMoveSetFacing, OpServerMoveSetPitch, OpServerForceRunSpeedChange, OpServerForceSwimSpeedChange, OpServerMoveHeartbeat, OpServerPlayCinematic, OpServerTutorialFlags, OpServerInventoryChangeFail, OpServerFactionReputation, OpServerSetFactionVisible, OpServerSetFactionStanding, OpServerActionButtons, OpServerInitialSpells, OpServerLearnedSpell, OpServerCastFailed, OpServerSpellStart, OpServerSpellGo, OpServerSpellFailure, OpServerChannelStart, OpServerChannelUpdate, OpServerAttackStart, OpServerAttackStop, OpServerAttackSwingNotInRange, OpServerAttackSwingBadFacing, OpServerAttackSwingDeadTarget, OpServerAttackSwingCantAttack, OpServerAttackerStateUpdate, OpServerHearthLocation, OpServerItemPushResult, OpServerPlayedTime, OpServerTime, OpServerLogXPGain, OpServerLevelUpInfo, OpServerPong, OpServerAuthChallenge, OpServerAuthResponse, OpServerExplorationExperience, OpServerRemovedSpell, OpServerClientStorageTimes, OpServerGetStorage, OpServerCorpseQuery, OpServerCharLoginVerifyWorld, OpServerSendMailResult, OpServerMailListResult, OpServerPeriodicAuraLog, OpServerQueryNextMailTime, OpServerReceivedMail, OpServerStandState, OpServerSpellFailedOther, OpServerChatPlayerNotFound, OpServerTalentWipeConfirm, OpServerInitialWorldStates, OpServerPartyMemberStatsFull, OpServerSplineSetRunSpeed, OpServerSplineSetSwimSpeed, OpServerRaidTargetUpdate, OpServerRaidReadyCheck, OpServerMOTD, OpServerMoveStartAscend, OpServerMoveStopAscend, OpServerDeathReleaseLoc, OpServerForceFlightSpeedChange, OpServerSplineSetFlightSpeed, OpServerRealmSplit, OpServerMoveStartDescend, OpServerRaidReadyCheckConfirm, OpServerRaidReadyCheckFinished, OpServerSystemFeatures, OpServerGuildPermissions, OpServerPutStorageOK, OpServerAuraUpdateAll, OpServerAuraUpdate, OpServerPlayerTalents, OpServerUITime}
 
 var _ServerOpcodeNameToValueMap = map[string]ServerOpcode{
-	_ServerOpcodeName[0:16]:         OpServerCharCreate,
-	_ServerOpcodeLowerName[0:16]:    OpServerCharCreate,
-	_ServerOpcodeName[16:30]:        OpServerCharList,
-	_ServerOpcodeLowerName[16:30]:   OpServerCharList,
-	_ServerOpcodeName[30:46]:        OpServerCharDelete,
-	_ServerOpcodeLowerName[30:46]:   OpServerCharDelete,
-	_ServerOpcodeName[46:67]:        OpServerCharLoginFailed,
-	_ServerOpcodeLowerName[46:67]:   OpServerCharLoginFailed,
-	_ServerOpcodeName[67:85]:        OpServerSetTimeSpeed,
-	_ServerOpcodeLowerName[67:85]:   OpServerSetTimeSpeed,
-	_ServerOpcodeName[85:97]:        OpServerLogout,
-	_ServerOpcodeLowerName[85:97]:   OpServerLogout,
-	_ServerOpcodeName[97:117]:       OpServerLogoutComplete,
-	_ServerOpcodeLowerName[97:117]:  OpServerLogoutComplete,
-	_ServerOpcodeName[117:138]:      OpServerLogoutCancelACK,
-	_ServerOpcodeLowerName[117:138]: OpServerLogoutCancelACK,
-	_ServerOpcodeName[138:165]:      OpServerGetPlayerNameResponse,
-	_ServerOpcodeLowerName[138:165]: OpServerGetPlayerNameResponse,
-	_ServerOpcodeName[165:183]:      OpServerUpdateObject,
-	_ServerOpcodeLowerName[165:183]: OpServerUpdateObject,
-	_ServerOpcodeName[183:202]:      OpServerPlayCinematic,
-	_ServerOpcodeLowerName[183:202]: OpServerPlayCinematic,
-	_ServerOpcodeName[202:221]:      OpServerTutorialFlags,
-	_ServerOpcodeLowerName[202:221]: OpServerTutorialFlags,
-	_ServerOpcodeName[221:244]:      OpServerFactionReputation,
-	_ServerOpcodeLowerName[221:244]: OpServerFactionReputation,
-	_ServerOpcodeName[244:263]:      OpServerActionButtons,
-	_ServerOpcodeLowerName[244:263]: OpServerActionButtons,
-	_ServerOpcodeName[263:282]:      OpServerInitialSpells,
-	_ServerOpcodeLowerName[263:282]: OpServerInitialSpells,
-	_ServerOpcodeName[282:302]:      OpServerHearthLocation,
-	_ServerOpcodeLowerName[282:302]: OpServerHearthLocation,
-	_ServerOpcodeName[302:318]:      OpServerPlayedTime,
-	_ServerOpcodeLowerName[302:318]: OpServerPlayedTime,
-	_ServerOpcodeName[318:328]:      OpServerTime,
-	_ServerOpcodeLowerName[318:328]: OpServerTime,
-	_ServerOpcodeName[328:338]:      OpServerPong,
-	_ServerOpcodeLowerName[328:338]: OpServerPong,
-	_ServerOpcodeName[338:357]:      OpServerAuthChallenge,
-	_ServerOpcodeLowerName[338:357]: OpServerAuthChallenge,
-	_ServerOpcodeName[357:375]:      OpServerAuthResponse,
-	_ServerOpcodeLowerName[357:375]: OpServerAuthResponse,
-	_ServerOpcodeName[375:399]:      OpServerClientStorageTimes,
-	_ServerOpcodeLowerName[375:399]: OpServerClientStorageTimes,
-	_ServerOpcodeName[399:415]:      OpServerGetStorage,
-	_ServerOpcodeLowerName[399:415]: OpServerGetStorage,
-	_ServerOpcodeName[415:441]:      OpServerCharLoginVerifyWorld,
-	_ServerOpcodeLowerName[415:441]: OpServerCharLoginVerifyWorld,
-	_ServerOpcodeName[441:457]:      OpServerStandState,
-	_ServerOpcodeLowerName[441:457]: OpServerStandState,
-	_ServerOpcodeName[457:481]:      OpServerInitialWorldStates,
-	_ServerOpcodeLowerName[457:481]: OpServerInitialWorldStates,
-	_ServerOpcodeName[481:491]:      OpServerMOTD,
-	_ServerOpcodeLowerName[481:491]: OpServerMOTD,
-	_ServerOpcodeName[491:507]:      OpServerRealmSplit,
-	_ServerOpcodeLowerName[491:507]: OpServerRealmSplit,
-	_ServerOpcodeName[507:527]:      OpServerSystemFeatures,
-	_ServerOpcodeLowerName[507:527]: OpServerSystemFeatures,
-	_ServerOpcodeName[527:545]:      OpServerPutStorageOK,
-	_ServerOpcodeLowerName[527:545]: OpServerPutStorageOK,
-	_ServerOpcodeName[545:564]:      OpServerPlayerTalents,
-	_ServerOpcodeLowerName[545:564]: OpServerPlayerTalents,
-	_ServerOpcodeName[564:576]:      OpServerUITime,
-	_ServerOpcodeLowerName[564:576]: OpServerUITime,
+	_ServerOpcodeName[0:16]:           OpServerCharCreate,
+	_ServerOpcodeLowerName[0:16]:      OpServerCharCreate,
+	_ServerOpcodeName[16:30]:          OpServerCharList,
+	_ServerOpcodeLowerName[16:30]:     OpServerCharList,
+	_ServerOpcodeName[30:46]:          OpServerCharDelete,
+	_ServerOpcodeLowerName[30:46]:     OpServerCharDelete,
+	_ServerOpcodeName[46:60]:          OpServerNewWorld,
+	_ServerOpcodeLowerName[46:60]:     OpServerNewWorld,
+	_ServerOpcodeName[60:81]:          OpServerTransferPending,
+	_ServerOpcodeLowerName[60:81]:     OpServerTransferPending,
+	_ServerOpcodeName[81:102]:         OpServerCharLoginFailed,
+	_ServerOpcodeLowerName[81:102]:    OpServerCharLoginFailed,
+	_ServerOpcodeName[102:120]:        OpServerSetTimeSpeed,
+	_ServerOpcodeLowerName[102:120]:   OpServerSetTimeSpeed,
+	_ServerOpcodeName[120:132]:        OpServerLogout,
+	_ServerOpcodeLowerName[120:132]:   OpServerLogout,
+	_ServerOpcodeName[132:152]:        OpServerLogoutComplete,
+	_ServerOpcodeLowerName[132:152]:   OpServerLogoutComplete,
+	_ServerOpcodeName[152:173]:        OpServerLogoutCancelACK,
+	_ServerOpcodeLowerName[152:173]:   OpServerLogoutCancelACK,
+	_ServerOpcodeName[173:200]:        OpServerGetPlayerNameResponse,
+	_ServerOpcodeLowerName[173:200]:   OpServerGetPlayerNameResponse,
+	_ServerOpcodeName[200:224]:        OpServerGuildQueryResponse,
+	_ServerOpcodeLowerName[200:224]:   OpServerGuildQueryResponse,
+	_ServerOpcodeName[224:247]:        OpServerItemQueryResponse,
+	_ServerOpcodeLowerName[224:247]:   OpServerItemQueryResponse,
+	_ServerOpcodeName[247:256]:        OpServerWho,
+	_ServerOpcodeLowerName[247:256]:   OpServerWho,
+	_ServerOpcodeName[256:273]:        OpServerContactList,
+	_ServerOpcodeLowerName[256:273]:   OpServerContactList,
+	_ServerOpcodeName[273:291]:        OpServerFriendStatus,
+	_ServerOpcodeLowerName[273:291]:   OpServerFriendStatus,
+	_ServerOpcodeName[291:308]:        OpServerGroupInvite,
+	_ServerOpcodeLowerName[291:308]:   OpServerGroupInvite,
+	_ServerOpcodeName[308:326]:        OpServerGroupDecline,
+	_ServerOpcodeLowerName[308:326]:   OpServerGroupDecline,
+	_ServerOpcodeName[326:345]:        OpServerGroupUninvite,
+	_ServerOpcodeLowerName[326:345]:   OpServerGroupUninvite,
+	_ServerOpcodeName[345:365]:        OpServerGroupSetLeader,
+	_ServerOpcodeLowerName[345:365]:   OpServerGroupSetLeader,
+	_ServerOpcodeName[365:385]:        OpServerGroupDestroyed,
+	_ServerOpcodeLowerName[365:385]:   OpServerGroupDestroyed,
+	_ServerOpcodeName[385:400]:        OpServerGroupList,
+	_ServerOpcodeLowerName[385:400]:   OpServerGroupList,
+	_ServerOpcodeName[400:422]:        OpServerPartyMemberStats,
+	_ServerOpcodeLowerName[400:422]:   OpServerPartyMemberStats,
+	_ServerOpcodeName[422:446]:        OpServerPartyCommandResult,
+	_ServerOpcodeLowerName[422:446]:   OpServerPartyCommandResult,
+	_ServerOpcodeName[446:463]:        OpServerGuildInvite,
+	_ServerOpcodeLowerName[446:463]:   OpServerGuildInvite,
+	_ServerOpcodeName[463:481]:        OpServerGuildDecline,
+	_ServerOpcodeLowerName[463:481]:   OpServerGuildDecline,
+	_ServerOpcodeName[481:496]:        OpServerGuildInfo,
+	_ServerOpcodeLowerName[481:496]:   OpServerGuildInfo,
+	_ServerOpcodeName[496:513]:        OpServerGuildRoster,
+	_ServerOpcodeLowerName[496:513]:   OpServerGuildRoster,
+	_ServerOpcodeName[513:529]:        OpServerGuildEvent,
+	_ServerOpcodeLowerName[513:529]:   OpServerGuildEvent,
+	_ServerOpcodeName[529:553]:        OpServerGuildCommandResult,
+	_ServerOpcodeLowerName[529:553]:   OpServerGuildCommandResult,
+	_ServerOpcodeName[553:570]:        OpServerMessageChat,
+	_ServerOpcodeLowerName[553:570]:   OpServerMessageChat,
+	_ServerOpcodeName[570:589]:        OpServerChannelNotify,
+	_ServerOpcodeLowerName[570:589]:   OpServerChannelNotify,
+	_ServerOpcodeName[589:606]:        OpServerChannelList,
+	_ServerOpcodeLowerName[589:606]:   OpServerChannelList,
+	_ServerOpcodeName[606:624]:        OpServerUpdateObject,
+	_ServerOpcodeLowerName[606:624]:   OpServerUpdateObject,
+	_ServerOpcodeName[624:646]:        OpServerMoveStartForward,
+	_ServerOpcodeLowerName[624:646]:   OpServerMoveStartForward,
+	_ServerOpcodeName[646:669]:        OpServerMoveStartBackward,
+	_ServerOpcodeLowerName[646:669]:   OpServerMoveStartBackward,
+	_ServerOpcodeName[669:683]:        OpServerMoveStop,
+	_ServerOpcodeLowerName[669:683]:   OpServerMoveStop,
+	_ServerOpcodeName[683:708]:        OpServerMoveStartStrafeLeft,
+	_ServerOpcodeLowerName[683:708]:   OpServerMoveStartStrafeLeft,
+	_ServerOpcodeName[708:734]:        OpServerMoveStartStrafeRight,
+	_ServerOpcodeLowerName[708:734]:   OpServerMoveStartStrafeRight,
+	_ServerOpcodeName[734:754]:        OpServerMoveStopStrafe,
+	_ServerOpcodeLowerName[734:754]:   OpServerMoveStopStrafe,
+	_ServerOpcodeName[754:768]:        OpServerMoveJump,
+	_ServerOpcodeLowerName[754:768]:   OpServerMoveJump,
+	_ServerOpcodeName[768:791]:        OpServerMoveStartTurnLeft,
+	_ServerOpcodeLowerName[768:791]:   OpServerMoveStartTurnLeft,
+	_ServerOpcodeName[791:815]:        OpServerMoveStartTurnRight,
+	_ServerOpcodeLowerName[791:815]:   OpServerMoveStartTurnRight,
+	_ServerOpcodeName[815:833]:        OpServerMoveStopTurn,
+	_ServerOpcodeLowerName[815:833]:   OpServerMoveStopTurn,
+	_ServerOpcodeName[833:855]:        OpServerMoveStartPitchUp,
+	_ServerOpcodeLowerName[833:855]:   OpServerMoveStartPitchUp,
+	_ServerOpcodeName[855:879]:        OpServerMoveStartPitchDown,
+	_ServerOpcodeLowerName[855:879]:   OpServerMoveStartPitchDown,
+	_ServerOpcodeName[879:898]:        OpServerMoveStopPitch,
+	_ServerOpcodeLowerName[879:898]:   OpServerMoveStopPitch,
+	_ServerOpcodeName[898:918]:        OpServerMoveSetRunMode,
+	_ServerOpcodeLowerName[898:918]:   OpServerMoveSetRunMode,
+	_ServerOpcodeName[918:939]:        OpServerMoveSetWalkMode,
+	_ServerOpcodeLowerName[918:939]:   OpServerMoveSetWalkMode,
+	_ServerOpcodeName[939:960]:        OpServerMoveTeleportAck,
+	_ServerOpcodeLowerName[939:960]:   OpServerMoveTeleportAck,
+	_ServerOpcodeName[960:978]:        OpServerMoveFallLand,
+	_ServerOpcodeLowerName[960:978]:   OpServerMoveFallLand,
+	_ServerOpcodeName[978:997]:        OpServerMoveStartSwim,
+	_ServerOpcodeLowerName[978:997]:   OpServerMoveStartSwim,
+	_ServerOpcodeName[997:1015]:       OpServerMoveStopSwim,
+	_ServerOpcodeLowerName[997:1015]:  OpServerMoveStopSwim,
+	_ServerOpcodeName[1015:1034]:      OpServerMoveSetFacing,
+	_ServerOpcodeLowerName[1015:1034]: OpServerMoveSetFacing,
+	_ServerOpcodeName[1034:1052]:      OpServerMoveSetPitch,
+	_ServerOpcodeLowerName[1034:1052]: OpServerMoveSetPitch,
+	_ServerOpcodeName[1052:1077]:      OpServerForceRunSpeedChange,
+	_ServerOpcodeLowerName[1052:1077]: OpServerForceRunSpeedChange,
+	_ServerOpcodeName[1077:1103]:      OpServerForceSwimSpeedChange,
+	_ServerOpcodeLowerName[1077:1103]: OpServerForceSwimSpeedChange,
+	_ServerOpcodeName[1103:1122]:      OpServerMoveHeartbeat,
+	_ServerOpcodeLowerName[1103:1122]: OpServerMoveHeartbeat,
+	_ServerOpcodeName[1122:1141]:      OpServerPlayCinematic,
+	_ServerOpcodeLowerName[1122:1141]: OpServerPlayCinematic,
+	_ServerOpcodeName[1141:1160]:      OpServerTutorialFlags,
+	_ServerOpcodeLowerName[1141:1160]: OpServerTutorialFlags,
+	_ServerOpcodeName[1160:1185]:      OpServerInventoryChangeFail,
+	_ServerOpcodeLowerName[1160:1185]: OpServerInventoryChangeFail,
+	_ServerOpcodeName[1185:1208]:      OpServerFactionReputation,
+	_ServerOpcodeLowerName[1185:1208]: OpServerFactionReputation,
+	_ServerOpcodeName[1208:1231]:      OpServerSetFactionVisible,
+	_ServerOpcodeLowerName[1208:1231]: OpServerSetFactionVisible,
+	_ServerOpcodeName[1231:1255]:      OpServerSetFactionStanding,
+	_ServerOpcodeLowerName[1231:1255]: OpServerSetFactionStanding,
+	_ServerOpcodeName[1255:1274]:      OpServerActionButtons,
+	_ServerOpcodeLowerName[1255:1274]: OpServerActionButtons,
+	_ServerOpcodeName[1274:1293]:      OpServerInitialSpells,
+	_ServerOpcodeLowerName[1274:1293]: OpServerInitialSpells,
+	_ServerOpcodeName[1293:1311]:      OpServerLearnedSpell,
+	_ServerOpcodeLowerName[1293:1311]: OpServerLearnedSpell,
+	_ServerOpcodeName[1311:1327]:      OpServerCastFailed,
+	_ServerOpcodeLowerName[1311:1327]: OpServerCastFailed,
+	_ServerOpcodeName[1327:1343]:      OpServerSpellStart,
+	_ServerOpcodeLowerName[1327:1343]: OpServerSpellStart,
+	_ServerOpcodeName[1343:1356]:      OpServerSpellGo,
+	_ServerOpcodeLowerName[1343:1356]: OpServerSpellGo,
+	_ServerOpcodeName[1356:1374]:      OpServerSpellFailure,
+	_ServerOpcodeLowerName[1356:1374]: OpServerSpellFailure,
+	_ServerOpcodeName[1374:1392]:      OpServerChannelStart,
+	_ServerOpcodeLowerName[1374:1392]: OpServerChannelStart,
+	_ServerOpcodeName[1392:1411]:      OpServerChannelUpdate,
+	_ServerOpcodeLowerName[1392:1411]: OpServerChannelUpdate,
+	_ServerOpcodeName[1411:1428]:      OpServerAttackStart,
+	_ServerOpcodeLowerName[1411:1428]: OpServerAttackStart,
+	_ServerOpcodeName[1428:1444]:      OpServerAttackStop,
+	_ServerOpcodeLowerName[1428:1444]: OpServerAttackStop,
+	_ServerOpcodeName[1444:1471]:      OpServerAttackSwingNotInRange,
+	_ServerOpcodeLowerName[1444:1471]: OpServerAttackSwingNotInRange,
+	_ServerOpcodeName[1471:1497]:      OpServerAttackSwingBadFacing,
+	_ServerOpcodeLowerName[1471:1497]: OpServerAttackSwingBadFacing,
+	_ServerOpcodeName[1497:1524]:      OpServerAttackSwingDeadTarget,
+	_ServerOpcodeLowerName[1497:1524]: OpServerAttackSwingDeadTarget,
+	_ServerOpcodeName[1524:1551]:      OpServerAttackSwingCantAttack,
+	_ServerOpcodeLowerName[1524:1551]: OpServerAttackSwingCantAttack,
+	_ServerOpcodeName[1551:1576]:      OpServerAttackerStateUpdate,
+	_ServerOpcodeLowerName[1551:1576]: OpServerAttackerStateUpdate,
+	_ServerOpcodeName[1576:1596]:      OpServerHearthLocation,
+	_ServerOpcodeLowerName[1576:1596]: OpServerHearthLocation,
+	_ServerOpcodeName[1596:1616]:      OpServerItemPushResult,
+	_ServerOpcodeLowerName[1596:1616]: OpServerItemPushResult,
+	_ServerOpcodeName[1616:1632]:      OpServerPlayedTime,
+	_ServerOpcodeLowerName[1616:1632]: OpServerPlayedTime,
+	_ServerOpcodeName[1632:1642]:      OpServerTime,
+	_ServerOpcodeLowerName[1632:1642]: OpServerTime,
+	_ServerOpcodeName[1642:1657]:      OpServerLogXPGain,
+	_ServerOpcodeLowerName[1642:1657]: OpServerLogXPGain,
+	_ServerOpcodeName[1657:1674]:      OpServerLevelUpInfo,
+	_ServerOpcodeLowerName[1657:1674]: OpServerLevelUpInfo,
+	_ServerOpcodeName[1674:1684]:      OpServerPong,
+	_ServerOpcodeLowerName[1674:1684]: OpServerPong,
+	_ServerOpcodeName[1684:1703]:      OpServerAuthChallenge,
+	_ServerOpcodeLowerName[1684:1703]: OpServerAuthChallenge,
+	_ServerOpcodeName[1703:1721]:      OpServerAuthResponse,
+	_ServerOpcodeLowerName[1703:1721]: OpServerAuthResponse,
+	_ServerOpcodeName[1721:1748]:      OpServerExplorationExperience,
+	_ServerOpcodeLowerName[1721:1748]: OpServerExplorationExperience,
+	_ServerOpcodeName[1748:1766]:      OpServerRemovedSpell,
+	_ServerOpcodeLowerName[1748:1766]: OpServerRemovedSpell,
+	_ServerOpcodeName[1766:1790]:      OpServerClientStorageTimes,
+	_ServerOpcodeLowerName[1766:1790]: OpServerClientStorageTimes,
+	_ServerOpcodeName[1790:1806]:      OpServerGetStorage,
+	_ServerOpcodeLowerName[1790:1806]: OpServerGetStorage,
+	_ServerOpcodeName[1806:1823]:      OpServerCorpseQuery,
+	_ServerOpcodeLowerName[1806:1823]: OpServerCorpseQuery,
+	_ServerOpcodeName[1823:1849]:      OpServerCharLoginVerifyWorld,
+	_ServerOpcodeLowerName[1823:1849]: OpServerCharLoginVerifyWorld,
+	_ServerOpcodeName[1849:1869]:      OpServerSendMailResult,
+	_ServerOpcodeLowerName[1849:1869]: OpServerSendMailResult,
+	_ServerOpcodeName[1869:1889]:      OpServerMailListResult,
+	_ServerOpcodeLowerName[1869:1889]: OpServerMailListResult,
+	_ServerOpcodeName[1889:1910]:      OpServerPeriodicAuraLog,
+	_ServerOpcodeLowerName[1889:1910]: OpServerPeriodicAuraLog,
+	_ServerOpcodeName[1910:1933]:      OpServerQueryNextMailTime,
+	_ServerOpcodeLowerName[1910:1933]: OpServerQueryNextMailTime,
+	_ServerOpcodeName[1933:1951]:      OpServerReceivedMail,
+	_ServerOpcodeLowerName[1933:1951]: OpServerReceivedMail,
+	_ServerOpcodeName[1951:1967]:      OpServerStandState,
+	_ServerOpcodeLowerName[1951:1967]: OpServerStandState,
+	_ServerOpcodeName[1967:1989]:      OpServerSpellFailedOther,
+	_ServerOpcodeLowerName[1967:1989]: OpServerSpellFailedOther,
+	_ServerOpcodeName[1989:2013]:      OpServerChatPlayerNotFound,
+	_ServerOpcodeLowerName[1989:2013]: OpServerChatPlayerNotFound,
+	_ServerOpcodeName[2013:2036]:      OpServerTalentWipeConfirm,
+	_ServerOpcodeLowerName[2013:2036]: OpServerTalentWipeConfirm,
+	_ServerOpcodeName[2036:2060]:      OpServerInitialWorldStates,
+	_ServerOpcodeLowerName[2036:2060]: OpServerInitialWorldStates,
+	_ServerOpcodeName[2060:2086]:      OpServerPartyMemberStatsFull,
+	_ServerOpcodeLowerName[2060:2086]: OpServerPartyMemberStatsFull,
+	_ServerOpcodeName[2086:2109]:      OpServerSplineSetRunSpeed,
+	_ServerOpcodeLowerName[2086:2109]: OpServerSplineSetRunSpeed,
+	_ServerOpcodeName[2109:2133]:      OpServerSplineSetSwimSpeed,
+	_ServerOpcodeLowerName[2109:2133]: OpServerSplineSetSwimSpeed,
+	_ServerOpcodeName[2133:2155]:      OpServerRaidTargetUpdate,
+	_ServerOpcodeLowerName[2133:2155]: OpServerRaidTargetUpdate,
+	_ServerOpcodeName[2155:2175]:      OpServerRaidReadyCheck,
+	_ServerOpcodeLowerName[2155:2175]: OpServerRaidReadyCheck,
+	_ServerOpcodeName[2175:2185]:      OpServerMOTD,
+	_ServerOpcodeLowerName[2175:2185]: OpServerMOTD,
+	_ServerOpcodeName[2185:2206]:      OpServerMoveStartAscend,
+	_ServerOpcodeLowerName[2185:2206]: OpServerMoveStartAscend,
+	_ServerOpcodeName[2206:2226]:      OpServerMoveStopAscend,
+	_ServerOpcodeLowerName[2206:2226]: OpServerMoveStopAscend,
+	_ServerOpcodeName[2226:2247]:      OpServerDeathReleaseLoc,
+	_ServerOpcodeLowerName[2226:2247]: OpServerDeathReleaseLoc,
+	_ServerOpcodeName[2247:2275]:      OpServerForceFlightSpeedChange,
+	_ServerOpcodeLowerName[2247:2275]: OpServerForceFlightSpeedChange,
+	_ServerOpcodeName[2275:2301]:      OpServerSplineSetFlightSpeed,
+	_ServerOpcodeLowerName[2275:2301]: OpServerSplineSetFlightSpeed,
+	_ServerOpcodeName[2301:2317]:      OpServerRealmSplit,
+	_ServerOpcodeLowerName[2301:2317]: OpServerRealmSplit,
+	_ServerOpcodeName[2317:2339]:      OpServerMoveStartDescend,
+	_ServerOpcodeLowerName[2317:2339]: OpServerMoveStartDescend,
+	_ServerOpcodeName[2339:2366]:      OpServerRaidReadyCheckConfirm,
+	_ServerOpcodeLowerName[2339:2366]: OpServerRaidReadyCheckConfirm,
+	_ServerOpcodeName[2366:2394]:      OpServerRaidReadyCheckFinished,
+	_ServerOpcodeLowerName[2366:2394]: OpServerRaidReadyCheckFinished,
+	_ServerOpcodeName[2394:2414]:      OpServerSystemFeatures,
+	_ServerOpcodeLowerName[2394:2414]: OpServerSystemFeatures,
+	_ServerOpcodeName[2414:2436]:      OpServerGuildPermissions,
+	_ServerOpcodeLowerName[2414:2436]: OpServerGuildPermissions,
+	_ServerOpcodeName[2436:2454]:      OpServerPutStorageOK,
+	_ServerOpcodeLowerName[2436:2454]: OpServerPutStorageOK,
+	_ServerOpcodeName[2454:2473]:      OpServerAuraUpdateAll,
+	_ServerOpcodeLowerName[2454:2473]: OpServerAuraUpdateAll,
+	_ServerOpcodeName[2473:2489]:      OpServerAuraUpdate,
+	_ServerOpcodeLowerName[2473:2489]: OpServerAuraUpdate,
+	_ServerOpcodeName[2489:2508]:      OpServerPlayerTalents,
+	_ServerOpcodeLowerName[2489:2508]: OpServerPlayerTalents,
+	_ServerOpcodeName[2508:2520]:      OpServerUITime,
+	_ServerOpcodeLowerName[2508:2520]: OpServerUITime,
 }
 
 var _ServerOpcodeNames = []string{
 	_ServerOpcodeName[0:16],
 	_ServerOpcodeName[16:30],
 	_ServerOpcodeName[30:46],
-	_ServerOpcodeName[46:67],
-	_ServerOpcodeName[67:85],
-	_ServerOpcodeName[85:97],
-	_ServerOpcodeName[97:117],
-	_ServerOpcodeName[117:138],
-	_ServerOpcodeName[138:165],
-	_ServerOpcodeName[165:183],
-	_ServerOpcodeName[183:202],
-	_ServerOpcodeName[202:221],
-	_ServerOpcodeName[221:244],
-	_ServerOpcodeName[244:263],
-	_ServerOpcodeName[263:282],
-	_ServerOpcodeName[282:302],
-	_ServerOpcodeName[302:318],
-	_ServerOpcodeName[318:328],
-	_ServerOpcodeName[328:338],
-	_ServerOpcodeName[338:357],
-	_ServerOpcodeName[357:375],
-	_ServerOpcodeName[375:399],
-	_ServerOpcodeName[399:415],
-	_ServerOpcodeName[415:441],
-	_ServerOpcodeName[441:457],
-	_ServerOpcodeName[457:481],
-	_ServerOpcodeName[481:491],
-	_ServerOpcodeName[491:507],
-	_ServerOpcodeName[507:527],
-	_ServerOpcodeName[527:545],
-	_ServerOpcodeName[545:564],
-	_ServerOpcodeName[564:576],
+	_ServerOpcodeName[46:60],
+	_ServerOpcodeName[60:81],
+	_ServerOpcodeName[81:102],
+	_ServerOpcodeName[102:120],
+	_ServerOpcodeName[120:132],
+	_ServerOpcodeName[132:152],
+	_ServerOpcodeName[152:173],
+	_ServerOpcodeName[173:200],
+	_ServerOpcodeName[200:224],
+	_ServerOpcodeName[224:247],
+	_ServerOpcodeName[247:256],
+	_ServerOpcodeName[256:273],
+	_ServerOpcodeName[273:291],
+	_ServerOpcodeName[291:308],
+	_ServerOpcodeName[308:326],
+	_ServerOpcodeName[326:345],
+	_ServerOpcodeName[345:365],
+	_ServerOpcodeName[365:385],
+	_ServerOpcodeName[385:400],
+	_ServerOpcodeName[400:422],
+	_ServerOpcodeName[422:446],
+	_ServerOpcodeName[446:463],
+	_ServerOpcodeName[463:481],
+	_ServerOpcodeName[481:496],
+	_ServerOpcodeName[496:513],
+	_ServerOpcodeName[513:529],
+	_ServerOpcodeName[529:553],
+	_ServerOpcodeName[553:570],
+	_ServerOpcodeName[570:589],
+	_ServerOpcodeName[589:606],
+	_ServerOpcodeName[606:624],
+	_ServerOpcodeName[624:646],
+	_ServerOpcodeName[646:669],
+	_ServerOpcodeName[669:683],
+	_ServerOpcodeName[683:708],
+	_ServerOpcodeName[708:734],
+	_ServerOpcodeName[734:754],
+	_ServerOpcodeName[754:768],
+	_ServerOpcodeName[768:791],
+	_ServerOpcodeName[791:815],
+	_ServerOpcodeName[815:833],
+	_ServerOpcodeName[833:855],
+	_ServerOpcodeName[855:879],
+	_ServerOpcodeName[879:898],
+	_ServerOpcodeName[898:918],
+	_ServerOpcodeName[918:939],
+	_ServerOpcodeName[939:960],
+	_ServerOpcodeName[960:978],
+	_ServerOpcodeName[978:997],
+	_ServerOpcodeName[997:1015],
+	_ServerOpcodeName[1015:1034],
+	_ServerOpcodeName[1034:1052],
+	_ServerOpcodeName[1052:1077],
+	_ServerOpcodeName[1077:1103],
+	_ServerOpcodeName[1103:1122],
+	_ServerOpcodeName[1122:1141],
+	_ServerOpcodeName[1141:1160],
+	_ServerOpcodeName[1160:1185],
+	_ServerOpcodeName[1185:1208],
+	_ServerOpcodeName[1208:1231],
+	_ServerOpcodeName[1231:1255],
+	_ServerOpcodeName[1255:1274],
+	_ServerOpcodeName[1274:1293],
+	_ServerOpcodeName[1293:1311],
+	_ServerOpcodeName[1311:1327],
+	_ServerOpcodeName[1327:1343],
+	_ServerOpcodeName[1343:1356],
+	_ServerOpcodeName[1356:1374],
+	_ServerOpcodeName[1374:1392],
+	_ServerOpcodeName[1392:1411],
+	_ServerOpcodeName[1411:1428],
+	_ServerOpcodeName[1428:1444],
+	_ServerOpcodeName[1444:1471],
+	_ServerOpcodeName[1471:1497],
+	_ServerOpcodeName[1497:1524],
+	_ServerOpcodeName[1524:1551],
+	_ServerOpcodeName[1551:1576],
+	_ServerOpcodeName[1576:1596],
+	_ServerOpcodeName[1596:1616],
+	_ServerOpcodeName[1616:1632],
+	_ServerOpcodeName[1632:1642],
+	_ServerOpcodeName[1642:1657],
+	_ServerOpcodeName[1657:1674],
+	_ServerOpcodeName[1674:1684],
+	_ServerOpcodeName[1684:1703],
+	_ServerOpcodeName[1703:1721],
+	_ServerOpcodeName[1721:1748],
+	_ServerOpcodeName[1748:1766],
+	_ServerOpcodeName[1766:1790],
+	_ServerOpcodeName[1790:1806],
+	_ServerOpcodeName[1806:1823],
+	_ServerOpcodeName[1823:1849],
+	_ServerOpcodeName[1849:1869],
+	_ServerOpcodeName[1869:1889],
+	_ServerOpcodeName[1889:1910],
+	_ServerOpcodeName[1910:1933],
+	_ServerOpcodeName[1933:1951],
+	_ServerOpcodeName[1951:1967],
+	_ServerOpcodeName[1967:1989],
+	_ServerOpcodeName[1989:2013],
+	_ServerOpcodeName[2013:2036],
+	_ServerOpcodeName[2036:2060],
+	_ServerOpcodeName[2060:2086],
+	_ServerOpcodeName[2086:2109],
+	_ServerOpcodeName[2109:2133],
+	_ServerOpcodeName[2133:2155],
+	_ServerOpcodeName[2155:2175],
+	_ServerOpcodeName[2175:2185],
+	_ServerOpcodeName[2185:2206],
+	_ServerOpcodeName[2206:2226],
+	_ServerOpcodeName[2226:2247],
+	_ServerOpcodeName[2247:2275],
+	_ServerOpcodeName[2275:2301],
+	_ServerOpcodeName[2301:2317],
+	_ServerOpcodeName[2317:2339],
+	_ServerOpcodeName[2339:2366],
+	_ServerOpcodeName[2366:2394],
+	_ServerOpcodeName[2394:2414],
+	_ServerOpcodeName[2414:2436],
+	_ServerOpcodeName[2436:2454],
+	_ServerOpcodeName[2454:2473],
+	_ServerOpcodeName[2473:2489],
+	_ServerOpcodeName[2489:2508],
+	_ServerOpcodeName[2508:2520],
 }
 
 // ServerOpcodeString retrieves an enum value from the enum constants string name.
@@ -1,11 +1,38 @@
 package realmd
 
+import "io"
+
 // TODO: guids should be stored as (2) uint32 values, OR add an explicit Marshal method that encodes
 // them as (2) values. A little-endian uint64 is different from (2) little-endian uint32s.
 type Guid uint64
 type PackedGuid []byte
 
-// internal.PackGuid returns a packed *little-endian* representation of an 8-byte integer. The packing works
+// HighGuid is stored in the upper 16 bits of a guid and says what type of object the guid is for.
+// Players use HighGuidPlayer, so a player's guid is the same as their character id.
+type HighGuid uint16
+
+const (
+	HighGuidPlayer HighGuid = 0x0000
+	HighGuidItem   HighGuid = 0x4000
+	HighGuidCorpse HighGuid = 0xF101
+)
+
+// NewGuid returns a guid for an object of type high with the id low.
+func NewGuid(high HighGuid, low uint32) Guid {
+	return Guid(uint64(high)<<48 | uint64(low))
+}
+
+// High returns the type of object the guid is for.
+func (g Guid) High() HighGuid {
+	return HighGuid(g >> 48)
+}
+
+// Low returns the object's id.
+func (g Guid) Low() uint32 {
+	return uint32(g)
+}
+
+// PackGuid returns a packed *little-endian* representation of an 8-byte integer. The packing works
 // by creating a bit mask to mark which bytes are non-zero. Any bytes which are zero are discarded.
 // The result is a byte array with the first byte as the bitmask, followed by the remaining
 // undiscarded bytes. The bytes after the bitmask are little-endian.
@@ -20,7 +47,7 @@ func PackGuid(val uint64) PackedGuid {
 			result[0] |= 1 << i
 			// Add the byte to the result. The loop traverses the bytes from right-to-left but they
 			// are written to the result from left-to-right, which swaps it to little-endian.
-			result[1] = byte(val)
+			result[n+1] = byte(val)
 			n++
 		}
 		// Move to the next byte
@@ -29,3 +56,28 @@ func PackGuid(val uint64) PackedGuid {
 
 	return result[:n+1]
 }
+
+// ReadPackedGuid reads a packed guid from r and returns the unpacked value.
+func ReadPackedGuid(r io.ByteReader) (Guid, error) {
+	mask, err := r.ReadByte()
+	if err != nil {
+		return 0, err
+	}
+
+	var val uint64
+
+	for i := 0; i < 8; i++ {
+		if mask&(1<<i) == 0 {
+			continue
+		}
+
+		b, err := r.ReadByte()
+		if err != nil {
+			return 0, err
+		}
+
+		val |= uint64(b) << (i * 8)
+	}
+
+	return Guid(val), nil
+}
@@ -0,0 +1,38 @@
+package realmd
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPackGuid(t *testing.T) {
+	assert.Equal(t, PackedGuid{0x0}, PackGuid(0))
+	assert.Equal(t, PackedGuid{0x1, 0x12}, PackGuid(0x12))
+	assert.Equal(t, PackedGuid{0x5, 0x12, 0x34}, PackGuid(0x340012))
+	assert.Equal(t, PackedGuid{0xC1, 0x01, 0x10, 0xF1}, PackGuid(0xF110000000000001))
+
+	// Every non-zero byte gets its own position after the mask
+	assert.Equal(t, PackedGuid{0xFF, 1, 2, 3, 4, 5, 6, 7, 8}, PackGuid(0x0807060504030201))
+}
+
+func TestReadPackedGuid(t *testing.T) {
+	for _, val := range []uint64{0, 0x12, 0x340012, 0xF110000000000001} {
+		guid, err := ReadPackedGuid(bytes.NewReader(PackGuid(val)))
+		assert.NoError(t, err)
+		assert.Equal(t, Guid(val), guid)
+	}
+
+	_, err := ReadPackedGuid(bytes.NewReader([]byte{0x3, 0x1}))
+	assert.Error(t, err)
+}
+
+func TestNewGuid(t *testing.T) {
+	guid := NewGuid(HighGuidItem, 0x1234)
+	assert.Equal(t, Guid(0x4000000000001234), guid)
+	assert.Equal(t, HighGuidItem, guid.High())
+	assert.Equal(t, uint32(0x1234), guid.Low())
+
+	assert.Equal(t, Guid(5), NewGuid(HighGuidPlayer, 5))
+}
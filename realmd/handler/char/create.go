@@ -3,6 +3,8 @@ package char
 import (
 	"github.com/kangaroux/gomaggus/model"
 	"github.com/kangaroux/gomaggus/realmd"
+	"github.com/kangaroux/gomaggus/realmd/item"
+	"github.com/kangaroux/gomaggus/realmd/player"
 	"github.com/mixcode/binarystruct"
 )
 
@@ -50,6 +52,7 @@ func CreateHandler(svc *realmd.Service, client *realmd.Client, data []byte) erro
 	if existing != nil {
 		resp.ResponseCode = realmd.RespCodeCharCreateNameInUse
 	} else {
+		start := player.StartLocation(req.Race, req.Class)
 		char := &model.Character{
 			Name:          req.Name,
 			AccountId:     client.Account.Id,
@@ -57,14 +60,34 @@ func CreateHandler(svc *realmd.Service, client *realmd.Client, data []byte) erro
 			Race:          req.Race,
 			Class:         req.Class,
 			Gender:        req.Gender,
+			Level:         uint8(player.StartLevel(req.Class)),
 			SkinColor:     req.SkinColor,
 			Face:          req.Face,
 			HairStyle:     req.HairStyle,
 			HairColor:     req.HairColor,
 			ExtraCosmetic: req.ExtraCosmetic,
 			OutfitId:      req.OutfitId,
+			Map:           start.Map,
+			Zone:          start.Zone,
+			X:             start.Position.X,
+			Y:             start.Position.Y,
+			Z:             start.Position.Z,
+			Orientation:   start.Position.Rotation,
+			HomeMap:       start.Map,
+			HomeZone:      start.Zone,
+			HomeX:         start.Position.X,
+			HomeY:         start.Position.Y,
+			HomeZ:         start.Position.Z,
 		}
-		if err := svc.Characters.Create(char); err != nil {
+		items, err := item.StartOutfit(svc, client, char)
+		if err != nil {
+			return err
+		}
+		var actions []*model.ActionButton
+		for _, a := range player.DefaultActions(char.Class) {
+			actions = append(actions, &model.ActionButton{Button: a.Button, Action: a.Spell, Type: model.ActionTypeSpell})
+		}
+		if err := svc.Characters.CreateNew(char, items, actions); err != nil {
 			return err
 		}
 
@@ -28,8 +28,6 @@ func DeleteHandler(svc *realmd.Service, client *realmd.Client, data []byte) erro
 		return err
 	}
 
-	deleted := false
-
 	if char == nil {
 		client.Log.Warn().Uint64("char", req.CharacterId).Msg("tried to delete non-existent character")
 		return &realmd.ErrKickClient{Reason: "invalid char delete"}
@@ -41,18 +39,25 @@ func DeleteHandler(svc *realmd.Service, client *realmd.Client, data []byte) erro
 	} else if char.RealmId != client.Realm.Id {
 		client.Log.Warn().Str("char", char.String()).Msg("tried to delete character on another realm")
 		return &realmd.ErrKickClient{Reason: "invalid char delete"}
+	}
 
-	} else {
-		deleted, err = svc.Characters.Delete(char.Id)
+	resp := deleteResponse{}
 
-		if err != nil {
-			return err
-		}
+	// The guild master has to pass on leadership or disband the guild first
+	member, err := svc.Guilds.GetMember(char.Id)
+	if err != nil {
+		return err
+	} else if member != nil && member.Rank == 0 {
+		resp.ResponseCode = realmd.RespCodeCharDeleteFailedGuildLeader
+		return client.SendPacket(realmd.OpServerCharCreate, &resp)
+	}
 
-		client.Log.Info().Str("char", char.String()).Msg("character deleted")
+	deleted, err := svc.Characters.Delete(char.Id)
+	if err != nil {
+		return err
 	}
 
-	resp := deleteResponse{}
+	client.Log.Info().Str("char", char.String()).Msg("character deleted")
 
 	if deleted {
 		resp.ResponseCode = realmd.RespCodeCharDeleteSuccess
@@ -3,6 +3,7 @@ package char
 import (
 	"github.com/kangaroux/gomaggus/model"
 	"github.com/kangaroux/gomaggus/realmd"
+	"github.com/kangaroux/gomaggus/realmd/world"
 )
 
 // https://gtker.com/wow_messages/docs/charactergear.html
@@ -55,13 +56,33 @@ func ListHandler(svc *realmd.Service, client *realmd.Client) error {
 		return err
 	}
 
+	charIds := make([]uint32, len(accountChars))
+	for i, accountChar := range accountChars {
+		charIds[i] = accountChar.Id
+	}
+
+	members, err := svc.Guilds.ListCharacterMembers(charIds)
+	if err != nil {
+		return err
+	}
+
+	guildIds := make(map[uint32]uint32, len(members))
+	for _, member := range members {
+		guildIds[member.CharacterId] = member.GuildId
+	}
+
+	gear, err := listGear(svc, charIds)
+	if err != nil {
+		return err
+	}
+
 	resp := listResponse{
 		Count:      uint8(len(accountChars)),
 		Characters: make([]character, len(accountChars)),
 	}
 
 	for i, accountChar := range accountChars {
-		char := character{
+		resp.Characters[i] = character{
 			Guid:                 realmd.Guid(accountChar.Id),
 			Name:                 accountChar.Name,
 			Race:                 accountChar.Race,
@@ -72,29 +93,67 @@ func ListHandler(svc *realmd.Service, client *realmd.Client) error {
 			HairStyle:            accountChar.HairStyle,
 			HairColor:            accountChar.HairStyle,
 			ExtraCosmetic:        accountChar.ExtraCosmetic,
-			Level:                1,
-			Area:                 0xC,              // Elwynn forest
-			Map:                  0x0,              // Eastern kingdoms
-			Position:             realmd.Vector3{}, // Position doesn't matter for char list
-			GuildId:              0,
+			Level:                accountChar.Level,
+			Area:                 accountChar.Zone,
+			Map:                  accountChar.Map,
+			Position:             realmd.Vector3{X: accountChar.X, Y: accountChar.Y, Z: accountChar.Z},
+			GuildId:              guildIds[accountChar.Id],
 			Flags:                0, // ??
 			RecustomizationFlags: 0, // ??
 			FirstLogin:           !accountChar.LastLogin.Valid,
 			PetDisplayId:         0,
 			PetLevel:             0,
 			PetFamily:            0,
+			GearDisplay:          gear[accountChar.Id],
 		}
+	}
 
-		for j := 0; j < 23; j++ {
-			char.GearDisplay[j] = gearDisplay{
-				DisplayId:   0,
-				Slot:        uint8(j) + 1,
-				Enchantment: 0,
-			}
+	return client.SendPacket(realmd.OpServerCharList, &resp)
+}
+
+// listGear returns the display of each character's equipment and bags, by character id.
+func listGear(svc *realmd.Service, charIds []uint32) (map[uint32][23]gearDisplay, error) {
+	items, err := svc.Items.ListOwners(charIds)
+	if err != nil {
+		return nil, err
+	}
+
+	var equipped []*model.Item
+	var ids []uint32
+	for _, item := range items {
+		if item.ContainerId == 0 && item.Slot < world.BagSlotEnd {
+			equipped = append(equipped, item)
+			ids = append(ids, item.TemplateId)
 		}
+	}
 
-		resp.Characters[i] = char
+	templates, err := svc.Items.ListTemplates(ids)
+	if err != nil {
+		return nil, err
 	}
 
-	return client.SendPacket(realmd.OpServerCharList, &resp)
+	byId := make(map[uint32]*model.ItemTemplate, len(templates))
+	for _, t := range templates {
+		byId[t.Id] = t
+	}
+
+	result := make(map[uint32][23]gearDisplay, len(charIds))
+	for _, item := range equipped {
+		t, ok := byId[item.TemplateId]
+		if !ok {
+			continue
+		}
+
+		gear := result[item.OwnerId]
+		gear[item.Slot] = gearDisplay{
+			DisplayId: t.DisplayId,
+			Slot:      uint8(t.InventoryType),
+		}
+		if enchant, ok := svc.DBC.ItemEnchantments[item.Enchant]; ok {
+			gear[item.Slot].Enchantment = enchant.ItemVisual
+		}
+		result[item.OwnerId] = gear
+	}
+
+	return result, nil
 }
@@ -0,0 +1,180 @@
+package chat
+
+import (
+	"github.com/kangaroux/gomaggus/model"
+	"github.com/kangaroux/gomaggus/realmd"
+	"github.com/kangaroux/gomaggus/realmd/world"
+	"github.com/mixcode/binarystruct"
+)
+
+type joinChannelRequest struct {
+	// ChannelID is set when the client is joining a zone channel.
+	ChannelID    uint32
+	HasVoice     bool
+	JoinedByZone bool
+	Name         string `binary:"zstring"`
+	Password     string `binary:"zstring"`
+}
+
+// JoinChannelHandler handles the player joining a custom channel. The channel is saved so the player
+// rejoins it on their next login.
+// https://gtker.com/wow_messages/docs/cmsg_join_channel.html#client-version-335
+func JoinChannelHandler(svc *realmd.Service, w *world.World, client *realmd.Client, data []byte) error {
+	p := w.PlayerFor(client)
+	if p == nil {
+		return nil
+	}
+
+	req := joinChannelRequest{}
+	if _, err := binarystruct.Unmarshal(data, binarystruct.LittleEndian, &req); err != nil {
+		return err
+	}
+
+	// Zone channels are joined by the server when the player changes zones
+	if req.ChannelID != 0 {
+		return nil
+	}
+
+	c := w.JoinChannel(p, req.Name, req.Password)
+	if c == nil {
+		return nil
+	}
+
+	return svc.CharacterChannels.Add(&model.CharacterChannel{
+		CharacterId: p.Character.Id,
+		Name:        c.Name,
+		Password:    req.Password,
+	})
+}
+
+type leaveChannelRequest struct {
+	ChannelID uint32
+	Name      string `binary:"zstring"`
+}
+
+// https://gtker.com/wow_messages/docs/cmsg_leave_channel.html#client-version-335
+func LeaveChannelHandler(svc *realmd.Service, w *world.World, client *realmd.Client, data []byte) error {
+	p := w.PlayerFor(client)
+	if p == nil {
+		return nil
+	}
+
+	req := leaveChannelRequest{}
+	if _, err := binarystruct.Unmarshal(data, binarystruct.LittleEndian, &req); err != nil {
+		return err
+	}
+
+	c := w.LeaveChannel(p, req.Name)
+	if c == nil || !c.Custom() {
+		return nil
+	}
+
+	_, err := svc.CharacterChannels.Remove(p.Character.Id, c.Name)
+	return err
+}
+
+type channelRequest struct {
+	Name string `binary:"zstring"`
+}
+
+// ChannelHandler handles the channel commands that only need the channel's name.
+func ChannelHandler(w *world.World, client *realmd.Client, opcode realmd.ClientOpcode, data []byte) error {
+	p := w.PlayerFor(client)
+	if p == nil {
+		return nil
+	}
+
+	req := channelRequest{}
+	if _, err := binarystruct.Unmarshal(data, binarystruct.LittleEndian, &req); err != nil {
+		return err
+	}
+
+	switch opcode {
+	case realmd.OpClientChannelList, realmd.OpClientChannelDisplayList:
+		return w.SendChannelList(p, req.Name)
+	case realmd.OpClientChannelOwner:
+		w.ChannelOwner(p, req.Name)
+	case realmd.OpClientChannelAnnouncements:
+		w.ToggleChannelAnnouncements(p, req.Name)
+	case realmd.OpClientChannelModerate:
+		w.ToggleChannelModeration(p, req.Name)
+	}
+
+	return nil
+}
+
+type channelTargetRequest struct {
+	Name string `binary:"zstring"`
+
+	// Target is the name of the player the command is for. When changing the password, this is the new
+	// password instead.
+	Target string `binary:"zstring"`
+}
+
+// ChannelTargetHandler handles the channel commands that are used on another player.
+func ChannelTargetHandler(svc *realmd.Service, w *world.World, client *realmd.Client, opcode realmd.ClientOpcode, data []byte) error {
+	p := w.PlayerFor(client)
+	if p == nil {
+		return nil
+	}
+
+	req := channelTargetRequest{}
+	if _, err := binarystruct.Unmarshal(data, binarystruct.LittleEndian, &req); err != nil {
+		return err
+	}
+
+	var removed *world.Player
+
+	switch opcode {
+	case realmd.OpClientChannelPassword:
+		w.SetChannelPassword(p, req.Name, req.Target)
+	case realmd.OpClientChannelSetOwner:
+		w.SetChannelOwner(p, req.Name, req.Target)
+	case realmd.OpClientChannelModerator:
+		w.SetChannelModerator(p, req.Name, req.Target, true)
+	case realmd.OpClientChannelUnmoderator:
+		w.SetChannelModerator(p, req.Name, req.Target, false)
+	case realmd.OpClientChannelMute:
+		w.SetChannelMute(p, req.Name, req.Target, true)
+	case realmd.OpClientChannelUnmute:
+		w.SetChannelMute(p, req.Name, req.Target, false)
+	case realmd.OpClientChannelInvite:
+		w.InviteToChannel(p, req.Name, req.Target)
+	case realmd.OpClientChannelKick:
+		removed = w.KickFromChannel(p, req.Name, req.Target)
+	case realmd.OpClientChannelBan:
+		removed = w.BanFromChannel(p, req.Name, req.Target)
+	case realmd.OpClientChannelUnban:
+		w.UnbanFromChannel(p, req.Name, req.Target)
+	}
+
+	if removed == nil {
+		return nil
+	}
+
+	_, err := svc.CharacterChannels.Remove(removed.Character.Id, req.Name)
+	return err
+}
+
+// RejoinChannels puts the player in their zone's channels and any custom channels they were in when they
+// last logged out. Custom channels the player can no longer join are forgotten.
+func RejoinChannels(svc *realmd.Service, w *world.World, p *world.Player) error {
+	w.UpdateZoneChannels(p, svc.DBC.Areas[p.Zone])
+
+	channels, err := svc.CharacterChannels.List(p.Character.Id)
+	if err != nil {
+		return err
+	}
+
+	for _, c := range channels {
+		if w.JoinChannel(p, c.Name, c.Password) != nil {
+			continue
+		}
+
+		if _, err := svc.CharacterChannels.Remove(p.Character.Id, c.Name); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,132 @@
+package chat
+
+import (
+	"bytes"
+	"encoding/binary"
+
+	"github.com/kangaroux/gomaggus/internal"
+	"github.com/kangaroux/gomaggus/realmd"
+	"github.com/kangaroux/gomaggus/realmd/chat"
+	"github.com/kangaroux/gomaggus/realmd/command"
+	"github.com/kangaroux/gomaggus/realmd/world"
+)
+
+const (
+	// maxMessageLength is the longest message the client allows to be typed.
+	maxMessageLength = 255
+)
+
+type messageHeader struct {
+	Type     uint32
+	Language chat.Language
+}
+
+// MessageHandler handles chat messages sent by the player.
+// https://gtker.com/wow_messages/docs/cmsg_messagechat.html#client-version-335
+func MessageHandler(svc *realmd.Service, w *world.World, client *realmd.Client, data []byte) error {
+	p := w.PlayerFor(client)
+	if p == nil {
+		return nil
+	}
+
+	r := bytes.NewReader(data)
+	header := messageHeader{}
+	if err := binary.Read(r, binary.LittleEndian, &header); err != nil {
+		return err
+	}
+
+	msgType := chat.MessageType(header.Type)
+
+	// Whispers are sent to a player and channel messages are sent to a channel
+	var target string
+	if msgType == chat.MessageTypeWhisper || msgType == chat.MessageTypeChannel {
+		name, err := internal.ReadCString(r)
+		if err != nil {
+			return err
+		}
+		target = name
+	}
+
+	text, err := internal.ReadCString(r)
+	if err != nil {
+		return err
+	}
+
+	if len(text) > maxMessageLength {
+		client.Log.Warn().Int("len", len(text)).Msg("chat message too long")
+		return nil
+	}
+
+	// Commands can be typed anywhere the player can chat
+	if msgType != chat.MessageTypeAFK && msgType != chat.MessageTypeDND {
+		ctx := &command.Context{Service: svc, World: w, Player: p}
+		if handled, err := command.Run(ctx, text); handled {
+			return err
+		}
+	}
+
+	if !p.KnowsLanguage(header.Language) {
+		client.Log.Warn().
+			Str("char", p.Character.String()).
+			Uint32("lang", uint32(header.Language)).
+			Msg("tried to speak unknown language")
+		return nil
+	}
+
+	switch msgType {
+	case chat.MessageTypeSay, chat.MessageTypeYell, chat.MessageTypeEmote:
+		// Addon messages are never spoken out loud
+		if header.Language == chat.LanguageAddon || text == "" {
+			return nil
+		}
+
+		w.LocalChat(p, msgType, header.Language, text)
+
+	case chat.MessageTypeWhisper:
+		if text == "" {
+			return nil
+		}
+
+		other := w.PlayerByName(target)
+		if other == nil {
+			return sendPlayerNotFound(client, target)
+		}
+
+		return w.Whisper(p, other, header.Language, text)
+
+	case chat.MessageTypeChannel:
+		if text == "" {
+			return nil
+		}
+
+		w.ChannelChat(p, target, header.Language, text)
+
+	case chat.MessageTypeGuild, chat.MessageTypeOfficer:
+		if text == "" {
+			return nil
+		}
+
+		w.GuildChat(p, msgType == chat.MessageTypeOfficer, header.Language, text)
+
+	case chat.MessageTypeAFK:
+		p.ToggleAFK(text)
+
+	case chat.MessageTypeDND:
+		p.ToggleDND(text)
+
+	default:
+		client.Log.Debug().Uint32("type", header.Type).Msg("unhandled chat message type")
+	}
+
+	return nil
+}
+
+type playerNotFoundResponse struct {
+	Name string `binary:"zstring"`
+}
+
+// https://gtker.com/wow_messages/docs/smsg_chat_player_not_found.html
+func sendPlayerNotFound(client *realmd.Client, name string) error {
+	resp := playerNotFoundResponse{Name: name}
+	return client.SendPacket(realmd.OpServerChatPlayerNotFound, &resp)
+}
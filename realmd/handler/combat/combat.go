@@ -0,0 +1,38 @@
+package combat
+
+import (
+	"github.com/kangaroux/gomaggus/realmd"
+	"github.com/kangaroux/gomaggus/realmd/world"
+	"github.com/mixcode/binarystruct"
+)
+
+type attackSwingRequest struct {
+	Guid realmd.Guid
+}
+
+// https://gtker.com/wow_messages/docs/cmsg_attackswing.html
+func AttackSwingHandler(w *world.World, client *realmd.Client, data []byte) error {
+	p := w.PlayerFor(client)
+	if p == nil {
+		return nil
+	}
+
+	req := attackSwingRequest{}
+	if _, err := binarystruct.Unmarshal(data, binarystruct.LittleEndian, &req); err != nil {
+		return err
+	}
+
+	w.StartAttack(p, req.Guid)
+	return nil
+}
+
+// https://gtker.com/wow_messages/docs/cmsg_attackstop.html
+func AttackStopHandler(w *world.World, client *realmd.Client) error {
+	p := w.PlayerFor(client)
+	if p == nil {
+		return nil
+	}
+
+	w.StopAttack(p)
+	return nil
+}
@@ -0,0 +1,101 @@
+package combat
+
+import (
+	"bytes"
+	"encoding/binary"
+
+	"github.com/kangaroux/gomaggus/realmd"
+	"github.com/kangaroux/gomaggus/realmd/world"
+	"github.com/mixcode/binarystruct"
+)
+
+// RepopRequestHandler releases a dead player's spirit and sends them to the nearest graveyard.
+// https://gtker.com/wow_messages/docs/cmsg_repop_request.html
+func RepopRequestHandler(svc *realmd.Service, w *world.World, client *realmd.Client) error {
+	p := w.PlayerFor(client)
+	if p == nil {
+		return nil
+	}
+
+	pos := p.Position()
+	graveyard := svc.DBC.NearestGraveyard(p.MapID, pos.X, pos.Y)
+
+	return w.ReleaseSpirit(p, graveyard, svc.DBC.Spells[world.SpellGhost])
+}
+
+type reclaimCorpseRequest struct {
+	Corpse realmd.Guid
+}
+
+// https://gtker.com/wow_messages/docs/cmsg_reclaim_corpse.html
+func ReclaimCorpseHandler(w *world.World, client *realmd.Client, data []byte) error {
+	p := w.PlayerFor(client)
+	if p == nil {
+		return nil
+	}
+
+	req := reclaimCorpseRequest{}
+	if _, err := binarystruct.Unmarshal(data, binarystruct.LittleEndian, &req); err != nil {
+		return err
+	}
+
+	if c := p.Corpse(); c == nil || c.GUID() != req.Corpse {
+		return nil
+	}
+
+	w.ReclaimCorpse(p)
+	return nil
+}
+
+type spiritHealerActivateRequest struct {
+	Guid realmd.Guid
+}
+
+// SpiritHealerActivateHandler brings a ghost back to life at a spirit healer. There aren't any creatures in
+// the world yet, so the spirit healer the client talked to can't be checked.
+// https://gtker.com/wow_messages/docs/cmsg_spirit_healer_activate.html
+func SpiritHealerActivateHandler(svc *realmd.Service, w *world.World, client *realmd.Client, data []byte) error {
+	p := w.PlayerFor(client)
+	if p == nil {
+		return nil
+	}
+
+	req := spiritHealerActivateRequest{}
+	if _, err := binarystruct.Unmarshal(data, binarystruct.LittleEndian, &req); err != nil {
+		return err
+	}
+
+	sickness, ok := svc.DBC.Spells[world.SpellResurrectionSickness]
+	if !ok {
+		w.SpiritHealerResurrect(p, nil, 0)
+		return nil
+	}
+
+	w.SpiritHealerResurrect(p, sickness, svc.DBC.Duration(sickness, p.Values.Level()))
+	return nil
+}
+
+// CorpseQueryHandler tells the player where their corpse is, so it can be shown on their map.
+// https://gtker.com/wow_messages/docs/msg_corpse_query_server.html
+func CorpseQueryHandler(w *world.World, client *realmd.Client) error {
+	p := w.PlayerFor(client)
+	if p == nil {
+		return nil
+	}
+
+	c := p.Corpse()
+	if c == nil {
+		return client.SendPacketBytes(realmd.OpServerCorpseQuery, []byte{0})
+	}
+
+	pos := c.Position()
+
+	buf := bytes.Buffer{}
+	buf.WriteByte(1) // Found
+	binary.Write(&buf, binary.LittleEndian, c.MapID)
+	binary.Write(&buf, binary.LittleEndian, [3]float32{pos.X, pos.Y, pos.Z})
+	binary.Write(&buf, binary.LittleEndian, c.MapID)
+	binary.Write(&buf, binary.LittleEndian, uint32(0)) // Unknown
+
+	return client.SendPacketBytes(realmd.OpServerCorpseQuery, buf.Bytes())
+}
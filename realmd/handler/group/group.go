@@ -0,0 +1,174 @@
+package group
+
+import (
+	"github.com/kangaroux/gomaggus/realmd"
+	"github.com/kangaroux/gomaggus/realmd/world"
+	"github.com/mixcode/binarystruct"
+)
+
+type inviteRequest struct {
+	Name    string `binary:"zstring"`
+	Unknown uint32
+}
+
+// https://gtker.com/wow_messages/docs/cmsg_group_invite.html#client-version-335
+func InviteHandler(w *world.World, client *realmd.Client, data []byte) error {
+	p := w.PlayerFor(client)
+	if p == nil {
+		return nil
+	}
+
+	req := inviteRequest{}
+	if _, err := binarystruct.Unmarshal(data, binarystruct.LittleEndian, &req); err != nil {
+		return err
+	}
+
+	w.InviteToGroup(p, req.Name)
+
+	return nil
+}
+
+// InviteResponseHandler handles the player accepting or declining a group invite.
+// https://gtker.com/wow_messages/docs/cmsg_group_accept.html#client-version-335
+// https://gtker.com/wow_messages/docs/cmsg_group_decline.html
+func InviteResponseHandler(w *world.World, client *realmd.Client, opcode realmd.ClientOpcode) error {
+	p := w.PlayerFor(client)
+	if p == nil {
+		return nil
+	}
+
+	switch opcode {
+	case realmd.OpClientGroupAccept:
+		w.AcceptGroupInvite(p)
+	case realmd.OpClientGroupDecline:
+		w.DeclineGroupInvite(p)
+	}
+
+	return nil
+}
+
+type uninviteRequest struct {
+	Name string `binary:"zstring"`
+}
+
+// https://gtker.com/wow_messages/docs/cmsg_group_uninvite.html
+func UninviteHandler(w *world.World, client *realmd.Client, data []byte) error {
+	p := w.PlayerFor(client)
+	if p == nil {
+		return nil
+	}
+
+	req := uninviteRequest{}
+	if _, err := binarystruct.Unmarshal(data, binarystruct.LittleEndian, &req); err != nil {
+		return err
+	}
+
+	w.UninviteFromGroup(p, req.Name)
+
+	return nil
+}
+
+type uninviteGuidRequest struct {
+	Guid   realmd.Guid
+	Reason string `binary:"zstring"`
+}
+
+// https://gtker.com/wow_messages/docs/cmsg_group_uninvite_guid.html#client-version-335
+func UninviteGuidHandler(w *world.World, client *realmd.Client, data []byte) error {
+	p := w.PlayerFor(client)
+	if p == nil {
+		return nil
+	}
+
+	req := uninviteGuidRequest{}
+	if _, err := binarystruct.Unmarshal(data, binarystruct.LittleEndian, &req); err != nil {
+		return err
+	}
+
+	w.UninviteFromGroupGuid(p, req.Guid)
+
+	return nil
+}
+
+// LeaveHandler removes the player from their group. The client sends CMSG_GROUP_DISBAND when the player
+// leaves the group, even if they aren't the leader.
+// https://gtker.com/wow_messages/docs/cmsg_group_disband.html
+func LeaveHandler(w *world.World, client *realmd.Client) error {
+	p := w.PlayerFor(client)
+	if p == nil {
+		return nil
+	}
+
+	w.LeaveGroup(p)
+
+	return nil
+}
+
+type setLeaderRequest struct {
+	Guid realmd.Guid
+}
+
+// https://gtker.com/wow_messages/docs/cmsg_group_set_leader.html
+func SetLeaderHandler(w *world.World, client *realmd.Client, data []byte) error {
+	p := w.PlayerFor(client)
+	if p == nil {
+		return nil
+	}
+
+	req := setLeaderRequest{}
+	if _, err := binarystruct.Unmarshal(data, binarystruct.LittleEndian, &req); err != nil {
+		return err
+	}
+
+	w.SetGroupLeader(p, req.Guid)
+
+	return nil
+}
+
+type lootMethodRequest struct {
+	Method    uint32
+	Master    realmd.Guid
+	Threshold uint32
+}
+
+// https://gtker.com/wow_messages/docs/cmsg_loot_method.html
+func LootMethodHandler(w *world.World, client *realmd.Client, data []byte) error {
+	p := w.PlayerFor(client)
+	if p == nil {
+		return nil
+	}
+
+	req := lootMethodRequest{}
+	if _, err := binarystruct.Unmarshal(data, binarystruct.LittleEndian, &req); err != nil {
+		return err
+	}
+
+	if req.Method > 0xFF || req.Threshold > 0xFF {
+		return nil
+	}
+
+	w.SetLootMethod(p, world.LootMethod(req.Method), req.Master, uint8(req.Threshold))
+
+	return nil
+}
+
+type partyMemberStatsRequest struct {
+	Guid realmd.Guid
+}
+
+// https://gtker.com/wow_messages/docs/cmsg_request_party_member_stats.html
+func PartyMemberStatsHandler(w *world.World, client *realmd.Client, data []byte) error {
+	p := w.PlayerFor(client)
+	if p == nil {
+		return nil
+	}
+
+	req := partyMemberStatsRequest{}
+	if _, err := binarystruct.Unmarshal(data, binarystruct.LittleEndian, &req); err != nil {
+		return err
+	}
+
+	w.SendPartyMemberStats(p, req.Guid)
+
+	return nil
+}
@@ -0,0 +1,144 @@
+package group
+
+import (
+	"github.com/kangaroux/gomaggus/realmd"
+	"github.com/kangaroux/gomaggus/realmd/world"
+	"github.com/mixcode/binarystruct"
+)
+
+// https://gtker.com/wow_messages/docs/cmsg_group_raid_convert.html
+func RaidConvertHandler(w *world.World, client *realmd.Client) error {
+	p := w.PlayerFor(client)
+	if p == nil {
+		return nil
+	}
+
+	w.ConvertToRaid(p)
+
+	return nil
+}
+
+type changeSubgroupRequest struct {
+	Name     string `binary:"zstring"`
+	Subgroup uint8
+}
+
+// https://gtker.com/wow_messages/docs/cmsg_group_change_sub_group.html
+func ChangeSubgroupHandler(w *world.World, client *realmd.Client, data []byte) error {
+	p := w.PlayerFor(client)
+	if p == nil {
+		return nil
+	}
+
+	req := changeSubgroupRequest{}
+	if _, err := binarystruct.Unmarshal(data, binarystruct.LittleEndian, &req); err != nil {
+		return err
+	}
+
+	w.ChangeSubgroup(p, req.Name, req.Subgroup)
+
+	return nil
+}
+
+type swapSubgroupRequest struct {
+	First  string `binary:"zstring"`
+	Second string `binary:"zstring"`
+}
+
+// https://gtker.com/wow_messages/docs/cmsg_group_swap_sub_group.html
+func SwapSubgroupHandler(w *world.World, client *realmd.Client, data []byte) error {
+	p := w.PlayerFor(client)
+	if p == nil {
+		return nil
+	}
+
+	req := swapSubgroupRequest{}
+	if _, err := binarystruct.Unmarshal(data, binarystruct.LittleEndian, &req); err != nil {
+		return err
+	}
+
+	w.SwapSubgroups(p, req.First, req.Second)
+
+	return nil
+}
+
+type assistantRequest struct {
+	Guid      realmd.Guid
+	Assistant bool
+}
+
+// https://gtker.com/wow_messages/docs/cmsg_group_assistant_leader.html
+func AssistantHandler(w *world.World, client *realmd.Client, data []byte) error {
+	p := w.PlayerFor(client)
+	if p == nil {
+		return nil
+	}
+
+	req := assistantRequest{}
+	if _, err := binarystruct.Unmarshal(data, binarystruct.LittleEndian, &req); err != nil {
+		return err
+	}
+
+	w.SetGroupAssistant(p, req.Guid, req.Assistant)
+
+	return nil
+}
+
+// ReadyCheckHandler starts a ready check, or answers one. The leader's client sends an empty packet to
+// start the check, and each member's client sends whether they are ready.
+// https://gtker.com/wow_messages/docs/msg_raid_ready_check_client.html
+func ReadyCheckHandler(w *world.World, client *realmd.Client, data []byte) error {
+	p := w.PlayerFor(client)
+	if p == nil {
+		return nil
+	}
+
+	if len(data) == 0 {
+		w.StartReadyCheck(p)
+	} else {
+		w.ReadyCheckResponse(p, data[0] != 0)
+	}
+
+	return nil
+}
+
+// https://gtker.com/wow_messages/docs/msg_raid_ready_check_finished.html
+func ReadyCheckFinishedHandler(w *world.World, client *realmd.Client) error {
+	p := w.PlayerFor(client)
+	if p == nil {
+		return nil
+	}
+
+	w.FinishReadyCheck(p)
+
+	return nil
+}
+
+type raidTargetRequest struct {
+	Icon   uint8
+	Target realmd.Guid
+}
+
+// RaidTargetHandler marks a target with a raid target icon, or sends the player the icons in their group.
+// https://gtker.com/wow_messages/docs/msg_raid_target_update_client.html
+func RaidTargetHandler(w *world.World, client *realmd.Client, data []byte) error {
+	p := w.PlayerFor(client)
+	if p == nil || len(data) == 0 {
+		return nil
+	}
+
+	// The client only sends the icon when it's asking for the icons
+	if data[0] == world.RaidTargetIconsQuery {
+		w.SendRaidTargetIcons(p)
+		return nil
+	}
+
+	req := raidTargetRequest{}
+	if _, err := binarystruct.Unmarshal(data, binarystruct.LittleEndian, &req); err != nil {
+		return err
+	}
+
+	w.SetRaidTargetIcon(p, req.Icon, req.Target)
+
+	return nil
+}
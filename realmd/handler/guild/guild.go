@@ -0,0 +1,341 @@
+package guild
+
+import (
+	"github.com/kangaroux/gomaggus/model"
+	"github.com/kangaroux/gomaggus/realmd"
+	"github.com/kangaroux/gomaggus/realmd/world"
+	"github.com/mixcode/binarystruct"
+)
+
+// SignOn loads the player's guild if it isn't loaded yet and marks them as online in it.
+func SignOn(svc *realmd.Service, w *world.World, p *world.Player) error {
+	m, err := svc.Guilds.GetMember(p.Character.Id)
+	if err != nil || m == nil {
+		return err
+	}
+
+	g := w.Guild(m.GuildId)
+	if g == nil {
+		if g, err = Load(svc, w, m.GuildId); err != nil {
+			return err
+		}
+	}
+
+	w.GuildSignOn(p, g)
+
+	return nil
+}
+
+// Load loads a guild, its ranks and its members from the database and adds it to the world.
+func Load(svc *realmd.Service, w *world.World, id uint32) (*world.Guild, error) {
+	g, err := svc.Guilds.Get(id)
+	if err != nil {
+		return nil, err
+	}
+
+	ranks, err := svc.Guilds.ListRanks(id)
+	if err != nil {
+		return nil, err
+	}
+
+	members, err := svc.Guilds.ListMembers(id)
+	if err != nil {
+		return nil, err
+	}
+
+	chars, err := svc.Characters.List(&model.CharacterListParams{GuildId: id})
+	if err != nil {
+		return nil, err
+	}
+
+	return w.LoadGuild(g, ranks, members, chars), nil
+}
+
+type queryRequest struct {
+	GuildId uint32
+}
+
+// https://gtker.com/wow_messages/docs/cmsg_guild_query.html
+func QueryHandler(w *world.World, client *realmd.Client, data []byte) error {
+	p := w.PlayerFor(client)
+	if p == nil {
+		return nil
+	}
+
+	req := queryRequest{}
+	if _, err := binarystruct.Unmarshal(data, binarystruct.LittleEndian, &req); err != nil {
+		return err
+	}
+
+	w.SendGuildQuery(p, req.GuildId)
+
+	return nil
+}
+
+// https://gtker.com/wow_messages/docs/cmsg_guild_roster.html
+func RosterHandler(w *world.World, client *realmd.Client) error {
+	p := w.PlayerFor(client)
+	if p == nil {
+		return nil
+	}
+
+	w.SendGuildRoster(p)
+
+	return nil
+}
+
+// https://gtker.com/wow_messages/docs/cmsg_guild_info.html
+func InfoHandler(w *world.World, client *realmd.Client) error {
+	p := w.PlayerFor(client)
+	if p == nil {
+		return nil
+	}
+
+	w.SendGuildInfo(p)
+
+	return nil
+}
+
+// https://gtker.com/wow_messages/docs/msg_guild_permissions_client.html
+func PermissionsHandler(w *world.World, client *realmd.Client) error {
+	p := w.PlayerFor(client)
+	if p == nil {
+		return nil
+	}
+
+	w.SendGuildPermissions(p)
+
+	return nil
+}
+
+type nameRequest struct {
+	Name string `binary:"zstring"`
+}
+
+// https://gtker.com/wow_messages/docs/cmsg_guild_invite.html
+func InviteHandler(w *world.World, client *realmd.Client, data []byte) error {
+	p := w.PlayerFor(client)
+	if p == nil {
+		return nil
+	}
+
+	req := nameRequest{}
+	if _, err := binarystruct.Unmarshal(data, binarystruct.LittleEndian, &req); err != nil {
+		return err
+	}
+
+	w.InviteToGuild(p, req.Name)
+
+	return nil
+}
+
+// InviteResponseHandler handles the player accepting or declining a guild invite.
+// https://gtker.com/wow_messages/docs/cmsg_guild_accept.html
+// https://gtker.com/wow_messages/docs/cmsg_guild_decline.html
+func InviteResponseHandler(svc *realmd.Service, w *world.World, client *realmd.Client, opcode realmd.ClientOpcode) error {
+	p := w.PlayerFor(client)
+	if p == nil {
+		return nil
+	}
+
+	switch opcode {
+	case realmd.OpClientGuildAccept:
+		if m := w.AcceptGuildInvite(p); m != nil {
+			return svc.Guilds.SaveMember(m)
+		}
+	case realmd.OpClientGuildDecline:
+		w.DeclineGuildInvite(p)
+	}
+
+	return nil
+}
+
+// RankChangeHandler handles the player promoting or demoting another member of their guild.
+// https://gtker.com/wow_messages/docs/cmsg_guild_promote.html
+// https://gtker.com/wow_messages/docs/cmsg_guild_demote.html
+func RankChangeHandler(svc *realmd.Service, w *world.World, client *realmd.Client, opcode realmd.ClientOpcode, data []byte) error {
+	p := w.PlayerFor(client)
+	if p == nil {
+		return nil
+	}
+
+	req := nameRequest{}
+	if _, err := binarystruct.Unmarshal(data, binarystruct.LittleEndian, &req); err != nil {
+		return err
+	}
+
+	var m *model.GuildMember
+
+	switch opcode {
+	case realmd.OpClientGuildPromote:
+		m = w.PromoteGuildMember(p, req.Name)
+	case realmd.OpClientGuildDemote:
+		m = w.DemoteGuildMember(p, req.Name)
+	}
+
+	if m == nil {
+		return nil
+	}
+
+	return svc.Guilds.SaveMember(m)
+}
+
+// https://gtker.com/wow_messages/docs/cmsg_guild_leave.html
+func LeaveHandler(svc *realmd.Service, w *world.World, client *realmd.Client) error {
+	p := w.PlayerFor(client)
+	if p == nil {
+		return nil
+	}
+
+	m := w.LeaveGuild(p)
+	if m == nil {
+		return nil
+	}
+
+	_, err := svc.Guilds.DeleteMember(m.CharacterId)
+	return err
+}
+
+// https://gtker.com/wow_messages/docs/cmsg_guild_remove.html
+func RemoveHandler(svc *realmd.Service, w *world.World, client *realmd.Client, data []byte) error {
+	p := w.PlayerFor(client)
+	if p == nil {
+		return nil
+	}
+
+	req := nameRequest{}
+	if _, err := binarystruct.Unmarshal(data, binarystruct.LittleEndian, &req); err != nil {
+		return err
+	}
+
+	m := w.RemoveFromGuild(p, req.Name)
+	if m == nil {
+		return nil
+	}
+
+	_, err := svc.Guilds.DeleteMember(m.CharacterId)
+	return err
+}
+
+// https://gtker.com/wow_messages/docs/cmsg_guild_leader.html
+func LeaderHandler(svc *realmd.Service, w *world.World, client *realmd.Client, data []byte) error {
+	p := w.PlayerFor(client)
+	if p == nil {
+		return nil
+	}
+
+	req := nameRequest{}
+	if _, err := binarystruct.Unmarshal(data, binarystruct.LittleEndian, &req); err != nil {
+		return err
+	}
+
+	members := w.SetGuildLeader(p, req.Name)
+	if members == nil {
+		return nil
+	}
+
+	if _, err := svc.Guilds.Update(p.Guild().Guild); err != nil {
+		return err
+	}
+
+	for _, m := range members {
+		if err := svc.Guilds.SaveMember(m); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// https://gtker.com/wow_messages/docs/cmsg_guild_disband.html
+func DisbandHandler(svc *realmd.Service, w *world.World, client *realmd.Client) error {
+	p := w.PlayerFor(client)
+	if p == nil {
+		return nil
+	}
+
+	g := w.DisbandGuild(p)
+	if g == nil {
+		return nil
+	}
+
+	_, err := svc.Guilds.Delete(g.Id)
+	return err
+}
+
+type motdRequest struct {
+	MOTD string `binary:"zstring"`
+}
+
+// https://gtker.com/wow_messages/docs/cmsg_guild_motd.html
+func MOTDHandler(svc *realmd.Service, w *world.World, client *realmd.Client, data []byte) error {
+	p := w.PlayerFor(client)
+	if p == nil {
+		return nil
+	}
+
+	req := motdRequest{}
+	if _, err := binarystruct.Unmarshal(data, binarystruct.LittleEndian, &req); err != nil {
+		return err
+	}
+
+	g := w.SetGuildMOTD(p, req.MOTD)
+	if g == nil {
+		return nil
+	}
+
+	_, err := svc.Guilds.Update(g.Guild)
+	return err
+}
+
+type infoTextRequest struct {
+	Info string `binary:"zstring"`
+}
+
+// https://gtker.com/wow_messages/docs/cmsg_guild_info_text.html
+func InfoTextHandler(svc *realmd.Service, w *world.World, client *realmd.Client, data []byte) error {
+	p := w.PlayerFor(client)
+	if p == nil {
+		return nil
+	}
+
+	req := infoTextRequest{}
+	if _, err := binarystruct.Unmarshal(data, binarystruct.LittleEndian, &req); err != nil {
+		return err
+	}
+
+	g := w.SetGuildInfo(p, req.Info)
+	if g == nil {
+		return nil
+	}
+
+	_, err := svc.Guilds.Update(g.Guild)
+	return err
+}
+
+type noteRequest struct {
+	Name string `binary:"zstring"`
+	Note string `binary:"zstring"`
+}
+
+// NoteHandler changes the public or officer note of a member of the player's guild.
+// https://gtker.com/wow_messages/docs/cmsg_guild_set_public_note.html
+// https://gtker.com/wow_messages/docs/cmsg_guild_set_officer_note.html
+func NoteHandler(svc *realmd.Service, w *world.World, client *realmd.Client, opcode realmd.ClientOpcode, data []byte) error {
+	p := w.PlayerFor(client)
+	if p == nil {
+		return nil
+	}
+
+	req := noteRequest{}
+	if _, err := binarystruct.Unmarshal(data, binarystruct.LittleEndian, &req); err != nil {
+		return err
+	}
+
+	m := w.SetGuildNote(p, req.Name, req.Note, opcode == realmd.OpClientGuildSetOfficerNote)
+	if m == nil {
+		return nil
+	}
+
+	return svc.Guilds.SaveMember(m)
+}
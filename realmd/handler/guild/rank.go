@@ -0,0 +1,81 @@
+package guild
+
+import (
+	"github.com/kangaroux/gomaggus/model"
+	"github.com/kangaroux/gomaggus/realmd"
+	"github.com/kangaroux/gomaggus/realmd/world"
+	"github.com/mixcode/binarystruct"
+)
+
+type setRankRequest struct {
+	Rank        uint32
+	Rights      model.GuildRights
+	Name        string `binary:"zstring"`
+	MoneyPerDay uint32
+	// The rest of the packet is the guild bank tab rights, which aren't supported
+}
+
+// https://gtker.com/wow_messages/docs/cmsg_guild_rank.html#client-version-335
+func SetRankHandler(svc *realmd.Service, w *world.World, client *realmd.Client, data []byte) error {
+	p := w.PlayerFor(client)
+	if p == nil {
+		return nil
+	}
+
+	req := setRankRequest{}
+	if _, err := binarystruct.Unmarshal(data, binarystruct.LittleEndian, &req); err != nil {
+		return err
+	}
+
+	if req.Rank >= world.MaxGuildRanks {
+		return nil
+	}
+
+	r := w.SetGuildRank(p, uint8(req.Rank), req.Name, req.Rights, req.MoneyPerDay)
+	if r == nil {
+		return nil
+	}
+
+	return svc.Guilds.SaveRank(r)
+}
+
+type addRankRequest struct {
+	Name string `binary:"zstring"`
+}
+
+// https://gtker.com/wow_messages/docs/cmsg_guild_add_rank.html
+func AddRankHandler(svc *realmd.Service, w *world.World, client *realmd.Client, data []byte) error {
+	p := w.PlayerFor(client)
+	if p == nil {
+		return nil
+	}
+
+	req := addRankRequest{}
+	if _, err := binarystruct.Unmarshal(data, binarystruct.LittleEndian, &req); err != nil {
+		return err
+	}
+
+	r := w.AddGuildRank(p, req.Name)
+	if r == nil {
+		return nil
+	}
+
+	return svc.Guilds.SaveRank(r)
+}
+
+// DeleteRankHandler deletes the lowest rank of the player's guild.
+// https://gtker.com/wow_messages/docs/cmsg_guild_del_rank.html
+func DeleteRankHandler(svc *realmd.Service, w *world.World, client *realmd.Client) error {
+	p := w.PlayerFor(client)
+	if p == nil {
+		return nil
+	}
+
+	r := w.DeleteGuildRank(p)
+	if r == nil {
+		return nil
+	}
+
+	_, err := svc.Guilds.DeleteRank(r.GuildId, r.Rank)
+	return err
+}
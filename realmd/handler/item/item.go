@@ -0,0 +1,155 @@
+package item
+
+import (
+	"github.com/kangaroux/gomaggus/realmd"
+	"github.com/kangaroux/gomaggus/realmd/item"
+	"github.com/kangaroux/gomaggus/realmd/world"
+	"github.com/mixcode/binarystruct"
+)
+
+type swapItemRequest struct {
+	DstBag  uint8
+	DstSlot uint8
+	SrcBag  uint8
+	SrcSlot uint8
+}
+
+// SwapItemHandler moves an item to another slot, which may be in a different bag.
+// https://gtker.com/wow_messages/docs/cmsg_swap_item.html
+func SwapItemHandler(svc *realmd.Service, w *world.World, client *realmd.Client, data []byte) error {
+	p := w.PlayerFor(client)
+	if p == nil {
+		return nil
+	}
+
+	req := swapItemRequest{}
+	if _, err := binarystruct.Unmarshal(data, binarystruct.LittleEndian, &req); err != nil {
+		return err
+	}
+
+	return item.Save(svc, w.SwapItems(p, req.SrcBag, req.SrcSlot, req.DstBag, req.DstSlot))
+}
+
+type swapInvItemRequest struct {
+	SrcSlot uint8
+	DstSlot uint8
+}
+
+// SwapInvItemHandler moves an item between two of the player's own slots.
+// https://gtker.com/wow_messages/docs/cmsg_swap_inv_item.html
+func SwapInvItemHandler(svc *realmd.Service, w *world.World, client *realmd.Client, data []byte) error {
+	p := w.PlayerFor(client)
+	if p == nil {
+		return nil
+	}
+
+	req := swapInvItemRequest{}
+	if _, err := binarystruct.Unmarshal(data, binarystruct.LittleEndian, &req); err != nil {
+		return err
+	}
+
+	changes := w.SwapItems(p, world.InventorySlotBag0, req.SrcSlot, world.InventorySlotBag0, req.DstSlot)
+	return item.Save(svc, changes)
+}
+
+type splitItemRequest struct {
+	SrcBag  uint8
+	SrcSlot uint8
+	DstBag  uint8
+	DstSlot uint8
+	Count   uint32
+}
+
+// SplitItemHandler moves part of a stack to another slot.
+// https://gtker.com/wow_messages/docs/cmsg_split_item.html#client-version-335
+func SplitItemHandler(svc *realmd.Service, w *world.World, client *realmd.Client, data []byte) error {
+	p := w.PlayerFor(client)
+	if p == nil {
+		return nil
+	}
+
+	req := splitItemRequest{}
+	if _, err := binarystruct.Unmarshal(data, binarystruct.LittleEndian, &req); err != nil {
+		return err
+	}
+
+	changes, split := w.SplitItem(p, req.SrcBag, req.SrcSlot, req.DstBag, req.DstSlot, req.Count)
+	if err := item.Save(svc, changes); err != nil {
+		return err
+	}
+
+	if split != nil {
+		if err := svc.Items.Create(split.Item); err != nil {
+			return err
+		}
+		w.PlaceItem(p, split)
+	}
+
+	return nil
+}
+
+type destroyItemRequest struct {
+	Bag     uint8
+	Slot    uint8
+	Count   uint8
+	Unknown [3]uint8
+}
+
+// DestroyItemHandler destroys some or all of an item.
+// https://gtker.com/wow_messages/docs/cmsg_destroyitem.html
+func DestroyItemHandler(svc *realmd.Service, w *world.World, client *realmd.Client, data []byte) error {
+	p := w.PlayerFor(client)
+	if p == nil {
+		return nil
+	}
+
+	req := destroyItemRequest{}
+	if _, err := binarystruct.Unmarshal(data, binarystruct.LittleEndian, &req); err != nil {
+		return err
+	}
+
+	return item.Save(svc, w.DestroyItem(p, req.Bag, req.Slot, uint32(req.Count)))
+}
+
+type autoEquipItemRequest struct {
+	Bag  uint8
+	Slot uint8
+}
+
+// AutoEquipItemHandler equips an item in whichever slot it goes in.
+// https://gtker.com/wow_messages/docs/cmsg_autoequip_item.html
+func AutoEquipItemHandler(svc *realmd.Service, w *world.World, client *realmd.Client, data []byte) error {
+	p := w.PlayerFor(client)
+	if p == nil {
+		return nil
+	}
+
+	req := autoEquipItemRequest{}
+	if _, err := binarystruct.Unmarshal(data, binarystruct.LittleEndian, &req); err != nil {
+		return err
+	}
+
+	return item.Save(svc, w.AutoEquipItem(p, req.Bag, req.Slot))
+}
+
+type autoStoreBagItemRequest struct {
+	SrcBag  uint8
+	SrcSlot uint8
+	DstBag  uint8
+}
+
+// AutoStoreBagItemHandler moves an item to the first free slot in a bag.
+// https://gtker.com/wow_messages/docs/cmsg_autostore_bag_item.html
+func AutoStoreBagItemHandler(svc *realmd.Service, w *world.World, client *realmd.Client, data []byte) error {
+	p := w.PlayerFor(client)
+	if p == nil {
+		return nil
+	}
+
+	req := autoStoreBagItemRequest{}
+	if _, err := binarystruct.Unmarshal(data, binarystruct.LittleEndian, &req); err != nil {
+		return err
+	}
+
+	return item.Save(svc, w.AutoStoreItem(p, req.SrcBag, req.SrcSlot, req.DstBag))
+}
@@ -0,0 +1,104 @@
+package item
+
+import (
+	"bytes"
+	"encoding/binary"
+
+	"github.com/kangaroux/gomaggus/model"
+	"github.com/kangaroux/gomaggus/realmd"
+)
+
+// notFoundFlag is set on the item id in the response when the item doesn't exist.
+const notFoundFlag = 0x80000000
+
+// QueryHandler sends the client the info for an item template, which it uses for tooltips and icons.
+// https://gtker.com/wow_messages/docs/cmsg_item_query_single.html#client-version-335
+func QueryHandler(svc *realmd.Service, client *realmd.Client, data []byte) error {
+	if len(data) < 4 {
+		return nil
+	}
+
+	entry := binary.LittleEndian.Uint32(data)
+
+	t, err := svc.Items.GetTemplate(entry)
+	if err != nil {
+		return err
+	}
+
+	if t == nil {
+		resp := binary.LittleEndian.AppendUint32(nil, entry|notFoundFlag)
+		return client.SendPacketBytes(realmd.OpServerItemQueryResponse, resp)
+	}
+
+	return client.SendPacketBytes(realmd.OpServerItemQueryResponse, queryResponse(t))
+}
+
+// queryResponse builds the body of SMSG_ITEM_QUERY_SINGLE_RESPONSE. Most of the fields are for features that
+// templates don't support yet, so they're sent as zero.
+// https://gtker.com/wow_messages/docs/smsg_item_query_single_response.html#client-version-335
+func queryResponse(t *model.ItemTemplate) []byte {
+	buf := bytes.Buffer{}
+	write := func(v any) { binary.Write(&buf, binary.LittleEndian, v) }
+
+	write(t.Id)
+	write(uint32(t.Class))
+	write(t.Subclass)
+	write(int32(-1)) // Sound override subclass
+	buf.WriteString(t.Name)
+	buf.Write([]byte{0, 0, 0, 0}) // Name terminator, then the three unused names
+	write(t.DisplayId)
+	write(uint32(t.Quality))
+	write(t.Flags)
+	write(uint32(0)) // Flags 2
+	write(t.BuyPrice)
+	write(t.SellPrice)
+	write(uint32(t.InventoryType))
+	write(t.AllowableClass)
+	write(t.AllowableRace)
+	write(t.ItemLevel)
+	write(t.RequiredLevel)
+	write([7]uint32{}) // Required skill, skill rank, spell, honor rank, city rank, faction, reputation rank
+	write(int32(t.MaxCount))
+	write(int32(t.Stackable))
+	write(uint32(t.ContainerSlots))
+	write(uint32(0))   // Stats count
+	write([2]uint32{}) // Scaling stat distribution and value
+
+	write(t.DamageMin)
+	write(t.DamageMax)
+	write(t.DamageType)
+	write([3]uint32{}) // Second damage type
+
+	write(t.Armor)
+	write([6]uint32{}) // Resistances
+	write(t.Delay)
+	write(uint32(0))  // Ammo type
+	write(float32(0)) // Ranged range modifier
+
+	// Each spell is its id, trigger, charges, cooldown, category and category cooldown. The item's spell is
+	// cast when it's used, and the cooldowns of -1 use the spell's own.
+	var spells [30]int32
+	if t.SpellId != 0 {
+		spells[0], spells[3], spells[5] = int32(t.SpellId), -1, -1
+	}
+	write(spells)
+
+	write(uint32(t.Bonding))
+	buf.WriteString(t.Description)
+	buf.WriteByte(0)
+	write([5]uint32{}) // Page text, language, page material, start quest, lock
+	write(t.Material)
+	write(t.Sheath)
+	write([4]uint32{}) // Random property, random suffix, block, item set
+	write(t.MaxDurability)
+	write([2]uint32{}) // Area and map
+	write(t.BagFamily)
+	write(uint32(0))   // Totem category
+	write([6]uint32{}) // Sockets
+	write([2]uint32{}) // Socket bonus and gem properties
+	write(int32(-1))   // Required disenchant skill
+	write(float32(0))  // Armor damage modifier
+	write([3]uint32{}) // Duration, item limit category, holiday
+
+	return buf.Bytes()
+}
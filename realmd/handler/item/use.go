@@ -0,0 +1,63 @@
+package item
+
+import (
+	"bytes"
+	"encoding/binary"
+
+	"github.com/kangaroux/gomaggus/realmd"
+	"github.com/kangaroux/gomaggus/realmd/spell"
+	"github.com/kangaroux/gomaggus/realmd/world"
+)
+
+type useItemRequest struct {
+	Bag       uint8
+	Slot      uint8
+	Count     uint8
+	Spell     uint32
+	Item      realmd.Guid
+	GlyphSlot uint32
+	CastFlags uint8
+}
+
+// UseItemHandler casts the spell on one of the player's items, like the glyph on a glyph item. Missile
+// trajectories sent after the targets are ignored.
+// https://gtker.com/wow_messages/docs/cmsg_use_item.html#client-version-335
+func UseItemHandler(svc *realmd.Service, w *world.World, client *realmd.Client, data []byte) error {
+	p := w.PlayerFor(client)
+	if p == nil {
+		return nil
+	}
+
+	r := bytes.NewReader(data)
+
+	req := useItemRequest{}
+	if err := binary.Read(r, binary.LittleEndian, &req); err != nil {
+		return err
+	}
+
+	targets, err := world.ReadSpellTargets(r)
+	if err != nil {
+		return err
+	}
+
+	item := p.Item(req.Bag, req.Slot)
+	if item == nil || item.GUID() != req.Item || item.Template.SpellId == 0 || item.Template.SpellId != req.Spell {
+		client.Log.Warn().Uint32("spell", req.Spell).Msg("tried to use an item that can't be used")
+		return nil
+	}
+
+	s, ok := svc.DBC.Spells[req.Spell]
+	if !ok {
+		client.Log.Warn().Uint32("spell", req.Spell).Msg("tried to use item with unknown spell")
+		return nil
+	}
+
+	// Slots past the last one are locked, and the cast fails with that
+	slot := uint8(world.MaxGlyphSlots)
+	if req.GlyphSlot < world.MaxGlyphSlots {
+		slot = uint8(req.GlyphSlot)
+	}
+
+	w.CastSpell(p, spell.NewItemCast(svc, p, item, s, req.Count, targets, slot))
+	return nil
+}
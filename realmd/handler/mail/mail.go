@@ -0,0 +1,575 @@
+package mail
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"strings"
+	"time"
+
+	"github.com/kangaroux/gomaggus/model"
+	"github.com/kangaroux/gomaggus/realmd"
+	"github.com/kangaroux/gomaggus/realmd/mail"
+	"github.com/kangaroux/gomaggus/realmd/world"
+	"github.com/mixcode/binarystruct"
+)
+
+// The client only shows this much mail at a time, even if the mailbox has more.
+const maxMailListSize = 50
+
+type sendItem struct {
+	Slot uint8
+	Guid realmd.Guid
+}
+
+type sendRequest struct {
+	Mailbox    realmd.Guid
+	Receiver   string
+	Subject    string
+	Body       string
+	Stationery uint32
+	Unknown    uint32
+	Items      []sendItem
+	Money      uint32
+	COD        uint32
+	// The rest of the packet is unused
+}
+
+func (req *sendRequest) read(data []byte) error {
+	r := bufio.NewReader(bytes.NewReader(data))
+
+	if err := binary.Read(r, binary.LittleEndian, &req.Mailbox); err != nil {
+		return err
+	}
+
+	for _, s := range []*string{&req.Receiver, &req.Subject, &req.Body} {
+		str, err := r.ReadString(0)
+		if err != nil {
+			return err
+		}
+		*s = strings.TrimSuffix(str, "\x00")
+	}
+
+	if err := binary.Read(r, binary.LittleEndian, &req.Stationery); err != nil {
+		return err
+	}
+	if err := binary.Read(r, binary.LittleEndian, &req.Unknown); err != nil {
+		return err
+	}
+
+	count, err := r.ReadByte()
+	if err != nil {
+		return err
+	}
+	req.Items = make([]sendItem, count)
+	if err := binary.Read(r, binary.LittleEndian, req.Items); err != nil {
+		return err
+	}
+
+	if err := binary.Read(r, binary.LittleEndian, &req.Money); err != nil {
+		return err
+	}
+	return binary.Read(r, binary.LittleEndian, &req.COD)
+}
+
+// SendHandler sends mail to another character on the same realm and faction. The sender pays for
+// postage and any attached money, which is saved with the mail in one transaction.
+// https://gtker.com/wow_messages/docs/cmsg_send_mail.html#client-version-335
+func SendHandler(svc *realmd.Service, w *world.World, client *realmd.Client, data []byte) error {
+	p := w.PlayerFor(client)
+	if p == nil {
+		return nil
+	}
+
+	req := sendRequest{}
+	if err := req.read(data); err != nil {
+		return err
+	}
+
+	if len(req.Items) > mail.MaxAttachments {
+		return mail.SendResult(client, 0, mail.ActionSend, mail.ResultTooManyAttachments)
+	}
+
+	// COD is paid to take the attached items, so there must be something attached
+	if req.COD > 0 && len(req.Items) == 0 {
+		return mail.SendResult(client, 0, mail.ActionSend, mail.ResultAttachmentInvalid)
+	}
+
+	items := make([]*world.Item, 0, len(req.Items))
+	for _, attachment := range req.Items {
+		item := p.ItemByGUID(attachment.Guid)
+		if item == nil || item.Flags&model.ItemFlagSoulbound != 0 || !item.Empty() || contains(items, item) {
+			return mail.SendResult(client, 0, mail.ActionSend, mail.ResultAttachmentInvalid)
+		}
+		items = append(items, item)
+	}
+
+	receiver, err := svc.Characters.GetName(req.Receiver, client.Realm.Id)
+	if err != nil {
+		return err
+	} else if receiver == nil {
+		return mail.SendResult(client, 0, mail.ActionSend, mail.ResultRecipientNotFound)
+	} else if receiver.Id == p.Character.Id {
+		return mail.SendResult(client, 0, mail.ActionSend, mail.ResultCannotSendToSelf)
+	}
+
+	if race, ok := svc.DBC.ChrRaces[receiver.Race]; !ok || race.Horde != p.Horde {
+		return mail.SendResult(client, 0, mail.ActionSend, mail.ResultNotYourTeam)
+	}
+
+	mailbox, err := svc.Mail.List(receiver.Id)
+	if err != nil {
+		return err
+	} else if len(mailbox) >= mail.MaxMailboxSize {
+		return mail.SendResult(client, 0, mail.ActionSend, mail.ResultRecipientCapReached)
+	}
+
+	postage := int64(mail.Postage)
+	if len(items) > 1 {
+		postage *= int64(len(items))
+	}
+
+	cost := int64(req.Money) + postage
+	if cost > int64(p.Money()) {
+		return mail.SendResult(client, 0, mail.ActionSend, mail.ResultNotEnoughMoney)
+	}
+
+	m := &model.Mail{
+		ReceiverId:  receiver.Id,
+		SenderId:    p.Character.Id,
+		MessageType: model.MailTypeNormal,
+		Stationery:  model.MailStationeryDefault,
+		Subject:     truncate(req.Subject, mail.MaxSubjectLength),
+		Body:        truncate(req.Body, mail.MaxBodyLength),
+		Money:       req.Money,
+		COD:         req.COD,
+	}
+	if m.Body != "" {
+		m.Flags |= model.MailFlagHasBody
+	}
+
+	// The items are attached as they are so they keep their durability and flags
+	for _, attached := range items {
+		m.Items = append(m.Items, &model.MailItem{
+			ItemId:     attached.TemplateId,
+			InstanceId: attached.Id,
+			Count:      attached.Count,
+		})
+	}
+
+	if err := mail.Post(svc, w, m, p.Money()-uint32(cost)); err != nil {
+		return err
+	}
+
+	p.AddMoney(-cost)
+	for _, attached := range items {
+		w.RemoveItem(p, attached)
+	}
+
+	return mail.SendResult(client, 0, mail.ActionSend, mail.ResultOK)
+}
+
+type listRequest struct {
+	Mailbox realmd.Guid
+}
+
+// ListHandler sends the player the mail in their mailbox.
+// https://gtker.com/wow_messages/docs/cmsg_get_mail_list.html
+func ListHandler(svc *realmd.Service, w *world.World, client *realmd.Client, data []byte) error {
+	p := w.PlayerFor(client)
+	if p == nil {
+		return nil
+	}
+
+	req := listRequest{}
+	if _, err := binarystruct.Unmarshal(data, binarystruct.LittleEndian, &req); err != nil {
+		return err
+	}
+
+	mailbox, err := mail.Mailbox(svc, w, p.Character.Id)
+	if err != nil {
+		return err
+	}
+
+	shown := mailbox
+	if len(shown) > maxMailListSize {
+		shown = shown[:maxMailListSize]
+	}
+
+	// https://gtker.com/wow_messages/docs/smsg_mail_list_result.html#client-version-335
+	buf := bytes.Buffer{}
+	binary.Write(&buf, binary.LittleEndian, uint32(len(mailbox)))
+	buf.WriteByte(uint8(len(shown)))
+
+	for _, m := range shown {
+		entry := writeMail(m)
+		binary.Write(&buf, binary.LittleEndian, uint16(entry.Len()+2))
+		buf.Write(entry.Bytes())
+	}
+
+	return client.SendPacketBytes(realmd.OpServerMailListResult, buf.Bytes())
+}
+
+func writeMail(m *model.Mail) *bytes.Buffer {
+	buf := &bytes.Buffer{}
+	binary.Write(buf, binary.LittleEndian, m.Id)
+	buf.WriteByte(uint8(m.MessageType))
+	if m.MessageType == model.MailTypeNormal {
+		binary.Write(buf, binary.LittleEndian, uint64(realmd.Guid(m.SenderId)))
+	} else {
+		binary.Write(buf, binary.LittleEndian, m.SenderId)
+	}
+	binary.Write(buf, binary.LittleEndian, m.COD)
+	binary.Write(buf, binary.LittleEndian, uint32(0)) // Unknown
+	binary.Write(buf, binary.LittleEndian, uint32(m.Stationery))
+	binary.Write(buf, binary.LittleEndian, m.Money)
+	binary.Write(buf, binary.LittleEndian, m.Flags)
+	binary.Write(buf, binary.LittleEndian, float32(time.Until(m.ExpiresAt).Hours()/24))
+	binary.Write(buf, binary.LittleEndian, uint32(0)) // Mail template
+	buf.WriteString(m.Subject)
+	buf.WriteByte(0)
+	buf.WriteString(m.Body)
+	buf.WriteByte(0)
+
+	buf.WriteByte(uint8(len(m.Items)))
+	for i, item := range m.Items {
+		buf.WriteByte(uint8(i))
+		binary.Write(buf, binary.LittleEndian, item.Id)
+		binary.Write(buf, binary.LittleEndian, item.ItemId)
+		// Enchantments: 7x (id, duration, charges)
+		buf.Write(make([]byte, 7*3*4))
+		binary.Write(buf, binary.LittleEndian, uint32(0)) // Random property
+		binary.Write(buf, binary.LittleEndian, uint32(0)) // Suffix factor
+		binary.Write(buf, binary.LittleEndian, item.Count)
+		binary.Write(buf, binary.LittleEndian, uint32(0)) // Spell charges
+		binary.Write(buf, binary.LittleEndian, uint32(0)) // Max durability
+		binary.Write(buf, binary.LittleEndian, uint32(0)) // Durability
+		buf.WriteByte(0)                                  // Locked
+	}
+
+	return buf
+}
+
+type mailRequest struct {
+	Mailbox realmd.Guid
+	MailId  uint32
+}
+
+// getMail returns the mail if it's in the player's mailbox, or nil.
+func getMail(svc *realmd.Service, p *world.Player, id uint32) (*model.Mail, error) {
+	m, err := svc.Mail.Get(id)
+	if err != nil || m == nil {
+		return nil, err
+	} else if m.ReceiverId != p.Character.Id {
+		return nil, nil
+	}
+	return m, nil
+}
+
+// TakeMoneyHandler moves the money attached to a mail to the player. The mail and the player's money are saved
+// in one transaction.
+// https://gtker.com/wow_messages/docs/cmsg_mail_take_money.html
+func TakeMoneyHandler(svc *realmd.Service, w *world.World, client *realmd.Client, data []byte) error {
+	p := w.PlayerFor(client)
+	if p == nil {
+		return nil
+	}
+
+	req := mailRequest{}
+	if _, err := binarystruct.Unmarshal(data, binarystruct.LittleEndian, &req); err != nil {
+		return err
+	}
+
+	m, err := getMail(svc, p, req.MailId)
+	if err != nil {
+		return err
+	} else if m == nil || m.Money == 0 {
+		return mail.SendResult(client, req.MailId, mail.ActionMoneyTaken, mail.ResultInternalError)
+	}
+
+	money := m.Money
+	if uint64(p.Money())+uint64(money) > world.MaxMoney {
+		return mail.SendResult(client, req.MailId, mail.ActionMoneyTaken, mail.ResultEquipError)
+	}
+
+	// The money is only given to the player once it's saved, so it can't be lost or taken twice
+	m.Money = 0
+	if err := svc.Mail.TakeMoney(m, p.Money()+money); err != nil {
+		return err
+	}
+	p.AddMoney(int64(money))
+
+	return mail.SendResult(client, req.MailId, mail.ActionMoneyTaken, mail.ResultOK)
+}
+
+type takeItemRequest struct {
+	Mailbox realmd.Guid
+	MailId  uint32
+	ItemId  uint32
+}
+
+// TakeItemHandler moves an item attached to a mail to the player's bags. If the mail has COD, the player
+// pays it and the money is mailed to the sender. Everything is saved in one transaction.
+// https://gtker.com/wow_messages/docs/cmsg_mail_take_item.html#client-version-335
+func TakeItemHandler(svc *realmd.Service, w *world.World, client *realmd.Client, data []byte) error {
+	p := w.PlayerFor(client)
+	if p == nil {
+		return nil
+	}
+
+	req := takeItemRequest{}
+	if _, err := binarystruct.Unmarshal(data, binarystruct.LittleEndian, &req); err != nil {
+		return err
+	}
+
+	m, err := getMail(svc, p, req.MailId)
+	if err != nil {
+		return err
+	}
+
+	var attachment *model.MailItem
+	if m != nil {
+		for _, mi := range m.Items {
+			if mi.Id == req.ItemId {
+				attachment = mi
+			}
+		}
+	}
+	if attachment == nil {
+		return mail.SendResult(client, req.MailId, mail.ActionItemTaken, mail.ResultInternalError)
+	}
+
+	if m.COD > p.Money() {
+		return mail.SendResult(client, req.MailId, mail.ActionItemTaken, mail.ResultNotEnoughMoney)
+	}
+
+	t, err := svc.Items.GetTemplate(attachment.ItemId)
+	if err != nil {
+		return err
+	} else if t == nil {
+		return mail.SendResult(client, req.MailId, mail.ActionItemTaken, mail.ResultInternalError)
+	}
+
+	take := &model.MailTake{Mail: m, Attachment: attachment}
+	var placed []*world.Item
+
+	if attachment.InstanceId != 0 {
+		instance, err := svc.Items.Get(attachment.InstanceId)
+		if err != nil {
+			return err
+		} else if instance == nil {
+			return mail.SendResult(client, req.MailId, mail.ActionItemTaken, mail.ResultInternalError)
+		}
+
+		taken := world.NewItem(instance, t, p.GUID())
+		if result := w.StoreItem(p, taken); result != world.InventoryResultOK {
+			return mail.SendEquipError(client, req.MailId, result)
+		}
+		take.Updated = append(take.Updated, taken.Item)
+		placed = append(placed, taken)
+	} else {
+		changes, created, result := w.StoreNewItem(p, t, attachment.Count)
+		if result != world.InventoryResultOK {
+			return mail.SendEquipError(client, req.MailId, result)
+		}
+		for _, item := range changes.Updated {
+			take.Updated = append(take.Updated, item.Item)
+		}
+		for _, item := range created {
+			take.Created = append(take.Created, item.Item)
+		}
+		placed = created
+	}
+
+	cod := m.COD
+	if cod > 0 {
+		take.Payment = codPayment(p, m)
+		take.Money = p.Money() - cod
+		m.COD = 0
+	}
+
+	if err := mail.TakeItem(svc, w, take); err != nil {
+		return err
+	}
+
+	for _, item := range placed {
+		w.PlaceItem(p, item)
+	}
+	if cod > 0 {
+		p.AddMoney(-int64(cod))
+	}
+
+	return mail.SendItemTaken(client, req.MailId, attachment.Id, attachment.Count)
+}
+
+// codPayment returns the mail that sends the COD of m to its sender.
+func codPayment(p *world.Player, m *model.Mail) *model.Mail {
+	return &model.Mail{
+		ReceiverId:  m.SenderId,
+		SenderId:    p.Character.Id,
+		MessageType: model.MailTypeNormal,
+		Subject:     m.Subject,
+		Money:       m.COD,
+		Flags:       model.MailFlagCODPayment,
+	}
+}
+
+// MarkAsReadHandler marks a mail as read.
+// https://gtker.com/wow_messages/docs/cmsg_mail_mark_as_read.html
+func MarkAsReadHandler(svc *realmd.Service, w *world.World, client *realmd.Client, data []byte) error {
+	p := w.PlayerFor(client)
+	if p == nil {
+		return nil
+	}
+
+	req := mailRequest{}
+	if _, err := binarystruct.Unmarshal(data, binarystruct.LittleEndian, &req); err != nil {
+		return err
+	}
+
+	m, err := getMail(svc, p, req.MailId)
+	if err != nil || m == nil || m.Flags&model.MailFlagRead != 0 {
+		return err
+	}
+
+	m.Flags |= model.MailFlagRead
+	_, err = svc.Mail.Update(m)
+	return err
+}
+
+type returnRequest struct {
+	Mailbox realmd.Guid
+	MailId  uint32
+	Sender  realmd.Guid
+}
+
+// ReturnHandler sends a mail back to the player that sent it.
+// https://gtker.com/wow_messages/docs/cmsg_mail_return_to_sender.html#client-version-335
+func ReturnHandler(svc *realmd.Service, w *world.World, client *realmd.Client, data []byte) error {
+	p := w.PlayerFor(client)
+	if p == nil {
+		return nil
+	}
+
+	req := returnRequest{}
+	if _, err := binarystruct.Unmarshal(data, binarystruct.LittleEndian, &req); err != nil {
+		return err
+	}
+
+	m, err := getMail(svc, p, req.MailId)
+	if err != nil {
+		return err
+	} else if m == nil || !mail.Returnable(m) {
+		return mail.SendResult(client, req.MailId, mail.ActionReturned, mail.ResultInternalError)
+	}
+
+	if err := mail.Return(svc, w, m); err != nil {
+		return err
+	}
+
+	return mail.SendResult(client, req.MailId, mail.ActionReturned, mail.ResultOK)
+}
+
+type deleteRequest struct {
+	Mailbox    realmd.Guid
+	MailId     uint32
+	TemplateId uint32
+}
+
+// DeleteHandler deletes a mail and anything still attached to it.
+// https://gtker.com/wow_messages/docs/cmsg_mail_delete.html#client-version-335
+func DeleteHandler(svc *realmd.Service, w *world.World, client *realmd.Client, data []byte) error {
+	p := w.PlayerFor(client)
+	if p == nil {
+		return nil
+	}
+
+	req := deleteRequest{}
+	if _, err := binarystruct.Unmarshal(data, binarystruct.LittleEndian, &req); err != nil {
+		return err
+	}
+
+	m, err := getMail(svc, p, req.MailId)
+	if err != nil {
+		return err
+	} else if m == nil {
+		return mail.SendResult(client, req.MailId, mail.ActionDeleted, mail.ResultInternalError)
+	}
+
+	if _, err := svc.Mail.Delete(m.Id); err != nil {
+		return err
+	}
+
+	return mail.SendResult(client, req.MailId, mail.ActionDeleted, mail.ResultOK)
+}
+
+// QueryNextMailTimeHandler tells the client if the player has unread mail, and who it's from.
+// https://gtker.com/wow_messages/docs/msg_query_next_mail_time_server.html
+func QueryNextMailTimeHandler(svc *realmd.Service, w *world.World, client *realmd.Client) error {
+	p := w.PlayerFor(client)
+	if p == nil {
+		return nil
+	}
+
+	mailbox, err := svc.Mail.List(p.Character.Id)
+	if err != nil {
+		return err
+	}
+
+	buf := bytes.Buffer{}
+
+	if !mail.HasUnread(mailbox) {
+		binary.Write(&buf, binary.LittleEndian, float32(-86400))
+		binary.Write(&buf, binary.LittleEndian, uint32(0))
+		return client.SendPacketBytes(realmd.OpServerQueryNextMailTime, buf.Bytes())
+	}
+
+	// The client shows at most two senders
+	var senders []*model.Mail
+	seen := map[uint32]bool{}
+	for _, m := range mailbox {
+		if m.Flags&model.MailFlagRead != 0 || seen[m.SenderId] {
+			continue
+		}
+		seen[m.SenderId] = true
+		senders = append(senders, m)
+		if len(senders) == 2 {
+			break
+		}
+	}
+
+	binary.Write(&buf, binary.LittleEndian, float32(0))
+	binary.Write(&buf, binary.LittleEndian, uint32(len(senders)))
+	for _, m := range senders {
+		if m.MessageType == model.MailTypeNormal {
+			binary.Write(&buf, binary.LittleEndian, uint64(realmd.Guid(m.SenderId)))
+			binary.Write(&buf, binary.LittleEndian, uint32(0))
+		} else {
+			binary.Write(&buf, binary.LittleEndian, uint64(0))
+			binary.Write(&buf, binary.LittleEndian, m.SenderId)
+		}
+		binary.Write(&buf, binary.LittleEndian, uint32(m.MessageType))
+		binary.Write(&buf, binary.LittleEndian, uint32(m.Stationery))
+		binary.Write(&buf, binary.LittleEndian, float32(0))
+	}
+
+	return client.SendPacketBytes(realmd.OpServerQueryNextMailTime, buf.Bytes())
+}
+
+// contains reports whether item is in items.
+func contains(items []*world.Item, item *world.Item) bool {
+	for _, other := range items {
+		if other == item {
+			return true
+		}
+	}
+	return false
+}
+
+// truncate shortens s to at most n bytes.
+func truncate(s string, n int) string {
+	if len(s) > n {
+		return s[:n]
+	}
+	return s
+}
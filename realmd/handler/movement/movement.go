@@ -0,0 +1,86 @@
+package movement
+
+import (
+	"bytes"
+	"encoding/binary"
+
+	"github.com/kangaroux/gomaggus/realmd"
+	"github.com/kangaroux/gomaggus/realmd/values"
+	"github.com/kangaroux/gomaggus/realmd/world"
+)
+
+// Handler handles all of the MSG_MOVE_* opcodes. The movement is relayed to nearby players using the
+// same opcode.
+// https://gtker.com/wow_messages/docs/msg_move_start_forward_client.html#client-version-335
+func Handler(w *world.World, client *realmd.Client, opcode realmd.ClientOpcode, data []byte) error {
+	p := w.PlayerFor(client)
+	if p == nil {
+		return nil
+	}
+
+	r := bytes.NewReader(data)
+
+	guid, err := realmd.ReadPackedGuid(r)
+	if err != nil {
+		return err
+	}
+
+	// Players can only move themselves (for now)
+	if guid != p.GUID() {
+		client.Log.Warn().Uint64("guid", uint64(guid)).Msg("tried to move another unit")
+		return nil
+	}
+
+	info := &values.MovementInfo{}
+	if err := info.Read(r); err != nil {
+		return err
+	}
+
+	// Client and server MSG opcodes have the same value
+	return w.MovePlayer(p, realmd.ServerOpcode(opcode), info)
+}
+
+// FallResetHandler handles CMSG_MOVE_FALL_RESET, which is sent when a fall is interrupted. Nearby players
+// receive the movement as a heartbeat since there is no server version of the opcode.
+// https://gtker.com/wow_messages/docs/cmsg_move_fall_reset.html#client-version-335
+func FallResetHandler(w *world.World, client *realmd.Client, data []byte) error {
+	return Handler(w, client, realmd.OpClientMoveHeartbeat, data)
+}
+
+type teleportAckRequest struct {
+	Counter uint32
+	Time    uint32
+}
+
+// TeleportAckHandler handles the client acknowledging a teleport.
+// https://gtker.com/wow_messages/docs/msg_move_teleport_ack_client.html#client-version-335
+func TeleportAckHandler(w *world.World, client *realmd.Client, data []byte) error {
+	p := w.PlayerFor(client)
+	if p == nil {
+		return nil
+	}
+
+	r := bytes.NewReader(data)
+
+	if _, err := realmd.ReadPackedGuid(r); err != nil {
+		return err
+	}
+
+	req := teleportAckRequest{}
+	if err := binary.Read(r, binary.LittleEndian, &req); err != nil {
+		return err
+	}
+
+	return w.AckTeleport(p, req.Counter)
+}
+
+// WorldportAckHandler handles the client finishing loading a new map after being transferred.
+// https://gtker.com/wow_messages/docs/msg_move_worldport_ack.html
+func WorldportAckHandler(w *world.World, client *realmd.Client) error {
+	p := w.PlayerFor(client)
+	if p == nil {
+		return nil
+	}
+
+	return w.AckTransfer(p)
+}
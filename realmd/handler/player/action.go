@@ -0,0 +1,91 @@
+package player
+
+import (
+	"github.com/kangaroux/gomaggus/model"
+	"github.com/kangaroux/gomaggus/realmd"
+	"github.com/kangaroux/gomaggus/realmd/world"
+	"github.com/mixcode/binarystruct"
+)
+
+const (
+	actionMask = 0x00FFFFFF
+)
+
+type setActionButtonRequest struct {
+	Button uint8
+	// Packed stores an action in the lower 24 bits and the action type in the upper 8 bits
+	Packed uint32
+}
+
+// https://gtker.com/wow_messages/docs/cmsg_set_action_button.html#client-version-335
+func SetActionButtonHandler(svc *realmd.Service, w *world.World, client *realmd.Client, data []byte) error {
+	p := w.PlayerFor(client)
+	if p == nil {
+		return nil
+	}
+
+	req := setActionButtonRequest{}
+	if _, err := binarystruct.Unmarshal(data, binarystruct.LittleEndian, &req); err != nil {
+		return err
+	}
+
+	if req.Button >= world.ActionButtonCount {
+		client.Log.Warn().Uint8("button", req.Button).Msg("invalid action button")
+		return nil
+	}
+
+	// The client clears a button by setting it to nothing
+	if req.Packed == 0 {
+		p.Spec().Actions[req.Button] = 0
+		_, err := svc.Actions.Remove(p.Character.Id, p.ActiveSpec(), req.Button)
+		return err
+	}
+
+	button := &model.ActionButton{
+		CharacterId: p.Character.Id,
+		Spec:        p.ActiveSpec(),
+		Button:      req.Button,
+		Action:      req.Packed & actionMask,
+		Type:        model.ActionType(req.Packed >> 24),
+	}
+
+	switch button.Type {
+	case model.ActionTypeSpell:
+		if !p.KnowsSpell(button.Action) {
+			client.Log.Warn().Uint32("spell", button.Action).Msg("tried to put unknown spell on action bar")
+			return nil
+		}
+
+	case model.ActionTypeClick, model.ActionTypeEquipSet, model.ActionTypeMacro, model.ActionTypeCMacro,
+		model.ActionTypeItem:
+
+	default:
+		client.Log.Warn().Uint8("type", uint8(button.Type)).Msg("invalid action type")
+		return nil
+	}
+
+	p.Spec().Actions[req.Button] = req.Packed
+	return svc.Actions.Set(button)
+}
+
+type setActionBarTogglesRequest struct {
+	Mask uint8
+}
+
+// https://gtker.com/wow_messages/docs/cmsg_set_actionbar_toggles.html
+func SetActionBarTogglesHandler(w *world.World, client *realmd.Client, data []byte) error {
+	p := w.PlayerFor(client)
+	if p == nil {
+		return nil
+	}
+
+	req := setActionBarTogglesRequest{}
+	if _, err := binarystruct.Unmarshal(data, binarystruct.LittleEndian, &req); err != nil {
+		return err
+	}
+
+	// The client already shows the bars, this is just so they're remembered next time
+	p.Values.SetActionBarToggles(req.Mask)
+
+	return nil
+}
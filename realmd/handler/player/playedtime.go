@@ -4,6 +4,7 @@ import (
 	"encoding/binary"
 
 	"github.com/kangaroux/gomaggus/realmd"
+	"github.com/kangaroux/gomaggus/realmd/world"
 	"github.com/mixcode/binarystruct"
 )
 
@@ -17,15 +18,22 @@ type playedTimeResponse struct {
 	ShowInChat bool
 }
 
-func PlayedTimeHandler(client *realmd.Client, data []byte) error {
+// https://gtker.com/wow_messages/docs/cmsg_played_time.html
+func PlayedTimeHandler(w *world.World, client *realmd.Client, data []byte) error {
+	p := w.PlayerFor(client)
+	if p == nil {
+		return nil
+	}
+
 	req := playedTimeRequest{}
 	if _, err := binarystruct.Unmarshal(data, binary.LittleEndian, &req); err != nil {
 		return err
 	}
 
+	total, level := p.PlayedTime()
 	resp := playedTimeResponse{
-		TotalTime:  100, // TODO
-		LevelTime:  10,  // TODO
+		TotalTime:  uint32(total.Seconds()),
+		LevelTime:  uint32(level.Seconds()),
 		ShowInChat: req.ShowInChat,
 	}
 	return client.SendPacket(realmd.OpServerPlayedTime, &resp)
@@ -0,0 +1,46 @@
+package player
+
+import (
+	"github.com/kangaroux/gomaggus/realmd"
+	"github.com/kangaroux/gomaggus/realmd/world"
+	"github.com/mixcode/binarystruct"
+)
+
+type setFactionRequest struct {
+	Index uint32
+	Set   bool
+}
+
+// SetFactionAtWarHandler declares or ends war with a faction from the reputation window.
+// https://gtker.com/wow_messages/docs/cmsg_set_faction_atwar.html#client-version-335
+func SetFactionAtWarHandler(w *world.World, client *realmd.Client, data []byte) error {
+	p := w.PlayerFor(client)
+	if p == nil {
+		return nil
+	}
+
+	req := setFactionRequest{}
+	if _, err := binarystruct.Unmarshal(data, binarystruct.LittleEndian, &req); err != nil {
+		return err
+	}
+
+	w.SetFactionAtWar(p, req.Index, req.Set)
+	return nil
+}
+
+// SetFactionInactiveHandler moves a faction to or from the inactive section of the reputation window.
+// https://gtker.com/wow_messages/docs/cmsg_set_faction_inactive.html
+func SetFactionInactiveHandler(w *world.World, client *realmd.Client, data []byte) error {
+	p := w.PlayerFor(client)
+	if p == nil {
+		return nil
+	}
+
+	req := setFactionRequest{}
+	if _, err := binarystruct.Unmarshal(data, binarystruct.LittleEndian, &req); err != nil {
+		return err
+	}
+
+	w.SetFactionInactive(p, req.Index, req.Set)
+	return nil
+}
@@ -0,0 +1,148 @@
+package player
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"strings"
+
+	"github.com/kangaroux/gomaggus/realmd"
+	"github.com/kangaroux/gomaggus/realmd/world"
+)
+
+// errTooManyWhoFilters is returned when a who request has more zones or strings than the client can send.
+var errTooManyWhoFilters = errors.New("who request has too many filters")
+
+type whoRequest struct {
+	MinLevel  uint32
+	MaxLevel  uint32
+	Name      string
+	Guild     string
+	RaceMask  uint32
+	ClassMask uint32
+	Zones     []uint32
+	Strings   []string
+}
+
+// read decodes the request. The counts are checked before the zones and strings are read, so a bad count
+// can't make us allocate a huge list.
+func (req *whoRequest) read(data []byte) error {
+	r := bufio.NewReader(bytes.NewReader(data))
+
+	if err := binary.Read(r, binary.LittleEndian, &req.MinLevel); err != nil {
+		return err
+	}
+	if err := binary.Read(r, binary.LittleEndian, &req.MaxLevel); err != nil {
+		return err
+	}
+
+	for _, s := range []*string{&req.Name, &req.Guild} {
+		str, err := r.ReadString(0)
+		if err != nil {
+			return err
+		}
+		*s = strings.TrimSuffix(str, "\x00")
+	}
+
+	if err := binary.Read(r, binary.LittleEndian, &req.RaceMask); err != nil {
+		return err
+	}
+	if err := binary.Read(r, binary.LittleEndian, &req.ClassMask); err != nil {
+		return err
+	}
+
+	var zoneCount uint32
+	if err := binary.Read(r, binary.LittleEndian, &zoneCount); err != nil {
+		return err
+	} else if zoneCount > world.MaxWhoZones {
+		return errTooManyWhoFilters
+	}
+	req.Zones = make([]uint32, zoneCount)
+	if err := binary.Read(r, binary.LittleEndian, req.Zones); err != nil {
+		return err
+	}
+
+	var stringCount uint32
+	if err := binary.Read(r, binary.LittleEndian, &stringCount); err != nil {
+		return err
+	} else if stringCount > world.MaxWhoStrings {
+		return errTooManyWhoFilters
+	}
+	req.Strings = make([]string, stringCount)
+	for i := range req.Strings {
+		str, err := r.ReadString(0)
+		if err != nil {
+			return err
+		}
+		req.Strings[i] = strings.TrimSuffix(str, "\x00")
+	}
+
+	return nil
+}
+
+type whoEntry struct {
+	Name   string `binary:"zstring"`
+	Guild  string `binary:"zstring"`
+	Level  uint32
+	Class  uint32
+	Race   uint32
+	Gender uint8
+	Zone   uint32
+}
+
+type whoResponse struct {
+	DisplayCount uint32
+	MatchCount   uint32
+	Entries      []whoEntry `binary:"[DisplayCount]Any"`
+}
+
+// WhoHandler searches the online players for the who list.
+// https://gtker.com/wow_messages/docs/cmsg_who.html#client-version-335
+func WhoHandler(svc *realmd.Service, w *world.World, client *realmd.Client, data []byte) error {
+	p := w.PlayerFor(client)
+	if p == nil {
+		return nil
+	}
+
+	req := whoRequest{}
+	if err := req.read(data); errors.Is(err, errTooManyWhoFilters) {
+		client.Log.Warn().Err(err).Msg("ignoring who request")
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	query := &world.WhoQuery{
+		MinLevel:  req.MinLevel,
+		MaxLevel:  req.MaxLevel,
+		Name:      req.Name,
+		Guild:     req.Guild,
+		RaceMask:  req.RaceMask,
+		ClassMask: req.ClassMask,
+		Zones:     req.Zones,
+		Strings:   req.Strings,
+	}
+
+	entries, total := w.Who(p, query, svc.DBC)
+
+	resp := whoResponse{
+		DisplayCount: uint32(len(entries)),
+		MatchCount:   uint32(total),
+	}
+
+	for _, e := range entries {
+		resp.Entries = append(resp.Entries, whoEntry{
+			Name:   e.Name,
+			Guild:  e.Guild,
+			Level:  e.Level,
+			Class:  uint32(e.Class),
+			Race:   uint32(e.Race),
+			Gender: uint8(e.Gender),
+			Zone:   e.Zone,
+		})
+	}
+
+	// https://gtker.com/wow_messages/docs/smsg_who.html
+	return client.SendPacket(realmd.OpServerWho, &resp)
+}
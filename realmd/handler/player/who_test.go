@@ -0,0 +1,44 @@
+package player
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func whoPacket(zones []uint32, stringCount uint32, strs ...string) []byte {
+	buf := bytes.Buffer{}
+	binary.Write(&buf, binary.LittleEndian, [2]uint32{1, 80})
+	buf.WriteString("bob\x00")
+	buf.WriteString("\x00")
+	binary.Write(&buf, binary.LittleEndian, [2]uint32{0xFFFFFFFF, 0xFFFFFFFF})
+	binary.Write(&buf, binary.LittleEndian, uint32(len(zones)))
+	binary.Write(&buf, binary.LittleEndian, zones)
+	binary.Write(&buf, binary.LittleEndian, stringCount)
+	for _, s := range strs {
+		buf.WriteString(s + "\x00")
+	}
+	return buf.Bytes()
+}
+
+func TestWhoRequestRead(t *testing.T) {
+	req := whoRequest{}
+	assert.NoError(t, req.read(whoPacket([]uint32{12, 1519}, 2, "foo", "bar")))
+	assert.Equal(t, uint32(80), req.MaxLevel)
+	assert.Equal(t, "bob", req.Name)
+	assert.Equal(t, "", req.Guild)
+	assert.Equal(t, []uint32{12, 1519}, req.Zones)
+	assert.Equal(t, []string{"foo", "bar"}, req.Strings)
+
+	req = whoRequest{}
+	assert.ErrorIs(t, req.read(whoPacket(nil, 0xFFFFFFF0)), errTooManyWhoFilters)
+	assert.Nil(t, req.Strings)
+
+	data := whoPacket(nil, 0)
+	binary.LittleEndian.PutUint32(data[len(data)-8:], 0xFFFFFFF0)
+	req = whoRequest{}
+	assert.ErrorIs(t, req.read(data), errTooManyWhoFilters)
+	assert.Nil(t, req.Zones)
+}
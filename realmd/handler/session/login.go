@@ -3,14 +3,20 @@ package session
 import (
 	"bytes"
 	"database/sql"
-	"math"
 	"time"
 
 	"github.com/kangaroux/gomaggus/model"
 	"github.com/kangaroux/gomaggus/realmd"
 	"github.com/kangaroux/gomaggus/realmd/handler/account"
+	"github.com/kangaroux/gomaggus/realmd/handler/chat"
+	"github.com/kangaroux/gomaggus/realmd/handler/guild"
+	"github.com/kangaroux/gomaggus/realmd/item"
+	"github.com/kangaroux/gomaggus/realmd/mail"
+	"github.com/kangaroux/gomaggus/realmd/reputation"
+	"github.com/kangaroux/gomaggus/realmd/skill"
+	"github.com/kangaroux/gomaggus/realmd/spell"
 	"github.com/kangaroux/gomaggus/realmd/talent"
-	"github.com/kangaroux/gomaggus/realmd/values"
+	"github.com/kangaroux/gomaggus/realmd/world"
 	"github.com/mixcode/binarystruct"
 )
 
@@ -24,12 +30,39 @@ type loginFailed struct {
 	ResponseCode realmd.ResponseCode
 }
 
-func LoginHandler(svc *realmd.Service, client *realmd.Client, data []byte) error {
+// KickOtherSession removes the character the client is logging in as from the world if it's still in it from
+// another connection, and closes that connection. It returns the removed player, who must be saved with
+// SavePlayerData before LoginHandler loads the character. The world must be locked.
+func KickOtherSession(w *world.World, client *realmd.Client, data []byte) *world.Player {
+	req := loginRequest{}
+	if _, err := binarystruct.Unmarshal(data, binarystruct.LittleEndian, &req); err != nil || client.Character != nil {
+		return nil
+	}
+
+	other := w.Player(realmd.Guid(req.CharacterId))
+	if other == nil || other.Character.AccountId != client.Account.Id || other.Character.RealmId != client.Realm.Id {
+		return nil
+	}
+
+	other.Client.Log.Warn().Str("char", other.Character.String()).Msg("kicked by another login")
+	w.DetachPlayer(other)
+	other.Client.Conn.Close()
+
+	return other
+}
+
+func LoginHandler(svc *realmd.Service, w *world.World, client *realmd.Client, data []byte) error {
 	req := loginRequest{}
 	if _, err := binarystruct.Unmarshal(data, binarystruct.LittleEndian, &req); err != nil {
 		return err
 	}
 
+	// The client has to log out before logging in again
+	if client.Character != nil {
+		client.Log.Warn().Uint64("char", req.CharacterId).Msg("client tried logging in twice")
+		return client.SendPacket(realmd.OpServerCharLoginFailed, &loginFailed{ResponseCode: realmd.RespCodeCharLoginDuplicateCharacter})
+	}
+
 	char, err := svc.Characters.Get(uint32(req.CharacterId))
 	if err != nil {
 		return err
@@ -49,8 +82,25 @@ func LoginHandler(svc *realmd.Service, client *realmd.Client, data []byte) error
 		return &realmd.ErrKickClient{Reason: "invalid login"}
 	}
 
+	// KickOtherSession removes the character from the world before the client logs in, so if it's still in
+	// it, another connection logged in as it in the meantime
+	if w.Player(realmd.Guid(char.Id)) != nil {
+		client.Log.Warn().Str("char", char.String()).Msg("character is already in the world")
+		return client.SendPacket(realmd.OpServerCharLoginFailed, &loginFailed{ResponseCode: realmd.RespCodeCharLoginDuplicateCharacter})
+	}
+
 	client.Character = char
 
+	specs, err := talent.LoadSpecs(svc, char)
+	if err != nil {
+		return err
+	}
+
+	reps, err := reputation.List(svc, char)
+	if err != nil {
+		return err
+	}
+
 	if err := sendVerifyWorld(client); err != nil {
 		return err
 	}
@@ -69,30 +119,39 @@ func LoginHandler(svc *realmd.Service, client *realmd.Client, data []byte) error
 	if err := sendHearthLocation(client); err != nil {
 		return err
 	}
-	if err := sendIntroCinematic(client); err != nil {
+	if err := sendIntroCinematic(svc, client); err != nil {
 		return err
 	}
-	if err := sendTalents(client); err != nil {
+	if err := world.SendTalentsInfo(client, specs, char.ActiveSpec, uint32(char.Level)); err != nil {
 		return err
 	}
-	if err := sendInitialSpells(client); err != nil {
+	if err := sendInitialSpells(svc, client, specs); err != nil {
 		return err
 	}
-	if err := sendActionButtons(client); err != nil {
+	if err := world.SendActionButtons(client, specs[char.ActiveSpec]); err != nil {
 		return err
 	}
-	if err := sendFactionReputation(client); err != nil {
+	if err := world.SendInitializeFactions(client, reps); err != nil {
 		return err
 	}
 	if err := sendInitialWorldStates(client); err != nil {
 		return err
 	}
-	if err := sendSpawnPlayer(client); err != nil {
+	if err := sendSpawnPlayer(svc, w, client, specs, reps); err != nil {
+		return err
+	}
+	if err := chat.RejoinChannels(svc, w, w.PlayerFor(client)); err != nil {
+		return err
+	}
+	if err := guild.SignOn(svc, w, w.PlayerFor(client)); err != nil {
 		return err
 	}
 	if err := sendMOTD(client); err != nil {
 		return err
 	}
+	if err := notifyMail(svc, w, client); err != nil {
+		return err
+	}
 
 	client.Character.LastLogin = sql.NullTime{
 		Time:  time.Now(),
@@ -114,13 +173,14 @@ type verifyWorldResponse struct {
 }
 
 func sendVerifyWorld(client *realmd.Client) error {
+	char := client.Character
 	resp := verifyWorldResponse{
-		Map: 0x0, // Eastern kingdoms
+		Map: char.Map,
 		Position: realmd.Vector4{
-			X:        float32(-8949.95),
-			Y:        float32(-132.493),
-			Z:        float32(83.5312),
-			Rotation: float32(0),
+			X:        char.X,
+			Y:        char.Y,
+			Z:        char.Z,
+			Rotation: char.Orientation,
 		},
 	}
 
@@ -189,14 +249,15 @@ type hearthLocation struct {
 }
 
 func sendHearthLocation(client *realmd.Client) error {
+	char := client.Character
 	resp := hearthLocation{
 		Position: realmd.Vector3{
-			X: float32(-8949.95),
-			Y: float32(-132.493),
-			Z: float32(83.5312),
+			X: char.HomeX,
+			Y: char.HomeY,
+			Z: char.HomeZ,
 		},
-		Map:  0x0, // Eastern kingdoms
-		Area: 0xB, // Elwynn forest
+		Map:  char.HomeMap,
+		Area: char.HomeZone,
 	}
 	return client.SendPacket(realmd.OpServerHearthLocation, &resp)
 }
@@ -206,153 +267,96 @@ type playCinematic struct {
 	CinematicId uint32
 }
 
-func sendIntroCinematic(client *realmd.Client) error {
+func sendIntroCinematic(svc *realmd.Service, client *realmd.Client) error {
 	// Only play the cinematic on first login
 	if client.Character.LastLogin.Valid {
 		return nil
 	}
 
-	resp := playCinematic{CinematicId: 81} // 81 = human
-	return client.SendPacket(realmd.OpServerPlayCinematic, &resp)
-}
-
-type talentInfo struct {
-	ID      talent.Talent
-	MaxRank uint8
-}
-
-type talentSpec struct {
-	TalentCount uint8
-	Talents     []talentInfo `binary:"[TalentCount]Any"`
-	GlyphCount  uint8
-	Glyphs      []uint16 `binary:"[GlyphCount]uint16"`
-}
+	char := client.Character
+	resp := playCinematic{}
 
-type playerTalentsResponse struct {
-	TalentType    uint8
-	UnspentPoints uint32
-	SpecCount     uint8
-	ActiveSpec    uint8
-	Specs         []talentSpec `binary:"[SpecCount]Any"`
-}
+	// Some classes have their own intro instead of the race's
+	if class, ok := svc.DBC.ChrClasses[char.Class]; ok && class.CinematicSequence != 0 {
+		resp.CinematicId = class.CinematicSequence
+	} else if race, ok := svc.DBC.ChrRaces[char.Race]; ok {
+		resp.CinematicId = race.CinematicSequence
+	}
 
-func sendTalents(client *realmd.Client) error {
-	resp := playerTalentsResponse{
-		TalentType:    0, // player=0, pet=1
-		UnspentPoints: 0,
-		SpecCount:     1,
-		ActiveSpec:    0,
-		Specs: []talentSpec{{
-			TalentCount: 0,
-			GlyphCount:  0,
-		}},
+	if resp.CinematicId == 0 {
+		return nil
 	}
 
-	return client.SendPacket(realmd.OpServerPlayerTalents, &resp)
+	return client.SendPacket(realmd.OpServerPlayCinematic, &resp)
 }
 
-type spell struct {
+type initialSpell struct {
 	SpellID uint32
 	Unknown uint16 // gophercraft has this as TargetFlags
 }
 
+type initialCooldown struct {
+	SpellID          uint32
+	ItemID           uint16
+	Category         uint16
+	Cooldown         uint32
+	CategoryCooldown uint32
+}
+
 type initialSpellsResponse struct {
 	Unknown       uint8 // gophercraft has this as TalentSpec but it's unused
 	SpellCount    uint16
-	Spells        []spell `binary:"[SpellCount]Any"`
+	Spells        []initialSpell `binary:"[SpellCount]Any"`
 	CooldownCount uint16
-	// TODO: CooldownSpells
+	Cooldowns     []initialCooldown `binary:"[CooldownCount]Any"`
 }
 
 // https://gtker.com/wow_messages/docs/smsg_initial_spells.html#client-version-335
-func sendInitialSpells(client *realmd.Client) error {
-	spells := []spell{
-		{
-			SpellID: 668, // Language (common)
-			Unknown: 0,
-		},
-		{
-			SpellID: 669, // Language (orcish)
-			Unknown: 0,
-		},
-		{
-			SpellID: 122, // Frost nova
-			Unknown: 0,
-		},
-	}
-	resp := initialSpellsResponse{
-		Unknown:       0, // always zero
-		SpellCount:    uint16(len(spells)),
-		Spells:        spells,
-		CooldownCount: 0,
+func sendInitialSpells(svc *realmd.Service, client *realmd.Client, specs []*world.TalentSpec) error {
+	ids, err := spell.Known(svc, client.Character)
+	if err != nil {
+		return err
 	}
+	ids = append(ids, world.TalentSpells(specs, client.Character.ActiveSpec)...)
 
-	return client.SendPacket(realmd.OpServerInitialSpells, &resp)
-}
-
-type actionButtonSetType uint8
-
-const (
-	buttonsInitial actionButtonSetType = 0 // Unused
-	buttonsSet     actionButtonSetType = 1
-	buttonsClear   actionButtonSetType = 2
-)
-
-type actionButton struct {
-	// ActionPacked stores an action in the lower 24 bits and the action type in the upper 8 bits
-	ActionPacked uint32
-}
-
-const (
-	actionButtonCount = 144
-)
-
-// actionButtonSetResponse will overwrite all of the player's action bars
-type actionButtonSetResponse struct {
-	Type    actionButtonSetType
-	Buttons [actionButtonCount]actionButton
-}
-
-// actionButtonClearResponse will clear all of the player's action bars
-type actionButtonClearResponse struct {
-	Type actionButtonSetType
-}
-
-// https://gtker.com/wow_messages/docs/smsg_action_buttons.html#client-version-335
-func sendActionButtons(client *realmd.Client) error {
-	resp := actionButtonSetResponse{
-		// Trinity says there were issues using Initial, so Set is used instead
-		Type: buttonsSet,
-		// TODO: send buttons
+	cooldowns, err := svc.Spells.ListCooldowns(client.Character.Id)
+	if err != nil {
+		return err
 	}
-	return client.SendPacket(realmd.OpServerActionButtons, &resp)
-}
 
-const (
-	FactionCount = 128
-)
-
-type faction struct {
-	Flags    uint8
-	Standing uint32
-}
+	spells := make([]initialSpell, 0, len(ids))
+	for _, id := range ids {
+		spells = append(spells, initialSpell{SpellID: id})
+	}
 
-type factionReputationResponse struct {
-	Count   uint32
-	Faction [FactionCount]faction
-}
+	now := time.Now()
+	remaining := func(t time.Time) uint32 {
+		if t.Before(now) {
+			return 0
+		}
+		return uint32(t.Sub(now).Milliseconds())
+	}
 
-// https://gtker.com/wow_messages/docs/smsg_initialize_factions.html#client-version-3
-func sendFactionReputation(client *realmd.Client) error {
-	resp := factionReputationResponse{
-		Count: FactionCount,
+	resp := initialSpellsResponse{
+		Unknown:       0, // always zero
+		SpellCount:    uint16(len(spells)),
+		Spells:        spells,
+		CooldownCount: uint16(len(cooldowns)),
 	}
 
-	for _, f := range resp.Faction {
-		f.Flags = 0x1 // visible
+	for _, cd := range cooldowns {
+		entry := initialCooldown{
+			SpellID:  cd.SpellId,
+			Category: uint16(cd.Category),
+			Cooldown: remaining(cd.ExpiresAt),
+		}
+		if cd.Category != 0 {
+			entry.CategoryCooldown = remaining(cd.CategoryExpiresAt)
+		}
+		resp.Cooldowns = append(resp.Cooldowns, entry)
 	}
 
-	return client.SendPacket(realmd.OpServerFactionReputation, &resp)
+	return client.SendPacket(realmd.OpServerInitialSpells, &resp)
 }
 
 type worldState struct {
@@ -374,99 +378,46 @@ type initialWorldStateResponse struct {
 // https://gtker.com/wow_messages/docs/smsg_init_world_states.html#client-version-335
 func sendInitialWorldStates(client *realmd.Client) error {
 	resp := initialWorldStateResponse{
-		Map:  0,  // Eastern kingdoms
-		Area: 12, // Elwynn Forest
+		Map:  client.Character.Map,
+		Area: client.Character.Zone,
 	}
 	return client.SendPacket(realmd.OpServerInitialWorldStates, &resp)
 }
 
-// https://gtker.com/wow_messages/docs/smsg_update_object.html#client-version-335
-// TODO: need a builder for these packets
-func sendSpawnPlayer(client *realmd.Client) error {
-	char := client.Character
-	inner := bytes.Buffer{}
-	inner.Write([]byte{1, 0, 0, 0}) // number of objects
-
-	// nested object start
-	inner.WriteByte(byte(values.UpdateTypeCreateObject))
-	inner.Write(realmd.PackGuid(uint64(char.Id)))
-	inner.WriteByte(byte(values.ObjectTypePlayer))
-
-	movement := values.MovementValues{}
-	movement.Self()
-
-	living := movement.Living()
-	living.Data(&values.LivingData{
-		Timestamp: uint32(time.Now().UnixMilli()),
-		PositionRotation: realmd.Vector4{
-			X:        float32(-8949.95),
-			Y:        float32(-132.493),
-			Z:        float32(83.5312),
-			Rotation: float32(0),
-		},
-		FallTime:           0,
-		WalkSpeed:          1,
-		RunSpeed:           70,
-		ReverseSpeed:       4.5,
-		SwimSpeed:          0,
-		SwimReverseSpeed:   0,
-		FlightSpeed:        0,
-		FlightReverseSpeed: 0,
-		TurnRate:           math.Pi,
-		PitchRate:          0,
-	})
-
-	inner.Write(movement.Bytes())
-
-	v := values.NewPlayer()
-	v.SetGUID(realmd.Guid(char.Id))
-	v.SetType(values.ObjectTypeObject, values.ObjectTypeUnit, values.ObjectTypePlayer)
-	v.SetScaleX(1)
-
-	v.SetRace(char.Race)
-	v.SetClass(char.Class)
-	v.SetGender(char.Gender)
-	v.SetPowerType(realmd.PowerTypeForClass(char.Class))
-	v.SetHealth(100)
-	v.SetMaxHealth(100)
-	v.SetLevel(60)
-	v.SetFaction(uint32(char.Race))
-	v.SetDisplayID(0x4D0C)       // human female
-	v.SetNativeDisplayID(0x4D0C) // human female
-	v.SetPlayerControlled(true)
-	v.SetAurasVisible(true)
-	v.SetAgility(10)
-	v.SetIntellect(10)
-	v.SetStamina(10)
-	v.SetStrength(10)
-	v.SetSpirit(10)
-
-	v.SetFace(char.Face)
-	v.SetSkin(char.SkinColor)
-	v.SetHairStyle(char.HairStyle)
-	v.SetHairColor(char.HairColor)
-	v.SetExtraCosmetic(char.ExtraCosmetic)
-	v.SetBankBagSlotCount(8)
-	v.SetExperience(0)
-	v.SetNextLevelExperience(100)
-	v.SetWealth(0)
-
-	var skills [128]values.SkillEntry
-	skills[0] = values.SkillEntry{
-		ID:         98, // Language (Common)
-		SkillLevel: 300,
-		SkillCap:   300,
-	}
-	skills[1] = values.SkillEntry{
-		ID:         109, // Language (Orcish)
-		SkillLevel: 300,
-		SkillCap:   300,
-	}
-	v.SetSkills(skills)
-
-	inner.Write(v.Marshal(true))
-
-	return client.SendPacketBytes(realmd.OpServerUpdateObject, inner.Bytes())
+// sendSpawnPlayer loads the player's items and spells and adds the player to the world, which spawns them for
+// themselves and nearby players. The player's friends are told they are online, and the player is sent their
+// friend and ignore lists.
+func sendSpawnPlayer(svc *realmd.Service, w *world.World, client *realmd.Client, specs []*world.TalentSpec, reps []*world.Reputation) error {
+	p, err := world.NewPlayer(client, client.Character, svc.DBC)
+	if err != nil {
+		return err
+	}
+
+	contacts, err := svc.Contacts.List(client.Character.Id)
+	if err != nil {
+		return err
+	}
+	p.SetContacts(contacts)
+
+	if err := item.Load(svc, p); err != nil {
+		return err
+	}
+
+	if err := spell.Load(svc, p); err != nil {
+		return err
+	}
+
+	if err := skill.Load(svc, p); err != nil {
+		return err
+	}
+	p.SetSpecs(specs, client.Character.ActiveSpec)
+	p.SetReputations(reps)
+
+	if err := w.AddPlayer(p); err != nil {
+		return err
+	}
+
+	return w.SendContactList(p, model.ContactFlagFriend|model.ContactFlagIgnored|model.ContactFlagMuted)
 }
 
 type motdResponse struct {
@@ -485,3 +436,17 @@ func sendMOTD(client *realmd.Client) error {
 	}
 	return client.SendPacket(realmd.OpServerMOTD, &resp)
 }
+
+// notifyMail tells the player if they have unread mail.
+func notifyMail(svc *realmd.Service, w *world.World, client *realmd.Client) error {
+	mailbox, err := svc.Mail.List(client.Character.Id)
+	if err != nil {
+		return err
+	}
+
+	if mail.HasUnread(mailbox) {
+		mail.Notify(w, client.Character.Id)
+	}
+
+	return nil
+}
@@ -4,7 +4,10 @@ import (
 	"context"
 	"time"
 
+	"github.com/kangaroux/gomaggus/model"
 	"github.com/kangaroux/gomaggus/realmd"
+	"github.com/kangaroux/gomaggus/realmd/values"
+	"github.com/kangaroux/gomaggus/realmd/world"
 )
 
 type logoutResult uint32
@@ -26,32 +29,68 @@ const (
 	logoutDelay = time.Second * 20
 )
 
-func LogoutHandler(client *realmd.Client) error {
-	// TODO: lookup player in world, check rested state
-	resp := logoutResponse{Result: logoutSuccess, Instant: true}
+// Players can't log out while they're fighting, frozen or falling. They log out right away in a rest area or
+// if they're a GM, otherwise they have to wait.
+// https://gtker.com/wow_messages/docs/cmsg_logout_request.html
+func LogoutHandler(w *world.World, client *realmd.Client) error {
+	p := w.PlayerFor(client)
+	if p == nil || client.LogoutPending {
+		return nil
+	}
+
+	resp := logoutResponse{Result: logoutSuccess}
+	switch {
+	case p.Values.InCombat():
+		resp.Result = logoutFailInCombat
+	case p.Frozen():
+		resp.Result = logoutFailFrozenByGM
+	case p.Movement.HasFlag(values.LivingMovementFlagFalling):
+		resp.Result = logoutFailNotOnGround
+	default:
+		resp.Instant = p.Values.Resting() || p.GMLevel() > model.GMLevelPlayer
+	}
+
 	if err := client.SendPacket(realmd.OpServerLogout, &resp); err != nil {
 		return err
 	}
 
-	if resp.Instant {
-		return completeLogout(client)
-	} else {
-		go logoutAfterDelay(context.TODO(), client)
+	if resp.Result != logoutSuccess {
+		return nil
+	} else if resp.Instant {
+		return completeLogout(w, client)
 	}
 
+	w.StartLogout(p)
+
+	// The logout is pending as soon as the client is told, so it can be cancelled right away
+	ctx, cancel := context.WithCancel(context.Background())
+	client.CancelPendingLogout = cancel
+	client.LogoutPending = true
+	go logoutAfterDelay(ctx, w, client)
+
 	return nil
 }
 
-func LogoutCancelHandler(client *realmd.Client) error {
-	client.CancelPendingLogout()
+// https://gtker.com/wow_messages/docs/cmsg_logout_cancel.html
+func LogoutCancelHandler(w *world.World, client *realmd.Client) error {
+	if client.LogoutPending {
+		client.CancelPendingLogout()
+		client.LogoutPending = false
+		if p := w.PlayerFor(client); p != nil {
+			w.CancelLogout(p)
+		}
+	}
 
 	// Always send an ACK even if there was no logout pending.
 	return client.SendPacket(realmd.OpServerLogoutCancelACK, nil)
 }
 
 // completeLogout notifies the client they should logout (or exit game) immediately.
-func completeLogout(client *realmd.Client) error {
-	// TODO: remove player from world
+func completeLogout(w *world.World, client *realmd.Client) error {
+	if p := w.PlayerFor(client); p != nil {
+		w.RemovePlayer(p)
+	}
+
 	if err := client.SendPacket(realmd.OpServerLogoutComplete, nil); err != nil {
 		return err
 	}
@@ -63,26 +102,28 @@ func completeLogout(client *realmd.Client) error {
 }
 
 // logoutAfterDelay notifies the client to logout after a delay. During that delay, the logout is
-// considered pending and can be cancelled by calling client.CancelPendingLogout.
-func logoutAfterDelay(ctx context.Context, client *realmd.Client) {
-	ctx, cancel := context.WithCancel(ctx)
-
-	client.CancelPendingLogout = cancel
-	client.LogoutPending = true
-
-	defer func() {
-		// The client is either logged out or the logout was cancelled
-		client.LogoutPending = false
-
-		// Cleanup context
-		cancel()
-	}()
+// considered pending and can be cancelled by cancelling ctx with client.CancelPendingLogout.
+func logoutAfterDelay(ctx context.Context, w *world.World, client *realmd.Client) {
+	timer := time.NewTimer(logoutDelay)
+	defer timer.Stop()
 
 	select {
 	case <-ctx.Done():
 		return
-	case <-time.After(logoutDelay):
-		// TODO: handle error
-		completeLogout(client)
+	case <-timer.C:
+	}
+
+	w.Lock()
+	defer w.Unlock()
+
+	// The logout could have been cancelled while waiting for the lock
+	if ctx.Err() != nil {
+		return
+	}
+	client.LogoutPending = false
+	client.CancelPendingLogout()
+
+	if err := completeLogout(w, client); err != nil {
+		client.Log.Error().Err(err).Msg("error logging out")
 	}
 }
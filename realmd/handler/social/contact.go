@@ -0,0 +1,145 @@
+package social
+
+import (
+	"github.com/kangaroux/gomaggus/model"
+	"github.com/kangaroux/gomaggus/realmd"
+	"github.com/kangaroux/gomaggus/realmd/world"
+	"github.com/mixcode/binarystruct"
+)
+
+type contactListRequest struct {
+	Flags model.ContactFlags
+}
+
+// ContactListHandler sends the player their friend and ignore lists.
+// https://gtker.com/wow_messages/docs/cmsg_contact_list.html#client-version-335
+func ContactListHandler(w *world.World, client *realmd.Client, data []byte) error {
+	p := w.PlayerFor(client)
+	if p == nil {
+		return nil
+	}
+
+	req := contactListRequest{}
+	if _, err := binarystruct.Unmarshal(data, binarystruct.LittleEndian, &req); err != nil {
+		return err
+	}
+
+	return w.SendContactList(p, req.Flags)
+}
+
+type addFriendRequest struct {
+	Name string `binary:"zstring"`
+	Note string `binary:"zstring"`
+}
+
+// AddFriendHandler adds a character to the player's friend list. The character doesn't need to be online.
+// https://gtker.com/wow_messages/docs/cmsg_add_friend.html#client-version-335
+func AddFriendHandler(svc *realmd.Service, w *world.World, client *realmd.Client, data []byte) error {
+	p := w.PlayerFor(client)
+	if p == nil {
+		return nil
+	}
+
+	req := addFriendRequest{}
+	if _, err := binarystruct.Unmarshal(data, binarystruct.LittleEndian, &req); err != nil {
+		return err
+	}
+
+	target, err := svc.Characters.GetName(req.Name, client.Realm.Id)
+	if err != nil {
+		return err
+	}
+
+	return saveContact(svc, w.AddFriend(p, target, req.Note, svc.DBC))
+}
+
+type addIgnoreRequest struct {
+	Name string `binary:"zstring"`
+}
+
+// AddIgnoreHandler adds a character to the player's ignore list. The character doesn't need to be online.
+// https://gtker.com/wow_messages/docs/cmsg_add_ignore.html#client-version-335
+func AddIgnoreHandler(svc *realmd.Service, w *world.World, client *realmd.Client, data []byte) error {
+	p := w.PlayerFor(client)
+	if p == nil {
+		return nil
+	}
+
+	req := addIgnoreRequest{}
+	if _, err := binarystruct.Unmarshal(data, binarystruct.LittleEndian, &req); err != nil {
+		return err
+	}
+
+	target, err := svc.Characters.GetName(req.Name, client.Realm.Id)
+	if err != nil {
+		return err
+	}
+
+	return saveContact(svc, w.AddIgnore(p, target))
+}
+
+type removeContactRequest struct {
+	Guid realmd.Guid
+}
+
+// RemoveContactHandler removes a character from the player's friend or ignore list.
+// https://gtker.com/wow_messages/docs/cmsg_del_friend.html#client-version-335
+// https://gtker.com/wow_messages/docs/cmsg_del_ignore.html#client-version-335
+func RemoveContactHandler(svc *realmd.Service, w *world.World, client *realmd.Client, opcode realmd.ClientOpcode, data []byte) error {
+	p := w.PlayerFor(client)
+	if p == nil {
+		return nil
+	}
+
+	req := removeContactRequest{}
+	if _, err := binarystruct.Unmarshal(data, binarystruct.LittleEndian, &req); err != nil {
+		return err
+	}
+
+	var c *model.Contact
+
+	switch opcode {
+	case realmd.OpClientDelFriend:
+		c = w.RemoveFriend(p, req.Guid)
+	case realmd.OpClientDelIgnore:
+		c = w.RemoveIgnore(p, req.Guid)
+	}
+
+	return saveContact(svc, c)
+}
+
+type setContactNotesRequest struct {
+	Guid realmd.Guid
+	Note string `binary:"zstring"`
+}
+
+// SetContactNotesHandler changes the note the player has for one of their friends.
+// https://gtker.com/wow_messages/docs/cmsg_set_contact_notes.html#client-version-335
+func SetContactNotesHandler(svc *realmd.Service, w *world.World, client *realmd.Client, data []byte) error {
+	p := w.PlayerFor(client)
+	if p == nil {
+		return nil
+	}
+
+	req := setContactNotesRequest{}
+	if _, err := binarystruct.Unmarshal(data, binarystruct.LittleEndian, &req); err != nil {
+		return err
+	}
+
+	return saveContact(svc, w.SetContactNote(p, req.Guid, req.Note))
+}
+
+// saveContact saves the changes to c, or deletes it if it's no longer a friend or ignored. c is nil if
+// nothing changed.
+func saveContact(svc *realmd.Service, c *model.Contact) error {
+	if c == nil {
+		return nil
+	}
+
+	if c.Flags == 0 {
+		_, err := svc.Contacts.Delete(c.CharacterId, c.ContactId)
+		return err
+	}
+
+	return svc.Contacts.Save(c)
+}
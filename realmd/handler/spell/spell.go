@@ -0,0 +1,111 @@
+package spell
+
+import (
+	"bytes"
+	"encoding/binary"
+
+	"github.com/kangaroux/gomaggus/realmd"
+	"github.com/kangaroux/gomaggus/realmd/spell"
+	"github.com/kangaroux/gomaggus/realmd/world"
+	"github.com/mixcode/binarystruct"
+)
+
+type castRequest struct {
+	Count     uint8
+	Spell     uint32
+	CastFlags uint8
+}
+
+// CastHandler starts casting a spell. Missile trajectories sent after the targets are ignored.
+// https://gtker.com/wow_messages/docs/cmsg_cast_spell.html#client-version-335
+func CastHandler(svc *realmd.Service, w *world.World, client *realmd.Client, data []byte) error {
+	p := w.PlayerFor(client)
+	if p == nil {
+		return nil
+	}
+
+	r := bytes.NewReader(data)
+
+	req := castRequest{}
+	if err := binary.Read(r, binary.LittleEndian, &req); err != nil {
+		return err
+	}
+
+	targets, err := world.ReadSpellTargets(r)
+	if err != nil {
+		return err
+	}
+
+	s, ok := svc.DBC.Spells[req.Spell]
+	if !ok {
+		client.Log.Warn().Uint32("spell", req.Spell).Msg("tried to cast unknown spell")
+		return nil
+	}
+
+	w.CastSpell(p, spell.NewCast(svc, p, s, req.Count, targets))
+	return nil
+}
+
+type cancelCastRequest struct {
+	Count uint8
+	Spell uint32
+}
+
+// CancelCastHandler stops casting a spell.
+// https://gtker.com/wow_messages/docs/cmsg_cancel_cast.html#client-version-335
+func CancelCastHandler(w *world.World, client *realmd.Client, data []byte) error {
+	p := w.PlayerFor(client)
+	if p == nil {
+		return nil
+	}
+
+	req := cancelCastRequest{}
+	if _, err := binarystruct.Unmarshal(data, binarystruct.LittleEndian, &req); err != nil {
+		return err
+	}
+
+	w.CancelCast(p, req.Spell)
+	return nil
+}
+
+type cancelChannellingRequest struct {
+	Spell uint32
+}
+
+// CancelChannellingHandler stops channeling a spell.
+// https://gtker.com/wow_messages/docs/cmsg_cancel_channelling.html#client-version-335
+func CancelChannellingHandler(w *world.World, client *realmd.Client, data []byte) error {
+	p := w.PlayerFor(client)
+	if p == nil {
+		return nil
+	}
+
+	req := cancelChannellingRequest{}
+	if _, err := binarystruct.Unmarshal(data, binarystruct.LittleEndian, &req); err != nil {
+		return err
+	}
+
+	w.CancelChannel(p, req.Spell)
+	return nil
+}
+
+type cancelAuraRequest struct {
+	Spell uint32
+}
+
+// CancelAuraHandler removes one of the player's buffs.
+// https://gtker.com/wow_messages/docs/cmsg_cancel_aura.html
+func CancelAuraHandler(w *world.World, client *realmd.Client, data []byte) error {
+	p := w.PlayerFor(client)
+	if p == nil {
+		return nil
+	}
+
+	req := cancelAuraRequest{}
+	if _, err := binarystruct.Unmarshal(data, binarystruct.LittleEndian, &req); err != nil {
+		return err
+	}
+
+	w.CancelAura(p, req.Spell)
+	return nil
+}
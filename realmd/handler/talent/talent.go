@@ -0,0 +1,139 @@
+package talent
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+
+	"github.com/kangaroux/gomaggus/realmd"
+	"github.com/kangaroux/gomaggus/realmd/talent"
+	"github.com/kangaroux/gomaggus/realmd/world"
+	"github.com/mixcode/binarystruct"
+)
+
+type learnTalentRequest struct {
+	Talent uint32
+	Rank   uint32
+}
+
+// LearnTalentHandler learns a rank of a talent. The client sends this when previewing talents is turned off.
+// https://gtker.com/wow_messages/docs/cmsg_learn_talent.html#client-version-335
+func LearnTalentHandler(svc *realmd.Service, w *world.World, client *realmd.Client, data []byte) error {
+	p := w.PlayerFor(client)
+	if p == nil {
+		return nil
+	}
+
+	req := learnTalentRequest{}
+	if _, err := binarystruct.Unmarshal(data, binarystruct.LittleEndian, &req); err != nil {
+		return err
+	}
+
+	if _, err := talent.Learn(svc, w, p, req.Talent, uint8(req.Rank)); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// errTooManyPreviewTalents is returned when a preview has more talents than a player could ever learn.
+var errTooManyPreviewTalents = errors.New("too many talents in preview")
+
+type learnPreviewTalentsRequest struct {
+	Talents []learnTalentRequest
+}
+
+// read decodes the request. The count is checked before the talents are read, so a bad count can't make us
+// allocate a huge list. Every talent costs at least one point, so a preview can't have more talents than
+// there are talent points.
+func (req *learnPreviewTalentsRequest) read(data []byte) error {
+	r := bytes.NewReader(data)
+
+	var count uint32
+	if err := binary.Read(r, binary.LittleEndian, &count); err != nil {
+		return err
+	} else if count > world.MaxTalentPoints {
+		return errTooManyPreviewTalents
+	}
+
+	req.Talents = make([]learnTalentRequest, count)
+	return binary.Read(r, binary.LittleEndian, req.Talents)
+}
+
+// LearnPreviewTalentsHandler learns all of the talents the player picked while previewing them. The talents
+// are learned in order, so later talents can depend on earlier ones.
+// https://gtker.com/wow_messages/docs/cmsg_learn_preview_talents.html
+func LearnPreviewTalentsHandler(svc *realmd.Service, w *world.World, client *realmd.Client, data []byte) error {
+	p := w.PlayerFor(client)
+	if p == nil {
+		return nil
+	}
+
+	req := learnPreviewTalentsRequest{}
+	if err := req.read(data); errors.Is(err, errTooManyPreviewTalents) {
+		client.Log.Warn().Err(err).Msg("ignoring talent preview")
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	for _, t := range req.Talents {
+		learned, err := talent.Learn(svc, w, p, t.Talent, uint8(t.Rank))
+		if err != nil {
+			return err
+		}
+		if !learned {
+			break
+		}
+	}
+
+	return nil
+}
+
+type talentWipeConfirmRequest struct {
+	Trainer realmd.Guid
+}
+
+// TalentWipeConfirmHandler resets the player's talents after they agreed to pay for it.
+// https://gtker.com/wow_messages/docs/msg_talent_wipe_confirm.html#client-version-335
+func TalentWipeConfirmHandler(svc *realmd.Service, w *world.World, client *realmd.Client, data []byte) error {
+	p := w.PlayerFor(client)
+	if p == nil {
+		return nil
+	}
+
+	req := talentWipeConfirmRequest{}
+	if _, err := binarystruct.Unmarshal(data, binarystruct.LittleEndian, &req); err != nil {
+		return err
+	}
+
+	// TODO: check that the trainer is nearby once there are NPCs
+	if _, err := talent.Reset(svc, w, p); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+type removeGlyphRequest struct {
+	Slot uint32
+}
+
+// https://gtker.com/wow_messages/docs/cmsg_remove_glyph.html
+func RemoveGlyphHandler(svc *realmd.Service, w *world.World, client *realmd.Client, data []byte) error {
+	p := w.PlayerFor(client)
+	if p == nil {
+		return nil
+	}
+
+	req := removeGlyphRequest{}
+	if _, err := binarystruct.Unmarshal(data, binarystruct.LittleEndian, &req); err != nil {
+		return err
+	}
+
+	if req.Slot >= world.MaxGlyphSlots {
+		return nil
+	}
+
+	return talent.RemoveGlyph(svc, w, p, uint8(req.Slot))
+}
@@ -0,0 +1,27 @@
+package talent
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+
+	"github.com/kangaroux/gomaggus/realmd/world"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLearnPreviewTalentsRead(t *testing.T) {
+	buf := bytes.Buffer{}
+	binary.Write(&buf, binary.LittleEndian, []uint32{2, 23, 0, 24, 1})
+
+	req := learnPreviewTalentsRequest{}
+	assert.NoError(t, req.read(buf.Bytes()))
+	assert.Equal(t, []learnTalentRequest{{23, 0}, {24, 1}}, req.Talents)
+
+	req = learnPreviewTalentsRequest{}
+	assert.ErrorIs(t, req.read([]byte{0xF0, 0xFF, 0xFF, 0xFF}), errTooManyPreviewTalents)
+	assert.Nil(t, req.Talents)
+
+	buf.Reset()
+	binary.Write(&buf, binary.LittleEndian, uint32(world.MaxTalentPoints+1))
+	assert.ErrorIs(t, req.read(buf.Bytes()), errTooManyPreviewTalents)
+}
@@ -0,0 +1,40 @@
+package world
+
+import (
+	"github.com/kangaroux/gomaggus/realmd"
+	"github.com/kangaroux/gomaggus/realmd/world"
+	"github.com/mixcode/binarystruct"
+)
+
+type areaTriggerRequest struct {
+	Trigger uint32
+}
+
+// https://gtker.com/wow_messages/docs/cmsg_areatrigger.html
+func AreaTriggerHandler(svc *realmd.Service, w *world.World, client *realmd.Client, data []byte) error {
+	p := w.PlayerFor(client)
+	if p == nil {
+		return nil
+	}
+
+	req := areaTriggerRequest{}
+	if _, err := binarystruct.Unmarshal(data, binarystruct.LittleEndian, &req); err != nil {
+		return err
+	}
+
+	trigger, ok := svc.DBC.AreaTriggers[req.Trigger]
+	if !ok {
+		return nil
+	}
+
+	// The client decides when it's in a trigger, so make sure it's actually there
+	pos := p.Position()
+	if !trigger.Contains(p.MapID, pos.X, pos.Y, pos.Z) {
+		client.Log.Warn().Uint32("trigger", trigger.ID).Msg("player isn't in area trigger")
+		return nil
+	}
+
+	w.EnterInn(p, trigger)
+
+	return nil
+}
@@ -0,0 +1,39 @@
+package world
+
+import (
+	"github.com/kangaroux/gomaggus/realmd"
+	"github.com/kangaroux/gomaggus/realmd/world"
+	"github.com/mixcode/binarystruct"
+)
+
+type zoneUpdateRequest struct {
+	Zone uint32
+}
+
+// https://gtker.com/wow_messages/docs/cmsg_zoneupdate.html
+func ZoneUpdateHandler(svc *realmd.Service, w *world.World, client *realmd.Client, data []byte) error {
+	p := w.PlayerFor(client)
+	if p == nil {
+		return nil
+	}
+
+	req := zoneUpdateRequest{}
+	if _, err := binarystruct.Unmarshal(data, binarystruct.LittleEndian, &req); err != nil {
+		return err
+	}
+
+	// The client decides which zone it's in, so make sure it's actually there
+	zone := svc.DBC.Areas[req.Zone]
+	zoneMap := svc.DBC.ZoneMap(zone)
+	pos := p.Position()
+	if zoneMap == nil || !zoneMap.Contains(p.MapID, pos.X, pos.Y) {
+		client.Log.Warn().Uint32("zone", req.Zone).Msg("player isn't in zone")
+		return nil
+	}
+
+	p.Zone = req.Zone
+	w.UpdateZoneChannels(p, zone)
+	w.UpdateZoneRest(p, zone, zoneMap)
+
+	return w.ExploreArea(p, zone)
+}
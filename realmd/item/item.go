@@ -0,0 +1,174 @@
+// Package item loads and saves the items in players' inventories. The world moves items around, and the
+// changes it returns are saved with Save.
+package item
+
+import (
+	"bytes"
+	"encoding/binary"
+
+	"github.com/kangaroux/gomaggus/model"
+	"github.com/kangaroux/gomaggus/realmd"
+	"github.com/kangaroux/gomaggus/realmd/world"
+)
+
+// Load loads the character's items and puts them in the player's inventory. It should be called before the
+// player is added to the world.
+func Load(svc *realmd.Service, p *world.Player) error {
+	items, err := svc.Items.List(p.Character.Id)
+	if err != nil {
+		return err
+	}
+
+	ids := make([]uint32, 0, len(items))
+	for _, m := range items {
+		ids = append(ids, m.TemplateId)
+	}
+
+	templates, err := svc.Items.ListTemplates(ids)
+	if err != nil {
+		return err
+	}
+
+	byId := make(map[uint32]*model.ItemTemplate, len(templates))
+	for _, t := range templates {
+		byId[t.Id] = t
+	}
+
+	inventory := make([]*world.Item, 0, len(items))
+	for _, m := range items {
+		t, ok := byId[m.TemplateId]
+		if !ok {
+			p.Client.Log.Warn().Uint32("item", m.Id).Uint32("template", m.TemplateId).Msg("item template doesn't exist")
+			continue
+		}
+		inventory = append(inventory, world.NewItem(m, t, p.GUID()))
+	}
+
+	for _, item := range p.SetInventory(inventory) {
+		p.Client.Log.Warn().Uint32("item", item.Id).Uint32("container", item.ContainerId).
+			Uint8("slot", item.Slot).Msg("item is in an invalid slot")
+	}
+
+	return nil
+}
+
+// StartOutfit returns the items in a new character's starting outfit. The items aren't saved, and their
+// owner is set when the character is created. Items without a template are skipped.
+func StartOutfit(svc *realmd.Service, client *realmd.Client, char *model.Character) ([]*model.Item, error) {
+	outfit := svc.DBC.StartOutfit(char.Race, char.Class, char.Gender)
+	if outfit == nil || outfit.Outfit != char.OutfitId {
+		return nil, nil
+	}
+
+	var ids []uint32
+	for _, id := range outfit.ItemID {
+		if id > 0 {
+			ids = append(ids, uint32(id))
+		}
+	}
+
+	templates, err := svc.Items.ListTemplates(ids)
+	if err != nil {
+		return nil, err
+	}
+
+	byId := make(map[uint32]*model.ItemTemplate, len(templates))
+	for _, t := range templates {
+		byId[t.Id] = t
+	}
+
+	// Keep the outfit's order so items end up in the same slots every time
+	ordered := make([]*model.ItemTemplate, 0, len(ids))
+	for _, id := range ids {
+		if t, ok := byId[id]; ok {
+			ordered = append(ordered, t)
+		} else {
+			client.Log.Warn().Uint32("template", id).Msg("starting item template doesn't exist")
+		}
+	}
+
+	return world.StartingItems(char.Id, ordered), nil
+}
+
+// Save saves the items that were changed and deletes the items that were removed. Does nothing if changes
+// is nil.
+func Save(svc *realmd.Service, changes *world.ItemChanges) error {
+	if changes == nil {
+		return nil
+	}
+
+	for _, item := range changes.Updated {
+		if _, err := svc.Items.Update(item.Item); err != nil {
+			return err
+		}
+	}
+
+	for _, item := range changes.Removed {
+		if _, err := svc.Items.Delete(item.Id); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Give puts count of the item in the player's bags. The player isn't given anything unless there's
+// room for all of it, in which case the result says why.
+func Give(svc *realmd.Service, w *world.World, p *world.Player, t *model.ItemTemplate, count uint32) (world.InventoryResult, error) {
+	changes, created, result := w.StoreNewItem(p, t, count)
+	if result != world.InventoryResultOK {
+		return result, nil
+	}
+
+	if err := Save(svc, changes); err != nil {
+		return result, err
+	}
+
+	for _, item := range created {
+		if err := svc.Items.Create(item.Item); err != nil {
+			return result, err
+		}
+		w.PlaceItem(p, item)
+	}
+
+	var received *world.Item
+	if len(created) > 0 {
+		received = created[0]
+	} else if len(changes.Updated) > 0 {
+		received = changes.Updated[0]
+	}
+
+	if received != nil {
+		sendPushResult(p, received, count)
+	}
+
+	return result, nil
+}
+
+// sendPushResult tells the player they received count of the item, which is shown in their chat.
+// https://gtker.com/wow_messages/docs/smsg_item_push_result.html#client-version-335
+func sendPushResult(p *world.Player, item *world.Item, count uint32) {
+	bag := uint8(world.InventorySlotBag0)
+	for slot := uint8(world.BagSlotStart); slot < world.BagSlotEnd; slot++ {
+		if b := p.Item(world.InventorySlotBag0, slot); b != nil && b.Id == item.ContainerId {
+			bag = slot
+		}
+	}
+
+	buf := bytes.Buffer{}
+	binary.Write(&buf, binary.LittleEndian, p.GUID())
+	binary.Write(&buf, binary.LittleEndian, uint32(0)) // Source: looted
+	binary.Write(&buf, binary.LittleEndian, uint32(0)) // Creation type: received
+	binary.Write(&buf, binary.LittleEndian, uint32(1)) // Show in chat
+	buf.WriteByte(bag)
+	binary.Write(&buf, binary.LittleEndian, uint32(item.Slot))
+	binary.Write(&buf, binary.LittleEndian, item.TemplateId)
+	binary.Write(&buf, binary.LittleEndian, uint32(0)) // Suffix factor
+	binary.Write(&buf, binary.LittleEndian, int32(0))  // Random property id
+	binary.Write(&buf, binary.LittleEndian, count)
+	binary.Write(&buf, binary.LittleEndian, p.CountItem(item.TemplateId))
+
+	if err := p.Client.SendPacketBytes(realmd.OpServerItemPushResult, buf.Bytes()); err != nil {
+		p.Client.Log.Error().Err(err).Msg("error sending item push result")
+	}
+}
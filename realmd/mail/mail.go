@@ -0,0 +1,226 @@
+// Package mail delivers mail to characters' mailboxes. Anything that sends mail on behalf of the server,
+// such as auctions and GM commands, should use Send so the receiver is told they have new mail.
+package mail
+
+import (
+	"bytes"
+	"encoding/binary"
+	"time"
+
+	"github.com/kangaroux/gomaggus/model"
+	"github.com/kangaroux/gomaggus/realmd"
+	"github.com/kangaroux/gomaggus/realmd/world"
+)
+
+// Expiry is how long mail stays in a mailbox. Expired mail with money or items is returned to its sender,
+// any other mail is deleted.
+var Expiry = 30 * 24 * time.Hour
+
+const (
+	// MaxMailboxSize is the most mail a character can have in their mailbox.
+	MaxMailboxSize = 100
+
+	// MaxAttachments is the most items a mail can have.
+	MaxAttachments = 12
+
+	// The client doesn't allow subjects or bodies longer than this.
+	MaxSubjectLength = 64
+	MaxBodyLength    = 500
+
+	// Postage is how much copper it costs to send a mail.
+	Postage = 30
+)
+
+// Action is the mail action that a result is for.
+// https://gtker.com/wow_messages/docs/mailaction.html
+type Action uint32
+
+const (
+	ActionSend          Action = 0
+	ActionMoneyTaken    Action = 1
+	ActionItemTaken     Action = 2
+	ActionReturned      Action = 3
+	ActionDeleted       Action = 4
+	ActionMadePermanent Action = 5
+)
+
+// Result is the result of a mail action.
+// https://gtker.com/wow_messages/docs/mailresult.html
+type Result uint32
+
+const (
+	ResultOK                  Result = 0
+	ResultEquipError          Result = 1
+	ResultCannotSendToSelf    Result = 2
+	ResultNotEnoughMoney      Result = 3
+	ResultRecipientNotFound   Result = 4
+	ResultNotYourTeam         Result = 5
+	ResultInternalError       Result = 6
+	ResultRecipientCapReached Result = 15
+	ResultTooManyAttachments  Result = 18
+	ResultAttachmentInvalid   Result = 19
+)
+
+// Send delivers m to its receiver's mailbox. The mail expires after Expiry if it doesn't have an expiry
+// time yet. If the receiver is online, they are told they have new mail.
+func Send(svc *realmd.Service, w *world.World, m *model.Mail) error {
+	setDefaults(m)
+
+	if err := svc.Mail.Create(m); err != nil {
+		return err
+	}
+
+	Notify(w, m.ReceiverId)
+
+	return nil
+}
+
+// Post is like Send for mail that a player paid to send. The money the sender has left is saved with the
+// mail, so they're only charged if the mail is sent.
+func Post(svc *realmd.Service, w *world.World, m *model.Mail, senderMoney uint32) error {
+	setDefaults(m)
+
+	if err := svc.Mail.Send(m, senderMoney); err != nil {
+		return err
+	}
+
+	Notify(w, m.ReceiverId)
+
+	return nil
+}
+
+// TakeItem saves everything that changes when an item is taken from a mail. If the COD was paid, the
+// sender is told they have new mail.
+func TakeItem(svc *realmd.Service, w *world.World, t *model.MailTake) error {
+	if t.Payment != nil {
+		setDefaults(t.Payment)
+	}
+
+	if err := svc.Mail.TakeItem(t); err != nil {
+		return err
+	}
+
+	if t.Payment != nil {
+		Notify(w, t.Payment.ReceiverId)
+	}
+
+	return nil
+}
+
+// setDefaults makes the mail expire after Expiry and gives it the default stationery, if they aren't set.
+func setDefaults(m *model.Mail) {
+	if m.ExpiresAt.IsZero() {
+		m.ExpiresAt = time.Now().Add(Expiry)
+	}
+	if m.Stationery == 0 {
+		m.Stationery = model.MailStationeryDefault
+	}
+}
+
+// Notify tells the character they have new mail, if they are online.
+// https://gtker.com/wow_messages/docs/smsg_received_mail.html
+func Notify(w *world.World, characterId uint32) {
+	p := w.Player(realmd.Guid(characterId))
+	if p == nil {
+		return
+	}
+
+	if err := p.Client.SendPacketBytes(realmd.OpServerReceivedMail, make([]byte, 4)); err != nil {
+		p.Client.Log.Error().Err(err).Msg("error sending new mail notification")
+	}
+}
+
+// Returnable reports whether m can be sent back to the player that sent it. Mail sent by the server and
+// mail that was already returned can't be returned.
+func Returnable(m *model.Mail) bool {
+	return m.MessageType == model.MailTypeNormal && m.SenderId != 0 &&
+		m.Flags&(model.MailFlagReturned|model.MailFlagCODPayment) == 0
+}
+
+// Return sends m back to its sender, along with any money and items that are still attached. The COD is
+// removed, and the mail expires after Expiry.
+func Return(svc *realmd.Service, w *world.World, m *model.Mail) error {
+	m.ReceiverId, m.SenderId = m.SenderId, m.ReceiverId
+	m.Flags = (m.Flags | model.MailFlagReturned) &^ model.MailFlagRead
+	m.COD = 0
+	m.ExpiresAt = time.Now().Add(Expiry)
+
+	if _, err := svc.Mail.Update(m); err != nil {
+		return err
+	}
+
+	Notify(w, m.ReceiverId)
+
+	return nil
+}
+
+// Mailbox returns the mail in the character's mailbox. Expired mail is returned or deleted first.
+func Mailbox(svc *realmd.Service, w *world.World, characterId uint32) ([]*model.Mail, error) {
+	mailbox, err := svc.Mail.List(characterId)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	result := make([]*model.Mail, 0, len(mailbox))
+
+	for _, m := range mailbox {
+		if now.Before(m.ExpiresAt) {
+			result = append(result, m)
+			continue
+		}
+
+		if Returnable(m) && (m.Money > 0 || len(m.Items) > 0) {
+			err = Return(svc, w, m)
+		} else {
+			_, err = svc.Mail.Delete(m.Id)
+		}
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return result, nil
+}
+
+// HasUnread reports whether any of the mail is unread.
+func HasUnread(mailbox []*model.Mail) bool {
+	for _, m := range mailbox {
+		if m.Flags&model.MailFlagRead == 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// SendResult sends the result of a mail action to the client.
+// https://gtker.com/wow_messages/docs/smsg_send_mail_result.html#client-version-335
+func SendResult(client *realmd.Client, mailId uint32, action Action, result Result) error {
+	buf := bytes.Buffer{}
+	binary.Write(&buf, binary.LittleEndian, mailId)
+	binary.Write(&buf, binary.LittleEndian, action)
+	binary.Write(&buf, binary.LittleEndian, result)
+	return client.SendPacketBytes(realmd.OpServerSendMailResult, buf.Bytes())
+}
+
+// SendEquipError tells the client an item couldn't be taken from a mail, and why.
+func SendEquipError(client *realmd.Client, mailId uint32, equipError world.InventoryResult) error {
+	buf := bytes.Buffer{}
+	binary.Write(&buf, binary.LittleEndian, mailId)
+	binary.Write(&buf, binary.LittleEndian, ActionItemTaken)
+	binary.Write(&buf, binary.LittleEndian, ResultEquipError)
+	binary.Write(&buf, binary.LittleEndian, uint32(equipError))
+	return client.SendPacketBytes(realmd.OpServerSendMailResult, buf.Bytes())
+}
+
+// SendItemTaken tells the client an item was taken from a mail. itemId is the id of the attachment.
+func SendItemTaken(client *realmd.Client, mailId, itemId, count uint32) error {
+	buf := bytes.Buffer{}
+	binary.Write(&buf, binary.LittleEndian, mailId)
+	binary.Write(&buf, binary.LittleEndian, ActionItemTaken)
+	binary.Write(&buf, binary.LittleEndian, ResultOK)
+	binary.Write(&buf, binary.LittleEndian, itemId)
+	binary.Write(&buf, binary.LittleEndian, count)
+	return client.SendPacketBytes(realmd.OpServerSendMailResult, buf.Bytes())
+}
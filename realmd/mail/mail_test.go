@@ -0,0 +1,38 @@
+package mail
+
+import (
+	"testing"
+
+	"github.com/kangaroux/gomaggus/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReturnable(t *testing.T) {
+	tests := []struct {
+		name     string
+		mail     model.Mail
+		expected bool
+	}{
+		{"player mail", model.Mail{SenderId: 1, MessageType: model.MailTypeNormal}, true},
+		{"read player mail", model.Mail{SenderId: 1, Flags: model.MailFlagRead}, true},
+		{"already returned", model.Mail{SenderId: 1, Flags: model.MailFlagReturned}, false},
+		{"COD payment", model.Mail{SenderId: 1, Flags: model.MailFlagCODPayment}, false},
+		{"auction", model.Mail{SenderId: 1, MessageType: model.MailTypeAuction}, false},
+		{"no sender", model.Mail{}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, Returnable(&tt.mail))
+		})
+	}
+}
+
+func TestHasUnread(t *testing.T) {
+	read := &model.Mail{Flags: model.MailFlagRead}
+	unread := &model.Mail{}
+
+	assert.False(t, HasUnread(nil))
+	assert.False(t, HasUnread([]*model.Mail{read}))
+	assert.True(t, HasUnread([]*model.Mail{read, unread}))
+}
@@ -0,0 +1,35 @@
+package player
+
+import (
+	"github.com/kangaroux/gomaggus/model"
+)
+
+const (
+	// SpellAutoAttack is the spell every class starts with on their first button.
+	SpellAutoAttack uint32 = 6603
+)
+
+// DefaultAction is a spell that a new character starts with on one of their action bar buttons.
+type DefaultAction struct {
+	Button uint8
+	Spell  uint32
+}
+
+// Buttons 72+ are on the bar that warriors see while they're in battle stance.
+var classActions = map[model.Class][]DefaultAction{
+	model.ClassWarrior:     {{0, SpellAutoAttack}, {1, 78}, {72, SpellAutoAttack}, {73, 78}},
+	model.ClassPaladin:     {{0, SpellAutoAttack}, {1, 21084}, {2, 635}},
+	model.ClassHunter:      {{0, SpellAutoAttack}, {1, 2973}, {2, 75}},
+	model.ClassRogue:       {{0, SpellAutoAttack}, {1, 1752}, {2, 2098}},
+	model.ClassPriest:      {{0, SpellAutoAttack}, {1, 585}, {2, 2050}},
+	model.ClassDeathKnight: {{0, SpellAutoAttack}, {1, 49576}, {2, 45477}, {3, 45462}, {4, 45902}, {5, 47541}},
+	model.ClassShaman:      {{0, SpellAutoAttack}, {1, 403}, {2, 331}},
+	model.ClassMage:        {{0, SpellAutoAttack}, {1, 133}, {2, 168}},
+	model.ClassWarlock:     {{0, SpellAutoAttack}, {1, 686}, {2, 687}},
+	model.ClassDruid:       {{0, SpellAutoAttack}, {1, 5176}, {2, 5185}},
+}
+
+// DefaultActions returns the buttons that a new character of class starts with.
+func DefaultActions(class model.Class) []DefaultAction {
+	return classActions[class]
+}
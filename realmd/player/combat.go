@@ -0,0 +1,148 @@
+package player
+
+import "github.com/kangaroux/gomaggus/model"
+
+const (
+	// Every character has these chances to crit, dodge and block before their agility and gear are added.
+	// Blocking needs a shield and parrying needs a class that can parry.
+	baseCritChance  = 5
+	baseDodgeChance = 5
+	baseParryChance = 5
+	baseBlockChance = 5
+
+	// Each point of weapon skill above or below the defense of whoever is being hit changes the chance
+	// to miss, dodge, parry, block and crit by this many percent.
+	SkillChancePerPoint = 0.04
+
+	// BaseMissChance is the chance to miss someone whose defense matches the attacker's weapon skill.
+	// Dual wielding makes every attack more likely to miss.
+	BaseMissChance     = 5
+	DualWieldMissBonus = 19
+
+	// UnarmedSpeed is the attack speed of a character without a weapon, in milliseconds.
+	UnarmedSpeed = 2000
+
+	// Attack power adds this much damage per second to a weapon's damage.
+	attackPowerPerDPS = 14
+)
+
+// Unarmed characters hit for this much before their attack power is added.
+const (
+	UnarmedMinDamage = 1
+	UnarmedMaxDamage = 2
+)
+
+// https://wowdev.wiki/ItemSubClass#Weapon
+var weaponSkills = map[uint32]uint16{
+	0:  SkillAxes,
+	1:  SkillTwoHandedAxes,
+	2:  SkillBows,
+	3:  SkillGuns,
+	4:  SkillMaces,
+	5:  SkillTwoHandedMaces,
+	6:  SkillPolearms,
+	7:  SkillSwords,
+	8:  SkillTwoHandedSwords,
+	10: SkillStaves,
+	13: SkillFistWeapons,
+	15: SkillDaggers,
+	16: SkillThrown,
+	18: SkillCrossbows,
+	19: SkillWands,
+}
+
+// WeaponSkill returns the skill used by weapons of subclass, or 0 if the subclass doesn't use a skill.
+func WeaponSkill(subclass uint32) uint16 {
+	return weaponSkills[subclass]
+}
+
+// AttackPower returns the melee attack power a character of class gets from their level, strength and
+// agility.
+func AttackPower(class model.Class, level, strength, agility uint32) int32 {
+	l, str, agi := int32(level), int32(strength), int32(agility)
+
+	switch class {
+	case model.ClassWarrior, model.ClassPaladin, model.ClassDeathKnight:
+		return l*3 + str*2 - 20
+	case model.ClassRogue, model.ClassHunter, model.ClassShaman:
+		return l*2 + str + agi - 20
+	case model.ClassDruid:
+		return str*2 - 20
+	default:
+		return str - 10
+	}
+}
+
+// WeaponDamage returns the damage of a weapon that hits for min to max every speed milliseconds, with
+// attack power added.
+func WeaponDamage(min, max float32, speed uint32, attackPower int32) (float32, float32) {
+	bonus := float32(attackPower) / attackPowerPerDPS * float32(speed) / 1000
+	if bonus < 0 {
+		bonus = 0
+	}
+	return min + bonus, max + bonus
+}
+
+// agilityPerPercent returns how much agility it takes to get 1% crit or dodge at level. It takes more
+// agility as characters level up, from 2 at level 1 to about 63 at level 80.
+func agilityPerPercent(level uint32) float32 {
+	if level < 1 {
+		level = 1
+	}
+	return 2 + float32(level-1)*0.77
+}
+
+// CritChance returns the percent chance a character's melee attacks crit, from their agility at level.
+func CritChance(level, agility uint32) float32 {
+	return baseCritChance + float32(agility)/agilityPerPercent(level)
+}
+
+// DodgeChance returns the percent chance a character dodges melee attacks, from their agility at level.
+func DodgeChance(level, agility uint32) float32 {
+	return baseDodgeChance + float32(agility)/agilityPerPercent(level)
+}
+
+// ParryChance returns the percent chance a character of class parries melee attacks. Classes that can't
+// parry have no chance.
+func ParryChance(class model.Class) float32 {
+	switch class {
+	case model.ClassWarrior, model.ClassPaladin, model.ClassHunter, model.ClassRogue, model.ClassDeathKnight:
+		return baseParryChance
+	}
+	return 0
+}
+
+// BlockChance returns the percent chance a character blocks melee attacks. Characters without a shield
+// can't block.
+func BlockChance(shield bool) float32 {
+	if !shield {
+		return 0
+	}
+	return baseBlockChance
+}
+
+// BlockValue returns how much damage a character with strength stops when they block.
+func BlockValue(strength uint32) uint32 {
+	return strength / 2
+}
+
+// ArmorReduction returns the fraction of melee damage that armor stops when hit by an attacker at level.
+// Armor can't stop more than 75% of the damage.
+func ArmorReduction(armor, level uint32) float32 {
+	if armor == 0 {
+		return 0
+	}
+
+	a := float32(armor)
+	var reduction float32
+	if level < 60 {
+		reduction = a / (a + 400 + 85*float32(level))
+	} else {
+		reduction = a / (a + 467.5*float32(level) - 22167.5)
+	}
+
+	if reduction > 0.75 {
+		reduction = 0.75
+	}
+	return reduction
+}
@@ -0,0 +1,51 @@
+package player
+
+import (
+	"testing"
+
+	"github.com/kangaroux/gomaggus/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWeaponSkill(t *testing.T) {
+	assert.Equal(t, SkillAxes, WeaponSkill(0))
+	assert.Equal(t, SkillDaggers, WeaponSkill(15))
+	assert.Zero(t, WeaponSkill(20), "fishing poles aren't a weapon skill")
+}
+
+func TestAttackPower(t *testing.T) {
+	assert.Equal(t, int32(3+46-20), AttackPower(model.ClassWarrior, 1, 23, 20))
+	assert.Equal(t, int32(2+18+23-20), AttackPower(model.ClassRogue, 1, 18, 23))
+	assert.Equal(t, int32(20-10), AttackPower(model.ClassMage, 1, 20, 20))
+	assert.Equal(t, int32(-10), AttackPower(model.ClassPriest, 1, 0, 0))
+}
+
+func TestWeaponDamage(t *testing.T) {
+	min, max := WeaponDamage(2, 5, 2000, 28)
+	assert.Equal(t, float32(6), min)
+	assert.Equal(t, float32(9), max)
+
+	min, max = WeaponDamage(1, 2, 2000, -10)
+	assert.Equal(t, float32(1), min, "negative attack power doesn't take damage away")
+	assert.Equal(t, float32(2), max)
+}
+
+func TestCritDodgeChance(t *testing.T) {
+	assert.Equal(t, float32(15), CritChance(1, 20))
+	assert.Equal(t, float32(15), DodgeChance(1, 20))
+	assert.Less(t, CritChance(80, 20), CritChance(1, 20), "agility is worth less at higher levels")
+}
+
+func TestParryBlockChance(t *testing.T) {
+	assert.NotZero(t, ParryChance(model.ClassWarrior))
+	assert.Zero(t, ParryChance(model.ClassMage))
+	assert.NotZero(t, BlockChance(true))
+	assert.Zero(t, BlockChance(false))
+}
+
+func TestArmorReduction(t *testing.T) {
+	assert.Zero(t, ArmorReduction(0, 10))
+	assert.InDelta(t, 0.5, ArmorReduction(1250, 10), 0.001)
+	assert.InDelta(t, 0.312, ArmorReduction(6900, 80), 0.001)
+	assert.Equal(t, float32(0.75), ArmorReduction(1000000, 80))
+}
@@ -0,0 +1,124 @@
+package player
+
+var (
+	xpForLevel    = map[uint32]uint32{}
+	explorationXP = map[uint32]uint32{}
+)
+
+func init() {
+	for _, row := range mustReadTable("data/xpforlevel.csv") {
+		xpForLevel[row[0]] = row[1]
+	}
+
+	for _, row := range mustReadTable("data/explorationxp.csv") {
+		explorationXP[row[0]] = row[1]
+	}
+}
+
+// XPForLevel returns how much experience a character at level needs to reach the next level. Characters at
+// MaxLevel can't gain any more, so it's 0.
+func XPForLevel(level uint32) uint32 {
+	return xpForLevel[level]
+}
+
+// KillXP returns the experience a character at level gets for killing something at victimLevel. Killing
+// something that's gray to them doesn't give any.
+func KillXP(level, victimLevel uint32) uint32 {
+	// Monsters from each expansion give more
+	base := level * 5
+	switch {
+	case victimLevel <= 60:
+		base += 45
+	case victimLevel <= 70:
+		base += 235
+	default:
+		base += 580
+	}
+
+	if victimLevel >= level {
+		diff := victimLevel - level
+		if diff > 4 {
+			diff = 4
+		}
+		return (base*(20+diff)/10 + 1) / 2
+	}
+
+	if victimLevel <= GrayLevel(level) {
+		return 0
+	}
+
+	zd := zeroDifference(level)
+	return base * (zd + victimLevel - level) / zd
+}
+
+// zeroDifference returns how many levels below a character at level something has to be before it stops
+// giving experience. It's always more than the difference to the gray level.
+func zeroDifference(level uint32) uint32 {
+	switch {
+	case level < 8:
+		return 5
+	case level < 10:
+		return 6
+	case level < 12:
+		return 7
+	case level < 16:
+		return 8
+	case level < 20:
+		return 9
+	case level < 30:
+		return 11
+	case level < 40:
+		return 12
+	case level < 45:
+		return 13
+	case level < 50:
+		return 14
+	case level < 55:
+		return 15
+	case level < 60:
+		return 16
+	default:
+		return 17
+	}
+}
+
+// QuestXP returns the experience a character at level gets for completing a quest at questLevel that
+// rewards xp. Quests that are more than 5 levels below the character give less.
+func QuestXP(level, questLevel, xp uint32) uint32 {
+	if level <= questLevel+5 {
+		return xp
+	}
+
+	switch level - questLevel {
+	case 6:
+		return xp * 8 / 10
+	case 7:
+		return xp * 6 / 10
+	case 8:
+		return xp * 4 / 10
+	case 9:
+		return xp * 2 / 10
+	default:
+		return xp / 10
+	}
+}
+
+// ExplorationXP returns the experience a character at level gets for exploring an area at areaLevel. Areas
+// that are more than 5 levels below the character give less, and areas that are more than 5 levels above
+// them give as much as one 5 levels above.
+func ExplorationXP(level, areaLevel uint32) uint32 {
+	switch {
+	case areaLevel > level+5:
+		return explorationXP[level+5]
+
+	case level > areaLevel+5:
+		diff := level - areaLevel - 5
+		if diff >= 20 {
+			return 0
+		}
+		return explorationXP[areaLevel] * (100 - diff*5) / 100
+
+	default:
+		return explorationXP[areaLevel]
+	}
+}
@@ -0,0 +1,36 @@
+package player
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestXPForLevel(t *testing.T) {
+	assert.Equal(t, uint32(400), XPForLevel(1))
+	assert.Equal(t, uint32(1670800), XPForLevel(79))
+	assert.Equal(t, uint32(0), XPForLevel(MaxLevel))
+}
+
+func TestKillXP(t *testing.T) {
+	assert.Equal(t, uint32(50), KillXP(1, 1))
+	assert.Equal(t, uint32(60), KillXP(1, 5), "capped at 4 levels higher")
+	assert.Equal(t, KillXP(1, 5), KillXP(1, 10))
+	assert.Equal(t, uint32(67), KillXP(10, 8))
+	assert.Equal(t, uint32(0), KillXP(20, 13), "gray")
+	assert.Equal(t, uint32(980), KillXP(80, 80))
+}
+
+func TestQuestXP(t *testing.T) {
+	assert.Equal(t, uint32(1000), QuestXP(15, 10, 1000))
+	assert.Equal(t, uint32(800), QuestXP(16, 10, 1000))
+	assert.Equal(t, uint32(200), QuestXP(19, 10, 1000))
+	assert.Equal(t, uint32(100), QuestXP(30, 10, 1000))
+}
+
+func TestExplorationXP(t *testing.T) {
+	assert.Equal(t, uint32(85), ExplorationXP(10, 10))
+	assert.Equal(t, uint32(105), ExplorationXP(10, 30), "as much as 5 levels higher")
+	assert.Equal(t, uint32(85*90/100), ExplorationXP(17, 10))
+	assert.Equal(t, uint32(0), ExplorationXP(40, 10))
+}
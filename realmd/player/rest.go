@@ -0,0 +1,15 @@
+package player
+
+// taverns are the area triggers for inns, where characters rest.
+var taverns = map[uint32]bool{}
+
+func init() {
+	for _, row := range mustReadTable("data/taverns.csv") {
+		taverns[row[0]] = true
+	}
+}
+
+// IsTavern reports whether the area trigger is an inn.
+func IsTavern(trigger uint32) bool {
+	return taverns[trigger]
+}
@@ -0,0 +1,279 @@
+package player
+
+import (
+	"github.com/kangaroux/gomaggus/model"
+)
+
+// https://wowdev.wiki/DB/SkillLine
+const (
+	SkillLanguageOrcish      uint16 = 109
+	SkillLanguageDarnassian  uint16 = 113
+	SkillLanguageTaurahe     uint16 = 115
+	SkillLanguageDwarven     uint16 = 111
+	SkillLanguageCommon      uint16 = 98
+	SkillLanguageThalassian  uint16 = 137
+	SkillLanguageGnomish     uint16 = 313
+	SkillLanguageTroll       uint16 = 315
+	SkillLanguageGutterspeak uint16 = 673
+	SkillLanguageDraenei     uint16 = 759
+
+	SkillRacialDwarf    uint16 = 101
+	SkillRacialTauren   uint16 = 124
+	SkillRacialOrc      uint16 = 125
+	SkillRacialNightElf uint16 = 126
+	SkillRacialUndead   uint16 = 220
+	SkillRacialTroll    uint16 = 733
+	SkillRacialGnome    uint16 = 753
+	SkillRacialHuman    uint16 = 754
+	SkillRacialBloodElf uint16 = 756
+	SkillRacialDraenei  uint16 = 760
+
+	SkillSwords          uint16 = 43
+	SkillAxes            uint16 = 44
+	SkillBows            uint16 = 45
+	SkillGuns            uint16 = 46
+	SkillMaces           uint16 = 54
+	SkillTwoHandedSwords uint16 = 55
+	SkillDefense         uint16 = 95
+	SkillStaves          uint16 = 136
+	SkillTwoHandedMaces  uint16 = 160
+	SkillUnarmed         uint16 = 162
+	SkillTwoHandedAxes   uint16 = 172
+	SkillDaggers         uint16 = 173
+	SkillThrown          uint16 = 176
+	SkillCrossbows       uint16 = 226
+	SkillWands           uint16 = 228
+	SkillPolearms        uint16 = 229
+	SkillFistWeapons     uint16 = 473
+
+	SkillPlate   uint16 = 293
+	SkillMail    uint16 = 413
+	SkillLeather uint16 = 414
+	SkillCloth   uint16 = 415
+	SkillShield  uint16 = 433
+
+	SkillFirstAid       uint16 = 129
+	SkillBlacksmithing  uint16 = 164
+	SkillLeatherworking uint16 = 165
+	SkillAlchemy        uint16 = 171
+	SkillHerbalism      uint16 = 182
+	SkillCooking        uint16 = 185
+	SkillMining         uint16 = 186
+	SkillTailoring      uint16 = 197
+	SkillEngineering    uint16 = 202
+	SkillEnchanting     uint16 = 333
+	SkillFishing        uint16 = 356
+	SkillSkinning       uint16 = 393
+	SkillJewelcrafting  uint16 = 755
+	SkillInscription    uint16 = 773
+
+	SkillRiding uint16 = 762
+)
+
+// SkillType decides what a skill's value and max are, and how they change.
+type SkillType uint8
+
+const (
+	// SkillTypeMono skills are either known or not, like armor and racial skills.
+	SkillTypeMono SkillType = iota
+
+	// SkillTypeLanguage skills are always maxed out.
+	SkillTypeLanguage
+
+	// SkillTypeWeapon skills go up by using them, and their max goes up with the character's level.
+	SkillTypeWeapon
+
+	// SkillTypeClass skills are always maxed out for the character's level.
+	SkillTypeClass
+
+	// SkillTypeProfession skills go up by using them. Their max goes up by training the next rank.
+	SkillTypeProfession
+
+	// SkillTypeRiding skills are always maxed out for the rank that was trained.
+	SkillTypeRiding
+)
+
+const (
+	// Languages are always maxed out
+	languageSkillLevel = 300
+
+	// Racial skills only have a single rank
+	racialSkillLevel = 1
+
+	// ProfessionRank is how much a profession's max goes up with each rank.
+	ProfessionRank = 75
+
+	// Max weapon and class skill goes up by skillPerLevel with each level.
+	skillPerLevel = 5
+)
+
+var skillTypes = map[uint16]SkillType{
+	SkillSwords:          SkillTypeWeapon,
+	SkillAxes:            SkillTypeWeapon,
+	SkillBows:            SkillTypeWeapon,
+	SkillGuns:            SkillTypeWeapon,
+	SkillMaces:           SkillTypeWeapon,
+	SkillTwoHandedSwords: SkillTypeWeapon,
+	SkillDefense:         SkillTypeWeapon,
+	SkillStaves:          SkillTypeWeapon,
+	SkillTwoHandedMaces:  SkillTypeWeapon,
+	SkillUnarmed:         SkillTypeWeapon,
+	SkillTwoHandedAxes:   SkillTypeWeapon,
+	SkillDaggers:         SkillTypeWeapon,
+	SkillThrown:          SkillTypeWeapon,
+	SkillCrossbows:       SkillTypeWeapon,
+	SkillWands:           SkillTypeWeapon,
+	SkillPolearms:        SkillTypeWeapon,
+	SkillFistWeapons:     SkillTypeWeapon,
+
+	SkillPlate:   SkillTypeMono,
+	SkillMail:    SkillTypeMono,
+	SkillLeather: SkillTypeMono,
+	SkillCloth:   SkillTypeMono,
+	SkillShield:  SkillTypeMono,
+
+	SkillFirstAid:       SkillTypeProfession,
+	SkillBlacksmithing:  SkillTypeProfession,
+	SkillLeatherworking: SkillTypeProfession,
+	SkillAlchemy:        SkillTypeProfession,
+	SkillHerbalism:      SkillTypeProfession,
+	SkillCooking:        SkillTypeProfession,
+	SkillMining:         SkillTypeProfession,
+	SkillTailoring:      SkillTypeProfession,
+	SkillEngineering:    SkillTypeProfession,
+	SkillEnchanting:     SkillTypeProfession,
+	SkillFishing:        SkillTypeProfession,
+	SkillSkinning:       SkillTypeProfession,
+	SkillJewelcrafting:  SkillTypeProfession,
+	SkillInscription:    SkillTypeProfession,
+
+	SkillRiding: SkillTypeRiding,
+}
+
+func init() {
+	for _, skills := range raceSkills {
+		for _, id := range skills.Languages {
+			skillTypes[id] = SkillTypeLanguage
+		}
+		skillTypes[skills.Racial] = SkillTypeMono
+	}
+}
+
+// SkillTypeOf returns the type of a skill. Skills that aren't listed are assumed to be class skills, like
+// the skill for each of a class's spell schools.
+func SkillTypeOf(id uint16) SkillType {
+	if t, ok := skillTypes[id]; ok {
+		return t
+	}
+	return SkillTypeClass
+}
+
+// MaxSkillForLevel returns the max of weapon and class skills at level.
+func MaxSkillForLevel(level uint32) uint16 {
+	return uint16(level * skillPerLevel)
+}
+
+// Skill is a skill and the character's value in it. The value can't go past Max.
+type Skill struct {
+	ID    uint16
+	Value uint16
+	Max   uint16
+}
+
+// NewSkill returns a skill with the value and max that a character starts with when they learn it at level.
+func NewSkill(id uint16, level uint32) Skill {
+	max := MaxSkillForLevel(level)
+
+	switch SkillTypeOf(id) {
+	case SkillTypeLanguage:
+		return Skill{ID: id, Value: languageSkillLevel, Max: languageSkillLevel}
+
+	case SkillTypeWeapon:
+		// Characters that start above level 1 have the skill they would have had by then
+		value := uint16(1)
+		if level > 1 {
+			value = MaxSkillForLevel(level - 1)
+		}
+		return Skill{ID: id, Value: value, Max: max}
+
+	case SkillTypeClass:
+		return Skill{ID: id, Value: max, Max: max}
+
+	case SkillTypeProfession:
+		return Skill{ID: id, Value: 1, Max: ProfessionRank}
+
+	case SkillTypeRiding:
+		return Skill{ID: id, Value: ProfessionRank, Max: ProfessionRank}
+
+	default:
+		return Skill{ID: id, Value: racialSkillLevel, Max: racialSkillLevel}
+	}
+}
+
+type racialSkills struct {
+	Languages []uint16
+	Racial    uint16
+}
+
+var raceSkills = map[model.Race]racialSkills{
+	model.RaceHuman:    {[]uint16{SkillLanguageCommon}, SkillRacialHuman},
+	model.RaceOrc:      {[]uint16{SkillLanguageOrcish}, SkillRacialOrc},
+	model.RaceDwarf:    {[]uint16{SkillLanguageCommon, SkillLanguageDwarven}, SkillRacialDwarf},
+	model.RaceNightElf: {[]uint16{SkillLanguageCommon, SkillLanguageDarnassian}, SkillRacialNightElf},
+	model.RaceUndead:   {[]uint16{SkillLanguageOrcish, SkillLanguageGutterspeak}, SkillRacialUndead},
+	model.RaceTauren:   {[]uint16{SkillLanguageOrcish, SkillLanguageTaurahe}, SkillRacialTauren},
+	model.RaceGnome:    {[]uint16{SkillLanguageCommon, SkillLanguageGnomish}, SkillRacialGnome},
+	model.RaceTroll:    {[]uint16{SkillLanguageOrcish, SkillLanguageTroll}, SkillRacialTroll},
+	model.RaceBloodElf: {[]uint16{SkillLanguageOrcish, SkillLanguageThalassian}, SkillRacialBloodElf},
+	model.RaceDraenei:  {[]uint16{SkillLanguageCommon, SkillLanguageDraenei}, SkillRacialDraenei},
+}
+
+// RacialSkills returns the languages and racial skill that a character of race knows.
+func RacialSkills(race model.Race) []Skill {
+	skills, ok := raceSkills[race]
+	if !ok {
+		return nil
+	}
+
+	var result []Skill
+	for _, id := range skills.Languages {
+		result = append(result, NewSkill(id, 1))
+	}
+	result = append(result, NewSkill(skills.Racial, 1))
+
+	return result
+}
+
+// Every class can use these, and knows them when they're created
+var commonSkills = []uint16{SkillUnarmed, SkillDefense, SkillCloth}
+
+// classSkills are the weapon and armor skills each class starts with, for the gear they start with and
+// the gear they can use from the start.
+var classSkills = map[model.Class][]uint16{
+	model.ClassWarrior: {SkillSwords, SkillAxes, SkillMaces, SkillDaggers, SkillLeather, SkillMail, SkillShield},
+	model.ClassPaladin: {SkillMaces, SkillTwoHandedMaces, SkillLeather, SkillMail, SkillShield},
+	model.ClassHunter:  {SkillAxes, SkillBows, SkillLeather},
+	model.ClassRogue:   {SkillDaggers, SkillThrown, SkillLeather},
+	model.ClassPriest:  {SkillMaces, SkillWands},
+	model.ClassDeathKnight: {SkillSwords, SkillTwoHandedSwords, SkillAxes, SkillTwoHandedAxes, SkillMaces,
+		SkillTwoHandedMaces, SkillPolearms, SkillLeather, SkillMail, SkillPlate},
+	model.ClassShaman:  {SkillMaces, SkillStaves, SkillLeather, SkillShield},
+	model.ClassMage:    {SkillStaves, SkillWands},
+	model.ClassWarlock: {SkillDaggers, SkillWands},
+	model.ClassDruid:   {SkillStaves, SkillLeather},
+}
+
+// StartingSkills returns the skills a character of race and class starts with at level. The racial skills
+// come first.
+func StartingSkills(race model.Race, class model.Class, level uint32) []Skill {
+	result := RacialSkills(race)
+
+	for _, id := range commonSkills {
+		result = append(result, NewSkill(id, level))
+	}
+	for _, id := range classSkills[class] {
+		result = append(result, NewSkill(id, level))
+	}
+
+	return result
+}
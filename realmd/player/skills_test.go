@@ -0,0 +1,31 @@
+package player
+
+import (
+	"testing"
+
+	"github.com/kangaroux/gomaggus/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewSkill(t *testing.T) {
+	assert.Equal(t, Skill{ID: SkillSwords, Value: 1, Max: 5}, NewSkill(SkillSwords, 1))
+	assert.Equal(t, Skill{ID: SkillSwords, Value: 270, Max: 275}, NewSkill(SkillSwords, 55))
+	assert.Equal(t, Skill{ID: SkillLanguageCommon, Value: 300, Max: 300}, NewSkill(SkillLanguageCommon, 1))
+	assert.Equal(t, Skill{ID: SkillMining, Value: 1, Max: 75}, NewSkill(SkillMining, 20))
+	assert.Equal(t, Skill{ID: SkillCloth, Value: 1, Max: 1}, NewSkill(SkillCloth, 20))
+	assert.Equal(t, Skill{ID: 6, Value: 50, Max: 50}, NewSkill(6, 10), "frost is a class skill")
+}
+
+func TestStartingSkills(t *testing.T) {
+	skills := StartingSkills(model.RaceOrc, model.ClassWarrior, 1)
+	assert.Equal(t, SkillLanguageOrcish, skills[0].ID)
+	assert.Equal(t, SkillRacialOrc, skills[1].ID)
+
+	ids := make([]uint16, 0, len(skills))
+	for _, s := range skills {
+		ids = append(ids, s.ID)
+	}
+	assert.Contains(t, ids, SkillUnarmed)
+	assert.Contains(t, ids, SkillShield)
+	assert.NotContains(t, ids, SkillWands)
+}
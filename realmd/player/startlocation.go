@@ -0,0 +1,52 @@
+package player
+
+import (
+	"github.com/kangaroux/gomaggus/model"
+	"github.com/kangaroux/gomaggus/realmd"
+)
+
+// Location is a position on a specific map.
+type Location struct {
+	Map      uint32
+	Zone     uint32
+	Position realmd.Vector4
+}
+
+var (
+	northshire = Location{Map: 0, Zone: 12, Position: realmd.Vector4{X: -8949.95, Y: -132.493, Z: 83.5312, Rotation: 0}}
+	coldridge  = Location{Map: 0, Zone: 1, Position: realmd.Vector4{X: -6240.32, Y: 331.033, Z: 382.758, Rotation: 6.17716}}
+	shadowglen = Location{Map: 1, Zone: 141, Position: realmd.Vector4{X: 10311.3, Y: 832.463, Z: 1326.41, Rotation: 5.69632}}
+	deathknell = Location{Map: 0, Zone: 85, Position: realmd.Vector4{X: 1676.71, Y: 1678.31, Z: 121.67, Rotation: 2.70526}}
+	mulgore    = Location{Map: 1, Zone: 215, Position: realmd.Vector4{X: -2917.58, Y: -257.98, Z: 52.9968, Rotation: 0}}
+	valley     = Location{Map: 1, Zone: 14, Position: realmd.Vector4{X: -618.518, Y: -4251.67, Z: 38.718, Rotation: 0}}
+	sunstrider = Location{Map: 530, Zone: 3430, Position: realmd.Vector4{X: 10349.6, Y: -6357.29, Z: 33.4026, Rotation: 5.31605}}
+	ammenVale  = Location{Map: 530, Zone: 3524, Position: realmd.Vector4{X: -3961.64, Y: -13931.2, Z: 100.615, Rotation: 2.08364}}
+	acherus    = Location{Map: 609, Zone: 4298, Position: realmd.Vector4{X: 2355.84, Y: -5664.77, Z: 426.028, Rotation: 3.65997}}
+)
+
+// StartLocation returns where a new character with the given race and class starts.
+func StartLocation(race model.Race, class model.Class) Location {
+	// Death knights start in their own zone regardless of race
+	if class == model.ClassDeathKnight {
+		return acherus
+	}
+
+	switch race {
+	case model.RaceDwarf, model.RaceGnome:
+		return coldridge
+	case model.RaceNightElf:
+		return shadowglen
+	case model.RaceUndead:
+		return deathknell
+	case model.RaceTauren:
+		return mulgore
+	case model.RaceOrc, model.RaceTroll:
+		return valley
+	case model.RaceBloodElf:
+		return sunstrider
+	case model.RaceDraenei:
+		return ammenVale
+	default:
+		return northshire
+	}
+}
@@ -0,0 +1,156 @@
+package player
+
+import (
+	"embed"
+	"encoding/csv"
+	"fmt"
+	"strconv"
+
+	"github.com/kangaroux/gomaggus/model"
+)
+
+// The stat tables aren't part of the client data. Each row is the base values at a level, from the level
+// the class starts at up to MaxLevel.
+//
+//go:embed data/*.csv
+var dataFiles embed.FS
+
+// Stats are a character's primary stats before any items or auras are applied.
+type Stats struct {
+	Strength  uint32
+	Agility   uint32
+	Stamina   uint32
+	Intellect uint32
+	Spirit    uint32
+}
+
+// ClassLevelStats are the base health and mana for a class at a specific level.
+type ClassLevelStats struct {
+	Health uint32
+	Mana   uint32
+}
+
+const (
+	// MaxLevel is the highest level a character can reach.
+	MaxLevel = 80
+)
+
+type levelStatsKey struct {
+	Race  model.Race
+	Class model.Class
+}
+
+var (
+	levelStats      = map[levelStatsKey]map[uint32]Stats{}
+	classLevelStats = map[model.Class]map[uint32]ClassLevelStats{}
+)
+
+func init() {
+	for _, row := range mustReadTable("data/levelstats.csv") {
+		key := levelStatsKey{Race: model.Race(row[0]), Class: model.Class(row[1])}
+		if levelStats[key] == nil {
+			levelStats[key] = make(map[uint32]Stats)
+		}
+		levelStats[key][row[2]] = Stats{
+			Strength:  row[3],
+			Agility:   row[4],
+			Stamina:   row[5],
+			Intellect: row[6],
+			Spirit:    row[7],
+		}
+	}
+
+	for _, row := range mustReadTable("data/classlevelstats.csv") {
+		class := model.Class(row[0])
+		if classLevelStats[class] == nil {
+			classLevelStats[class] = make(map[uint32]ClassLevelStats)
+		}
+		classLevelStats[class][row[1]] = ClassLevelStats{Health: row[2], Mana: row[3]}
+	}
+}
+
+// mustReadTable reads an embedded CSV file of numbers, skipping the header. Panics if the file can't
+// be parsed.
+func mustReadTable(path string) [][]uint32 {
+	f, err := dataFiles.Open(path)
+	if err != nil {
+		panic(err)
+	}
+	defer f.Close()
+
+	rows, err := csv.NewReader(f).ReadAll()
+	if err != nil {
+		panic(fmt.Errorf("%s: %w", path, err))
+	}
+
+	result := make([][]uint32, 0, len(rows))
+
+	for _, row := range rows[1:] {
+		values := make([]uint32, len(row))
+		for i, col := range row {
+			val, err := strconv.ParseUint(col, 10, 32)
+			if err != nil {
+				panic(fmt.Errorf("%s: %w", path, err))
+			}
+			values[i] = uint32(val)
+		}
+		result = append(result, values)
+	}
+
+	return result
+}
+
+// BaseStats returns the stats for a race and class at level. ok is false if the race and class can't be
+// combined, or the level is below the class's start level or above MaxLevel.
+func BaseStats(race model.Race, class model.Class, level uint32) (stats Stats, ok bool) {
+	stats, ok = levelStats[levelStatsKey{race, class}][level]
+	return stats, ok
+}
+
+// BaseHealthMana returns the base health and mana for a class at level. ok is false if the class is
+// invalid, or the level is below the class's start level or above MaxLevel.
+func BaseHealthMana(class model.Class, level uint32) (stats ClassLevelStats, ok bool) {
+	stats, ok = classLevelStats[class][level]
+	return stats, ok
+}
+
+// HealthFromStamina returns the health granted by stamina. The first 20 points give 1 health each and
+// every point after that gives 10.
+func HealthFromStamina(stamina uint32) uint32 {
+	if stamina <= 20 {
+		return stamina
+	}
+	return 20 + (stamina-20)*10
+}
+
+// ManaFromIntellect returns the mana granted by intellect. The first 20 points give 1 mana each and
+// every point after that gives 15.
+func ManaFromIntellect(intellect uint32) uint32 {
+	if intellect <= 20 {
+		return intellect
+	}
+	return 20 + (intellect-20)*15
+}
+
+// StartLevel returns the level a new character of class starts at.
+func StartLevel(class model.Class) uint32 {
+	if class == model.ClassDeathKnight {
+		return 55
+	}
+	return 1
+}
+
+// GrayLevel returns the highest level that is too low to be worth anything to a character at level. Killing
+// things at or below it doesn't give experience or raise skills.
+func GrayLevel(level uint32) uint32 {
+	switch {
+	case level <= 5:
+		return 0
+	case level <= 39:
+		return level - 5 - level/10
+	case level <= 59:
+		return level - 1 - level/5
+	default:
+		return level - 9
+	}
+}
@@ -0,0 +1,62 @@
+package player
+
+import (
+	"testing"
+
+	"github.com/kangaroux/gomaggus/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBaseStats(t *testing.T) {
+	stats, ok := BaseStats(model.RaceHuman, model.ClassWarrior, 1)
+	assert.True(t, ok)
+	assert.Equal(t, Stats{Strength: 23, Agility: 20, Stamina: 22, Intellect: 20, Spirit: 20}, stats)
+
+	for level := uint32(2); level <= MaxLevel; level++ {
+		higher, ok := BaseStats(model.RaceHuman, model.ClassWarrior, level)
+		if assert.True(t, ok, "level %d", level) {
+			assert.GreaterOrEqual(t, higher.Strength, stats.Strength)
+			stats = higher
+		}
+	}
+	assert.Greater(t, stats.Strength, uint32(23))
+
+	_, ok = BaseStats(model.RaceHuman, model.ClassWarrior, MaxLevel+1)
+	assert.False(t, ok)
+
+	_, ok = BaseStats(model.RaceHuman, model.ClassDeathKnight, 1)
+	assert.False(t, ok, "death knights have no stats below their start level")
+
+	_, ok = BaseStats(model.RaceBloodElf, model.ClassWarrior, 1)
+	assert.False(t, ok, "invalid race and class combination")
+}
+
+func TestBaseHealthMana(t *testing.T) {
+	for _, class := range []model.Class{model.ClassWarrior, model.ClassMage, model.ClassDeathKnight} {
+		prev, ok := BaseHealthMana(class, StartLevel(class))
+		assert.True(t, ok)
+
+		for level := StartLevel(class) + 1; level <= MaxLevel; level++ {
+			stats, ok := BaseHealthMana(class, level)
+			if assert.True(t, ok, "class %d level %d", class, level) {
+				assert.Greater(t, stats.Health, prev.Health)
+				assert.GreaterOrEqual(t, stats.Mana, prev.Mana)
+				prev = stats
+			}
+		}
+	}
+}
+
+func TestHealthManaFromStats(t *testing.T) {
+	assert.Equal(t, uint32(15), HealthFromStamina(15))
+	assert.Equal(t, uint32(40), HealthFromStamina(22))
+	assert.Equal(t, uint32(20), ManaFromIntellect(20))
+	assert.Equal(t, uint32(65), ManaFromIntellect(23))
+}
+
+func TestGrayLevel(t *testing.T) {
+	assert.Equal(t, uint32(0), GrayLevel(5))
+	assert.Equal(t, uint32(5), GrayLevel(11))
+	assert.Equal(t, uint32(39), GrayLevel(50))
+	assert.Equal(t, uint32(71), GrayLevel(80))
+}
@@ -0,0 +1,60 @@
+// Package reputation loads and saves the players' reputations with each faction.
+package reputation
+
+import (
+	"github.com/kangaroux/gomaggus/model"
+	"github.com/kangaroux/gomaggus/realmd"
+	"github.com/kangaroux/gomaggus/realmd/world"
+)
+
+// List returns the character's reputation with each faction in the reputation list. Factions they haven't
+// changed their reputation with have the starting reputation for their race and class.
+func List(svc *realmd.Service, char *model.Character) ([]*world.Reputation, error) {
+	saved, err := svc.Reputations.List(char.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	byFaction := make(map[uint32]*model.CharacterReputation, len(saved))
+	for _, m := range saved {
+		byFaction[m.FactionId] = m
+	}
+
+	var result []*world.Reputation
+	for _, f := range svc.DBC.Factions {
+		if f.ReputationIndex < 0 || f.ReputationIndex >= world.MaxReputations {
+			continue
+		}
+
+		base, flags, _ := f.BaseReputation(char.Race, char.Class)
+		r := world.NewReputation(f, base, world.FactionFlag(flags))
+
+		if m, ok := byFaction[f.ID]; ok {
+			r.Standing = m.Standing
+			r.Flags = world.FactionFlag(m.Flags)
+		}
+
+		result = append(result, r)
+	}
+
+	return result, nil
+}
+
+// Save saves the player's reputations that changed from what they started with.
+func Save(svc *realmd.Service, p *world.Player) error {
+	var reps []*model.CharacterReputation
+	for _, r := range p.Reputations() {
+		if !r.Changed() {
+			continue
+		}
+
+		reps = append(reps, &model.CharacterReputation{
+			CharacterId: p.Character.Id,
+			FactionId:   r.Faction.ID,
+			Standing:    r.Standing,
+			Flags:       uint8(r.Flags),
+		})
+	}
+
+	return svc.Reputations.Save(p.Character.Id, reps)
+}
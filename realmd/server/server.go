@@ -11,13 +11,29 @@ import (
 	"github.com/jmoiron/sqlx"
 	"github.com/kangaroux/gomaggus/model"
 	"github.com/kangaroux/gomaggus/realmd"
+	"github.com/kangaroux/gomaggus/realmd/dbc"
 	"github.com/kangaroux/gomaggus/realmd/handler/account"
 	"github.com/kangaroux/gomaggus/realmd/handler/auth"
 	"github.com/kangaroux/gomaggus/realmd/handler/char"
+	"github.com/kangaroux/gomaggus/realmd/handler/chat"
+	"github.com/kangaroux/gomaggus/realmd/handler/combat"
+	"github.com/kangaroux/gomaggus/realmd/handler/group"
+	"github.com/kangaroux/gomaggus/realmd/handler/guild"
+	"github.com/kangaroux/gomaggus/realmd/handler/item"
+	"github.com/kangaroux/gomaggus/realmd/handler/mail"
+	"github.com/kangaroux/gomaggus/realmd/handler/movement"
 	"github.com/kangaroux/gomaggus/realmd/handler/player"
 	"github.com/kangaroux/gomaggus/realmd/handler/realm"
 	"github.com/kangaroux/gomaggus/realmd/handler/session"
-	"github.com/kangaroux/gomaggus/realmd/handler/world"
+	"github.com/kangaroux/gomaggus/realmd/handler/social"
+	spellHandler "github.com/kangaroux/gomaggus/realmd/handler/spell"
+	talentHandler "github.com/kangaroux/gomaggus/realmd/handler/talent"
+	worldHandler "github.com/kangaroux/gomaggus/realmd/handler/world"
+	"github.com/kangaroux/gomaggus/realmd/reputation"
+	"github.com/kangaroux/gomaggus/realmd/skill"
+	"github.com/kangaroux/gomaggus/realmd/spell"
+	"github.com/kangaroux/gomaggus/realmd/talent"
+	"github.com/kangaroux/gomaggus/realmd/world"
 	"github.com/phuslu/log"
 )
 
@@ -29,20 +45,54 @@ type Server struct {
 	listenAddr string
 
 	services *realmd.Service
+	world    *world.World
 }
 
-func New(db *sqlx.DB, listenAddr string) *Server {
-	return &Server{
+func New(db *sqlx.DB, data *dbc.Store, listenAddr string) *Server {
+	services := &realmd.Service{
+		Accounts:          model.NewDbAccountService(db),
+		Actions:           model.NewDbActionService(db),
+		AccountStorage:    model.NewDbAccountStorageService(db),
+		CharacterStorage:  model.NewDbCharacterStorageService(db),
+		CommandLog:        model.NewDbCommandLogService(db),
+		Contacts:          model.NewDbContactService(db),
+		Guilds:            model.NewDbGuildService(db),
+		Items:             model.NewDbItemService(db),
+		Mail:              model.NewDbMailService(db),
+		CharacterChannels: model.NewDbCharacterChannelService(db),
+		Characters:        model.NewDbCharacterService(db),
+		Realms:            model.NewDbRealmService(db),
+		Reputations:       model.NewDbReputationService(db),
+		Sessions:          model.NewDbSessionService(db),
+		Skills:            model.NewDbSkillService(db),
+		Spells:            model.NewDbSpellService(db),
+		Talents:           model.NewDbTalentService(db),
+		DBC:               data,
+	}
+
+	s := &Server{
 		listenAddr: listenAddr,
-		services: &realmd.Service{
-			Accounts:         model.NewDbAccountService(db),
-			AccountStorage:   model.NewDbAccountStorageService(db),
-			CharacterStorage: model.NewDbCharacterStorageService(db),
-			Characters:       model.NewDbCharacterService(db),
-			Realms:           model.NewDbRealmService(db),
-			Sessions:         model.NewDbSessionService(db),
-		},
+		services:   services,
+		world:      world.New(services.Characters),
+	}
+	s.world.SetDataSaver(s.savePlayerData)
+
+	return s
+}
+
+// savePlayerData saves everything about the player that isn't in their character. The world calls it when
+// players are autosaved and when they leave.
+func (s *Server) savePlayerData(p *world.Player) error {
+	if err := spell.Save(s.services, p); err != nil {
+		return err
+	}
+	if err := reputation.Save(s.services, p); err != nil {
+		return err
 	}
+	if err := skill.Save(s.services, p); err != nil {
+		return err
+	}
+	return talent.SaveGlyphs(s.services, p)
 }
 
 func (s *Server) Start() {
@@ -54,6 +104,8 @@ func (s *Server) Start() {
 	defer listener.Close()
 	log.Info().Str("listen", listener.Addr().String()).Msg("realmd start")
 
+	go s.world.Run()
+
 	for {
 		conn, err := listener.Accept()
 		if err != nil {
@@ -65,19 +117,21 @@ func (s *Server) Start() {
 }
 
 func (s *Server) handleConnection(conn net.Conn) {
+	client, err := realmd.NewClient(conn)
+	if err != nil {
+		log.Error().Err(err).Msg("error setting up client")
+		conn.Close()
+		return
+	}
+
 	defer func() {
 		if err := recover(); err != nil {
 			log.Warn().Stack().Any("err", err).Msg("recovered from panic")
 		}
+		s.removeFromWorld(client)
 		conn.Close()
 	}()
 
-	client, err := realmd.NewClient(conn)
-	if err != nil {
-		log.Error().Err(err).Msg("error setting up client")
-		return
-	}
-
 	ip := strings.Split(client.Conn.RemoteAddr().String(), ":")[0]
 
 	// Create a logger for the client that includes the client's ID/IP
@@ -169,9 +223,23 @@ func (s *Server) handlePacket(c *realmd.Client, header *realmd.ClientHeader, dat
 		}).
 		Msg("recv data")
 
+	// Logging in may have to save another session of the character, which is done without the world lock
+	if header.Opcode == realmd.OpClientPlayerLogin {
+		return s.login(c, data)
+	}
+
+	// Handlers run while holding the world lock so that they can safely access anything in the world
+	s.world.Lock()
+	defer s.world.Unlock()
+
 	switch header.Opcode {
 	// Ignored
-	case realmd.OpClientLogoutForce:
+	case realmd.OpClientLogoutForce,
+		realmd.OpClientMoveTimeSkipped,
+		realmd.OpClientSetActiveMover,
+		realmd.OpClientForceRunSpeedChangeAck,
+		realmd.OpClientForceSwimSpeedChangeAck,
+		realmd.OpClientForceFlightSpeedChangeAck:
 		c.Log.Debug().Str("op", opName).Msg("packet ignored")
 		return nil
 
@@ -200,14 +268,11 @@ func (s *Server) handlePacket(c *realmd.Client, header *realmd.ClientHeader, dat
 		}
 		return h.Handle()
 
-	case realmd.OpClientPlayerLogin:
-		return session.LoginHandler(s.services, c, data)
-
 	case realmd.OpClientLogoutRequest:
-		return session.LogoutHandler(c)
+		return session.LogoutHandler(s.world, c)
 
 	case realmd.OpClientLogoutCancel:
-		return session.LogoutCancelHandler(c)
+		return session.LogoutCancelHandler(s.world, c)
 
 	case realmd.OpClientPutStorage:
 		h := &account.StoragePutHandler{
@@ -224,21 +289,338 @@ func (s *Server) handlePacket(c *realmd.Client, header *realmd.ClientHeader, dat
 		return h.Handle(data)
 
 	case realmd.OpClientGetUITime:
-		return world.UITimeHandler(c)
+		return worldHandler.UITimeHandler(c)
 
 	case realmd.OpClientGetTime:
-		return world.ServerTimeHandler(c)
+		return worldHandler.ServerTimeHandler(c)
 
 	case realmd.OpClientGetPlayedTime:
-		return player.PlayedTimeHandler(c, data)
+		return player.PlayedTimeHandler(s.world, c, data)
 
 	case realmd.OpClientStandStateChange:
 		return player.StandStateHandler(c, data)
 
+	case realmd.OpClientSetActionButton:
+		return player.SetActionButtonHandler(s.services, s.world, c, data)
+
+	case realmd.OpClientSetActionBarToggles:
+		return player.SetActionBarTogglesHandler(s.world, c, data)
+
+	case realmd.OpClientSetFactionAtWar:
+		return player.SetFactionAtWarHandler(s.world, c, data)
+
+	case realmd.OpClientSetFactionInactive:
+		return player.SetFactionInactiveHandler(s.world, c, data)
+
 	case realmd.OpClientGetPlayerName:
 		return player.NameHandler(*s.services, c, data)
 
+	case realmd.OpClientWho:
+		return player.WhoHandler(s.services, s.world, c, data)
+
+	case realmd.OpClientContactList:
+		return social.ContactListHandler(s.world, c, data)
+
+	case realmd.OpClientAddFriend:
+		return social.AddFriendHandler(s.services, s.world, c, data)
+
+	case realmd.OpClientAddIgnore:
+		return social.AddIgnoreHandler(s.services, s.world, c, data)
+
+	case realmd.OpClientDelFriend, realmd.OpClientDelIgnore:
+		return social.RemoveContactHandler(s.services, s.world, c, header.Opcode, data)
+
+	case realmd.OpClientSetContactNotes:
+		return social.SetContactNotesHandler(s.services, s.world, c, data)
+
+	case realmd.OpClientGroupInvite:
+		return group.InviteHandler(s.world, c, data)
+
+	case realmd.OpClientGroupAccept, realmd.OpClientGroupDecline:
+		return group.InviteResponseHandler(s.world, c, header.Opcode)
+
+	case realmd.OpClientGroupUninvite:
+		return group.UninviteHandler(s.world, c, data)
+
+	case realmd.OpClientGroupUninviteGuid:
+		return group.UninviteGuidHandler(s.world, c, data)
+
+	case realmd.OpClientGroupDisband:
+		return group.LeaveHandler(s.world, c)
+
+	case realmd.OpClientGroupSetLeader:
+		return group.SetLeaderHandler(s.world, c, data)
+
+	case realmd.OpClientLootMethod:
+		return group.LootMethodHandler(s.world, c, data)
+
+	case realmd.OpClientRequestPartyMemberStats:
+		return group.PartyMemberStatsHandler(s.world, c, data)
+
+	case realmd.OpClientGroupRaidConvert:
+		return group.RaidConvertHandler(s.world, c)
+
+	case realmd.OpClientGroupChangeSubGroup:
+		return group.ChangeSubgroupHandler(s.world, c, data)
+
+	case realmd.OpClientGroupSwapSubGroup:
+		return group.SwapSubgroupHandler(s.world, c, data)
+
+	case realmd.OpClientGroupAssistantLeader:
+		return group.AssistantHandler(s.world, c, data)
+
+	case realmd.OpClientRaidReadyCheck:
+		return group.ReadyCheckHandler(s.world, c, data)
+
+	case realmd.OpClientRaidReadyCheckFinished:
+		return group.ReadyCheckFinishedHandler(s.world, c)
+
+	case realmd.OpClientRaidTargetUpdate:
+		return group.RaidTargetHandler(s.world, c, data)
+
+	case realmd.OpClientGuildQuery:
+		return guild.QueryHandler(s.world, c, data)
+
+	case realmd.OpClientGuildRoster:
+		return guild.RosterHandler(s.world, c)
+
+	case realmd.OpClientGuildInfo:
+		return guild.InfoHandler(s.world, c)
+
+	case realmd.OpClientGuildPermissions:
+		return guild.PermissionsHandler(s.world, c)
+
+	case realmd.OpClientGuildInvite:
+		return guild.InviteHandler(s.world, c, data)
+
+	case realmd.OpClientGuildAccept, realmd.OpClientGuildDecline:
+		return guild.InviteResponseHandler(s.services, s.world, c, header.Opcode)
+
+	case realmd.OpClientGuildPromote, realmd.OpClientGuildDemote:
+		return guild.RankChangeHandler(s.services, s.world, c, header.Opcode, data)
+
+	case realmd.OpClientGuildLeave:
+		return guild.LeaveHandler(s.services, s.world, c)
+
+	case realmd.OpClientGuildRemove:
+		return guild.RemoveHandler(s.services, s.world, c, data)
+
+	case realmd.OpClientGuildLeader:
+		return guild.LeaderHandler(s.services, s.world, c, data)
+
+	case realmd.OpClientGuildDisband:
+		return guild.DisbandHandler(s.services, s.world, c)
+
+	case realmd.OpClientGuildMOTD:
+		return guild.MOTDHandler(s.services, s.world, c, data)
+
+	case realmd.OpClientGuildInfoText:
+		return guild.InfoTextHandler(s.services, s.world, c, data)
+
+	case realmd.OpClientGuildSetPublicNote, realmd.OpClientGuildSetOfficerNote:
+		return guild.NoteHandler(s.services, s.world, c, header.Opcode, data)
+
+	case realmd.OpClientGuildRank:
+		return guild.SetRankHandler(s.services, s.world, c, data)
+
+	case realmd.OpClientGuildAddRank:
+		return guild.AddRankHandler(s.services, s.world, c, data)
+
+	case realmd.OpClientGuildDelRank:
+		return guild.DeleteRankHandler(s.services, s.world, c)
+
+	case realmd.OpClientGetItemInfo:
+		return item.QueryHandler(s.services, c, data)
+
+	case realmd.OpClientSwapItem:
+		return item.SwapItemHandler(s.services, s.world, c, data)
+
+	case realmd.OpClientSwapInvItem:
+		return item.SwapInvItemHandler(s.services, s.world, c, data)
+
+	case realmd.OpClientSplitItem:
+		return item.SplitItemHandler(s.services, s.world, c, data)
+
+	case realmd.OpClientDestroyItem:
+		return item.DestroyItemHandler(s.services, s.world, c, data)
+
+	case realmd.OpClientUseItem:
+		return item.UseItemHandler(s.services, s.world, c, data)
+
+	case realmd.OpClientAutoEquipItem:
+		return item.AutoEquipItemHandler(s.services, s.world, c, data)
+
+	case realmd.OpClientAutoStoreBagItem:
+		return item.AutoStoreBagItemHandler(s.services, s.world, c, data)
+
+	case realmd.OpClientCastSpell:
+		return spellHandler.CastHandler(s.services, s.world, c, data)
+
+	case realmd.OpClientCancelCast:
+		return spellHandler.CancelCastHandler(s.world, c, data)
+
+	case realmd.OpClientCancelAura:
+		return spellHandler.CancelAuraHandler(s.world, c, data)
+
+	case realmd.OpClientCancelChannelling:
+		return spellHandler.CancelChannellingHandler(s.world, c, data)
+
+	case realmd.OpClientLearnTalent:
+		return talentHandler.LearnTalentHandler(s.services, s.world, c, data)
+
+	case realmd.OpClientLearnPreviewTalents:
+		return talentHandler.LearnPreviewTalentsHandler(s.services, s.world, c, data)
+
+	case realmd.OpClientTalentWipeConfirm:
+		return talentHandler.TalentWipeConfirmHandler(s.services, s.world, c, data)
+
+	case realmd.OpClientRemoveGlyph:
+		return talentHandler.RemoveGlyphHandler(s.services, s.world, c, data)
+
+	case realmd.OpClientSendMail:
+		return mail.SendHandler(s.services, s.world, c, data)
+
+	case realmd.OpClientGetMailList:
+		return mail.ListHandler(s.services, s.world, c, data)
+
+	case realmd.OpClientMailTakeMoney:
+		return mail.TakeMoneyHandler(s.services, s.world, c, data)
+
+	case realmd.OpClientMailTakeItem:
+		return mail.TakeItemHandler(s.services, s.world, c, data)
+
+	case realmd.OpClientMailMarkAsRead:
+		return mail.MarkAsReadHandler(s.services, s.world, c, data)
+
+	case realmd.OpClientMailReturnToSender:
+		return mail.ReturnHandler(s.services, s.world, c, data)
+
+	case realmd.OpClientMailDelete:
+		return mail.DeleteHandler(s.services, s.world, c, data)
+
+	case realmd.OpClientQueryNextMailTime:
+		return mail.QueryNextMailTimeHandler(s.services, s.world, c)
+
+	case realmd.OpClientMoveStartForward,
+		realmd.OpClientMoveStartBackward,
+		realmd.OpClientMoveStop,
+		realmd.OpClientMoveStartStrafeLeft,
+		realmd.OpClientMoveStartStrafeRight,
+		realmd.OpClientMoveStopStrafe,
+		realmd.OpClientMoveJump,
+		realmd.OpClientMoveStartTurnLeft,
+		realmd.OpClientMoveStartTurnRight,
+		realmd.OpClientMoveStopTurn,
+		realmd.OpClientMoveStartPitchUp,
+		realmd.OpClientMoveStartPitchDown,
+		realmd.OpClientMoveStopPitch,
+		realmd.OpClientMoveSetRunMode,
+		realmd.OpClientMoveSetWalkMode,
+		realmd.OpClientMoveFallLand,
+		realmd.OpClientMoveStartSwim,
+		realmd.OpClientMoveStopSwim,
+		realmd.OpClientMoveSetFacing,
+		realmd.OpClientMoveSetPitch,
+		realmd.OpClientMoveHeartbeat,
+		realmd.OpClientMoveStartAscend,
+		realmd.OpClientMoveStopAscend,
+		realmd.OpClientMoveStartDescend:
+		return movement.Handler(s.world, c, header.Opcode, data)
+
+	case realmd.OpClientMoveFallReset:
+		return movement.FallResetHandler(s.world, c, data)
+
+	case realmd.OpClientMoveTeleportAck:
+		return movement.TeleportAckHandler(s.world, c, data)
+
+	case realmd.OpClientMoveWorldportAck:
+		return movement.WorldportAckHandler(s.world, c)
+
+	case realmd.OpClientMessageChat:
+		return chat.MessageHandler(s.services, s.world, c, data)
+
+	case realmd.OpClientJoinChannel:
+		return chat.JoinChannelHandler(s.services, s.world, c, data)
+
+	case realmd.OpClientLeaveChannel:
+		return chat.LeaveChannelHandler(s.services, s.world, c, data)
+
+	case realmd.OpClientChannelList,
+		realmd.OpClientChannelDisplayList,
+		realmd.OpClientChannelOwner,
+		realmd.OpClientChannelAnnouncements,
+		realmd.OpClientChannelModerate:
+		return chat.ChannelHandler(s.world, c, header.Opcode, data)
+
+	case realmd.OpClientChannelPassword,
+		realmd.OpClientChannelSetOwner,
+		realmd.OpClientChannelModerator,
+		realmd.OpClientChannelUnmoderator,
+		realmd.OpClientChannelMute,
+		realmd.OpClientChannelUnmute,
+		realmd.OpClientChannelInvite,
+		realmd.OpClientChannelKick,
+		realmd.OpClientChannelBan,
+		realmd.OpClientChannelUnban:
+		return chat.ChannelTargetHandler(s.services, s.world, c, header.Opcode, data)
+
+	case realmd.OpClientEnteredZone:
+		return worldHandler.ZoneUpdateHandler(s.services, s.world, c, data)
+
+	case realmd.OpClientAreaTrigger:
+		return worldHandler.AreaTriggerHandler(s.services, s.world, c, data)
+
+	case realmd.OpClientAttackSwing:
+		return combat.AttackSwingHandler(s.world, c, data)
+
+	case realmd.OpClientAttackStop:
+		return combat.AttackStopHandler(s.world, c)
+
+	case realmd.OpClientRepopRequest:
+		return combat.RepopRequestHandler(s.services, s.world, c)
+
+	case realmd.OpClientReclaimCorpse:
+		return combat.ReclaimCorpseHandler(s.world, c, data)
+
+	case realmd.OpClientSpiritHealerActivate:
+		return combat.SpiritHealerActivateHandler(s.services, s.world, c, data)
+
+	case realmd.OpClientCorpseQuery:
+		return combat.CorpseQueryHandler(s.world, c)
+
 	default:
 		return nil
 	}
 }
+
+// login logs the client in as a character. If the character is still in the world from another connection,
+// that session is kicked and saved first. The world is unlocked while it's saved, so a slow save doesn't hold
+// up the rest of the world.
+func (s *Server) login(c *realmd.Client, data []byte) error {
+	s.world.Lock()
+	other := session.KickOtherSession(s.world, c, data)
+	s.world.Unlock()
+
+	if other != nil {
+		s.world.SavePlayerData(other)
+	}
+
+	s.world.Lock()
+	defer s.world.Unlock()
+
+	return session.LoginHandler(s.services, s.world, c, data)
+}
+
+// removeFromWorld removes the client's player from the world, if they are in it. This should be
+// called when the client disconnects without logging out.
+func (s *Server) removeFromWorld(c *realmd.Client) {
+	s.world.Lock()
+	defer s.world.Unlock()
+
+	c.CancelPendingLogout()
+
+	if p := s.world.PlayerFor(c); p != nil {
+		s.world.RemovePlayer(p)
+		c.Log.Info().Str("char", c.Character.String()).Msg("player removed from world")
+	}
+}
@@ -1,12 +1,29 @@
 package realmd
 
-import "github.com/kangaroux/gomaggus/model"
+import (
+	"github.com/kangaroux/gomaggus/model"
+	"github.com/kangaroux/gomaggus/realmd/dbc"
+)
 
 type Service struct {
-	Accounts         model.AccountService
-	AccountStorage   model.AccountStorageService
-	CharacterStorage model.CharacterStorageService
-	Characters       model.CharacterService
-	Realms           model.RealmService
-	Sessions         model.SessionService
+	Accounts          model.AccountService
+	Actions           model.ActionService
+	AccountStorage    model.AccountStorageService
+	CharacterChannels model.CharacterChannelService
+	CharacterStorage  model.CharacterStorageService
+	Characters        model.CharacterService
+	CommandLog        model.CommandLogService
+	Contacts          model.ContactService
+	Guilds            model.GuildService
+	Items             model.ItemService
+	Mail              model.MailService
+	Realms            model.RealmService
+	Reputations       model.ReputationService
+	Sessions          model.SessionService
+	Skills            model.SkillService
+	Spells            model.SpellService
+	Talents           model.TalentService
+
+	// DBC contains the client's game data tables.
+	DBC *dbc.Store
 }
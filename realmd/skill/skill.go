@@ -0,0 +1,66 @@
+// Package skill loads and saves the skills that players know.
+package skill
+
+import (
+	"github.com/kangaroux/gomaggus/model"
+	"github.com/kangaroux/gomaggus/realmd"
+	"github.com/kangaroux/gomaggus/realmd/player"
+	"github.com/kangaroux/gomaggus/realmd/world"
+)
+
+// Load loads the skills the player knows. Players that don't have any saved skills haven't logged in before,
+// so they're given the skills for their race and class, and the class skills for the spells they know. It
+// should be called after the player's spells are loaded, and before they're added to the world.
+func Load(svc *realmd.Service, p *world.Player) error {
+	saved, err := svc.Skills.List(p.Character.Id)
+	if err != nil {
+		return err
+	}
+
+	if len(saved) > 0 {
+		skills := make([]player.Skill, 0, len(saved))
+		for _, m := range saved {
+			skills = append(skills, player.Skill{ID: m.SkillId, Value: m.Value, Max: m.Max})
+		}
+		p.SetSkills(skills)
+		return nil
+	}
+
+	char := p.Character
+	level := p.Values.Level()
+	skills := player.StartingSkills(char.Race, char.Class, level)
+
+	known := make(map[uint16]bool, len(skills))
+	for _, s := range skills {
+		known[s.ID] = true
+	}
+
+	for _, spell := range p.Spells() {
+		for _, a := range svc.DBC.SkillLineAbilitiesForSpell(spell) {
+			id := uint16(a.SkillLine)
+			if known[id] || player.SkillTypeOf(id) != player.SkillTypeClass {
+				continue
+			}
+			known[id] = true
+			skills = append(skills, player.NewSkill(id, level))
+		}
+	}
+
+	p.SetSkills(skills)
+	return nil
+}
+
+// Save saves the skills the player knows.
+func Save(svc *realmd.Service, p *world.Player) error {
+	var skills []*model.CharacterSkill
+	for _, s := range p.Skills() {
+		skills = append(skills, &model.CharacterSkill{
+			CharacterId: p.Character.Id,
+			SkillId:     s.ID,
+			Value:       s.Value,
+			Max:         s.Max,
+		})
+	}
+
+	return svc.Skills.Save(p.Character.Id, skills)
+}
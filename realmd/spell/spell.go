@@ -0,0 +1,181 @@
+// Package spell loads and saves the spells that players know, their cooldowns and their auras. The world
+// casts the spells, using the casts created with NewCast.
+package spell
+
+import (
+	"time"
+
+	"github.com/kangaroux/gomaggus/model"
+	"github.com/kangaroux/gomaggus/realmd"
+	"github.com/kangaroux/gomaggus/realmd/dbc"
+	"github.com/kangaroux/gomaggus/realmd/player"
+	"github.com/kangaroux/gomaggus/realmd/world"
+)
+
+// Known returns the ids of the spells the character knows. Characters that don't know any spells haven't
+// logged in before, so they're given their starting spells.
+func Known(svc *realmd.Service, char *model.Character) ([]uint32, error) {
+	spells, err := svc.Spells.List(char.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(spells) > 0 {
+		ids := make([]uint32, 0, len(spells))
+		for _, s := range spells {
+			ids = append(ids, s.SpellId)
+		}
+		return ids, nil
+	}
+
+	ids := svc.DBC.StartingSpells(char.Race, char.Class, player.StartLevel(char.Class))
+	for _, id := range ids {
+		if err := svc.Spells.Add(&model.CharacterSpell{CharacterId: char.Id, SpellId: id}); err != nil {
+			return nil, err
+		}
+	}
+
+	return ids, nil
+}
+
+// Load loads the spells the player knows, their cooldowns and their auras. It should be called before the
+// player is added to the world.
+func Load(svc *realmd.Service, p *world.Player) error {
+	ids, err := Known(svc, p.Character)
+	if err != nil {
+		return err
+	}
+	p.SetSpells(ids)
+
+	cooldowns, err := svc.Spells.ListCooldowns(p.Character.Id)
+	if err != nil {
+		return err
+	}
+	p.SetCooldowns(cooldowns)
+
+	auras, err := svc.Spells.ListAuras(p.Character.Id)
+	if err != nil {
+		return err
+	}
+
+	restored := make([]*world.Aura, 0, len(auras))
+	for _, m := range auras {
+		spell, ok := svc.DBC.Spells[m.SpellId]
+		if !ok {
+			p.Client.Log.Warn().Uint32("spell", m.SpellId).Msg("aura spell doesn't exist")
+			continue
+		}
+
+		restored = append(restored, &world.Aura{
+			Spell:       spell,
+			Caster:      realmd.Guid(m.CasterGuid),
+			Level:       m.Level,
+			Positive:    m.Positive,
+			Stacks:      m.Stacks,
+			Charges:     m.Charges,
+			Duration:    time.Duration(m.Remaining) * time.Millisecond,
+			MaxDuration: time.Duration(m.MaxDuration) * time.Millisecond,
+			Amounts:     [dbc.NumSpellEffects]int32{m.Amount1, m.Amount2, m.Amount3},
+		})
+	}
+	p.SetAuras(restored)
+
+	return nil
+}
+
+// Save saves the player's cooldowns and the auras that will expire, so they're still there the next time the
+// player logs in. Auras that last until they're removed come from somewhere else, and aren't saved.
+func Save(svc *realmd.Service, p *world.Player) error {
+	if err := svc.Spells.SaveCooldowns(p.Character.Id, p.Cooldowns()); err != nil {
+		return err
+	}
+
+	var auras []*model.CharacterAura
+	for _, a := range p.Auras() {
+		if a.Duration <= 0 {
+			continue
+		}
+
+		auras = append(auras, &model.CharacterAura{
+			CharacterId: p.Character.Id,
+			SpellId:     a.Spell.ID,
+			CasterGuid:  uint64(a.Caster),
+			Level:       a.Level,
+			Positive:    a.Positive,
+			Stacks:      a.Stacks,
+			Charges:     a.Charges,
+			Amount1:     a.Amounts[0],
+			Amount2:     a.Amounts[1],
+			Amount3:     a.Amounts[2],
+			Remaining:   int32(a.Duration.Milliseconds()),
+			MaxDuration: int32(a.MaxDuration.Milliseconds()),
+		})
+	}
+
+	return svc.Spells.SaveAuras(p.Character.Id, auras)
+}
+
+// Learn teaches the player a spell and saves it. Returns false if they already know it.
+func Learn(svc *realmd.Service, p *world.Player, id uint32) (bool, error) {
+	if !p.LearnSpell(id) {
+		return false, nil
+	}
+
+	if err := svc.Spells.Add(&model.CharacterSpell{CharacterId: p.Character.Id, SpellId: id}); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// NewCast creates a cast of the spell by the player, using the spell's cast time, duration, range and
+// cost at the player's level, and the skill it raises.
+func NewCast(svc *realmd.Service, p *world.Player, spell *dbc.Spell, count uint8, targets world.SpellTargets) *world.Cast {
+	level := p.Values.Level()
+
+	cost := spell.ManaCost
+	if spell.ManaCostPerLevel > 0 && level > spell.SpellLevel {
+		cost += spell.ManaCostPerLevel * (level - spell.SpellLevel)
+	}
+	if spell.ManaCostPercentage > 0 && realmd.PowerType(spell.PowerType) == realmd.PowerTypeMana {
+		cost += p.Values.BaseMana() * spell.ManaCostPercentage / 100
+	}
+
+	return &world.Cast{
+		Spell:    spell,
+		Count:    count,
+		Targets:  targets,
+		CastTime: svc.DBC.CastTime(spell),
+		Duration: svc.DBC.Duration(spell, level),
+		Range:    svc.DBC.SpellRanges[spell.RangeIndex],
+		Cost:     cost,
+		Ability:  skillAbility(svc, spell),
+	}
+}
+
+// NewItemCast creates a cast of the spell on an item the player is using. Spells that apply a glyph put it
+// in glyphSlot.
+func NewItemCast(svc *realmd.Service, p *world.Player, item *world.Item, spell *dbc.Spell, count uint8, targets world.SpellTargets, glyphSlot uint8) *world.Cast {
+	c := NewCast(svc, p, spell, count, targets)
+	c.Item = item
+
+	for _, effect := range spell.Effects {
+		if effect.Effect == world.SpellEffectApplyGlyph {
+			c.Glyph = svc.DBC.GlyphProperties[uint32(effect.MiscValue)]
+			c.GlyphSlot = glyphSlot
+		}
+	}
+
+	return c
+}
+
+// skillAbility returns the ability that can raise a skill when the spell is cast, or nil if it doesn't
+// raise one.
+func skillAbility(svc *realmd.Service, spell *dbc.Spell) *dbc.SkillLineAbility {
+	for _, a := range svc.DBC.SkillLineAbilitiesForSpell(spell.ID) {
+		if a.TrivialHigh > 0 {
+			return a
+		}
+	}
+	return nil
+}
@@ -0,0 +1,139 @@
+package talent
+
+import (
+	"github.com/kangaroux/gomaggus/model"
+	"github.com/kangaroux/gomaggus/realmd"
+	"github.com/kangaroux/gomaggus/realmd/world"
+)
+
+// LoadSpecs loads the talents, glyphs and action buttons in each of the character's specs. The character's
+// active spec is reset if it's not one of them.
+func LoadSpecs(svc *realmd.Service, char *model.Character) ([]*world.TalentSpec, error) {
+	count := int(char.SpecCount)
+	if count < 1 {
+		count = 1
+	} else if count > world.MaxTalentSpecs {
+		count = world.MaxTalentSpecs
+	}
+
+	if int(char.ActiveSpec) >= count {
+		char.ActiveSpec = 0
+	}
+
+	specs := make([]*world.TalentSpec, count)
+	for i := range specs {
+		specs[i] = world.NewTalentSpec()
+	}
+
+	talents, err := svc.Talents.List(char.Id)
+	if err != nil {
+		return nil, err
+	}
+	for _, m := range talents {
+		t, ok := svc.DBC.Talents[m.TalentId]
+		if !ok || int(m.Spec) >= count || int(m.Rank) >= t.Ranks() {
+			continue
+		}
+		specs[m.Spec].Talents[t.ID] = &world.LearnedTalent{Talent: t, Rank: m.Rank}
+	}
+
+	glyphs, err := svc.Talents.ListGlyphs(char.Id)
+	if err != nil {
+		return nil, err
+	}
+	for _, m := range glyphs {
+		if int(m.Spec) < count && m.Slot < world.MaxGlyphSlots {
+			specs[m.Spec].Glyphs[m.Slot] = m.GlyphId
+		}
+	}
+
+	for i, spec := range specs {
+		buttons, err := svc.Actions.List(char.Id, uint8(i))
+		if err != nil {
+			return nil, err
+		}
+		for _, b := range buttons {
+			if int(b.Button) < len(spec.Actions) {
+				spec.Actions[b.Button] = b.Packed()
+			}
+		}
+	}
+
+	return specs, nil
+}
+
+// Learn learns rank of a talent in the player's active spec and saves it. Returns false if the talent
+// isn't in one of the player's class's trees, or they can't learn it.
+func Learn(svc *realmd.Service, w *world.World, p *world.Player, id uint32, rank uint8) (bool, error) {
+	t, ok := svc.DBC.Talents[id]
+	if !ok {
+		return false, nil
+	}
+
+	tab, ok := svc.DBC.TalentTabs[t.Tab]
+	if !ok || tab.ClassMask&(1<<(p.Character.Class-1)) == 0 {
+		return false, nil
+	}
+
+	if !w.LearnTalent(p, t, rank) {
+		return false, nil
+	}
+
+	talent := &model.CharacterTalent{
+		CharacterId: p.Character.Id,
+		Spec:        p.ActiveSpec(),
+		TalentId:    t.ID,
+		Rank:        p.Spec().Talents[t.ID].Rank,
+	}
+	if err := svc.Talents.Set(talent); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// Reset charges the player to reset the talents in their active spec and saves it. Returns false if they
+// couldn't reset them.
+func Reset(svc *realmd.Service, w *world.World, p *world.Player) (bool, error) {
+	if !w.ResetTalents(p) {
+		return false, nil
+	}
+
+	if err := svc.Talents.Reset(p.Character.Id, p.ActiveSpec()); err != nil {
+		return false, err
+	}
+
+	// The cost of the next reset depends on this one, so it's saved right away
+	w.SavePlayer(p)
+
+	return true, nil
+}
+
+// RemoveGlyph clears one of the glyph slots in the player's active spec and saves it.
+func RemoveGlyph(svc *realmd.Service, w *world.World, p *world.Player, slot uint8) error {
+	if !w.RemoveGlyph(p, slot) {
+		return nil
+	}
+	return svc.Talents.RemoveGlyph(p.Character.Id, p.ActiveSpec(), slot)
+}
+
+// SaveGlyphs saves the glyphs in each of the player's specs. Glyphs are applied by spells, so they're saved
+// along with the rest of the player instead of right away.
+func SaveGlyphs(svc *realmd.Service, p *world.Player) error {
+	var glyphs []*model.CharacterGlyph
+	for i, spec := range p.Specs() {
+		for slot, id := range spec.Glyphs {
+			if id == 0 {
+				continue
+			}
+			glyphs = append(glyphs, &model.CharacterGlyph{
+				CharacterId: p.Character.Id,
+				Spec:        uint8(i),
+				Slot:        uint8(slot),
+				GlyphId:     id,
+			})
+		}
+	}
+
+	return svc.Talents.SaveGlyphs(p.Character.Id, glyphs)
+}
@@ -0,0 +1,50 @@
+package values
+
+import (
+	"reflect"
+
+	"github.com/kangaroux/gomaggus/realmd"
+)
+
+const (
+	ContainerDataOffset = ItemDataOffset + ItemDataSize
+	ContainerDataSize   = 74
+)
+
+// Adapted from Gophercraft with some modifications
+// https://github.com/Gophercraft/core/blob/master/packet/update/d12340/descriptor.go
+type ContainerData struct {
+	numSlots uint32
+	_        uint32
+	slots    [36]realmd.Guid
+
+	dirty *dirtyValues `value:"END"`
+}
+
+func NewContainerData() *ContainerData {
+	return &ContainerData{
+		dirty: newDirtyValues(getStructLayout(reflect.ValueOf(ContainerData{}))),
+	}
+}
+
+func (c *ContainerData) Marshal(onlyDirty bool) ([]byte, []structSection) {
+	return marshalValues(c, onlyDirty, c.dirty)
+}
+
+func (c *ContainerData) NumSlots() uint32 {
+	return c.numSlots
+}
+
+func (c *ContainerData) SetNumSlots(val uint32) {
+	c.numSlots = val
+	c.dirty.Flag("numSlots")
+}
+
+func (c *ContainerData) Slots() [36]realmd.Guid {
+	return c.slots
+}
+
+func (c *ContainerData) SetSlots(val [36]realmd.Guid) {
+	c.slots = val
+	c.dirty.Flag("slots")
+}
@@ -0,0 +1,36 @@
+package values
+
+// Corpse contains the values for a corpse.
+type Corpse struct {
+	*ObjectData
+	*CorpseData
+}
+
+func NewCorpse() *Corpse {
+	return &Corpse{
+		ObjectData: NewObjectData(),
+		CorpseData: NewCorpseData(),
+	}
+}
+
+// Marshal returns the corpse values and mask as a little endian byte array.
+func (c *Corpse) Marshal(onlyDirty bool) []byte {
+	var mask blockMask
+	var data, d []byte
+	var s []structSection
+
+	d, s = c.ObjectData.Marshal(onlyDirty)
+	data = append(data, d...)
+	mask.Update(s, ObjectDataOffset)
+
+	d, s = c.CorpseData.Marshal(onlyDirty)
+	data = append(data, d...)
+	mask.Update(s, CorpseDataOffset)
+
+	return append(mask.Bytes(), data...)
+}
+
+// Dirty reports whether any of the corpse's values were changed since they were last marshalled.
+func (c *Corpse) Dirty() bool {
+	return len(c.ObjectData.dirty.sections) > 0 || len(c.CorpseData.dirty.sections) > 0
+}
@@ -0,0 +1,179 @@
+package values
+
+import (
+	"reflect"
+
+	"github.com/kangaroux/gomaggus/model"
+	"github.com/kangaroux/gomaggus/realmd"
+)
+
+const (
+	CorpseDataOffset = ObjectDataSize
+	CorpseDataSize   = 30
+)
+
+// Adapted from Gophercraft with some modifications
+// https://github.com/Gophercraft/core/blob/master/packet/update/d12340/descriptor.go
+type CorpseData struct {
+	owner     realmd.Guid
+	party     realmd.Guid
+	displayID uint32
+
+	// items are the display ids of the owner's equipment, with the inventory type in the top byte.
+	items [19]uint32
+
+	// Bytes1
+	_      uint8
+	race   model.Race
+	gender model.Gender
+	skin   uint8
+
+	// Bytes2
+	face       uint8
+	hairStyle  uint8
+	hairColor  uint8
+	facialHair uint8
+
+	guild        uint32
+	flags        uint32
+	dynamicFlags uint32
+	_            uint32
+
+	dirty *dirtyValues `value:"END"`
+}
+
+func NewCorpseData() *CorpseData {
+	return &CorpseData{
+		dirty: newDirtyValues(getStructLayout(reflect.ValueOf(CorpseData{}))),
+	}
+}
+
+func (c *CorpseData) Marshal(onlyDirty bool) ([]byte, []structSection) {
+	return marshalValues(c, onlyDirty, c.dirty)
+}
+
+func (c *CorpseData) Owner() realmd.Guid {
+	return c.owner
+}
+
+func (c *CorpseData) SetOwner(val realmd.Guid) {
+	c.owner = val
+	c.dirty.Flag("owner")
+}
+
+func (c *CorpseData) Party() realmd.Guid {
+	return c.party
+}
+
+func (c *CorpseData) SetParty(val realmd.Guid) {
+	c.party = val
+	c.dirty.Flag("party")
+}
+
+func (c *CorpseData) DisplayID() uint32 {
+	return c.displayID
+}
+
+func (c *CorpseData) SetDisplayID(val uint32) {
+	c.displayID = val
+	c.dirty.Flag("displayID")
+}
+
+func (c *CorpseData) Items() [19]uint32 {
+	return c.items
+}
+
+func (c *CorpseData) SetItems(val [19]uint32) {
+	c.items = val
+	c.dirty.Flag("items")
+}
+
+func (c *CorpseData) Race() model.Race {
+	return c.race
+}
+
+func (c *CorpseData) SetRace(val model.Race) {
+	c.race = val
+	c.dirty.Flag("race")
+}
+
+func (c *CorpseData) Gender() model.Gender {
+	return c.gender
+}
+
+func (c *CorpseData) SetGender(val model.Gender) {
+	c.gender = val
+	c.dirty.Flag("gender")
+}
+
+func (c *CorpseData) Skin() uint8 {
+	return c.skin
+}
+
+func (c *CorpseData) SetSkin(val uint8) {
+	c.skin = val
+	c.dirty.Flag("skin")
+}
+
+func (c *CorpseData) Face() uint8 {
+	return c.face
+}
+
+func (c *CorpseData) SetFace(val uint8) {
+	c.face = val
+	c.dirty.Flag("face")
+}
+
+func (c *CorpseData) HairStyle() uint8 {
+	return c.hairStyle
+}
+
+func (c *CorpseData) SetHairStyle(val uint8) {
+	c.hairStyle = val
+	c.dirty.Flag("hairStyle")
+}
+
+func (c *CorpseData) HairColor() uint8 {
+	return c.hairColor
+}
+
+func (c *CorpseData) SetHairColor(val uint8) {
+	c.hairColor = val
+	c.dirty.Flag("hairColor")
+}
+
+func (c *CorpseData) FacialHair() uint8 {
+	return c.facialHair
+}
+
+func (c *CorpseData) SetFacialHair(val uint8) {
+	c.facialHair = val
+	c.dirty.Flag("facialHair")
+}
+
+func (c *CorpseData) Guild() uint32 {
+	return c.guild
+}
+
+func (c *CorpseData) SetGuild(val uint32) {
+	c.guild = val
+	c.dirty.Flag("guild")
+}
+
+func (c *CorpseData) Flags() uint32 {
+	return c.flags
+}
+
+func (c *CorpseData) SetFlags(val uint32) {
+	c.flags = val
+	c.dirty.Flag("flags")
+}
+
+func (c *CorpseData) DynamicFlags() uint32 {
+	return c.dynamicFlags
+}
+
+func (c *CorpseData) SetDynamicFlags(val uint32) {
+	c.dynamicFlags = val
+	c.dirty.Flag("dynamicFlags")
+}
@@ -0,0 +1,53 @@
+package values
+
+// Item contains the values for an item. Container is only set if the item is a bag.
+type Item struct {
+	*ObjectData
+	*ItemData
+	*ContainerData
+}
+
+func NewItem() *Item {
+	return &Item{
+		ObjectData: NewObjectData(),
+		ItemData:   NewItemData(),
+	}
+}
+
+func NewContainer() *Item {
+	return &Item{
+		ObjectData:    NewObjectData(),
+		ItemData:      NewItemData(),
+		ContainerData: NewContainerData(),
+	}
+}
+
+// Marshal returns the item values and mask as a little endian byte array.
+func (i *Item) Marshal(onlyDirty bool) []byte {
+	var mask blockMask
+	var data, d []byte
+	var s []structSection
+
+	d, s = i.ObjectData.Marshal(onlyDirty)
+	data = append(data, d...)
+	mask.Update(s, ObjectDataOffset)
+
+	d, s = i.ItemData.Marshal(onlyDirty)
+	data = append(data, d...)
+	mask.Update(s, ItemDataOffset)
+
+	if i.ContainerData != nil {
+		d, s = i.ContainerData.Marshal(onlyDirty)
+		data = append(data, d...)
+		mask.Update(s, ContainerDataOffset)
+	}
+
+	return append(mask.Bytes(), data...)
+}
+
+// Dirty reports whether any of the item's values were changed since they were last marshalled.
+func (i *Item) Dirty() bool {
+	return len(i.ObjectData.dirty.sections) > 0 ||
+		len(i.ItemData.dirty.sections) > 0 ||
+		(i.ContainerData != nil && len(i.ContainerData.dirty.sections) > 0)
+}
@@ -0,0 +1,177 @@
+package values
+
+import (
+	"reflect"
+
+	"github.com/kangaroux/gomaggus/model"
+	"github.com/kangaroux/gomaggus/realmd"
+)
+
+const (
+	ItemDataOffset = ObjectDataSize
+	ItemDataSize   = 58
+)
+
+type ItemEnchantment struct {
+	ID       uint32
+	Duration uint32
+	Charges  uint32
+}
+
+// Adapted from Gophercraft with some modifications
+// https://github.com/Gophercraft/core/blob/master/packet/update/d12340/descriptor.go
+type ItemData struct {
+	owner              realmd.Guid
+	contained          realmd.Guid
+	creator            realmd.Guid
+	giftCreator        realmd.Guid
+	stackCount         uint32
+	duration           uint32
+	spellCharges       [5]int32
+	flags              model.ItemFlags
+	enchantments       [12]ItemEnchantment
+	propertySeed       uint32
+	randomPropertiesID int32
+	durability         uint32
+	maxDurability      uint32
+	createPlayedTime   uint32
+	_                  uint32
+
+	dirty *dirtyValues `value:"END"`
+}
+
+func NewItemData() *ItemData {
+	return &ItemData{
+		dirty: newDirtyValues(getStructLayout(reflect.ValueOf(ItemData{}))),
+	}
+}
+
+func (i *ItemData) Marshal(onlyDirty bool) ([]byte, []structSection) {
+	return marshalValues(i, onlyDirty, i.dirty)
+}
+
+func (i *ItemData) Owner() realmd.Guid {
+	return i.owner
+}
+
+func (i *ItemData) SetOwner(val realmd.Guid) {
+	i.owner = val
+	i.dirty.Flag("owner")
+}
+
+func (i *ItemData) Contained() realmd.Guid {
+	return i.contained
+}
+
+func (i *ItemData) SetContained(val realmd.Guid) {
+	i.contained = val
+	i.dirty.Flag("contained")
+}
+
+func (i *ItemData) Creator() realmd.Guid {
+	return i.creator
+}
+
+func (i *ItemData) SetCreator(val realmd.Guid) {
+	i.creator = val
+	i.dirty.Flag("creator")
+}
+
+func (i *ItemData) GiftCreator() realmd.Guid {
+	return i.giftCreator
+}
+
+func (i *ItemData) SetGiftCreator(val realmd.Guid) {
+	i.giftCreator = val
+	i.dirty.Flag("giftCreator")
+}
+
+func (i *ItemData) StackCount() uint32 {
+	return i.stackCount
+}
+
+func (i *ItemData) SetStackCount(val uint32) {
+	i.stackCount = val
+	i.dirty.Flag("stackCount")
+}
+
+func (i *ItemData) Duration() uint32 {
+	return i.duration
+}
+
+func (i *ItemData) SetDuration(val uint32) {
+	i.duration = val
+	i.dirty.Flag("duration")
+}
+
+func (i *ItemData) SpellCharges() [5]int32 {
+	return i.spellCharges
+}
+
+func (i *ItemData) SetSpellCharges(val [5]int32) {
+	i.spellCharges = val
+	i.dirty.Flag("spellCharges")
+}
+
+func (i *ItemData) Flags() model.ItemFlags {
+	return i.flags
+}
+
+func (i *ItemData) SetFlags(val model.ItemFlags) {
+	i.flags = val
+	i.dirty.Flag("flags")
+}
+
+func (i *ItemData) Enchantments() [12]ItemEnchantment {
+	return i.enchantments
+}
+
+func (i *ItemData) SetEnchantments(val [12]ItemEnchantment) {
+	i.enchantments = val
+	i.dirty.Flag("enchantments")
+}
+
+func (i *ItemData) PropertySeed() uint32 {
+	return i.propertySeed
+}
+
+func (i *ItemData) SetPropertySeed(val uint32) {
+	i.propertySeed = val
+	i.dirty.Flag("propertySeed")
+}
+
+func (i *ItemData) RandomPropertiesID() int32 {
+	return i.randomPropertiesID
+}
+
+func (i *ItemData) SetRandomPropertiesID(val int32) {
+	i.randomPropertiesID = val
+	i.dirty.Flag("randomPropertiesID")
+}
+
+func (i *ItemData) Durability() uint32 {
+	return i.durability
+}
+
+func (i *ItemData) SetDurability(val uint32) {
+	i.durability = val
+	i.dirty.Flag("durability")
+}
+
+func (i *ItemData) MaxDurability() uint32 {
+	return i.maxDurability
+}
+
+func (i *ItemData) SetMaxDurability(val uint32) {
+	i.maxDurability = val
+	i.dirty.Flag("maxDurability")
+}
+
+func (i *ItemData) CreatePlayedTime() uint32 {
+	return i.createPlayedTime
+}
+
+func (i *ItemData) SetCreatePlayedTime(val uint32) {
+	i.createPlayedTime = val
+	i.dirty.Flag("createPlayedTime")
+}
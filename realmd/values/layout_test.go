@@ -243,3 +243,94 @@ func TestFieldNotMultipleOfBlockSize(t *testing.T) {
 		getStructLayout(reflect.ValueOf(foo{}))
 	})
 }
+
+func TestItemDataLayout(t *testing.T) {
+	m := getStructLayout(reflect.ValueOf(ItemData{}))
+	assert.Equal(t, ItemDataSize, m.size)
+	assert.Equal(t, 14, len(m.sections))
+
+	expected := []struct {
+		blockStart int
+		size       int
+	}{
+		{0, 2},
+		{2, 2},
+		{4, 2},
+		{6, 2},
+		{8, 1},
+		{9, 1},
+		{10, 5},
+		{15, 1},
+		{16, 36},
+		{52, 1},
+		{53, 1},
+		{54, 1},
+		{55, 1},
+		{56, 1},
+		// {57, 1}, padding
+	}
+
+	assert.Equal(t, len(m.sections), len(expected))
+
+	for i := 0; i < len(expected); i++ {
+		t.Run(fmt.Sprintf("block-%d", i), func(t *testing.T) {
+			assert.Equal(t, expected[i].blockStart, m.sections[i].blockStart)
+			assert.Equal(t, expected[i].size, m.sections[i].size)
+		})
+	}
+}
+
+func TestContainerDataLayout(t *testing.T) {
+	m := getStructLayout(reflect.ValueOf(ContainerData{}))
+	assert.Equal(t, ContainerDataSize, m.size)
+	assert.Equal(t, 2, len(m.sections))
+
+	expected := []struct {
+		blockStart int
+		size       int
+	}{
+		{0, 1},
+		// {1, 1}, padding
+		{2, 72},
+	}
+
+	assert.Equal(t, len(m.sections), len(expected))
+
+	for i := 0; i < len(expected); i++ {
+		t.Run(fmt.Sprintf("block-%d", i), func(t *testing.T) {
+			assert.Equal(t, expected[i].blockStart, m.sections[i].blockStart)
+			assert.Equal(t, expected[i].size, m.sections[i].size)
+		})
+	}
+}
+
+func TestCorpseDataLayout(t *testing.T) {
+	m := getStructLayout(reflect.ValueOf(CorpseData{}))
+	assert.Equal(t, CorpseDataSize, m.size)
+	assert.Equal(t, 9, len(m.sections))
+
+	expected := []struct {
+		blockStart int
+		size       int
+	}{
+		{0, 2},
+		{2, 2},
+		{4, 1},
+		{5, 19},
+		{24, 1},
+		{25, 1},
+		{26, 1},
+		{27, 1},
+		{28, 1},
+		// {29, 1}, padding
+	}
+
+	assert.Equal(t, len(m.sections), len(expected))
+
+	for i := 0; i < len(expected); i++ {
+		t.Run(fmt.Sprintf("block-%d", i), func(t *testing.T) {
+			assert.Equal(t, expected[i].blockStart, m.sections[i].blockStart)
+			assert.Equal(t, expected[i].size, m.sections[i].size)
+		})
+	}
+}
@@ -141,7 +141,7 @@ type PositionData struct {
 
 // MovementUpdateStationaryPosition
 type StationaryPositionData struct {
-	// TODO
+	PositionRotation realmd.Vector4
 }
 
 // MovementUpdateHighGuid
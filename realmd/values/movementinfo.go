@@ -0,0 +1,179 @@
+package values
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+
+	"github.com/kangaroux/gomaggus/realmd"
+)
+
+// TransportInfo is included in movement info when the mover is on a transport (boat, zeppelin, etc.)
+// https://gtker.com/wow_messages/docs/transportinfo.html#client-version-335
+type TransportInfo struct {
+	Guid     realmd.Guid
+	Position realmd.Vector4
+	Time     uint32
+	Seat     int8
+
+	// InterpolatedTime is only included if LivingMovementFlagTransportInterpolatedMovement is set.
+	InterpolatedTime uint32
+}
+
+// MovementInfo describes the state of a unit's movement. Clients send this with every movement packet.
+// https://gtker.com/wow_messages/docs/movementinfo.html#client-version-335
+type MovementInfo struct {
+	Flags    LivingMovementFlag
+	Time     uint32
+	Position realmd.Vector4
+
+	// Transport is nil unless LivingMovementFlagOnTransport is set.
+	Transport *TransportInfo
+
+	// Pitch is only used when swimming, flying, or LivingMovementFlagAlwaysAllowPitching is set.
+	Pitch float32
+
+	FallTime uint32
+
+	// Fall is nil unless LivingMovementFlagFalling is set.
+	Fall *FallData
+
+	// SplineElevation is only used if LivingMovementFlagSplineElevation is set.
+	SplineElevation float32
+}
+
+var ErrMovementFlagsMismatch = errors.New("movement info flags don't match the optional fields")
+
+// HasFlag reports whether all of the flags are set.
+func (m *MovementInfo) HasFlag(flags LivingMovementFlag) bool {
+	return m.Flags&flags == flags
+}
+
+// HasPitch reports whether the pitch field is included.
+func (m *MovementInfo) HasPitch() bool {
+	return m.Flags&(LivingMovementFlagSwimming|LivingMovementFlagFlying|LivingMovementFlagAlwaysAllowPitching) > 0
+}
+
+// Read decodes movement info from r.
+func (m *MovementInfo) Read(r *bytes.Reader) error {
+	// Flags are 48 bits: 32 bit flags followed by 16 bit extra flags
+	var flags uint32
+	var extraFlags uint16
+
+	if err := binary.Read(r, binary.LittleEndian, &flags); err != nil {
+		return err
+	}
+	if err := binary.Read(r, binary.LittleEndian, &extraFlags); err != nil {
+		return err
+	}
+
+	m.Flags = LivingMovementFlag(flags) | LivingMovementFlag(extraFlags)<<32
+
+	if err := binary.Read(r, binary.LittleEndian, &m.Time); err != nil {
+		return err
+	}
+	if err := binary.Read(r, binary.LittleEndian, &m.Position); err != nil {
+		return err
+	}
+
+	m.Transport = nil
+	if m.HasFlag(LivingMovementFlagOnTransport) {
+		t := &TransportInfo{}
+		guid, err := realmd.ReadPackedGuid(r)
+		if err != nil {
+			return err
+		}
+
+		t.Guid = guid
+
+		if err := binary.Read(r, binary.LittleEndian, &t.Position); err != nil {
+			return err
+		}
+		if err := binary.Read(r, binary.LittleEndian, &t.Time); err != nil {
+			return err
+		}
+		if err := binary.Read(r, binary.LittleEndian, &t.Seat); err != nil {
+			return err
+		}
+
+		if m.HasFlag(LivingMovementFlagTransportInterpolatedMovement) {
+			if err := binary.Read(r, binary.LittleEndian, &t.InterpolatedTime); err != nil {
+				return err
+			}
+		}
+
+		m.Transport = t
+	}
+
+	if m.HasPitch() {
+		if err := binary.Read(r, binary.LittleEndian, &m.Pitch); err != nil {
+			return err
+		}
+	}
+
+	if err := binary.Read(r, binary.LittleEndian, &m.FallTime); err != nil {
+		return err
+	}
+
+	m.Fall = nil
+	if m.HasFlag(LivingMovementFlagFalling) {
+		m.Fall = &FallData{}
+		if err := binary.Read(r, binary.LittleEndian, m.Fall); err != nil {
+			return err
+		}
+	}
+
+	if m.HasFlag(LivingMovementFlagSplineElevation) {
+		if err := binary.Read(r, binary.LittleEndian, &m.SplineElevation); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Bytes returns the movement info as a little-endian byte array. Bytes returns an error if a flag
+// is set for an optional field but the field is nil.
+func (m *MovementInfo) Bytes() ([]byte, error) {
+	buf := bytes.Buffer{}
+
+	binary.Write(&buf, binary.LittleEndian, uint32(m.Flags))
+	binary.Write(&buf, binary.LittleEndian, uint16(m.Flags>>32))
+	binary.Write(&buf, binary.LittleEndian, m.Time)
+	binary.Write(&buf, binary.LittleEndian, m.Position)
+
+	if m.HasFlag(LivingMovementFlagOnTransport) {
+		if m.Transport == nil {
+			return nil, ErrMovementFlagsMismatch
+		}
+
+		buf.Write(realmd.PackGuid(uint64(m.Transport.Guid)))
+		binary.Write(&buf, binary.LittleEndian, m.Transport.Position)
+		binary.Write(&buf, binary.LittleEndian, m.Transport.Time)
+		binary.Write(&buf, binary.LittleEndian, m.Transport.Seat)
+
+		if m.HasFlag(LivingMovementFlagTransportInterpolatedMovement) {
+			binary.Write(&buf, binary.LittleEndian, m.Transport.InterpolatedTime)
+		}
+	}
+
+	if m.HasPitch() {
+		binary.Write(&buf, binary.LittleEndian, m.Pitch)
+	}
+
+	binary.Write(&buf, binary.LittleEndian, m.FallTime)
+
+	if m.HasFlag(LivingMovementFlagFalling) {
+		if m.Fall == nil {
+			return nil, ErrMovementFlagsMismatch
+		}
+
+		binary.Write(&buf, binary.LittleEndian, m.Fall)
+	}
+
+	if m.HasFlag(LivingMovementFlagSplineElevation) {
+		binary.Write(&buf, binary.LittleEndian, m.SplineElevation)
+	}
+
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,76 @@
+package values
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/kangaroux/gomaggus/internal"
+	"github.com/kangaroux/gomaggus/realmd"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMovementInfoRead(t *testing.T) {
+	data := internal.MustDecodeHex(
+		"01000000" + "0000" + // forward
+			"10270000" + // time
+			"0000803F" + "00000040" + "00004040" + "00000000" + // position
+			"64000000") // fall time
+
+	m := MovementInfo{}
+	assert.NoError(t, m.Read(bytes.NewReader(data)))
+	assert.Equal(t, LivingMovementFlagForward, m.Flags)
+	assert.Equal(t, uint32(10000), m.Time)
+	assert.Equal(t, realmd.Vector4{X: 1, Y: 2, Z: 3}, m.Position)
+	assert.Equal(t, uint32(100), m.FallTime)
+	assert.Nil(t, m.Transport)
+	assert.Nil(t, m.Fall)
+
+	t.Run("truncated", func(t *testing.T) {
+		assert.Error(t, m.Read(bytes.NewReader(data[:10])))
+	})
+}
+
+func TestMovementInfoRoundTrip(t *testing.T) {
+	testCases := []MovementInfo{
+		{
+			Flags:    LivingMovementFlagNone,
+			Time:     1,
+			Position: realmd.Vector4{X: -8949.95, Y: -132.493, Z: 83.5312, Rotation: 1},
+		},
+		{
+			Flags:    LivingMovementFlagFalling | LivingMovementFlagForward,
+			Time:     2,
+			FallTime: 50,
+			Fall:     &FallData{FallSpeed: -7.9, CosAngle: 1, SinAngle: 0, HorizontalSpeed: 7},
+		},
+		{
+			Flags: LivingMovementFlagSwimming | LivingMovementFlagSplineElevation,
+			Pitch: 0.5, SplineElevation: 2,
+		},
+		{
+			Flags: LivingMovementFlagOnTransport | LivingMovementFlagTransportInterpolatedMovement,
+			Transport: &TransportInfo{
+				Guid:             0x1FC0000000000010,
+				Position:         realmd.Vector4{X: 1, Y: 2, Z: 3, Rotation: 4},
+				Time:             5,
+				Seat:             -1,
+				InterpolatedTime: 6,
+			},
+		},
+	}
+
+	for _, expected := range testCases {
+		data, err := expected.Bytes()
+		assert.NoError(t, err)
+
+		actual := MovementInfo{}
+		assert.NoError(t, actual.Read(bytes.NewReader(data)))
+		assert.Equal(t, expected, actual)
+	}
+
+	t.Run("missing optional field", func(t *testing.T) {
+		m := MovementInfo{Flags: LivingMovementFlagFalling}
+		_, err := m.Bytes()
+		assert.Equal(t, ErrMovementFlagsMismatch, err)
+	})
+}
@@ -34,3 +34,10 @@ func (p *Player) Marshal(onlyDirty bool) []byte {
 
 	return append(mask.Bytes(), data...)
 }
+
+// Dirty reports whether any of the player's values were changed since they were last marshalled.
+func (p *Player) Dirty() bool {
+	return len(p.ObjectData.dirty.sections) > 0 ||
+		len(p.UnitData.dirty.sections) > 0 ||
+		len(p.PlayerData.dirty.sections) > 0
+}
@@ -116,8 +116,8 @@ type UnitData struct {
 	mountDisplayID    uint32
 	minDamage         float32
 	maxDamage         float32
-	minOffhandDamage  uint32
-	maxOffhandDamage  uint32
+	minOffhandDamage  float32
+	maxOffhandDamage  float32
 	standState        uint8
 	loyaltyLevel      uint8
 	shapeshiftForm    uint8
@@ -1028,20 +1028,20 @@ func (u *UnitData) SetMaxDamage(val float32) {
 	u.dirty.Flag("maxDamage")
 }
 
-func (u *UnitData) MinOffhandDamage() uint32 {
+func (u *UnitData) MinOffhandDamage() float32 {
 	return u.minOffhandDamage
 }
 
-func (u *UnitData) SetMinOffhandDamage(val uint32) {
+func (u *UnitData) SetMinOffhandDamage(val float32) {
 	u.minOffhandDamage = val
 	u.dirty.Flag("minOffhandDamage")
 }
 
-func (u *UnitData) MaxOffhandDamage() uint32 {
+func (u *UnitData) MaxOffhandDamage() float32 {
 	return u.maxOffhandDamage
 }
 
-func (u *UnitData) SetMaxOffhandDamage(val uint32) {
+func (u *UnitData) SetMaxOffhandDamage(val float32) {
 	u.maxOffhandDamage = val
 	u.dirty.Flag("maxOffhandDamage")
 }
@@ -0,0 +1,63 @@
+package values
+
+import (
+	"bytes"
+	"encoding/binary"
+
+	"github.com/kangaroux/gomaggus/realmd"
+)
+
+// UpdateBuilder builds the payload for SMSG_UPDATE_OBJECT. An update contains one or more blocks,
+// and each block describes a change to a single object (or a list of objects for out of range blocks).
+// https://gtker.com/wow_messages/docs/smsg_update_object.html#client-version-335
+type UpdateBuilder struct {
+	count uint32
+	buf   bytes.Buffer
+}
+
+// Len returns the number of blocks in the update.
+func (b *UpdateBuilder) Len() int {
+	return int(b.count)
+}
+
+// CreateObject adds a block which spawns an object for the client. values should contain the
+// result of marshalling all of the object's values.
+func (b *UpdateBuilder) CreateObject(guid realmd.Guid, objType ObjectType, movement *MovementValues, values []byte) {
+	b.count++
+	b.buf.WriteByte(byte(UpdateTypeCreateObject))
+	b.buf.Write(realmd.PackGuid(uint64(guid)))
+	b.buf.WriteByte(byte(objType))
+	b.buf.Write(movement.Bytes())
+	b.buf.Write(values)
+}
+
+// Values adds a block which updates some of an object's values. values should contain the result
+// of marshalling the object's dirty values.
+func (b *UpdateBuilder) Values(guid realmd.Guid, values []byte) {
+	b.count++
+	b.buf.WriteByte(byte(UpdateTypePartial))
+	b.buf.Write(realmd.PackGuid(uint64(guid)))
+	b.buf.Write(values)
+}
+
+// OutOfRange adds a block which tells the client to despawn the objects. Does nothing if guids is empty.
+func (b *UpdateBuilder) OutOfRange(guids ...realmd.Guid) {
+	if len(guids) == 0 {
+		return
+	}
+
+	b.count++
+	b.buf.WriteByte(byte(UpdateTypeOutOfRangeObjects))
+	binary.Write(&b.buf, binary.LittleEndian, uint32(len(guids)))
+
+	for _, guid := range guids {
+		b.buf.Write(realmd.PackGuid(uint64(guid)))
+	}
+}
+
+// Bytes returns the update as a little-endian byte array.
+func (b *UpdateBuilder) Bytes() []byte {
+	data := make([]byte, 4, 4+b.buf.Len())
+	binary.LittleEndian.PutUint32(data, b.count)
+	return append(data, b.buf.Bytes()...)
+}
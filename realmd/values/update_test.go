@@ -0,0 +1,24 @@
+package values
+
+import (
+	"testing"
+
+	"github.com/kangaroux/gomaggus/internal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUpdateBuilder(t *testing.T) {
+	u := UpdateBuilder{}
+	assert.Equal(t, internal.MustDecodeHex("00000000"), u.Bytes())
+
+	u.OutOfRange()
+	assert.Equal(t, 0, u.Len())
+
+	u.OutOfRange(0x1, 0x200)
+	assert.Equal(t, 1, u.Len())
+	assert.Equal(t, internal.MustDecodeHex("01000000"+"04"+"02000000"+"0101"+"0202"), u.Bytes())
+
+	u.Values(0x1, []byte{0xAA})
+	assert.Equal(t, 2, u.Len())
+	assert.Equal(t, internal.MustDecodeHex("02000000"+"04"+"02000000"+"0101"+"0202"+"00"+"0101"+"AA"), u.Bytes())
+}
@@ -0,0 +1,478 @@
+package world
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math/rand"
+	"sort"
+	"time"
+
+	"github.com/kangaroux/gomaggus/realmd"
+	"github.com/kangaroux/gomaggus/realmd/dbc"
+)
+
+// The spell effects that the server handles.
+// https://wowdev.wiki/SpellEffect
+const (
+	SpellEffectApplyAura = 6
+	SpellEffectDispel    = 38
+)
+
+// The aura types that the server handles.
+// https://wowdev.wiki/SpellAuraNames
+const (
+	AuraPeriodicDamage   = 3
+	AuraPeriodicHeal     = 8
+	AuraPeriodicEnergize = 24
+	AuraModStat          = 29
+)
+
+// https://gtker.com/wow_messages/docs/auraflag.html#client-version-335
+const (
+	auraFlagNotCaster = 0x08
+	auraFlagPositive  = 0x10
+	auraFlagDuration  = 0x20
+	auraFlagNegative  = 0x80
+)
+
+// maxAuras is the number of aura slots a unit has.
+const maxAuras = 255
+
+// statAll is the stat that stat modifiers use when they change every stat.
+const statAll = -1
+
+// Aura is a buff or debuff on a player, which was applied by one of their own or someone else's spells.
+type Aura struct {
+	Spell  *dbc.Spell
+	Caster realmd.Guid
+	Level  uint32
+
+	// Positive is true for buffs and false for debuffs.
+	Positive bool
+
+	// Stacks multiplies the effect amounts, and goes up each time the caster applies the aura again, up to
+	// the spell's stack amount. Charges are used up by the aura's effects.
+	Stacks  uint8
+	Charges uint8
+
+	// Duration is how much longer the aura lasts, or -1 if it lasts until it's removed. MaxDuration is how
+	// long it lasted for when it was applied.
+	Duration    time.Duration
+	MaxDuration time.Duration
+
+	// Amounts are the values of each of the spell's aura effects for one stack.
+	Amounts [dbc.NumSpellEffects]int32
+
+	slot uint8
+
+	// ticks are how long it's been since each periodic effect last ticked.
+	ticks [dbc.NumSpellEffects]time.Duration
+}
+
+// NewAura creates a positive aura for spell cast by caster at level. The aura lasts for duration, or until
+// it's removed if duration isn't positive.
+func NewAura(spell *dbc.Spell, caster realmd.Guid, level uint32, duration time.Duration) *Aura {
+	if duration <= 0 {
+		duration = -1
+	}
+
+	a := &Aura{
+		Spell:       spell,
+		Caster:      caster,
+		Level:       level,
+		Positive:    true,
+		Stacks:      1,
+		Charges:     uint8(spell.ProcCharges),
+		Duration:    duration,
+		MaxDuration: duration,
+	}
+
+	for i, effect := range spell.Effects {
+		if effect.Effect == SpellEffectApplyAura {
+			a.Amounts[i] = effectAmount(spell, &effect, level)
+		}
+	}
+
+	return a
+}
+
+// effectAmount returns the value of a spell effect cast at level. Effects with die sides add a random
+// amount, and effects with points per level get stronger with the caster's level.
+func effectAmount(spell *dbc.Spell, effect *dbc.SpellEffect, level uint32) int32 {
+	amount := effect.BasePoints
+
+	switch {
+	case effect.DieSides == 1:
+		amount++
+	case effect.DieSides > 1:
+		amount += 1 + rand.Int31n(effect.DieSides)
+	}
+
+	if spell.MaxLevel > 0 && level > spell.MaxLevel {
+		level = spell.MaxLevel
+	} else if level < spell.BaseLevel {
+		level = spell.BaseLevel
+	}
+	amount += int32(float32(int32(level)-int32(spell.SpellLevel)) * effect.RealPointsPerLevel)
+
+	return amount
+}
+
+// hasAura reports whether any of the spell's effects apply an aura.
+func hasAura(spell *dbc.Spell) bool {
+	for _, effect := range spell.Effects {
+		if effect.Effect == SpellEffectApplyAura {
+			return true
+		}
+	}
+	return false
+}
+
+// effectMask returns a mask of the spell's effects that the aura applies.
+func (a *Aura) effectMask() uint8 {
+	var mask uint8
+	for i, effect := range a.Spell.Effects {
+		if effect.Effect == SpellEffectApplyAura {
+			mask |= 1 << i
+		}
+	}
+	return mask
+}
+
+// Auras returns the auras on the player, in order of their slots.
+func (p *Player) Auras() []*Aura {
+	result := make([]*Aura, 0, len(p.auras))
+	for _, a := range p.auras {
+		result = append(result, a)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].slot < result[j].slot })
+	return result
+}
+
+// SetAuras puts the auras on the player without telling anyone. It should only be called before the player
+// is added to the world. Auras that don't fit are dropped.
+func (p *Player) SetAuras(auras []*Aura) {
+	for _, a := range auras {
+		if !p.addAura(a) {
+			break
+		}
+	}
+}
+
+// addAura puts the aura in the player's first free slot and applies its modifiers. Returns false if the
+// player doesn't have a free slot.
+func (p *Player) addAura(a *Aura) bool {
+	for slot := 0; slot < maxAuras; slot++ {
+		if _, ok := p.auras[uint8(slot)]; !ok {
+			a.slot = uint8(slot)
+			p.auras[a.slot] = a
+			p.applyAuraMods(a, 1)
+			return true
+		}
+	}
+	return false
+}
+
+// findAura returns the aura from spell that was cast by caster, or nil if the player doesn't have it.
+func (p *Player) findAura(spellId uint32, caster realmd.Guid) *Aura {
+	for _, a := range p.auras {
+		if a.Spell.ID == spellId && a.Caster == caster {
+			return a
+		}
+	}
+	return nil
+}
+
+// applyAuraMods adds the aura's stat modifiers to the player, or takes them away if sign is -1.
+func (p *Player) applyAuraMods(a *Aura, sign int32) {
+	changed := false
+
+	for i, effect := range a.Spell.Effects {
+		if effect.Effect != SpellEffectApplyAura || effect.ApplyAuraName != AuraModStat {
+			continue
+		}
+
+		amount := a.Amounts[i] * int32(a.Stacks) * sign
+
+		for stat := range p.statMods {
+			if effect.MiscValue == statAll || effect.MiscValue == int32(stat) {
+				p.statMods[stat] += amount
+				changed = true
+			}
+		}
+	}
+
+	if changed {
+		p.updateStats()
+	}
+}
+
+// ApplyAura puts the aura on the player and tells them and anyone nearby. If the caster already put the
+// same aura on the player, that aura is refreshed and gains a stack instead.
+func (w *World) ApplyAura(p *Player, a *Aura) {
+	if existing := p.findAura(a.Spell.ID, a.Caster); existing != nil {
+		p.applyAuraMods(existing, -1)
+
+		if existing.Stacks < uint8(a.Spell.StackAmount) {
+			existing.Stacks++
+		}
+		existing.Charges = a.Charges
+		existing.Duration = a.Duration
+		existing.MaxDuration = a.MaxDuration
+		existing.Amounts = a.Amounts
+
+		p.applyAuraMods(existing, 1)
+		w.Broadcast(p, false, realmd.OpServerAuraUpdate, auraUpdatePacket(p, existing))
+		return
+	}
+
+	if !p.addAura(a) {
+		return
+	}
+
+	w.Broadcast(p, false, realmd.OpServerAuraUpdate, auraUpdatePacket(p, a))
+}
+
+// RemoveAura takes the aura off of the player and tells them and anyone nearby.
+func (w *World) RemoveAura(p *Player, a *Aura) {
+	if p.auras[a.slot] != a {
+		return
+	}
+
+	delete(p.auras, a.slot)
+	p.applyAuraMods(a, -1)
+
+	data := bytes.Buffer{}
+	data.Write(realmd.PackGuid(uint64(p.GUID())))
+	data.WriteByte(a.slot)
+	binary.Write(&data, binary.LittleEndian, uint32(0)) // No spell, which removes the aura
+
+	w.Broadcast(p, false, realmd.OpServerAuraUpdate, data.Bytes())
+}
+
+// RemoveAllAuras takes every aura off of the player.
+func (w *World) RemoveAllAuras(p *Player) {
+	for _, a := range p.Auras() {
+		w.RemoveAura(p, a)
+	}
+}
+
+// CancelAura removes the player's buff from spell. Debuffs and passive auras can't be cancelled.
+func (w *World) CancelAura(p *Player, spellId uint32) {
+	for _, a := range p.Auras() {
+		if a.Spell.ID == spellId && a.Positive && !a.Spell.Passive() {
+			w.RemoveAura(p, a)
+		}
+	}
+}
+
+// dispel removes up to count of the target's auras that have the dispel type. Enemies lose their buffs and
+// friends lose their debuffs.
+func (w *World) dispel(caster, target *Player, dispelType uint32, count int32) {
+	positive := caster.hostileTo(target)
+
+	for _, a := range target.Auras() {
+		if count <= 0 {
+			return
+		}
+		if a.Spell.Dispel == dispelType && a.Positive == positive {
+			w.RemoveAura(target, a)
+			count--
+		}
+	}
+}
+
+// applyEffects applies the effects of the spell the player cast to its target.
+func (w *World) applyEffects(p *Player, c *Cast) {
+	target := w.castTarget(p, c)
+	if target == nil {
+		return
+	}
+
+	level := p.Values.Level()
+
+	for _, effect := range c.Spell.Effects {
+		switch effect.Effect {
+		case SpellEffectDispel:
+			w.dispel(p, target, uint32(effect.MiscValue), effectAmount(c.Spell, &effect, level))
+
+		case SpellEffectReputation:
+			w.ModifyReputation(target, uint32(effect.MiscValue), effectAmount(c.Spell, &effect, level))
+
+		case SpellEffectTalentSpecCount:
+			w.setSpecCount(target, uint8(effectAmount(c.Spell, &effect, level)))
+
+		case SpellEffectTalentSpecSelect:
+			// The spells for each spec have the spec's index + 1 as their amount
+			w.ActivateSpec(target, uint8(effectAmount(c.Spell, &effect, level)-1))
+
+		case SpellEffectApplyGlyph:
+			if c.Glyph != nil && target == p {
+				w.ApplyGlyph(p, c.Glyph, c.GlyphSlot)
+			}
+		}
+	}
+
+	if hasAura(c.Spell) {
+		a := NewAura(c.Spell, p.GUID(), level, c.Duration)
+		a.Positive = !p.hostileTo(target) && !targetsEnemy(c.Spell)
+		w.ApplyAura(target, a)
+	}
+}
+
+// targetsEnemy reports whether the spell must be cast on an enemy.
+func targetsEnemy(spell *dbc.Spell) bool {
+	for _, effect := range spell.Effects {
+		if effect.ImplicitTargetA == implicitTargetEnemy {
+			return true
+		}
+	}
+	return false
+}
+
+// updateAuras ticks the player's periodic auras and removes the auras that expired.
+func (w *World) updateAuras(p *Player, diff time.Duration) {
+	for _, a := range p.Auras() {
+		for i, effect := range a.Spell.Effects {
+			if effect.Amplitude == 0 || !isPeriodic(effect.ApplyAuraName) {
+				continue
+			}
+
+			amplitude := time.Duration(effect.Amplitude) * time.Millisecond
+			a.ticks[i] += diff
+
+			// The aura is removed if the player dies from a tick
+			for a.ticks[i] >= amplitude && p.auras[a.slot] == a {
+				a.ticks[i] -= amplitude
+				w.tickAura(p, a, i)
+			}
+		}
+
+		if p.auras[a.slot] != a || a.Duration < 0 {
+			continue
+		}
+
+		a.Duration -= diff
+		if a.Duration <= 0 {
+			w.RemoveAura(p, a)
+		}
+	}
+}
+
+func isPeriodic(auraType uint32) bool {
+	return auraType == AuraPeriodicDamage || auraType == AuraPeriodicHeal || auraType == AuraPeriodicEnergize
+}
+
+// tickAura applies one tick of the aura's periodic effect, and tells the player and anyone nearby.
+// https://gtker.com/wow_messages/docs/smsg_periodicauralog.html#client-version-335
+func (w *World) tickAura(p *Player, a *Aura, i int) {
+	effect := a.Spell.Effects[i]
+	amount := a.Amounts[i] * int32(a.Stacks)
+	if amount <= 0 {
+		return
+	}
+
+	data := bytes.Buffer{}
+	write := func(v any) { binary.Write(&data, binary.LittleEndian, v) }
+
+	data.Write(realmd.PackGuid(uint64(p.GUID())))
+	data.Write(realmd.PackGuid(uint64(a.Caster)))
+	write(a.Spell.ID)
+	write(uint32(1)) // Log count
+	write(effect.ApplyAuraName)
+
+	switch effect.ApplyAuraName {
+	case AuraPeriodicDamage:
+		overkill := w.damage(p, uint32(amount))
+		write(uint32(amount))
+		write(overkill)
+		write(a.Spell.SchoolMask)
+		write([2]uint32{}) // Absorbed and resisted
+		data.WriteByte(0)  // Critical
+
+	case AuraPeriodicHeal:
+		overheal := w.heal(p, uint32(amount))
+		write(uint32(amount))
+		write(overheal)
+		write(uint32(0))  // Absorbed
+		data.WriteByte(0) // Critical
+
+	case AuraPeriodicEnergize:
+		if realmd.PowerType(effect.MiscValue) != p.Values.PowerType() {
+			return
+		}
+
+		power := p.Power() + uint32(amount)
+		if power > p.MaxPower() {
+			power = p.MaxPower()
+		}
+		p.SetPower(power)
+
+		write(uint32(effect.MiscValue))
+		write(uint32(amount))
+	}
+
+	w.Broadcast(p, false, realmd.OpServerPeriodicAuraLog, data.Bytes())
+}
+
+// https://gtker.com/wow_messages/docs/aurainfo.html#client-version-335
+func (a *Aura) write(buf *bytes.Buffer, owner realmd.Guid) {
+	buf.WriteByte(a.slot)
+	binary.Write(buf, binary.LittleEndian, a.Spell.ID)
+
+	flags := a.effectMask()
+	if a.Caster == owner {
+		flags |= auraFlagNotCaster
+	}
+	if a.Positive {
+		flags |= auraFlagPositive
+	} else {
+		flags |= auraFlagNegative
+	}
+	if a.MaxDuration > 0 {
+		flags |= auraFlagDuration
+	}
+
+	buf.WriteByte(flags)
+	buf.WriteByte(uint8(a.Level))
+
+	// Auras that don't stack show their charges instead
+	if a.Spell.StackAmount > 0 {
+		buf.WriteByte(a.Stacks)
+	} else {
+		buf.WriteByte(a.Charges)
+	}
+
+	if flags&auraFlagNotCaster == 0 {
+		buf.Write(realmd.PackGuid(uint64(a.Caster)))
+	}
+
+	if flags&auraFlagDuration > 0 {
+		binary.Write(buf, binary.LittleEndian, durationMillis(a.MaxDuration))
+		binary.Write(buf, binary.LittleEndian, durationMillis(a.Duration))
+	}
+}
+
+// https://gtker.com/wow_messages/docs/smsg_aura_update.html#client-version-335
+func auraUpdatePacket(p *Player, a *Aura) []byte {
+	data := bytes.Buffer{}
+	data.Write(realmd.PackGuid(uint64(p.GUID())))
+	a.write(&data, p.GUID())
+	return data.Bytes()
+}
+
+// sendAuras sends viewer all of the player's auras. Does nothing if the player doesn't have any.
+// https://gtker.com/wow_messages/docs/smsg_aura_update_all.html#client-version-335
+func (p *Player) sendAuras(viewer *Player) {
+	if len(p.auras) == 0 {
+		return
+	}
+
+	data := bytes.Buffer{}
+	data.Write(realmd.PackGuid(uint64(p.GUID())))
+	for _, a := range p.Auras() {
+		a.write(&data, p.GUID())
+	}
+
+	viewer.sendPacket(realmd.OpServerAuraUpdateAll, data.Bytes())
+}
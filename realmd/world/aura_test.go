@@ -0,0 +1,96 @@
+package world
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kangaroux/gomaggus/realmd/dbc"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	testCorruption = &dbc.Spell{ID: 172, Dispel: 3, Effects: [dbc.NumSpellEffects]dbc.SpellEffect{{
+		Effect: SpellEffectApplyAura, ApplyAuraName: AuraPeriodicDamage, BasePoints: 9, DieSides: 1, Amplitude: 3000,
+	}}}
+	testStrength = &dbc.Spell{ID: 1, StackAmount: 3, Effects: [dbc.NumSpellEffects]dbc.SpellEffect{{
+		Effect: SpellEffectApplyAura, ApplyAuraName: AuraModStat, MiscValue: StatStamina, BasePoints: 9, DieSides: 1,
+	}}}
+)
+
+func TestAuraPeriodic(t *testing.T) {
+	w := New(nil)
+	p, _ := newTestPlayer(1, 0, 0)
+	assert.NoError(t, w.AddPlayer(p))
+
+	a := NewAura(testCorruption, 2, 1, 12*time.Second)
+	a.Positive = false
+	w.ApplyAura(p, a)
+	assert.Equal(t, []*Aura{a}, p.Auras())
+
+	health := p.Values.Health()
+
+	w.update(2 * time.Second)
+	assert.Equal(t, health, p.Values.Health())
+
+	w.update(time.Second)
+	assert.Equal(t, health-10, p.Values.Health())
+
+	w.update(9 * time.Second)
+	assert.Equal(t, health-40, p.Values.Health())
+	assert.Empty(t, p.Auras(), "expired auras are removed")
+
+	t.Run("death", func(t *testing.T) {
+		w.ApplyAura(p, NewAura(testCorruption, 2, 1, 12*time.Second))
+		w.damage(p, p.Values.Health())
+		assert.Empty(t, p.Auras())
+	})
+}
+
+func TestAuraStats(t *testing.T) {
+	w := New(nil)
+	p, _ := newTestPlayer(1, 0, 0)
+	assert.NoError(t, w.AddPlayer(p))
+
+	stamina := p.Values.Stamina()
+	maxHealth := p.Values.MaxHealth()
+
+	a := NewAura(testStrength, p.GUID(), 1, time.Minute)
+	w.ApplyAura(p, a)
+	assert.Equal(t, stamina+10, p.Values.Stamina())
+	assert.Greater(t, p.Values.MaxHealth(), maxHealth)
+
+	t.Run("stacks", func(t *testing.T) {
+		for i := 0; i < 3; i++ {
+			w.ApplyAura(p, NewAura(testStrength, p.GUID(), 1, time.Minute))
+		}
+		assert.Len(t, p.Auras(), 1)
+		assert.Equal(t, uint8(3), a.Stacks)
+		assert.Equal(t, stamina+30, p.Values.Stamina())
+	})
+
+	t.Run("cancel", func(t *testing.T) {
+		w.CancelAura(p, testStrength.ID)
+		assert.Empty(t, p.Auras())
+		assert.Equal(t, stamina, p.Values.Stamina())
+		assert.Equal(t, maxHealth, p.Values.MaxHealth())
+	})
+}
+
+func TestAuraDispel(t *testing.T) {
+	w := New(nil)
+	p, _ := newTestPlayer(1, 0, 0)
+	enemy, _ := newTestPlayer(2, 10, 0)
+	enemy.Horde = true
+	assert.NoError(t, w.AddPlayer(p))
+	assert.NoError(t, w.AddPlayer(enemy))
+
+	debuff := NewAura(testCorruption, enemy.GUID(), 1, time.Minute)
+	debuff.Positive = false
+	w.ApplyAura(p, debuff)
+
+	w.dispel(enemy, p, testCorruption.Dispel, 1)
+	assert.Len(t, p.Auras(), 1, "enemies can't dispel debuffs")
+
+	w.dispel(p, p, testCorruption.Dispel, 1)
+	assert.Empty(t, p.Auras())
+}
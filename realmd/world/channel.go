@@ -0,0 +1,655 @@
+package world
+
+import (
+	"bytes"
+	"encoding/binary"
+	"strings"
+
+	"github.com/kangaroux/gomaggus/realmd"
+	"github.com/kangaroux/gomaggus/realmd/chat"
+	"github.com/kangaroux/gomaggus/realmd/dbc"
+)
+
+// zoneChannel is a channel that players automatically join based on the zone they are in.
+// https://wowdev.wiki/DB/ChatChannels
+type zoneChannel struct {
+	ID     uint32
+	Prefix string
+	Flags  chat.ChannelFlags
+}
+
+var (
+	generalChannel = zoneChannel{
+		ID:     1,
+		Prefix: "General",
+		Flags:  chat.ChannelFlagGeneral | chat.ChannelFlagNotLFG,
+	}
+	tradeChannel = zoneChannel{
+		ID:     2,
+		Prefix: "Trade",
+		Flags:  chat.ChannelFlagGeneral | chat.ChannelFlagTrade | chat.ChannelFlagCity | chat.ChannelFlagNotLFG,
+	}
+	localDefenseChannel = zoneChannel{
+		ID:     22,
+		Prefix: "LocalDefense",
+		Flags:  chat.ChannelFlagGeneral | chat.ChannelFlagNotLFG,
+	}
+
+	zoneChannels = []zoneChannel{generalChannel, tradeChannel, localDefenseChannel}
+)
+
+// name returns the channel's name in the zone. Trade is shared by all the cities so it doesn't use the
+// zone's name.
+func (zc zoneChannel) name(zone string) string {
+	if zc.ID == tradeChannel.ID {
+		return zc.Prefix + " - City"
+	}
+	return zc.Prefix + " - " + zone
+}
+
+// isZoneChannelName reports whether name belongs to a zone channel. Players can't create custom channels
+// with these names.
+func isZoneChannelName(name string) bool {
+	for _, zc := range zoneChannels {
+		if strings.HasPrefix(strings.ToLower(name), strings.ToLower(zc.Prefix+" - ")) {
+			return true
+		}
+	}
+	return false
+}
+
+// channelKey identifies a channel. Each team has their own set of channels, even if the names match.
+type channelKey struct {
+	name  string
+	horde bool
+}
+
+type channelMember struct {
+	player *Player
+	flags  chat.MemberFlags
+}
+
+// Channel is a chat channel. Zone channels are managed by the server, while custom channels are created
+// by the first player to join them and removed once everyone leaves.
+type Channel struct {
+	Name string
+
+	// ID is the zone channel id, or zero if it's a custom channel.
+	ID       uint32
+	Flags    chat.ChannelFlags
+	Password string
+
+	// Owner is the player that owns the channel. Zone channels don't have an owner.
+	Owner realmd.Guid
+
+	horde bool
+
+	// announce is true if players are told when someone joins or leaves the channel.
+	announce bool
+
+	// moderated is true if only moderators and voiced players can speak in the channel.
+	moderated bool
+
+	// members is kept in the order the players joined, which is used to pick the next owner.
+	members []*channelMember
+	banned  map[realmd.Guid]string
+}
+
+// Custom reports whether the channel was created by a player.
+func (c *Channel) Custom() bool {
+	return c.ID == 0
+}
+
+func (c *Channel) member(p *Player) *channelMember {
+	for _, m := range c.members {
+		if m.player == p {
+			return m
+		}
+	}
+	return nil
+}
+
+func (c *Channel) owner() *channelMember {
+	for _, m := range c.members {
+		if m.player.GUID() == c.Owner {
+			return m
+		}
+	}
+	return nil
+}
+
+func (c *Channel) memberByName(name string) *channelMember {
+	for _, m := range c.members {
+		if strings.EqualFold(m.player.Character.Name, name) {
+			return m
+		}
+	}
+	return nil
+}
+
+// broadcast sends a notice to every member of the channel.
+func (c *Channel) broadcast(notice chat.Notice, args ...interface{}) {
+	data := chat.NoticeBytes(notice, c.Name, args...)
+
+	for _, m := range c.members {
+		if err := m.player.Client.SendPacketBytes(realmd.OpServerChannelNotify, data); err != nil {
+			m.player.Client.Log.Error().Err(err).Msg("error sending channel notice")
+		}
+	}
+}
+
+// setFlags changes the member's flags and tells everyone in the channel.
+func (c *Channel) setFlags(m *channelMember, flags chat.MemberFlags) {
+	if m.flags == flags {
+		return
+	}
+
+	old := m.flags
+	m.flags = flags
+	c.broadcast(chat.NoticeModeChange, m.player.GUID(), uint8(old), uint8(flags))
+}
+
+// setOwner gives ownership of the channel to m. The previous owner stays a moderator.
+func (c *Channel) setOwner(m *channelMember) {
+	if prev := c.owner(); prev != nil {
+		c.setFlags(prev, prev.flags&^chat.MemberFlagOwner)
+	}
+
+	c.Owner = m.player.GUID()
+	c.setFlags(m, m.flags|chat.MemberFlagOwner|chat.MemberFlagModerator)
+	c.broadcast(chat.NoticeOwnerChanged, c.Owner)
+}
+
+// join adds p to the channel. The player is told why if they can't join.
+func (c *Channel) join(p *Player, password string) bool {
+	if c.member(p) != nil {
+		p.sendNotice(chat.NoticePlayerAlreadyMember, c.Name, p.GUID())
+		return true
+	}
+
+	if _, ok := c.banned[p.GUID()]; ok {
+		p.sendNotice(chat.NoticeBanned, c.Name)
+		return false
+	}
+
+	if c.Password != "" && password != c.Password {
+		p.sendNotice(chat.NoticeWrongPassword, c.Name)
+		return false
+	}
+
+	if c.announce {
+		c.broadcast(chat.NoticeJoined, p.GUID())
+	}
+
+	m := &channelMember{player: p}
+	c.members = append(c.members, m)
+	p.channels = append(p.channels, c)
+
+	p.sendNotice(chat.NoticeYouJoined, c.Name, uint8(c.Flags), c.ID, uint32(0))
+
+	// The first player in a custom channel owns it
+	if c.Custom() && c.Owner == 0 {
+		c.setOwner(m)
+	}
+
+	return true
+}
+
+// sendNotice sends a channel notice to the player.
+func (p *Player) sendNotice(notice chat.Notice, channel string, args ...interface{}) {
+	data := chat.NoticeBytes(notice, channel, args...)
+	if err := p.Client.SendPacketBytes(realmd.OpServerChannelNotify, data); err != nil {
+		p.Client.Log.Error().Err(err).Msg("error sending channel notice")
+	}
+}
+
+// Channels returns the channels the player is in, in the order they were joined.
+func (p *Player) Channels() []*Channel {
+	return p.channels
+}
+
+// Channel returns the channel with the given name on p's team, or nil if it doesn't exist. Names are
+// case-insensitive.
+func (w *World) Channel(p *Player, name string) *Channel {
+	return w.channels[channelKey{strings.ToLower(name), p.Horde}]
+}
+
+func (w *World) newChannel(name string, id uint32, flags chat.ChannelFlags, horde bool) *Channel {
+	c := &Channel{
+		Name:   name,
+		ID:     id,
+		Flags:  flags,
+		horde:  horde,
+		banned: make(map[realmd.Guid]string),
+	}
+	w.channels[channelKey{strings.ToLower(name), horde}] = c
+	return c
+}
+
+// JoinChannel adds p to the custom channel with the given name, creating it if it doesn't exist. Returns
+// the channel if p is in it.
+func (w *World) JoinChannel(p *Player, name, password string) *Channel {
+	if name == "" || len(name) > chat.MaxChannelNameLength || isZoneChannelName(name) {
+		p.sendNotice(chat.NoticeInvalidName, name)
+		return nil
+	}
+
+	c := w.Channel(p, name)
+	if c == nil {
+		c = w.newChannel(name, 0, chat.ChannelFlagCustom, p.Horde)
+		c.Password = password
+		c.announce = true
+	}
+
+	if !c.join(p, password) {
+		return nil
+	}
+
+	return c
+}
+
+// LeaveChannel removes p from the channel with the given name. Returns the channel if p left it.
+func (w *World) LeaveChannel(p *Player, name string) *Channel {
+	c, m := w.memberChannel(p, name)
+	if m == nil {
+		return nil
+	}
+
+	w.leave(c, p)
+	p.sendNotice(chat.NoticeYouLeft, c.Name, c.ID, uint8(0))
+
+	return c
+}
+
+// leaveAllChannels removes p from every channel they're in without telling them. This is used when p
+// leaves the world.
+func (w *World) leaveAllChannels(p *Player) {
+	for _, c := range append([]*Channel(nil), p.channels...) {
+		w.leave(c, p)
+	}
+}
+
+// leave removes p from the channel. If p was the owner, the next member in line becomes the owner. The
+// channel is removed if it's empty.
+func (w *World) leave(c *Channel, p *Player) {
+	for i, m := range c.members {
+		if m.player == p {
+			c.members = append(c.members[:i], c.members[i+1:]...)
+			break
+		}
+	}
+
+	for i, other := range p.channels {
+		if other == c {
+			p.channels = append(p.channels[:i], p.channels[i+1:]...)
+			break
+		}
+	}
+
+	if len(c.members) == 0 {
+		delete(w.channels, channelKey{strings.ToLower(c.Name), c.horde})
+		return
+	}
+
+	if c.announce {
+		c.broadcast(chat.NoticeLeft, p.GUID())
+	}
+
+	if c.Owner == p.GUID() {
+		c.Owner = 0
+		c.setOwner(c.members[0])
+	}
+}
+
+// UpdateZoneChannels moves p to the zone channels for zone, leaving the channels for their previous zone.
+// If zone is nil, p leaves all their zone channels.
+func (w *World) UpdateZoneChannels(p *Player, zone *dbc.Area) {
+	wanted := make(map[string]zoneChannel)
+
+	if zone != nil {
+		wanted[generalChannel.name(zone.Name)] = generalChannel
+		wanted[localDefenseChannel.name(zone.Name)] = localDefenseChannel
+
+		if zone.Flags&dbc.AreaFlagCapital != 0 {
+			wanted[tradeChannel.name(zone.Name)] = tradeChannel
+		}
+	}
+
+	for _, c := range append([]*Channel(nil), p.channels...) {
+		if c.Custom() {
+			continue
+		}
+
+		if _, ok := wanted[c.Name]; ok {
+			delete(wanted, c.Name)
+			continue
+		}
+
+		w.leave(c, p)
+		p.sendNotice(chat.NoticeYouLeft, c.Name, c.ID, uint8(0))
+	}
+
+	// Join them in a consistent order so the client numbers them the same way every time
+	for _, zc := range zoneChannels {
+		for name, wantedZC := range wanted {
+			if wantedZC.ID != zc.ID {
+				continue
+			}
+
+			c := w.Channel(p, name)
+			if c == nil {
+				c = w.newChannel(name, zc.ID, zc.Flags, p.Horde)
+			}
+			c.join(p, "")
+		}
+	}
+}
+
+// memberChannel returns the channel with the given name and p's membership in it. If p isn't in the
+// channel they are told so and the returned member is nil.
+func (w *World) memberChannel(p *Player, name string) (*Channel, *channelMember) {
+	c := w.Channel(p, name)
+	if c == nil {
+		p.sendNotice(chat.NoticeNotMember, name)
+		return nil, nil
+	}
+
+	m := c.member(p)
+	if m == nil {
+		p.sendNotice(chat.NoticeNotMember, c.Name)
+		return nil, nil
+	}
+
+	return c, m
+}
+
+// moderatorChannel is like memberChannel, but p also needs to be a moderator of the channel.
+func (w *World) moderatorChannel(p *Player, name string) (*Channel, *channelMember) {
+	c, m := w.memberChannel(p, name)
+	if m == nil {
+		return nil, nil
+	}
+
+	if m.flags&chat.MemberFlagModerator == 0 {
+		p.sendNotice(chat.NoticeNotModerator, c.Name)
+		return nil, nil
+	}
+
+	return c, m
+}
+
+// moderatedMember returns the member with the given name that p is moderating. p is told if the member
+// can't be found, or if they are the owner and p isn't.
+func (w *World) moderatedMember(c *Channel, p *Player, name string) *channelMember {
+	target := c.memberByName(name)
+	if target == nil {
+		p.sendNotice(chat.NoticePlayerNotFound, c.Name, name)
+		return nil
+	}
+
+	if target.player.GUID() == c.Owner && p.GUID() != c.Owner {
+		p.sendNotice(chat.NoticeNotOwner, c.Name)
+		return nil
+	}
+
+	return target
+}
+
+// SetChannelPassword changes the password needed to join the channel. Only moderators can change it.
+func (w *World) SetChannelPassword(p *Player, name, password string) {
+	c, m := w.moderatorChannel(p, name)
+	if m == nil {
+		return
+	}
+
+	c.Password = password
+	c.broadcast(chat.NoticePasswordChanged, p.GUID())
+}
+
+// SetChannelOwner gives ownership of the channel to the member named target. Only the owner can do this.
+func (w *World) SetChannelOwner(p *Player, name, target string) {
+	c, m := w.memberChannel(p, name)
+	if m == nil {
+		return
+	}
+
+	if c.Owner != p.GUID() {
+		p.sendNotice(chat.NoticeNotOwner, c.Name)
+		return
+	}
+
+	other := c.memberByName(target)
+	if other == nil {
+		p.sendNotice(chat.NoticePlayerNotFound, c.Name, target)
+		return
+	}
+
+	if other != m {
+		c.setOwner(other)
+	}
+}
+
+// ChannelOwner tells p who owns the channel.
+func (w *World) ChannelOwner(p *Player, name string) {
+	c, m := w.memberChannel(p, name)
+	if m == nil {
+		return
+	}
+
+	owner := "Nobody"
+	if m := c.owner(); m != nil {
+		owner = m.player.Character.Name
+	}
+
+	p.sendNotice(chat.NoticeChannelOwner, c.Name, owner)
+}
+
+// SetChannelModerator adds or removes the member named target as a moderator.
+func (w *World) SetChannelModerator(p *Player, name, target string, moderator bool) {
+	w.setMemberFlag(p, name, target, chat.MemberFlagModerator, moderator)
+}
+
+// SetChannelMute mutes or unmutes the member named target. Muted members can't speak in the channel.
+func (w *World) SetChannelMute(p *Player, name, target string, muted bool) {
+	w.setMemberFlag(p, name, target, chat.MemberFlagMuted, muted)
+}
+
+func (w *World) setMemberFlag(p *Player, name, target string, flag chat.MemberFlags, on bool) {
+	c, m := w.moderatorChannel(p, name)
+	if m == nil {
+		return
+	}
+
+	other := w.moderatedMember(c, p, target)
+	if other == nil {
+		return
+	}
+
+	if on {
+		c.setFlags(other, other.flags|flag)
+	} else {
+		c.setFlags(other, other.flags&^flag)
+	}
+}
+
+// InviteToChannel invites the player named target to join the channel.
+func (w *World) InviteToChannel(p *Player, name, target string) {
+	c, m := w.memberChannel(p, name)
+	if m == nil {
+		return
+	}
+
+	other := w.PlayerByName(target)
+	if other == nil {
+		p.sendNotice(chat.NoticePlayerNotFound, c.Name, target)
+		return
+	}
+
+	if other.Horde != p.Horde {
+		p.sendNotice(chat.NoticeInviteWrongFaction, c.Name)
+		return
+	}
+
+	if _, ok := c.banned[other.GUID()]; ok {
+		p.sendNotice(chat.NoticePlayerInviteBanned, c.Name, other.Character.Name)
+		return
+	}
+
+	if c.member(other) != nil {
+		p.sendNotice(chat.NoticePlayerAlreadyMember, c.Name, other.GUID())
+		return
+	}
+
+	// Players that are ignored aren't told the invite was dropped
+	if !other.Ignores(p) {
+		other.sendNotice(chat.NoticeInvite, c.Name, p.GUID())
+	}
+	p.sendNotice(chat.NoticePlayerInvited, c.Name, other.Character.Name)
+}
+
+// KickFromChannel removes the member named target from the channel. Returns the player that was kicked.
+func (w *World) KickFromChannel(p *Player, name, target string) *Player {
+	c, m := w.moderatorChannel(p, name)
+	if m == nil {
+		return nil
+	}
+
+	other := w.moderatedMember(c, p, target)
+	if other == nil {
+		return nil
+	}
+
+	c.broadcast(chat.NoticePlayerKicked, other.player.GUID(), p.GUID())
+	w.leave(c, other.player)
+	other.player.sendNotice(chat.NoticeYouLeft, c.Name, c.ID, uint8(0))
+
+	return other.player
+}
+
+// BanFromChannel removes the member named target from the channel and stops them from rejoining. Returns
+// the player that was banned.
+func (w *World) BanFromChannel(p *Player, name, target string) *Player {
+	c, m := w.moderatorChannel(p, name)
+	if m == nil {
+		return nil
+	}
+
+	other := w.moderatedMember(c, p, target)
+	if other == nil {
+		return nil
+	}
+
+	c.banned[other.player.GUID()] = other.player.Character.Name
+	c.broadcast(chat.NoticePlayerBanned, other.player.GUID(), p.GUID())
+	w.leave(c, other.player)
+	other.player.sendNotice(chat.NoticeYouLeft, c.Name, c.ID, uint8(0))
+
+	return other.player
+}
+
+// UnbanFromChannel lets the player named target join the channel again.
+func (w *World) UnbanFromChannel(p *Player, name, target string) {
+	c, m := w.moderatorChannel(p, name)
+	if m == nil {
+		return
+	}
+
+	for guid, bannedName := range c.banned {
+		if strings.EqualFold(bannedName, target) {
+			delete(c.banned, guid)
+			c.broadcast(chat.NoticePlayerUnbanned, guid, p.GUID())
+			return
+		}
+	}
+
+	p.sendNotice(chat.NoticePlayerNotBanned, c.Name, target)
+}
+
+// ToggleChannelAnnouncements toggles whether members are told when players join or leave the channel.
+func (w *World) ToggleChannelAnnouncements(p *Player, name string) {
+	c, m := w.moderatorChannel(p, name)
+	if m == nil {
+		return
+	}
+
+	c.announce = !c.announce
+
+	if c.announce {
+		c.broadcast(chat.NoticeAnnouncementsOn, p.GUID())
+	} else {
+		c.broadcast(chat.NoticeAnnouncementsOff, p.GUID())
+	}
+}
+
+// ToggleChannelModeration toggles whether only moderators and voiced members can speak in the channel.
+func (w *World) ToggleChannelModeration(p *Player, name string) {
+	c, m := w.moderatorChannel(p, name)
+	if m == nil {
+		return
+	}
+
+	c.moderated = !c.moderated
+
+	if c.moderated {
+		c.broadcast(chat.NoticeModerationOn, p.GUID())
+	} else {
+		c.broadcast(chat.NoticeModerationOff, p.GUID())
+	}
+}
+
+// SendChannelList sends p the members of the channel.
+// https://gtker.com/wow_messages/docs/smsg_channel_list.html#client-version-335
+func (w *World) SendChannelList(p *Player, name string) error {
+	c, m := w.memberChannel(p, name)
+	if m == nil {
+		return nil
+	}
+
+	buf := bytes.Buffer{}
+	buf.WriteByte(1) // channel type
+	buf.WriteString(c.Name)
+	buf.WriteByte(0)
+	buf.WriteByte(byte(c.Flags))
+	binary.Write(&buf, binary.LittleEndian, uint32(len(c.members)))
+
+	for _, other := range c.members {
+		binary.Write(&buf, binary.LittleEndian, other.player.GUID())
+		buf.WriteByte(byte(other.flags))
+	}
+
+	return p.Client.SendPacketBytes(realmd.OpServerChannelList, buf.Bytes())
+}
+
+// ChannelChat sends a message from p to everyone in the channel.
+func (w *World) ChannelChat(p *Player, name string, lang chat.Language, text string) {
+	c, m := w.memberChannel(p, name)
+	if m == nil {
+		return
+	}
+
+	canSpeak := m.flags&(chat.MemberFlagModerator|chat.MemberFlagVoiced) != 0
+	if m.flags&chat.MemberFlagMuted != 0 || (c.moderated && !canSpeak) {
+		p.sendNotice(chat.NoticeMuted, c.Name)
+		return
+	}
+
+	msg := &chat.Message{
+		Type:     chat.MessageTypeChannel,
+		Language: lang,
+		Sender:   p.GUID(),
+		Target:   p.GUID(),
+		Channel:  c.Name,
+		Text:     text,
+		Tag:      p.ChatTag(),
+	}
+
+	for _, other := range c.members {
+		if other.player.Ignores(p) {
+			continue
+		}
+
+		if err := other.player.SendChat(msg); err != nil {
+			other.player.Client.Log.Error().Err(err).Msg("error sending channel message")
+		}
+	}
+}
@@ -0,0 +1,148 @@
+package world
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/kangaroux/gomaggus/realmd/chat"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestZoneChannels(t *testing.T) {
+	w := New(nil)
+	p, _ := newTestPlayer(1, 0, 0)
+	assert.NoError(t, w.AddPlayer(p))
+
+	channelNames := func() []string {
+		var names []string
+		for _, c := range p.Channels() {
+			names = append(names, c.Name)
+		}
+		return names
+	}
+
+	w.UpdateZoneChannels(p, testData.Areas[12])
+	assert.Equal(t, []string{"General - Elwynn Forest", "LocalDefense - Elwynn Forest"}, channelNames())
+
+	w.UpdateZoneChannels(p, testData.Areas[1519])
+	assert.Equal(t, []string{"General - Stormwind City", "Trade - City", "LocalDefense - Stormwind City"}, channelNames())
+	assert.Nil(t, w.Channel(p, "General - Elwynn Forest"), "empty channels are removed")
+
+	w.UpdateZoneChannels(p, nil)
+	assert.Empty(t, channelNames())
+
+	t.Run("teams are separate", func(t *testing.T) {
+		horde, _ := newTestPlayer(2, 0, 0)
+		horde.Horde = true
+
+		w.UpdateZoneChannels(p, testData.Areas[12])
+		w.UpdateZoneChannels(horde, testData.Areas[12])
+
+		assert.NotSame(t, w.Channel(p, "General - Elwynn Forest"), w.Channel(horde, "General - Elwynn Forest"))
+	})
+}
+
+func TestJoinChannel(t *testing.T) {
+	w := New(nil)
+	a, _ := newTestPlayer(1, 0, 0)
+	b, bConn := newTestPlayer(2, 0, 0)
+	a.Character.Name = "Alice"
+	b.Character.Name = "Bob"
+
+	t.Run("invalid name", func(t *testing.T) {
+		assert.Nil(t, w.JoinChannel(a, "", ""))
+		assert.Nil(t, w.JoinChannel(a, "General - Elwynn Forest", ""))
+		assert.Nil(t, w.JoinChannel(a, "abcdefghijklmnopqrstuvwxyz0123456789", ""))
+	})
+
+	c := w.JoinChannel(a, "Secret", "hunter2")
+	if !assert.NotNil(t, c) {
+		return
+	}
+	assert.Equal(t, a.GUID(), c.Owner)
+	assert.Equal(t, chat.MemberFlagOwner|chat.MemberFlagModerator, c.member(a).flags)
+
+	t.Run("wrong password", func(t *testing.T) {
+		writes := bConn.writes
+		assert.Nil(t, w.JoinChannel(b, "secret", "password"))
+		assert.Equal(t, writes+1, bConn.writes)
+		assert.Equal(t, byte(chat.NoticeWrongPassword), bConn.last[4])
+	})
+
+	t.Run("join", func(t *testing.T) {
+		assert.Same(t, c, w.JoinChannel(b, "secret", "hunter2"))
+		assert.Len(t, c.members, 2)
+		assert.Equal(t, chat.MemberFlagNone, c.member(b).flags)
+	})
+
+	t.Run("owner leaves", func(t *testing.T) {
+		assert.Same(t, c, w.LeaveChannel(a, "Secret"))
+		assert.Equal(t, b.GUID(), c.Owner)
+		assert.Empty(t, a.Channels())
+	})
+
+	t.Run("last member leaves", func(t *testing.T) {
+		assert.Same(t, c, w.LeaveChannel(b, "Secret"))
+		assert.Nil(t, w.Channel(b, "Secret"))
+		assert.Nil(t, w.LeaveChannel(b, "Secret"))
+	})
+}
+
+func TestChannelModeration(t *testing.T) {
+	w := New(nil)
+	owner, _ := newTestPlayer(1, 0, 0)
+	other, otherConn := newTestPlayer(2, 0, 0)
+	owner.Character.Name = "Alice"
+	other.Character.Name = "Bob"
+
+	assert.NoError(t, w.AddPlayer(owner))
+	assert.NoError(t, w.AddPlayer(other))
+
+	c := w.JoinChannel(owner, "Club", "")
+	w.JoinChannel(other, "Club", "")
+
+	t.Run("not moderator", func(t *testing.T) {
+		assert.Nil(t, w.KickFromChannel(other, "Club", "Alice"))
+		assert.Equal(t, byte(chat.NoticeNotModerator), otherConn.last[4])
+	})
+
+	t.Run("mute", func(t *testing.T) {
+		w.SetChannelMute(owner, "Club", "bob", true)
+		assert.NotZero(t, c.member(other).flags&chat.MemberFlagMuted)
+
+		writes := otherConn.writes
+		w.ChannelChat(other, "Club", chat.LanguageCommon, "hello")
+		assert.Equal(t, writes+1, otherConn.writes)
+		assert.Equal(t, byte(chat.NoticeMuted), otherConn.last[4])
+
+		w.SetChannelMute(owner, "Club", "bob", false)
+		w.ChannelChat(other, "Club", chat.LanguageCommon, "hello")
+		assert.True(t, bytes.Contains(otherConn.last, []byte("hello")))
+	})
+
+	t.Run("moderator can't kick owner", func(t *testing.T) {
+		w.SetChannelModerator(owner, "Club", "Bob", true)
+		assert.Nil(t, w.KickFromChannel(other, "Club", "Alice"))
+		assert.Equal(t, byte(chat.NoticeNotOwner), otherConn.last[4])
+	})
+
+	t.Run("ban", func(t *testing.T) {
+		assert.Same(t, other, w.BanFromChannel(owner, "Club", "Bob"))
+		assert.Nil(t, c.member(other))
+		assert.Nil(t, w.JoinChannel(other, "Club", ""))
+		assert.Equal(t, byte(chat.NoticeBanned), otherConn.last[4])
+
+		w.UnbanFromChannel(owner, "Club", "bob")
+		assert.NotNil(t, w.JoinChannel(other, "Club", ""))
+	})
+
+	t.Run("kick", func(t *testing.T) {
+		assert.Same(t, other, w.KickFromChannel(owner, "Club", "Bob"))
+		assert.Nil(t, c.member(other))
+	})
+
+	t.Run("remove player", func(t *testing.T) {
+		w.RemovePlayer(owner)
+		assert.Nil(t, w.Channel(owner, "Club"))
+	})
+}
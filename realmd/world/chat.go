@@ -0,0 +1,240 @@
+package world
+
+import (
+	"strings"
+
+	"github.com/kangaroux/gomaggus/realmd"
+	"github.com/kangaroux/gomaggus/realmd/chat"
+)
+
+const (
+	// How far away players can hear local chat messages, in yards.
+	sayRange   = 25
+	emoteRange = 25
+	yellRange  = 300
+)
+
+const (
+	defaultAFKMessage = "Away from Keyboard"
+	defaultDNDMessage = "Do not Disturb"
+)
+
+// PlayerByName returns the player with the given character name, or nil if they aren't in the world.
+// Names are case-insensitive.
+func (w *World) PlayerByName(name string) *Player {
+	for _, p := range w.players {
+		if strings.EqualFold(p.Character.Name, name) {
+			return p
+		}
+	}
+	return nil
+}
+
+// KnowsLanguage reports whether the player can speak and understand lang.
+func (p *Player) KnowsLanguage(lang chat.Language) bool {
+	if lang == chat.LanguageUniversal || lang == chat.LanguageAddon {
+		return true
+	}
+
+	skill, ok := lang.Skill()
+	if !ok {
+		return false
+	}
+
+	for _, s := range p.Values.Skills() {
+		if s.ID == skill {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ChatTag returns the tag that is shown next to the player's name in chat.
+func (p *Player) ChatTag() chat.Tag {
+	switch {
+	case p.Values.GM():
+		return chat.TagGM
+	case p.Values.AFK():
+		return chat.TagAFK
+	case p.Values.DND():
+		return chat.TagDND
+	default:
+		return chat.TagNone
+	}
+}
+
+// ToggleAFK marks the player as away, or clears it if they were already away and msg is empty. While
+// away, msg is sent to anyone who whispers the player.
+func (p *Player) ToggleAFK(msg string) {
+	if p.Values.AFK() {
+		if msg == "" {
+			p.Values.SetAFK(false)
+			p.AutoReply = ""
+		} else {
+			p.AutoReply = msg
+		}
+		return
+	}
+
+	if msg == "" {
+		msg = defaultAFKMessage
+	}
+
+	p.AutoReply = msg
+	p.Values.SetDND(false)
+	p.Values.SetAFK(true)
+}
+
+// ToggleDND marks the player as do not disturb, or clears it if they were already marked and msg is
+// empty. While marked, msg is sent to anyone who whispers the player.
+func (p *Player) ToggleDND(msg string) {
+	if p.Values.DND() {
+		if msg == "" {
+			p.Values.SetDND(false)
+			p.AutoReply = ""
+		} else {
+			p.AutoReply = msg
+		}
+		return
+	}
+
+	if msg == "" {
+		msg = defaultDNDMessage
+	}
+
+	p.AutoReply = msg
+	p.Values.SetAFK(false)
+	p.Values.SetDND(true)
+}
+
+// SendChat sends msg to the player. The text is scrambled if the player doesn't know the message's
+// language.
+func (p *Player) SendChat(msg *chat.Message) error {
+	if !p.KnowsLanguage(msg.Language) {
+		scrambled := *msg
+		scrambled.Text = chat.Scramble(msg.Language, msg.Text)
+		msg = &scrambled
+	}
+
+	return p.Client.SendPacketBytes(realmd.OpServerMessageChat, msg.Bytes())
+}
+
+// LocalChat sends a say, yell or emote from p to every player who is close enough to hear it.
+func (w *World) LocalChat(p *Player, msgType chat.MessageType, lang chat.Language, text string) {
+	var dist float32
+
+	switch msgType {
+	case chat.MessageTypeSay:
+		dist = sayRange
+	case chat.MessageTypeYell:
+		dist = yellRange
+	case chat.MessageTypeEmote:
+		// Emotes aren't spoken, everyone can understand them
+		dist = emoteRange
+		lang = chat.LanguageUniversal
+	default:
+		return
+	}
+
+	msg := &chat.Message{
+		Type:     msgType,
+		Language: lang,
+		Sender:   p.GUID(),
+		Target:   p.GUID(),
+		Text:     text,
+		Tag:      p.ChatTag(),
+	}
+
+	for _, listener := range w.Map(p.MapID).PlayersInRange(p.Position(), dist) {
+		if listener.Ignores(p) {
+			continue
+		}
+
+		if err := listener.SendChat(msg); err != nil {
+			listener.Client.Log.Error().Err(err).Msg("error sending chat message")
+		}
+	}
+}
+
+// Whisper sends a private message from p to target. p is sent a copy of the message, along with
+// target's auto reply if they are away or don't want to be disturbed. If target is ignoring p, the
+// message isn't delivered and p is told they are being ignored.
+func (w *World) Whisper(p *Player, target *Player, lang chat.Language, text string) error {
+	if target.Ignores(p) {
+		return p.sendIgnored(target)
+	}
+
+	msg := &chat.Message{
+		Type:     chat.MessageTypeWhisper,
+		Language: lang,
+		Sender:   p.GUID(),
+		Target:   p.GUID(),
+		Text:     text,
+		Tag:      p.ChatTag(),
+	}
+
+	if err := target.SendChat(msg); err != nil {
+		target.Client.Log.Error().Err(err).Msg("error sending whisper")
+	}
+
+	// Addon messages aren't shown, so there's no need to confirm them
+	if lang == chat.LanguageAddon {
+		return nil
+	}
+
+	inform := &chat.Message{
+		Type:     chat.MessageTypeWhisperInform,
+		Language: lang,
+		Sender:   target.GUID(),
+		Target:   target.GUID(),
+		Text:     text,
+		Tag:      target.ChatTag(),
+	}
+
+	if err := p.Client.SendPacketBytes(realmd.OpServerMessageChat, inform.Bytes()); err != nil {
+		return err
+	}
+
+	var replyType chat.MessageType
+
+	switch {
+	case target.Values.AFK():
+		replyType = chat.MessageTypeAFK
+	case target.Values.DND():
+		replyType = chat.MessageTypeDND
+	default:
+		return nil
+	}
+
+	reply := &chat.Message{
+		Type:     replyType,
+		Language: chat.LanguageUniversal,
+		Sender:   target.GUID(),
+		Target:   target.GUID(),
+		Text:     target.AutoReply,
+		Tag:      target.ChatTag(),
+	}
+
+	return p.Client.SendPacketBytes(realmd.OpServerMessageChat, reply.Bytes())
+}
+
+// SendSystemMessage sends text to the player as a system message.
+func (p *Player) SendSystemMessage(text string) error {
+	msg := &chat.Message{
+		Type:     chat.MessageTypeSystem,
+		Language: chat.LanguageUniversal,
+		Text:     text,
+	}
+
+	return p.Client.SendPacketBytes(realmd.OpServerMessageChat, msg.Bytes())
+}
+
+// Announce sends text to every player in the world as a system message.
+func (w *World) Announce(text string) {
+	for _, p := range w.players {
+		if err := p.SendSystemMessage(text); err != nil {
+			p.Client.Log.Error().Err(err).Msg("error sending announcement")
+		}
+	}
+}
@@ -0,0 +1,104 @@
+package world
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/kangaroux/gomaggus/model"
+	"github.com/kangaroux/gomaggus/realmd/chat"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKnowsLanguage(t *testing.T) {
+	human, _ := newTestPlayer(1, 0, 0)
+
+	assert.True(t, human.KnowsLanguage(chat.LanguageUniversal))
+	assert.True(t, human.KnowsLanguage(chat.LanguageCommon))
+	assert.False(t, human.KnowsLanguage(chat.LanguageOrcish))
+	assert.False(t, human.KnowsLanguage(chat.LanguageDemonic))
+}
+
+func TestLocalChat(t *testing.T) {
+	w := New(nil)
+	speaker, _ := newTestPlayer(1, 0, 0)
+	near, nearConn := newTestPlayer(2, 10, 0)
+	far, farConn := newTestPlayer(3, 50, 0)
+
+	assert.NoError(t, w.AddPlayer(speaker))
+	assert.NoError(t, w.AddPlayer(near))
+	assert.NoError(t, w.AddPlayer(far))
+
+	t.Run("say", func(t *testing.T) {
+		nearWrites, farWrites := nearConn.writes, farConn.writes
+		w.LocalChat(speaker, chat.MessageTypeSay, chat.LanguageCommon, "hello")
+
+		assert.Equal(t, nearWrites+1, nearConn.writes)
+		assert.Equal(t, farWrites, farConn.writes)
+		assert.True(t, bytes.Contains(nearConn.last, []byte("hello")))
+	})
+
+	t.Run("yell", func(t *testing.T) {
+		farWrites := farConn.writes
+		w.LocalChat(speaker, chat.MessageTypeYell, chat.LanguageCommon, "hello")
+
+		assert.Equal(t, farWrites+1, farConn.writes)
+	})
+
+	t.Run("scrambled", func(t *testing.T) {
+		char := &model.Character{Id: 4, Race: model.RaceOrc, Class: model.ClassWarrior}
+		orc, err := NewPlayer(near.Client, char, testData)
+		if !assert.NoError(t, err) {
+			return
+		}
+
+		assert.NoError(t, orc.SendChat(&chat.Message{Type: chat.MessageTypeSay, Language: chat.LanguageCommon, Text: "hello"}))
+		assert.False(t, bytes.Contains(nearConn.last, []byte("hello")))
+	})
+}
+
+func TestWhisper(t *testing.T) {
+	w := New(nil)
+	a, aConn := newTestPlayer(1, 0, 0)
+	b, bConn := newTestPlayer(2, 1000, 1000)
+	a.Character.Name = "Alice"
+	b.Character.Name = "Bob"
+
+	assert.NoError(t, w.AddPlayer(a))
+	assert.NoError(t, w.AddPlayer(b))
+
+	assert.Equal(t, b, w.PlayerByName("bob"))
+	assert.Nil(t, w.PlayerByName("carol"))
+
+	t.Run("whisper", func(t *testing.T) {
+		aWrites, bWrites := aConn.writes, bConn.writes
+		assert.NoError(t, w.Whisper(a, b, chat.LanguageCommon, "psst"))
+
+		assert.Equal(t, bWrites+1, bConn.writes)
+		assert.Equal(t, aWrites+1, aConn.writes, "sender is informed")
+		assert.True(t, bytes.Contains(bConn.last, []byte("psst")))
+	})
+
+	t.Run("afk auto reply", func(t *testing.T) {
+		b.ToggleAFK("")
+		assert.True(t, b.Values.AFK())
+		assert.Equal(t, chat.TagAFK, b.ChatTag())
+
+		aWrites := aConn.writes
+		assert.NoError(t, w.Whisper(a, b, chat.LanguageCommon, "psst"))
+
+		assert.Equal(t, aWrites+2, aConn.writes)
+		assert.True(t, bytes.Contains(aConn.last, []byte(defaultAFKMessage)))
+	})
+
+	t.Run("dnd replaces afk", func(t *testing.T) {
+		b.ToggleDND("busy")
+		assert.False(t, b.Values.AFK())
+		assert.True(t, b.Values.DND())
+
+		assert.NoError(t, w.Whisper(a, b, chat.LanguageCommon, "psst"))
+		assert.True(t, bytes.Contains(aConn.last, []byte("busy")))
+
+		b.ToggleDND("")
+		assert.False(t, b.Values.DND())
+	})
+}
@@ -0,0 +1,746 @@
+package world
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math"
+	"math/rand"
+	"time"
+
+	"github.com/kangaroux/gomaggus/model"
+	"github.com/kangaroux/gomaggus/realmd"
+	"github.com/kangaroux/gomaggus/realmd/dbc"
+	"github.com/kangaroux/gomaggus/realmd/player"
+)
+
+const (
+	// Players can hit anything within the combat reach of both of them plus meleeRangeBonus, but never
+	// less than minMeleeRange.
+	meleeRangeBonus = 4.0 / 3
+	minMeleeRange   = 5
+
+	// Players have to be facing their target within meleeArc to hit it.
+	meleeArc = 2 * math.Pi / 3
+
+	// The size of a player's model and how far they can reach.
+	playerBoundingRadius = 0.389
+	playerCombatReach    = 1.5
+
+	// Players leave combat once they haven't attacked or been attacked for combatDuration.
+	combatDuration = 5 * time.Second
+
+	// Ghosts can reclaim their corpse once they're within corpseReclaimRadius of it, and corpseReclaimDelay
+	// has passed since they released their spirit.
+	corpseReclaimRadius = 39
+	corpseReclaimDelay  = 30 * time.Second
+
+	// Players above resurrectionSicknessLevel get resurrection sickness when a spirit healer brings them
+	// back to life.
+	resurrectionSicknessLevel = 10
+
+	// SpellGhost makes a player look like a ghost, and SpellResurrectionSickness weakens a player that was
+	// resurrected by a spirit healer.
+	SpellGhost                = 8326
+	SpellResurrectionSickness = 15007
+
+	spellSchoolMaskPhysical = 0x1
+)
+
+// attackHand is the hand a melee attack is made with.
+type attackHand uint8
+
+const (
+	attackMainHand attackHand = iota
+	attackOffHand
+)
+
+// https://gtker.com/wow_messages/docs/hitinfo.html#client-version-335
+type hitInfo uint32
+
+const (
+	hitInfoAffectsVictim hitInfo = 0x2
+	hitInfoOffHand       hitInfo = 0x4
+	hitInfoMiss          hitInfo = 0x10
+	hitInfoCriticalHit   hitInfo = 0x200
+	hitInfoBlock         hitInfo = 0x2000
+)
+
+// https://gtker.com/wow_messages/docs/victimstate.html
+type victimState uint8
+
+const (
+	victimStateIntact victimState = 0
+	victimStateHit    victimState = 1
+	victimStateDodge  victimState = 2
+	victimStateParry  victimState = 3
+	victimStateBlocks victimState = 5
+)
+
+// meleeOutcome is how a melee attack went.
+type meleeOutcome uint8
+
+const (
+	meleeHit meleeOutcome = iota
+	meleeMiss
+	meleeDodge
+	meleeParry
+	meleeBlock
+	meleeCrit
+)
+
+// meleeChances are the percent chances of each outcome of a melee attack. Whatever is left over is a hit.
+type meleeChances struct {
+	Miss  float32
+	Dodge float32
+	Parry float32
+	Block float32
+	Crit  float32
+}
+
+// roll returns the outcome for roll, which is from 0 to 100. The outcomes are stacked on top of each other
+// in order, so a big chance of one outcome pushes the ones after it off of the table.
+func (c meleeChances) roll(roll float32) meleeOutcome {
+	outcomes := []struct {
+		chance  float32
+		outcome meleeOutcome
+	}{
+		{c.Miss, meleeMiss},
+		{c.Dodge, meleeDodge},
+		{c.Parry, meleeParry},
+		{c.Block, meleeBlock},
+		{c.Crit, meleeCrit},
+	}
+
+	for _, o := range outcomes {
+		if roll < o.chance {
+			return o.outcome
+		}
+		roll -= o.chance
+	}
+
+	return meleeHit
+}
+
+// clampChance keeps a percent chance between 0 and 100.
+func clampChance(chance float32) float32 {
+	if chance < 0 {
+		return 0
+	} else if chance > 100 {
+		return 100
+	}
+	return chance
+}
+
+// Alive reports whether the player is alive. Players are dead once their health reaches 0, and stay dead as
+// a ghost after releasing their spirit.
+func (p *Player) Alive() bool {
+	return p.Values.Health() > 0 && !p.Values.Ghost()
+}
+
+// Corpse returns the corpse the player left behind, or nil if they aren't a ghost.
+func (p *Player) Corpse() *Corpse {
+	return p.corpse
+}
+
+// AttackTarget returns who the player is auto-attacking, or 0 if they aren't attacking anyone.
+func (p *Player) AttackTarget() realmd.Guid {
+	return p.attackTarget
+}
+
+// weapon returns the weapon the player attacks with in hand, or nil if they're unarmed. Shields and other
+// off hand items aren't weapons.
+func (p *Player) weapon(hand attackHand) *Item {
+	slot := EquipmentSlotMainHand
+	if hand == attackOffHand {
+		slot = EquipmentSlotOffHand
+	}
+
+	item := p.items[slot]
+	if item == nil || item.Template.Class != model.ItemClassWeapon {
+		return nil
+	}
+	return item
+}
+
+// weaponSkill returns the skill the player uses to attack with in hand.
+func (p *Player) weaponSkill(hand attackHand) uint16 {
+	if item := p.weapon(hand); item != nil {
+		if id := player.WeaponSkill(item.Template.Subclass); id != 0 {
+			return id
+		}
+	}
+	return player.SkillUnarmed
+}
+
+// skillValue returns the player's value in a weapon skill or defense. Skills the player doesn't know count
+// as maxed out for their level.
+func (p *Player) skillValue(id uint16) uint16 {
+	if skill, ok := p.Skill(id); ok {
+		return skill.Value
+	}
+	return player.MaxSkillForLevel(p.Values.Level())
+}
+
+// hasShield reports whether the player has a shield equipped.
+func (p *Player) hasShield() bool {
+	item := p.items[EquipmentSlotOffHand]
+	return item != nil && item.Template.InventoryType == model.InventoryTypeShield
+}
+
+// updateCombatStats sets the player's attack power, weapon damage and speed, and their chances to crit,
+// dodge, parry and block from their stats and equipment.
+func (p *Player) updateCombatStats() {
+	v := p.Values
+	level := v.Level()
+
+	ap := player.AttackPower(p.Character.Class, level, v.Strength(), v.Agility())
+	v.SetAttackPower(ap)
+
+	speed, min, max := uint32(player.UnarmedSpeed), float32(player.UnarmedMinDamage), float32(player.UnarmedMaxDamage)
+	if item := p.weapon(attackMainHand); item != nil {
+		speed, min, max = item.Template.Delay, item.Template.DamageMin, item.Template.DamageMax
+	}
+	min, max = player.WeaponDamage(min, max, speed, ap)
+	v.SetBaseAttackTime(speed)
+	v.SetMinDamage(min)
+	v.SetMaxDamage(max)
+
+	// Off hand weapons only do half damage
+	if item := p.weapon(attackOffHand); item != nil {
+		speed, min, max = item.Template.Delay, item.Template.DamageMin, item.Template.DamageMax
+		min, max = player.WeaponDamage(min, max, speed, ap)
+		v.SetOffhandAttackTime(speed)
+		v.SetMinOffhandDamage(min / 2)
+		v.SetMaxOffhandDamage(max / 2)
+	} else {
+		v.SetOffhandAttackTime(0)
+		v.SetMinOffhandDamage(0)
+		v.SetMaxOffhandDamage(0)
+	}
+
+	crit := player.CritChance(level, v.Agility())
+	v.SetCritPercentage(crit)
+	v.SetOffhandCritPercentage(crit)
+	v.SetDodgePercentage(player.DodgeChance(level, v.Agility()))
+	v.SetParryPercentage(player.ParryChance(p.Character.Class))
+	v.SetBlockPercentage(player.BlockChance(p.hasShield()))
+
+	if p.hasShield() {
+		v.SetShieldBlock(player.BlockValue(v.Strength()))
+	} else {
+		v.SetShieldBlock(0)
+	}
+}
+
+// meleeRange returns how close the player has to be to hit the victim.
+func meleeRange(p, victim *Player) float32 {
+	reach := p.Values.CombatReach() + victim.Values.CombatReach() + meleeRangeBonus
+	if reach < minMeleeRange {
+		return minMeleeRange
+	}
+	return reach
+}
+
+// inArc reports whether to is within an arc in front of from. The arc is centered on the way from is facing.
+func inArc(from, to realmd.Vector4, arc float64) bool {
+	angle := math.Atan2(float64(to.Y-from.Y), float64(to.X-from.X)) - float64(from.Rotation)
+
+	// Keep the angle between -pi and pi
+	angle = math.Mod(angle, 2*math.Pi)
+	if angle > math.Pi {
+		angle -= 2 * math.Pi
+	} else if angle < -math.Pi {
+		angle += 2 * math.Pi
+	}
+
+	return math.Abs(angle) <= arc/2
+}
+
+// meleeChances returns the chances of each outcome when the player attacks the victim with hand. The
+// difference between the player's weapon skill and the victim's defense makes everything more or less
+// likely. Victims can only dodge, parry and block attacks from the front, and sitting victims can't avoid
+// attacks at all and are always crit.
+func (p *Player) meleeChances(victim *Player, hand attackHand) meleeChances {
+	skill := p.skillValue(p.weaponSkill(hand))
+	defense := victim.skillValue(player.SkillDefense)
+	diff := float32(int32(defense)-int32(skill)) * player.SkillChancePerPoint
+
+	vv := victim.Values
+	c := meleeChances{
+		Miss: player.BaseMissChance + diff,
+		Crit: p.Values.CritPercentage() - diff,
+	}
+
+	if p.weapon(attackOffHand) != nil {
+		c.Miss += player.DualWieldMissBonus
+	}
+
+	if vv.StandState() != uint8(player.StateStand) {
+		c.Crit = 100
+	} else if inArc(victim.Position(), p.Position(), math.Pi) {
+		c.Dodge = vv.DodgePercentage() + diff
+		if vv.ParryPercentage() > 0 {
+			c.Parry = vv.ParryPercentage() + diff
+		}
+		if vv.BlockPercentage() > 0 {
+			c.Block = vv.BlockPercentage() + diff
+		}
+	}
+
+	c.Miss = clampChance(c.Miss)
+	c.Dodge = clampChance(c.Dodge)
+	c.Parry = clampChance(c.Parry)
+	c.Block = clampChance(c.Block)
+	c.Crit = clampChance(c.Crit)
+
+	return c
+}
+
+// meleeDamage returns the damage the player does to the victim with hand, before crits and blocks. roll
+// is from 0 to 1 and picks the damage between the weapon's min and max. The victim's armor stops some of it.
+func (p *Player) meleeDamage(victim *Player, hand attackHand, roll float32) uint32 {
+	v := p.Values
+
+	min, max := v.MinDamage(), v.MaxDamage()
+	if hand == attackOffHand {
+		min, max = v.MinOffhandDamage(), v.MaxOffhandDamage()
+	}
+
+	damage := min + (max-min)*roll
+	damage *= 1 - player.ArmorReduction(victim.Values.Resistances()[0], v.Level())
+
+	if damage < 1 {
+		return 1
+	}
+	return uint32(damage)
+}
+
+// enterCombat puts the player in combat with opponent, or keeps them in it for a while longer.
+func (p *Player) enterCombat(opponent realmd.Guid) {
+	p.Values.SetInCombat(true)
+	p.combatTimer = combatDuration
+	p.opponents[opponent] = true
+}
+
+// leaveCombat takes the player out of combat.
+func (p *Player) leaveCombat() {
+	p.Values.SetInCombat(false)
+	p.combatTimer = 0
+	p.opponents = make(map[realmd.Guid]bool)
+}
+
+// StartAttack starts auto-attacking the target. The player is told if the target can't be attacked.
+// https://gtker.com/wow_messages/docs/smsg_attackstart.html
+func (w *World) StartAttack(p *Player, target realmd.Guid) {
+	if !p.Alive() {
+		return
+	}
+
+	victim := w.players[target]
+	if victim == nil || victim == p || !p.CanSee(victim) || !p.hostileTo(victim) {
+		p.sendPacket(realmd.OpServerAttackSwingCantAttack, nil)
+		return
+	}
+	if !victim.Alive() {
+		p.sendPacket(realmd.OpServerAttackSwingDeadTarget, nil)
+		return
+	}
+	if p.attackTarget == target {
+		return
+	}
+
+	p.attackTarget = target
+	p.swingError = 0
+
+	buf := make([]byte, 16)
+	binary.LittleEndian.PutUint64(buf, uint64(p.GUID()))
+	binary.LittleEndian.PutUint64(buf[8:], uint64(target))
+	w.Broadcast(p, false, realmd.OpServerAttackStart, buf)
+}
+
+// StopAttack stops the player's auto-attack and tells them and anyone nearby. Does nothing if they aren't
+// attacking anyone.
+// https://gtker.com/wow_messages/docs/smsg_attackstop.html
+func (w *World) StopAttack(p *Player) {
+	if p.attackTarget == 0 {
+		return
+	}
+
+	buf := bytes.Buffer{}
+	buf.Write(realmd.PackGuid(uint64(p.GUID())))
+	buf.Write(realmd.PackGuid(uint64(p.attackTarget)))
+	binary.Write(&buf, binary.LittleEndian, uint32(0)) // Unknown
+
+	p.attackTarget = 0
+	w.Broadcast(p, false, realmd.OpServerAttackStop, buf.Bytes())
+}
+
+// updateCombat swings at the player's target when their weapons are ready, and takes them out of combat
+// once they haven't fought for a while.
+func (w *World) updateCombat(p *Player, diff time.Duration) {
+	for hand, timer := range p.attackTimers {
+		if timer -= diff; timer < 0 {
+			timer = 0
+		}
+		p.attackTimers[hand] = timer
+	}
+
+	if p.attackTarget != 0 {
+		w.updateAttack(p)
+	}
+
+	if p.attackTarget == 0 && p.Values.InCombat() {
+		p.combatTimer -= diff
+		if p.combatTimer <= 0 {
+			p.leaveCombat()
+		}
+	}
+}
+
+// updateAttack swings with each of the player's hands that's ready, as long as they're in range of their
+// target and facing it. The attack stops if the target died or can't be seen anymore.
+func (w *World) updateAttack(p *Player) {
+	victim := w.players[p.attackTarget]
+	if victim == nil || !victim.Alive() || !p.CanSee(victim) || !p.Alive() {
+		w.StopAttack(p)
+		return
+	}
+
+	// Swings wait until the player is done casting and can move again
+	if p.cast != nil || p.Values.IsStunned() {
+		return
+	}
+
+	offHand := p.weapon(attackOffHand) != nil
+	if p.attackTimers[attackMainHand] > 0 && (!offHand || p.attackTimers[attackOffHand] > 0) {
+		return
+	}
+
+	if distance(p.Position(), victim.Position()) > meleeRange(p, victim) {
+		p.swingFailed(realmd.OpServerAttackSwingNotInRange)
+		return
+	}
+	if !inArc(p.Position(), victim.Position(), meleeArc) {
+		p.swingFailed(realmd.OpServerAttackSwingBadFacing)
+		return
+	}
+	p.swingError = 0
+
+	if p.attackTimers[attackMainHand] <= 0 {
+		w.swing(p, victim, attackMainHand)
+		p.attackTimers[attackMainHand] = time.Duration(p.Values.BaseAttackTime()) * time.Millisecond
+	}
+	if offHand && p.attackTimers[attackOffHand] <= 0 && victim.Alive() {
+		w.swing(p, victim, attackOffHand)
+		p.attackTimers[attackOffHand] = time.Duration(p.Values.OffhandAttackTime()) * time.Millisecond
+	}
+}
+
+// swingFailed tells the player why they can't swing. They're only told again once the reason changes.
+func (p *Player) swingFailed(opcode realmd.ServerOpcode) {
+	if p.swingError == opcode {
+		return
+	}
+
+	p.swingError = opcode
+	p.sendPacket(opcode, nil)
+}
+
+// swing makes a melee attack against the victim with hand, and tells anyone nearby how it went. Both of
+// them are put in combat and have a chance to raise their weapon skill and defense.
+func (w *World) swing(p, victim *Player, hand attackHand) {
+	outcome := p.meleeChances(victim, hand).roll(rand.Float32() * 100)
+
+	var damage, blocked uint32
+	switch outcome {
+	case meleeHit, meleeCrit, meleeBlock:
+		damage = p.meleeDamage(victim, hand, rand.Float32())
+
+		if outcome == meleeCrit {
+			damage *= 2
+		} else if outcome == meleeBlock {
+			blocked = victim.Values.ShieldBlock()
+			if blocked > damage {
+				blocked = damage
+			}
+			damage -= blocked
+		}
+	}
+
+	p.enterCombat(victim.GUID())
+	victim.enterCombat(p.GUID())
+
+	if outcome != meleeMiss {
+		w.UpdateCombatSkill(p, p.weaponSkill(hand), victim.Values.Level())
+		w.UpdateCombatSkill(victim, player.SkillDefense, p.Values.Level())
+	}
+
+	// Getting hit makes the victim stand up
+	if victim.Values.StandState() != uint8(player.StateStand) && !victim.Values.IsStunned() {
+		w.setStandState(victim, player.StateStand)
+	}
+
+	w.Broadcast(p, false, realmd.OpServerAttackerStateUpdate,
+		attackerStateUpdatePacket(p, victim, hand, outcome, damage, blocked))
+
+	if damage > 0 {
+		w.damage(victim, damage)
+	}
+}
+
+// https://gtker.com/wow_messages/docs/smsg_attackerstateupdate.html#client-version-335
+func attackerStateUpdatePacket(p, victim *Player, hand attackHand, outcome meleeOutcome, damage, blocked uint32) []byte {
+	info := hitInfoAffectsVictim
+	state := victimStateHit
+
+	switch outcome {
+	case meleeMiss:
+		info = hitInfoMiss
+		state = victimStateIntact
+	case meleeDodge:
+		state = victimStateDodge
+	case meleeParry:
+		state = victimStateParry
+	case meleeBlock:
+		info |= hitInfoBlock
+		state = victimStateBlocks
+	case meleeCrit:
+		info |= hitInfoCriticalHit
+	}
+
+	if hand == attackOffHand {
+		info |= hitInfoOffHand
+	}
+
+	var overkill uint32
+	if health := victim.Values.Health(); damage > health {
+		overkill = damage - health
+	}
+
+	buf := bytes.Buffer{}
+	binary.Write(&buf, binary.LittleEndian, uint32(info))
+	buf.Write(realmd.PackGuid(uint64(p.GUID())))
+	buf.Write(realmd.PackGuid(uint64(victim.GUID())))
+	binary.Write(&buf, binary.LittleEndian, damage)
+	binary.Write(&buf, binary.LittleEndian, overkill)
+
+	// All of the damage is physical
+	buf.WriteByte(1)
+	binary.Write(&buf, binary.LittleEndian, uint32(spellSchoolMaskPhysical))
+	binary.Write(&buf, binary.LittleEndian, float32(damage))
+	binary.Write(&buf, binary.LittleEndian, damage)
+
+	buf.WriteByte(byte(state))
+	binary.Write(&buf, binary.LittleEndian, uint32(0)) // Unknown
+	binary.Write(&buf, binary.LittleEndian, uint32(0)) // Melee spell id
+
+	if info&hitInfoBlock != 0 {
+		binary.Write(&buf, binary.LittleEndian, blocked)
+	}
+
+	return buf.Bytes()
+}
+
+// damage takes health from the player, and they die if it reaches 0. Returns how much of the damage was
+// more than the player's health.
+func (w *World) damage(p *Player, amount uint32) uint32 {
+	v := p.Values
+
+	if !p.Alive() {
+		return amount
+	}
+	health := v.Health()
+
+	if amount < health {
+		v.SetHealth(health - amount)
+		return 0
+	}
+
+	v.SetHealth(0)
+	w.die(p)
+
+	return amount - health
+}
+
+// heal gives the player health, up to their max health. Returns how much of the heal was more than the
+// player was missing. Dead players can't be healed.
+func (w *World) heal(p *Player, amount uint32) uint32 {
+	v := p.Values
+
+	if !p.Alive() {
+		return amount
+	}
+	health := v.Health()
+
+	missing := v.MaxHealth() - health
+	if amount < missing {
+		v.SetHealth(health + amount)
+		return 0
+	}
+
+	v.SetHealth(v.MaxHealth())
+
+	return amount - missing
+}
+
+// die is called when the player's health reaches 0. The spell they were casting is interrupted, their
+// auras are removed, and they and anyone attacking them stop attacking. They stay dead until they release
+// their spirit and are resurrected.
+func (w *World) die(p *Player) {
+	w.InterruptCast(p, SpellCastResultInterrupted)
+	w.RemoveAllAuras(p)
+	w.StopAttack(p)
+
+	for _, other := range p.VisiblePlayers() {
+		if other.attackTarget == p.GUID() {
+			w.StopAttack(other)
+		}
+	}
+
+	// Anyone who was only fighting the player isn't in combat anymore
+	for _, other := range w.players {
+		if !other.opponents[p.GUID()] {
+			continue
+		}
+		delete(other.opponents, p.GUID())
+		if len(other.opponents) == 0 && other.attackTarget == 0 {
+			other.leaveCombat()
+		}
+	}
+
+	p.leaveCombat()
+}
+
+// ReleaseSpirit turns a dead player into a ghost. They leave a corpse where they died and are sent to the
+// graveyard, if there is one. ghost is the spell that makes them look like a ghost, if it's known.
+// https://gtker.com/wow_messages/docs/smsg_death_release_loc.html
+func (w *World) ReleaseSpirit(p *Player, graveyard *dbc.WorldSafeLoc, ghost *dbc.Spell) error {
+	v := p.Values
+	if v.Health() > 0 || v.Ghost() {
+		return nil
+	}
+
+	w.nextCorpseID++
+	p.corpse = NewCorpse(w.nextCorpseID, p)
+	w.addObject(p.MapID, p.corpse)
+
+	// Ghosts have a little health so the client doesn't treat them as a body
+	v.SetGhost(true)
+	v.SetHealth(1)
+
+	if ghost != nil {
+		w.ApplyAura(p, NewAura(ghost, p.GUID(), v.Level(), 0))
+	}
+
+	if graveyard == nil {
+		return nil
+	}
+
+	pos := realmd.Vector4{X: graveyard.X, Y: graveyard.Y, Z: graveyard.Z}
+	if err := w.TransferPlayer(p, graveyard.Map, pos); err != nil {
+		return err
+	}
+
+	buf := bytes.Buffer{}
+	binary.Write(&buf, binary.LittleEndian, graveyard.Map)
+	binary.Write(&buf, binary.LittleEndian, [3]float32{pos.X, pos.Y, pos.Z})
+	p.sendPacket(realmd.OpServerDeathReleaseLoc, buf.Bytes())
+
+	return nil
+}
+
+// ReclaimCorpse brings a ghost back to life at their corpse with half of their health and mana. They have
+// to be near their corpse, and a little while has to pass since they released their spirit. Returns false
+// if they can't reclaim it.
+func (w *World) ReclaimCorpse(p *Player) bool {
+	c := p.corpse
+	if c == nil || c.MapID != p.MapID {
+		return false
+	}
+
+	if distance(p.Position(), c.Position()) > corpseReclaimRadius || time.Since(c.CreatedAt) < corpseReclaimDelay {
+		return false
+	}
+
+	w.resurrect(p, 0.5)
+	return true
+}
+
+// SpiritHealerResurrect brings a ghost back to life where they are with half of their health and mana. Players
+// above level 10 get resurrection sickness for duration, if the spell is known.
+func (w *World) SpiritHealerResurrect(p *Player, sickness *dbc.Spell, duration time.Duration) {
+	if !p.Values.Ghost() {
+		return
+	}
+
+	w.resurrect(p, 0.5)
+
+	if level := p.Values.Level(); sickness != nil && level > resurrectionSicknessLevel {
+		a := NewAura(sickness, p.GUID(), level, duration)
+		a.Positive = false
+		w.ApplyAura(p, a)
+	}
+}
+
+// Resurrect brings a dead player or a ghost back to life where they are with full health and mana. Players
+// that are alive are restored to full health and mana.
+func (w *World) Resurrect(p *Player) {
+	if p.Alive() {
+		p.Revive()
+		return
+	}
+
+	w.resurrect(p, 1)
+}
+
+// resurrect brings a dead player or a ghost back to life with a fraction of their max health and mana, and removes their
+// corpse. Their energy is full and their rage and runic power are empty.
+func (w *World) resurrect(p *Player, fraction float32) {
+	v := p.Values
+	v.SetGhost(false)
+
+	for _, a := range p.Auras() {
+		if a.Spell.ID == SpellGhost {
+			w.RemoveAura(p, a)
+		}
+	}
+
+	health := uint32(float32(v.MaxHealth()) * fraction)
+	if health == 0 {
+		health = 1
+	}
+	v.SetHealth(health)
+	v.SetMana(uint32(float32(v.MaxMana()) * fraction))
+	v.SetEnergy(v.MaxEnergy())
+	v.SetRage(0)
+	v.SetRunicPower(0)
+
+	w.removeCorpse(p)
+}
+
+// restoreCorpse puts back the corpse of a player that logged out as a ghost, where their character says it
+// is.
+func (w *World) restoreCorpse(p *Player) {
+	char := p.Character
+	if !p.Values.Ghost() || p.corpse != nil {
+		return
+	}
+
+	w.nextCorpseID++
+	c := NewCorpse(w.nextCorpseID, p)
+	c.MapID = char.CorpseMap
+	c.pos = realmd.Vector4{X: char.CorpseX, Y: char.CorpseY, Z: char.CorpseZ, Rotation: char.CorpseOrientation}
+
+	p.corpse = c
+	w.addObject(c.MapID, c)
+}
+
+// removeCorpse takes the player's corpse out of the world, if they have one.
+func (w *World) removeCorpse(p *Player) {
+	if p.corpse == nil {
+		return
+	}
+
+	w.removeObject(p.corpse.MapID, p.corpse)
+	p.corpse = nil
+}
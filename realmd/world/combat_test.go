@@ -0,0 +1,281 @@
+package world
+
+import (
+	"encoding/binary"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/kangaroux/gomaggus/realmd"
+	"github.com/kangaroux/gomaggus/realmd/dbc"
+	"github.com/kangaroux/gomaggus/realmd/player"
+	"github.com/stretchr/testify/assert"
+)
+
+func lastOpcode(conn *testConn) realmd.ServerOpcode {
+	return realmd.ServerOpcode(binary.LittleEndian.Uint16(conn.last[2:]))
+}
+
+func TestMeleeChancesRoll(t *testing.T) {
+	c := meleeChances{Miss: 5, Dodge: 5, Parry: 5, Block: 5, Crit: 10}
+
+	assert.Equal(t, meleeMiss, c.roll(0))
+	assert.Equal(t, meleeDodge, c.roll(5))
+	assert.Equal(t, meleeParry, c.roll(12))
+	assert.Equal(t, meleeBlock, c.roll(19.9))
+	assert.Equal(t, meleeCrit, c.roll(29))
+	assert.Equal(t, meleeHit, c.roll(30))
+
+	c = meleeChances{Miss: 100, Crit: 100}
+	assert.Equal(t, meleeMiss, c.roll(99), "misses push crits off of the table")
+}
+
+func TestInArc(t *testing.T) {
+	from := realmd.Vector4{}
+
+	assert.True(t, inArc(from, realmd.Vector4{X: 5}, meleeArc))
+	assert.False(t, inArc(from, realmd.Vector4{X: -5}, meleeArc))
+	assert.False(t, inArc(from, realmd.Vector4{Y: 5}, meleeArc))
+
+	from.Rotation = math.Pi / 2
+	assert.True(t, inArc(from, realmd.Vector4{Y: 5}, meleeArc))
+	assert.True(t, inArc(from, realmd.Vector4{X: 1, Y: 5}, meleeArc))
+}
+
+func TestMeleeChances(t *testing.T) {
+	p, _ := newTestPlayer(1, 0, 0)
+	victim, _ := newTestPlayer(2, 2, 0)
+
+	// The victim is facing away
+	c := p.meleeChances(victim, attackMainHand)
+	assert.Equal(t, float32(player.BaseMissChance), c.Miss)
+	assert.Zero(t, c.Dodge)
+	assert.Zero(t, c.Parry)
+
+	victim.Movement.Position.Rotation = math.Pi
+	c = p.meleeChances(victim, attackMainHand)
+	assert.Equal(t, victim.Values.DodgePercentage(), c.Dodge)
+	assert.Zero(t, c.Parry, "mages can't parry")
+	assert.Zero(t, c.Block, "no shield")
+
+	victim.Values.SetStandState(uint8(player.StateSit))
+	c = p.meleeChances(victim, attackMainHand)
+	assert.Equal(t, float32(100), c.Crit)
+	assert.Zero(t, c.Dodge)
+}
+
+func TestUpdateCombatStats(t *testing.T) {
+	p, _ := newTestPlayer(1, 0, 0)
+	v := p.Values
+
+	min, _ := player.WeaponDamage(player.UnarmedMinDamage, player.UnarmedMaxDamage, player.UnarmedSpeed, v.AttackPower())
+	assert.Equal(t, uint32(player.UnarmedSpeed), v.BaseAttackTime())
+	assert.Equal(t, min, v.MinDamage())
+
+	sword := *testSword
+	sword.DamageMin, sword.DamageMax, sword.Delay = 2, 5, 1900
+	newTestItem(p, &sword, InventorySlotBag0, EquipmentSlotMainHand, 1)
+	min, max := player.WeaponDamage(2, 5, 1900, v.AttackPower())
+	assert.Equal(t, uint32(1900), v.BaseAttackTime())
+	assert.Equal(t, min, v.MinDamage())
+	assert.Equal(t, max, v.MaxDamage())
+	assert.Zero(t, v.BlockPercentage())
+
+	newTestItem(p, testShield, InventorySlotBag0, EquipmentSlotOffHand, 1)
+	assert.NotZero(t, v.BlockPercentage())
+	assert.Equal(t, player.BlockValue(v.Strength()), v.ShieldBlock())
+	assert.Zero(t, v.OffhandAttackTime(), "shields aren't weapons")
+}
+
+func TestStartAttack(t *testing.T) {
+	w := New(nil)
+	p, conn := newTestPlayer(1, 0, 0)
+	friend, _ := newTestPlayer(2, 2, 0)
+	enemy, enemyConn := newTestPlayer(3, 2, 0)
+	enemy.Horde = true
+
+	assert.NoError(t, w.AddPlayer(p))
+	assert.NoError(t, w.AddPlayer(friend))
+	assert.NoError(t, w.AddPlayer(enemy))
+
+	w.StartAttack(p, friend.GUID())
+	assert.Equal(t, realmd.OpServerAttackSwingCantAttack, lastOpcode(conn))
+	assert.Zero(t, p.AttackTarget())
+
+	w.StartAttack(p, enemy.GUID())
+	assert.Equal(t, enemy.GUID(), p.AttackTarget())
+	assert.Equal(t, realmd.OpServerAttackStart, lastOpcode(enemyConn))
+
+	w.StopAttack(p)
+	assert.Zero(t, p.AttackTarget())
+	assert.Equal(t, realmd.OpServerAttackStop, lastOpcode(enemyConn))
+}
+
+func TestAutoAttack(t *testing.T) {
+	w := New(nil)
+	p, conn := newTestPlayer(1, 0, 0)
+	enemy, _ := newTestPlayer(2, 2, 0)
+	enemy.Horde = true
+
+	assert.NoError(t, w.AddPlayer(p))
+	assert.NoError(t, w.AddPlayer(enemy))
+
+	t.Run("bad facing", func(t *testing.T) {
+		p.Movement.Position.Rotation = math.Pi
+		w.StartAttack(p, enemy.GUID())
+		w.updateCombat(p, time.Millisecond)
+		assert.Equal(t, realmd.OpServerAttackSwingBadFacing, lastOpcode(conn))
+		assert.False(t, p.Values.InCombat())
+	})
+
+	t.Run("not in range", func(t *testing.T) {
+		p.Movement.Position.Rotation = 0
+		enemy.Movement.Position.X = 20
+		w.updateCombat(p, time.Millisecond)
+		assert.Equal(t, realmd.OpServerAttackSwingNotInRange, lastOpcode(conn))
+	})
+
+	t.Run("swing", func(t *testing.T) {
+		enemy.Movement.Position.X = 2
+		w.updateCombat(p, time.Millisecond)
+		assert.Equal(t, realmd.OpServerAttackerStateUpdate, lastOpcode(conn))
+		assert.True(t, p.Values.InCombat())
+		assert.True(t, enemy.Values.InCombat())
+		assert.Equal(t, time.Duration(p.Values.BaseAttackTime())*time.Millisecond, p.attackTimers[attackMainHand])
+	})
+
+	t.Run("leave combat", func(t *testing.T) {
+		w.StopAttack(p)
+		w.updateCombat(p, combatDuration)
+		assert.False(t, p.Values.InCombat())
+	})
+}
+
+func TestDeath(t *testing.T) {
+	w := New(nil)
+	p, _ := newTestPlayer(1, 0, 0)
+	enemy, _ := newTestPlayer(2, 2, 0)
+	enemy.Horde = true
+
+	assert.NoError(t, w.AddPlayer(p))
+	assert.NoError(t, w.AddPlayer(enemy))
+
+	w.StartAttack(enemy, p.GUID())
+	enemy.enterCombat(p.GUID())
+	p.enterCombat(enemy.GUID())
+	w.damage(p, p.Values.Health()+10)
+
+	assert.False(t, p.Alive())
+	assert.Zero(t, enemy.AttackTarget(), "the attacker stops once the victim is dead")
+	assert.False(t, p.Values.InCombat())
+	assert.False(t, enemy.Values.InCombat(), "the attacker isn't fighting anyone else")
+	assert.Equal(t, uint32(10), w.heal(p, 10), "the dead can't be healed")
+
+	graveyard := &dbc.WorldSafeLoc{ID: 4, X: 100, Y: 0, Z: 0}
+	assert.NoError(t, w.ReleaseSpirit(p, graveyard, nil))
+	assert.True(t, p.Values.Ghost())
+	assert.False(t, p.Alive())
+	assert.Equal(t, float32(100), p.Position().X)
+
+	corpse := p.Corpse()
+	if assert.NotNil(t, corpse) {
+		assert.Equal(t, float32(0), corpse.Position().X)
+		assert.Equal(t, p.GUID(), corpse.Values.Owner())
+		assert.True(t, enemy.CanSee(corpse))
+	}
+
+	t.Run("reclaim corpse", func(t *testing.T) {
+		assert.False(t, w.ReclaimCorpse(p), "too far")
+
+		p.Movement.Position.X = 5
+		assert.False(t, w.ReclaimCorpse(p), "too soon")
+
+		corpse.CreatedAt = time.Now().Add(-corpseReclaimDelay)
+		assert.True(t, w.ReclaimCorpse(p))
+		assert.True(t, p.Alive())
+		assert.Equal(t, p.Values.MaxHealth()/2, p.Values.Health())
+		assert.Nil(t, p.Corpse())
+		assert.False(t, enemy.CanSee(corpse))
+	})
+}
+
+func TestSpiritHealerResurrect(t *testing.T) {
+	w := New(nil)
+	p, _ := newTestPlayer(1, 0, 0)
+	assert.NoError(t, w.AddPlayer(p))
+
+	p.Values.SetLevel(20)
+	sickness := &dbc.Spell{ID: SpellResurrectionSickness}
+
+	w.damage(p, p.Values.Health())
+	assert.NoError(t, w.ReleaseSpirit(p, nil, nil))
+	w.SpiritHealerResurrect(p, sickness, time.Minute)
+
+	assert.True(t, p.Alive())
+	assert.Nil(t, p.Corpse())
+	if auras := p.Auras(); assert.Len(t, auras, 1) {
+		assert.Equal(t, uint32(SpellResurrectionSickness), auras[0].Spell.ID)
+		assert.False(t, auras[0].Positive)
+	}
+}
+
+func TestResurrect(t *testing.T) {
+	w := New(nil)
+	p, _ := newTestPlayer(1, 0, 0)
+	assert.NoError(t, w.AddPlayer(p))
+
+	p.Values.SetMana(0)
+	w.Resurrect(p)
+	assert.Equal(t, p.Values.MaxMana(), p.Values.Mana())
+
+	w.damage(p, p.Values.Health())
+	w.Resurrect(p)
+	assert.True(t, p.Alive())
+	assert.Equal(t, p.Values.MaxHealth(), p.Values.Health())
+
+	w.damage(p, p.Values.Health())
+	assert.NoError(t, w.ReleaseSpirit(p, nil, nil))
+	w.Resurrect(p)
+	assert.True(t, p.Alive())
+	assert.Equal(t, p.Values.MaxHealth(), p.Values.Health())
+	assert.Nil(t, p.Corpse())
+}
+
+func TestDeathSaved(t *testing.T) {
+	w := New(nil)
+	p, _ := newTestPlayer(1, 0, 0)
+	assert.NoError(t, w.AddPlayer(p))
+
+	w.damage(p, p.Values.Health())
+	p.SyncCharacter()
+	assert.True(t, p.Character.Dead)
+	assert.False(t, p.Character.Ghost)
+
+	dead, err := NewPlayer(nil, p.Character, testData)
+	if assert.NoError(t, err) {
+		assert.False(t, dead.Alive())
+		assert.False(t, dead.Values.Ghost())
+	}
+
+	graveyard := &dbc.WorldSafeLoc{ID: 4, X: 100, Y: 0, Z: 0}
+	assert.NoError(t, w.ReleaseSpirit(p, graveyard, nil))
+	p.Movement.Position.X = 50
+	p.SyncCharacter()
+	assert.True(t, p.Character.Ghost)
+	w.RemovePlayer(p)
+
+	// The player logs back in
+	other, _ := newTestPlayer(1, 0, 0)
+	ghost, err := NewPlayer(other.Client, p.Character, testData)
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.True(t, ghost.Values.Ghost())
+	assert.Equal(t, float32(50), ghost.Position().X)
+
+	assert.NoError(t, w.AddPlayer(ghost))
+	if corpse := ghost.Corpse(); assert.NotNil(t, corpse) {
+		assert.Equal(t, float32(0), corpse.Position().X)
+		assert.True(t, ghost.CanSee(corpse))
+	}
+}
@@ -0,0 +1,85 @@
+package world
+
+import (
+	"time"
+
+	"github.com/kangaroux/gomaggus/realmd"
+	"github.com/kangaroux/gomaggus/realmd/values"
+)
+
+// Corpse is the body a player leaves behind when they release their spirit. It looks like the player did
+// when they died, and stays until they come back to life.
+type Corpse struct {
+	ID        uint32
+	Owner     realmd.Guid
+	MapID     uint32
+	Values    *values.Corpse
+	CreatedAt time.Time
+
+	pos realmd.Vector4
+}
+
+var _ Object = (*Corpse)(nil)
+
+// NewCorpse creates a corpse for the player where they're standing.
+func NewCorpse(id uint32, p *Player) *Corpse {
+	c := &Corpse{
+		ID:        id,
+		Owner:     p.GUID(),
+		MapID:     p.MapID,
+		Values:    values.NewCorpse(),
+		CreatedAt: time.Now(),
+		pos:       p.Position(),
+	}
+
+	pv := p.Values
+	v := c.Values
+	v.SetGUID(c.GUID())
+	v.SetType(values.ObjectTypeObject, values.ObjectTypeCorpse)
+	v.SetScaleX(1)
+	v.SetOwner(p.GUID())
+	v.SetDisplayID(pv.NativeDisplayID())
+	v.SetRace(pv.Race())
+	v.SetGender(pv.Gender())
+	v.SetSkin(pv.Skin())
+	v.SetFace(pv.Face())
+	v.SetHairStyle(pv.HairStyle())
+	v.SetHairColor(pv.HairColor())
+	v.SetFacialHair(pv.ExtraCosmetic())
+	v.SetGuild(pv.GuildID())
+
+	// The corpse is wearing the player's equipment
+	var items [EquipmentSlotEnd]uint32
+	for slot := range items {
+		if item := p.items[slot]; item != nil {
+			items[slot] = item.Template.DisplayId | uint32(item.Template.InventoryType)<<24
+		}
+	}
+	v.SetItems(items)
+
+	return c
+}
+
+func (c *Corpse) GUID() realmd.Guid {
+	return realmd.NewGuid(realmd.HighGuidCorpse, c.ID)
+}
+
+func (c *Corpse) Position() realmd.Vector4 {
+	return c.pos
+}
+
+func (c *Corpse) WriteCreate(u *values.UpdateBuilder, viewer *Player) {
+	movement := values.MovementValues{}
+	movement.HasPosition(&values.StationaryPositionData{PositionRotation: c.pos})
+
+	u.CreateObject(c.GUID(), values.ObjectTypeCorpse, &movement, c.Values.Marshal(false))
+}
+
+func (c *Corpse) WriteValues(u *values.UpdateBuilder) bool {
+	if !c.Values.Dirty() {
+		return false
+	}
+
+	u.Values(c.GUID(), c.Values.Marshal(true))
+	return true
+}
@@ -0,0 +1,197 @@
+package world
+
+import (
+	"encoding/binary"
+
+	"github.com/kangaroux/gomaggus/realmd"
+	"github.com/kangaroux/gomaggus/realmd/dbc"
+	"github.com/kangaroux/gomaggus/realmd/player"
+)
+
+// https://gtker.com/wow_messages/docs/experienceawardtype.html
+type experienceAwardType uint8
+
+const (
+	experienceAwardKill    experienceAwardType = 0
+	experienceAwardNonKill experienceAwardType = 1
+)
+
+// CanGainExperience reports whether the player can get experience. Players at the max level, or that turned
+// off experience gain, can't.
+func (p *Player) CanGainExperience() bool {
+	return p.Values.Level() < player.MaxLevel && !p.Values.NoExperienceGain()
+}
+
+// GiveExperience gives the player experience, and levels them up each time they have enough for the next
+// level. victim is what they killed to get it, or 0 if it wasn't from a kill.
+func (w *World) GiveExperience(p *Player, amount uint32, victim realmd.Guid) error {
+	return w.giveExperience(p, amount, 0, victim)
+}
+
+// giveExperience gives the player experience, plus a bonus from their rested experience.
+func (w *World) giveExperience(p *Player, amount, rested uint32, victim realmd.Guid) error {
+	if amount == 0 || !p.CanGainExperience() {
+		return nil
+	}
+
+	p.sendLogXPGain(amount, rested, victim)
+
+	v := p.Values
+	xp := v.Experience() + amount + rested
+	for v.Level() < player.MaxLevel && xp >= v.NextLevelExperience() {
+		xp -= v.NextLevelExperience()
+		if err := w.GiveLevel(p, v.Level()+1); err != nil {
+			return err
+		}
+	}
+
+	// Experience past the max level is lost
+	if v.Level() >= player.MaxLevel {
+		xp = 0
+	}
+	v.SetExperience(xp)
+
+	return nil
+}
+
+// RewardKill gives the player experience for killing victim at victimLevel. Their rested experience gives
+// them up to twice as much.
+func (w *World) RewardKill(p *Player, victim realmd.Guid, victimLevel uint32) error {
+	xp := player.KillXP(p.Values.Level(), victimLevel)
+	if xp == 0 || !p.CanGainExperience() {
+		return nil
+	}
+
+	return w.giveExperience(p, xp, p.takeRestBonus(xp), victim)
+}
+
+// RewardQuestExperience gives the player experience for completing a quest at questLevel that rewards xp.
+func (w *World) RewardQuestExperience(p *Player, questLevel, xp uint32) error {
+	return w.GiveExperience(p, player.QuestXP(p.Values.Level(), questLevel, xp), 0)
+}
+
+// ExploreArea marks the area as explored by the player. The first time they explore an area with an
+// exploration level, they get experience for it.
+func (w *World) ExploreArea(p *Player, area *dbc.Area) error {
+	if area == nil || area.AreaBit < 0 || int(area.AreaBit/32) >= len(p.Values.ExploredZones()) {
+		return nil
+	}
+
+	zones := p.Values.ExploredZones()
+	i, bit := area.AreaBit/32, uint32(1)<<(area.AreaBit%32)
+	if zones[i]&bit != 0 {
+		return nil
+	}
+
+	zones[i] |= bit
+	p.Values.SetExploredZones(zones)
+
+	if area.ExplorationLevel <= 0 {
+		return nil
+	}
+
+	var xp uint32
+	if p.CanGainExperience() {
+		xp = player.ExplorationXP(p.Values.Level(), uint32(area.ExplorationLevel))
+		if err := w.GiveExperience(p, xp, 0); err != nil {
+			return err
+		}
+	}
+
+	p.sendExplorationExperience(area.ID, xp)
+	return nil
+}
+
+// GiveLevel changes the player's level, tells them how much their health, power and stats changed, and
+// restores their health and power. Their talent points are updated for the new level.
+func (w *World) GiveLevel(p *Player, level uint32) error {
+	v := p.Values
+	oldHealth, oldMana, oldStats := v.BaseHealth(), v.BaseMana(), p.baseStats
+
+	if err := p.SetLevel(level); err != nil {
+		return err
+	}
+
+	stats := p.baseStats
+	resp := levelUpInfo{
+		Level:  level,
+		Health: int32(v.BaseHealth()) - int32(oldHealth),
+		Stats: [5]int32{
+			int32(stats.Strength) - int32(oldStats.Strength),
+			int32(stats.Agility) - int32(oldStats.Agility),
+			int32(stats.Stamina) - int32(oldStats.Stamina),
+			int32(stats.Intellect) - int32(oldStats.Intellect),
+			int32(stats.Spirit) - int32(oldStats.Spirit),
+		},
+	}
+	resp.Power[realmd.PowerTypeMana] = int32(v.BaseMana()) - int32(oldMana)
+
+	if err := p.Client.SendPacket(realmd.OpServerLevelUpInfo, &resp); err != nil {
+		p.Client.Log.Error().Err(err).Msg("error sending level up info")
+	}
+
+	p.Revive()
+	p.SendTalents()
+
+	return nil
+}
+
+// exploredZonesBytes returns the player's explored zones so they can be saved.
+func (p *Player) exploredZonesBytes() []byte {
+	zones := p.Values.ExploredZones()
+	buf := make([]byte, 0, len(zones)*4)
+	for _, z := range zones {
+		buf = binary.LittleEndian.AppendUint32(buf, z)
+	}
+	return buf
+}
+
+// setExploredZonesBytes restores the player's explored zones from what was saved.
+func (p *Player) setExploredZonesBytes(buf []byte) {
+	var zones [128]uint32
+	for i := range zones {
+		if len(buf) < (i+1)*4 {
+			break
+		}
+		zones[i] = binary.LittleEndian.Uint32(buf[i*4:])
+	}
+	p.Values.SetExploredZones(zones)
+}
+
+// https://gtker.com/wow_messages/docs/smsg_log_xpgain.html#client-version-335
+func (p *Player) sendLogXPGain(amount, rested uint32, victim realmd.Guid) {
+	awardType := experienceAwardKill
+	if victim == 0 {
+		awardType = experienceAwardNonKill
+	}
+
+	buf := make([]byte, 0, 22)
+	buf = binary.LittleEndian.AppendUint64(buf, uint64(victim))
+	buf = binary.LittleEndian.AppendUint32(buf, amount+rested)
+	buf = append(buf, byte(awardType))
+	if awardType == experienceAwardKill {
+		// Experience without the rested bonus, and the group bonus
+		buf = binary.LittleEndian.AppendUint32(buf, amount)
+		buf = binary.LittleEndian.AppendUint32(buf, 0)
+	}
+	// Refer-a-friend bonus
+	buf = append(buf, 0)
+
+	p.sendPacket(realmd.OpServerLogXPGain, buf)
+}
+
+// https://gtker.com/wow_messages/docs/smsg_levelup_info.html
+type levelUpInfo struct {
+	Level  uint32
+	Health int32
+	Power  [7]int32
+	Stats  [5]int32
+}
+
+// https://gtker.com/wow_messages/docs/smsg_explorationexperience.html
+func (p *Player) sendExplorationExperience(area, xp uint32) {
+	buf := make([]byte, 8)
+	binary.LittleEndian.PutUint32(buf, area)
+	binary.LittleEndian.PutUint32(buf[4:], xp)
+	p.sendPacket(realmd.OpServerExplorationExperience, buf)
+}
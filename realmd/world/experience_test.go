@@ -0,0 +1,90 @@
+package world
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+
+	"github.com/kangaroux/gomaggus/realmd/dbc"
+	"github.com/kangaroux/gomaggus/realmd/player"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGiveExperience(t *testing.T) {
+	w := New(nil)
+	p, _ := newTestPlayer(1, 0, 0)
+	assert.NoError(t, w.AddPlayer(p))
+	v := p.Values
+
+	assert.Equal(t, uint32(400), v.NextLevelExperience())
+
+	assert.NoError(t, w.GiveExperience(p, 300, 0))
+	assert.Equal(t, uint32(1), v.Level())
+	assert.Equal(t, uint32(300), v.Experience())
+
+	assert.NoError(t, w.GiveExperience(p, 1100, 0))
+	assert.Equal(t, uint32(3), v.Level(), "can level up more than once")
+	assert.Equal(t, uint32(100), v.Experience())
+	assert.Equal(t, uint32(1400), v.NextLevelExperience())
+	assert.Equal(t, v.MaxHealth(), v.Health())
+
+	v.SetNoExperienceGain(true)
+	assert.NoError(t, w.GiveExperience(p, 1000, 0))
+	assert.Equal(t, uint32(100), v.Experience(), "turned off experience gain")
+	v.SetNoExperienceGain(false)
+
+	assert.NoError(t, w.GiveLevel(p, player.MaxLevel-1))
+	assert.NoError(t, w.GiveExperience(p, player.XPForLevel(player.MaxLevel-1)*2, 0))
+	assert.Equal(t, uint32(player.MaxLevel), v.Level())
+	assert.Equal(t, uint32(0), v.Experience())
+	assert.False(t, p.CanGainExperience())
+}
+
+func TestGiveLevelInfo(t *testing.T) {
+	w := New(nil)
+	p, conn := newTestPlayer(1, 0, 0)
+	assert.NoError(t, w.AddPlayer(p))
+
+	for _, level := range []uint32{2, 60, 80} {
+		oldHealth := p.Values.BaseHealth()
+		conn.sent = nil
+		assert.NoError(t, w.GiveLevel(p, level))
+		assert.Greater(t, p.Values.BaseHealth(), oldHealth)
+
+		// The level up info is followed by the talents
+		info := levelUpInfo{}
+		assert.NoError(t, binary.Read(bytes.NewReader(conn.sent[0][4:]), binary.LittleEndian, &info))
+		assert.Equal(t, level, info.Level)
+		assert.Equal(t, int32(p.Values.BaseHealth()-oldHealth), info.Health)
+		assert.Positive(t, info.Power[0], "mages gain mana")
+		assert.Positive(t, info.Stats[4], "mages gain intellect")
+	}
+}
+
+func TestRewardKill(t *testing.T) {
+	w := New(nil)
+	p, _ := newTestPlayer(1, 0, 0)
+	assert.NoError(t, w.AddPlayer(p))
+
+	assert.NoError(t, w.RewardKill(p, 99, 1))
+	assert.Equal(t, player.KillXP(1, 1), p.Values.Experience())
+}
+
+func TestExploreArea(t *testing.T) {
+	w := New(nil)
+	p, _ := newTestPlayer(1, 0, 0)
+	assert.NoError(t, w.AddPlayer(p))
+
+	area := &dbc.Area{ID: 12, AreaBit: 33, ExplorationLevel: 1}
+	assert.NoError(t, w.ExploreArea(p, area))
+	assert.Equal(t, uint32(1<<1), p.Values.ExploredZones()[1])
+	assert.Equal(t, player.ExplorationXP(1, 1), p.Values.Experience())
+
+	assert.NoError(t, w.ExploreArea(p, area))
+	assert.Equal(t, player.ExplorationXP(1, 1), p.Values.Experience(), "only the first time")
+
+	p.SyncCharacter()
+	other, _ := newTestPlayer(2, 0, 0)
+	other.setExploredZonesBytes(p.Character.ExploredZones)
+	assert.Equal(t, p.Values.ExploredZones(), other.Values.ExploredZones())
+}
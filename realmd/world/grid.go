@@ -0,0 +1,61 @@
+package world
+
+import (
+	"math"
+
+	"github.com/kangaroux/gomaggus/realmd"
+)
+
+const (
+	// Maps are split into 64x64 grids, and each grid is split into 8x8 cells.
+	gridSize = 533.33333
+	cellSize = gridSize / 8
+
+	// visibilityDistance is how far away (in yards) a player can see other objects.
+	visibilityDistance = 90
+)
+
+// cellCoord identifies a cell within a map.
+type cellCoord struct {
+	X int32
+	Y int32
+}
+
+func cellAt(x, y float32) cellCoord {
+	return cellCoord{
+		X: int32(math.Floor(float64(x) / cellSize)),
+		Y: int32(math.Floor(float64(y) / cellSize)),
+	}
+}
+
+// cellsInRange returns all the cells which are at least partially within dist of pos.
+func cellsInRange(pos realmd.Vector4, dist float32) []cellCoord {
+	min := cellAt(pos.X-dist, pos.Y-dist)
+	max := cellAt(pos.X+dist, pos.Y+dist)
+	cells := make([]cellCoord, 0, (max.X-min.X+1)*(max.Y-min.Y+1))
+
+	for x := min.X; x <= max.X; x++ {
+		for y := min.Y; y <= max.Y; y++ {
+			cells = append(cells, cellCoord{x, y})
+		}
+	}
+
+	return cells
+}
+
+// distance returns the 3D distance between a and b.
+func distance(a, b realmd.Vector4) float32 {
+	dx := float64(a.X - b.X)
+	dy := float64(a.Y - b.Y)
+	dz := float64(a.Z - b.Z)
+	return float32(math.Sqrt(dx*dx + dy*dy + dz*dz))
+}
+
+// cell contains the objects whose position is within the cell's bounds.
+type cell struct {
+	objects map[realmd.Guid]Object
+}
+
+func newCell() *cell {
+	return &cell{objects: make(map[realmd.Guid]Object)}
+}
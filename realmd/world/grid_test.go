@@ -0,0 +1,23 @@
+package world
+
+import (
+	"testing"
+
+	"github.com/kangaroux/gomaggus/realmd"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCellAt(t *testing.T) {
+	assert.Equal(t, cellCoord{0, 0}, cellAt(0, 0))
+	assert.Equal(t, cellCoord{0, 0}, cellAt(66, 66))
+	assert.Equal(t, cellCoord{1, -1}, cellAt(67, -1))
+	assert.Equal(t, cellCoord{-135, -2}, cellAt(-8949.95, -132.493))
+}
+
+func TestCellsInRange(t *testing.T) {
+	cells := cellsInRange(realmd.Vector4{X: 33, Y: 33}, 10)
+	assert.Equal(t, []cellCoord{{0, 0}}, cells)
+
+	cells = cellsInRange(realmd.Vector4{X: 0, Y: 0}, 10)
+	assert.ElementsMatch(t, []cellCoord{{-1, -1}, {-1, 0}, {0, -1}, {0, 0}}, cells)
+}
@@ -0,0 +1,639 @@
+package world
+
+import (
+	"bytes"
+	"encoding/binary"
+	"strings"
+
+	"github.com/kangaroux/gomaggus/realmd"
+)
+
+const (
+	MaxPartyMembers = 5
+	MaxRaidMembers  = 40
+
+	// groupGuidHigh is the high part of a group's guid.
+	groupGuidHigh = 0x1F50
+)
+
+// GroupType is the kind of group.
+type GroupType uint8
+
+const (
+	GroupTypeNormal GroupType = 0x00
+	GroupTypeRaid   GroupType = 0x02
+
+	// groupTypeNone is sent in an empty group list when the player is no longer in a group.
+	groupTypeNone GroupType = 0x10
+)
+
+type GroupMemberFlags uint8
+
+const (
+	GroupMemberFlagAssistant  GroupMemberFlags = 0x01
+	GroupMemberFlagMainTank   GroupMemberFlags = 0x02
+	GroupMemberFlagMainAssist GroupMemberFlags = 0x04
+)
+
+// LootMethod decides who can loot a creature that the group killed.
+type LootMethod uint8
+
+const (
+	LootMethodFreeForAll      LootMethod = 0
+	LootMethodRoundRobin      LootMethod = 1
+	LootMethodMasterLoot      LootMethod = 2
+	LootMethodGroupLoot       LootMethod = 3
+	LootMethodNeedBeforeGreed LootMethod = 4
+)
+
+const (
+	// The loot threshold is an item quality, and can be set from uncommon (green) to artifact.
+	minLootThreshold     = 2
+	maxLootThreshold     = 6
+	defaultLootThreshold = minLootThreshold
+)
+
+// PartyOperation is the group action that a party result is for.
+type PartyOperation uint32
+
+const (
+	PartyOperationInvite   PartyOperation = 0
+	PartyOperationUninvite PartyOperation = 1
+	PartyOperationLeave    PartyOperation = 2
+	PartyOperationSwap     PartyOperation = 4
+)
+
+// PartyResult is the result of a group action.
+// https://gtker.com/wow_messages/docs/partyresult.html#client-version-335
+type PartyResult uint32
+
+const (
+	PartyResultOK                  PartyResult = 0
+	PartyResultBadPlayerName       PartyResult = 1
+	PartyResultTargetNotInGroup    PartyResult = 2
+	PartyResultTargetNotInInstance PartyResult = 3
+	PartyResultGroupFull           PartyResult = 4
+	PartyResultAlreadyInGroup      PartyResult = 5
+	PartyResultNotInGroup          PartyResult = 6
+	PartyResultNotLeader           PartyResult = 7
+	PartyResultWrongFaction        PartyResult = 8
+	PartyResultIgnoringYou         PartyResult = 9
+)
+
+// GroupMember is a player in a group. Members stay in the group while they are offline.
+type GroupMember struct {
+	Guid     realmd.Guid
+	Name     string
+	Subgroup uint8
+	Flags    GroupMemberFlags
+
+	// player is nil while the member is offline.
+	player *Player
+}
+
+// Online reports whether the member is in the world.
+func (m *GroupMember) Online() bool {
+	return m.player != nil
+}
+
+// Group is a party or raid. Groups are kept in memory, so members can disconnect and reconnect without
+// leaving the group, but groups don't survive a server restart.
+type Group struct {
+	ID     uint32
+	Type   GroupType
+	Leader realmd.Guid
+
+	LootMethod    LootMethod
+	LootMaster    realmd.Guid
+	LootThreshold uint8
+
+	members []*GroupMember
+
+	// icons are the targets that are marked with each raid target icon.
+	icons [MaxRaidTargetIcons]realmd.Guid
+
+	// readyCheck is true while a ready check is in progress.
+	readyCheck bool
+
+	// counter is incremented every time the group list is sent.
+	counter uint32
+}
+
+func (g *Group) GUID() realmd.Guid {
+	return realmd.Guid(groupGuidHigh<<48 | uint64(g.ID))
+}
+
+// Raid reports whether the group is a raid.
+func (g *Group) Raid() bool {
+	return g.Type == GroupTypeRaid
+}
+
+// Members returns the group's members in the order they joined.
+func (g *Group) Members() []*GroupMember {
+	return g.members
+}
+
+// Member returns the member with guid, or nil if they aren't in the group.
+func (g *Group) Member(guid realmd.Guid) *GroupMember {
+	for _, m := range g.members {
+		if m.Guid == guid {
+			return m
+		}
+	}
+	return nil
+}
+
+// MemberByName returns the member with the given name, or nil if they aren't in the group. Names are
+// case-insensitive.
+func (g *Group) MemberByName(name string) *GroupMember {
+	for _, m := range g.members {
+		if strings.EqualFold(m.Name, name) {
+			return m
+		}
+	}
+	return nil
+}
+
+// maxMembers returns how many members the group can have.
+func (g *Group) maxMembers() int {
+	if g.Raid() {
+		return MaxRaidMembers
+	}
+	return MaxPartyMembers
+}
+
+func (g *Group) full() bool {
+	return len(g.members) >= g.maxMembers()
+}
+
+// canManage reports whether guid is allowed to invite, kick and move members. In a raid this includes
+// the leader's assistants.
+func (g *Group) canManage(guid realmd.Guid) bool {
+	if guid == g.Leader {
+		return true
+	}
+
+	m := g.Member(guid)
+	return g.Raid() && m != nil && m.Flags&GroupMemberFlagAssistant != 0
+}
+
+// online returns the members that are in the world.
+func (g *Group) online() []*Player {
+	var result []*Player
+	for _, m := range g.members {
+		if m.player != nil {
+			result = append(result, m.player)
+		}
+	}
+	return result
+}
+
+// broadcast sends a packet to every member that is online.
+func (g *Group) broadcast(opcode realmd.ServerOpcode, data []byte) {
+	for _, p := range g.online() {
+		p.sendPacket(opcode, data)
+	}
+}
+
+// listBytes returns the SMSG_GROUP_LIST payload for m. The list contains everyone in the group except m.
+// https://gtker.com/wow_messages/docs/smsg_group_list.html#client-version-335
+func (g *Group) listBytes(m *GroupMember) []byte {
+	buf := bytes.Buffer{}
+	buf.WriteByte(byte(g.Type))
+	buf.WriteByte(m.Subgroup)
+	buf.WriteByte(byte(m.Flags))
+	buf.WriteByte(0) // roles
+	binary.Write(&buf, binary.LittleEndian, g.GUID())
+	binary.Write(&buf, binary.LittleEndian, g.counter)
+	binary.Write(&buf, binary.LittleEndian, uint32(len(g.members)-1))
+
+	for _, other := range g.members {
+		if other == m {
+			continue
+		}
+
+		buf.WriteString(other.Name)
+		buf.WriteByte(0)
+		binary.Write(&buf, binary.LittleEndian, other.Guid)
+
+		status := MemberStatusOffline
+		if other.Online() {
+			status = MemberStatusOnline
+		}
+		buf.WriteByte(byte(status))
+		buf.WriteByte(other.Subgroup)
+		buf.WriteByte(byte(other.Flags))
+		buf.WriteByte(0) // roles
+	}
+
+	binary.Write(&buf, binary.LittleEndian, g.Leader)
+	buf.WriteByte(byte(g.LootMethod))
+	binary.Write(&buf, binary.LittleEndian, g.LootMaster)
+	buf.WriteByte(g.LootThreshold)
+	buf.WriteByte(0) // dungeon difficulty
+	buf.WriteByte(0) // raid difficulty
+	buf.WriteByte(0) // heroic raid
+
+	return buf.Bytes()
+}
+
+// emptyGroupList is the SMSG_GROUP_LIST payload that is sent to players who are no longer in a group.
+var emptyGroupList = func() []byte {
+	buf := bytes.Buffer{}
+	buf.WriteByte(byte(groupTypeNone))
+	buf.WriteByte(0)                                   // subgroup
+	buf.WriteByte(0)                                   // flags
+	buf.WriteByte(0)                                   // roles
+	binary.Write(&buf, binary.LittleEndian, uint64(0)) // group guid
+	binary.Write(&buf, binary.LittleEndian, uint32(0)) // counter
+	binary.Write(&buf, binary.LittleEndian, uint32(0)) // member count
+	binary.Write(&buf, binary.LittleEndian, uint64(0)) // leader
+	return buf.Bytes()
+}()
+
+// sendGroupList sends the group list to every member that is online.
+func (w *World) sendGroupList(g *Group) {
+	g.counter++
+
+	for _, m := range g.members {
+		if m.player == nil {
+			continue
+		}
+
+		m.player.sendPacket(realmd.OpServerGroupList, g.listBytes(m))
+	}
+}
+
+// sendPartyResult sends the result of a group action to the player. name is the player the action was for.
+// https://gtker.com/wow_messages/docs/smsg_party_command_result.html#client-version-335
+func (p *Player) sendPartyResult(op PartyOperation, name string, result PartyResult) {
+	buf := bytes.Buffer{}
+	binary.Write(&buf, binary.LittleEndian, op)
+	buf.WriteString(name)
+	buf.WriteByte(0)
+	binary.Write(&buf, binary.LittleEndian, result)
+	binary.Write(&buf, binary.LittleEndian, uint32(0)) // LFG cooldown
+
+	if err := p.Client.SendPacketBytes(realmd.OpServerPartyCommandResult, buf.Bytes()); err != nil {
+		p.Client.Log.Error().Err(err).Msg("error sending party result")
+	}
+}
+
+// sendPacket sends a packet to the player and logs any errors.
+func (p *Player) sendPacket(opcode realmd.ServerOpcode, data []byte) {
+	if err := p.Client.SendPacketBytes(opcode, data); err != nil {
+		p.Client.Log.Error().Err(err).Str("op", opcode.String()).Msg("error sending packet")
+	}
+}
+
+// Group returns the group p is in, or nil if they aren't in a group.
+func (w *World) Group(p *Player) *Group {
+	return w.groups[p.GUID()]
+}
+
+// InviteToGroup invites the player named target to join p's group. If p isn't in a group, one is created
+// once the target accepts.
+func (w *World) InviteToGroup(p *Player, target string) {
+	other := w.PlayerByName(target)
+	if other == nil || other == p {
+		p.sendPartyResult(PartyOperationInvite, target, PartyResultBadPlayerName)
+		return
+	}
+
+	if other.Horde != p.Horde {
+		p.sendPartyResult(PartyOperationInvite, target, PartyResultWrongFaction)
+		return
+	}
+
+	if other.Ignores(p) {
+		p.sendPartyResult(PartyOperationInvite, other.Character.Name, PartyResultIgnoringYou)
+		return
+	}
+
+	if g := w.groups[p.GUID()]; g != nil {
+		if !g.canManage(p.GUID()) {
+			p.sendPartyResult(PartyOperationInvite, "", PartyResultNotLeader)
+			return
+		}
+
+		if g.full() {
+			p.sendPartyResult(PartyOperationInvite, "", PartyResultGroupFull)
+			return
+		}
+	}
+
+	if w.groups[other.GUID()] != nil || other.groupInvite != 0 {
+		p.sendPartyResult(PartyOperationInvite, other.Character.Name, PartyResultAlreadyInGroup)
+		return
+	}
+
+	other.groupInvite = p.GUID()
+
+	// https://gtker.com/wow_messages/docs/smsg_group_invite.html#client-version-335
+	buf := bytes.Buffer{}
+	buf.WriteByte(1) // can accept
+	buf.WriteString(p.Character.Name)
+	buf.WriteByte(0)
+	binary.Write(&buf, binary.LittleEndian, uint32(0))
+	buf.WriteByte(0) // count
+	binary.Write(&buf, binary.LittleEndian, uint32(0))
+	other.sendPacket(realmd.OpServerGroupInvite, buf.Bytes())
+
+	p.sendPartyResult(PartyOperationInvite, other.Character.Name, PartyResultOK)
+}
+
+// AcceptGroupInvite adds p to the group of the player that invited them.
+func (w *World) AcceptGroupInvite(p *Player) {
+	inviter := p.groupInvite
+	p.groupInvite = 0
+
+	if inviter == 0 || w.groups[p.GUID()] != nil {
+		return
+	}
+
+	g := w.groups[inviter]
+	if g == nil {
+		leader := w.players[inviter]
+		if leader == nil {
+			p.sendPartyResult(PartyOperationInvite, "", PartyResultBadPlayerName)
+			return
+		}
+		g = w.newGroup(leader)
+	}
+
+	if g.full() {
+		p.sendPartyResult(PartyOperationInvite, "", PartyResultGroupFull)
+		return
+	}
+
+	w.addGroupMember(g, p)
+	w.sendGroupList(g)
+}
+
+// DeclineGroupInvite declines p's group invite. The player that invited them is told.
+func (w *World) DeclineGroupInvite(p *Player) {
+	inviter := w.players[p.groupInvite]
+	p.groupInvite = 0
+
+	if inviter == nil {
+		return
+	}
+
+	// https://gtker.com/wow_messages/docs/smsg_group_decline.html
+	inviter.sendPacket(realmd.OpServerGroupDecline, append([]byte(p.Character.Name), 0))
+}
+
+// LeaveGroup removes p from their group.
+func (w *World) LeaveGroup(p *Player) {
+	g := w.groups[p.GUID()]
+	if g == nil {
+		return
+	}
+
+	p.sendPartyResult(PartyOperationLeave, p.Character.Name, PartyResultOK)
+	w.removeGroupMember(g, p.GUID(), false)
+}
+
+// UninviteFromGroup kicks the member named target from p's group.
+func (w *World) UninviteFromGroup(p *Player, target string) {
+	g := w.groups[p.GUID()]
+	if g == nil {
+		p.sendPartyResult(PartyOperationUninvite, "", PartyResultNotInGroup)
+		return
+	}
+
+	m := g.MemberByName(target)
+	if m == nil {
+		p.sendPartyResult(PartyOperationUninvite, target, PartyResultTargetNotInGroup)
+		return
+	}
+
+	w.UninviteFromGroupGuid(p, m.Guid)
+}
+
+// UninviteFromGroupGuid kicks the member with guid from p's group. Assistants can kick members, but not
+// the leader or other assistants.
+func (w *World) UninviteFromGroupGuid(p *Player, guid realmd.Guid) {
+	g := w.groups[p.GUID()]
+	if g == nil {
+		p.sendPartyResult(PartyOperationUninvite, "", PartyResultNotInGroup)
+		return
+	}
+
+	if guid == p.GUID() {
+		w.LeaveGroup(p)
+		return
+	}
+
+	m := g.Member(guid)
+	if m == nil {
+		p.sendPartyResult(PartyOperationUninvite, "", PartyResultTargetNotInGroup)
+		return
+	}
+
+	if !g.canManage(p.GUID()) || (p.GUID() != g.Leader && g.canManage(guid)) {
+		p.sendPartyResult(PartyOperationUninvite, "", PartyResultNotLeader)
+		return
+	}
+
+	w.removeGroupMember(g, guid, true)
+}
+
+// SetGroupLeader makes the member with guid the leader of p's group. Only the leader can do this, and the
+// new leader must be online.
+func (w *World) SetGroupLeader(p *Player, guid realmd.Guid) {
+	g := w.groups[p.GUID()]
+	if g == nil || g.Leader != p.GUID() || guid == p.GUID() {
+		return
+	}
+
+	m := g.Member(guid)
+	if m == nil || !m.Online() {
+		return
+	}
+
+	w.setGroupLeader(g, m)
+	w.sendGroupList(g)
+}
+
+// SetLootMethod changes how p's group loots. Only the leader can do this. master is only used for
+// master loot, and must be in the group.
+func (w *World) SetLootMethod(p *Player, method LootMethod, master realmd.Guid, threshold uint8) {
+	g := w.groups[p.GUID()]
+	if g == nil || g.Leader != p.GUID() {
+		return
+	}
+
+	if method > LootMethodNeedBeforeGreed || threshold < minLootThreshold || threshold > maxLootThreshold {
+		return
+	}
+
+	if method == LootMethodMasterLoot && g.Member(master) == nil {
+		return
+	}
+
+	g.LootMethod = method
+	g.LootMaster = master
+	g.LootThreshold = threshold
+
+	w.sendGroupList(g)
+}
+
+// newGroup creates a party led by leader.
+func (w *World) newGroup(leader *Player) *Group {
+	w.nextGroupID++
+
+	g := &Group{
+		ID:            w.nextGroupID,
+		Type:          GroupTypeNormal,
+		Leader:        leader.GUID(),
+		LootMethod:    LootMethodGroupLoot,
+		LootThreshold: defaultLootThreshold,
+	}
+
+	w.addGroupMember(g, leader)
+	leader.Values.SetGroupLeader(true)
+
+	return g
+}
+
+// addGroupMember adds p to the group. In a raid, they are put in the first subgroup that has room.
+func (w *World) addGroupMember(g *Group, p *Player) {
+	m := &GroupMember{
+		Guid:   p.GUID(),
+		Name:   p.Character.Name,
+		player: p,
+	}
+
+	if g.Raid() {
+		m.Subgroup = g.openSubgroup()
+	}
+
+	g.members = append(g.members, m)
+	w.groups[p.GUID()] = g
+
+	// Send all of the player's stats to the group on the next update
+	p.partyStats = partyStats{}
+}
+
+// removeGroupMember removes the member with guid from the group. If kicked is true, the member is told
+// they were removed. The group is disbanded if there aren't enough members left.
+func (w *World) removeGroupMember(g *Group, guid realmd.Guid, kicked bool) {
+	for i, m := range g.members {
+		if m.Guid != guid {
+			continue
+		}
+
+		g.members = append(g.members[:i], g.members[i+1:]...)
+		delete(w.groups, guid)
+
+		if p := m.player; p != nil {
+			if kicked {
+				// https://gtker.com/wow_messages/docs/smsg_group_uninvite.html
+				p.sendPacket(realmd.OpServerGroupUninvite, nil)
+			}
+			p.sendPacket(realmd.OpServerGroupList, emptyGroupList)
+			p.Values.SetGroupLeader(false)
+		}
+		break
+	}
+
+	if len(g.members) < 2 {
+		w.disbandGroup(g)
+		return
+	}
+
+	if g.Leader == guid {
+		w.setGroupLeader(g, g.nextLeader())
+	}
+
+	w.sendGroupList(g)
+}
+
+// disbandGroup removes everyone from the group.
+func (w *World) disbandGroup(g *Group) {
+	for _, m := range g.members {
+		delete(w.groups, m.Guid)
+
+		if p := m.player; p != nil {
+			// https://gtker.com/wow_messages/docs/smsg_group_destroyed.html
+			p.sendPacket(realmd.OpServerGroupDestroyed, nil)
+			p.sendPacket(realmd.OpServerGroupList, emptyGroupList)
+			p.Values.SetGroupLeader(false)
+		}
+	}
+
+	g.members = nil
+}
+
+// nextLeader returns who should lead the group if the leader leaves, preferring members that are online.
+func (g *Group) nextLeader() *GroupMember {
+	for _, m := range g.members {
+		if m.Guid != g.Leader && m.Online() {
+			return m
+		}
+	}
+
+	for _, m := range g.members {
+		if m.Guid != g.Leader {
+			return m
+		}
+	}
+
+	return nil
+}
+
+// setGroupLeader makes m the leader of the group and tells the group.
+func (w *World) setGroupLeader(g *Group, m *GroupMember) {
+	if old := g.Member(g.Leader); old != nil && old.player != nil {
+		old.player.Values.SetGroupLeader(false)
+	}
+
+	g.Leader = m.Guid
+	if m.player != nil {
+		m.player.Values.SetGroupLeader(true)
+	}
+
+	// https://gtker.com/wow_messages/docs/smsg_group_set_leader.html
+	g.broadcast(realmd.OpServerGroupSetLeader, append([]byte(m.Name), 0))
+}
+
+// reconnectGroupMember puts p back in the group they were in before they went offline.
+func (w *World) reconnectGroupMember(p *Player) {
+	g := w.groups[p.GUID()]
+	if g == nil {
+		return
+	}
+
+	g.Member(p.GUID()).player = p
+	p.Values.SetGroupLeader(g.Leader == p.GUID())
+	p.partyStats = partyStats{}
+
+	w.sendGroupList(g)
+}
+
+// disconnectGroupMember marks p as offline in their group. If p was the leader, the group is handed to
+// another member that is online. The group is disbanded once all of its members are offline.
+func (w *World) disconnectGroupMember(p *Player) {
+	p.groupInvite = 0
+
+	g := w.groups[p.GUID()]
+	if g == nil {
+		return
+	}
+
+	g.Member(p.GUID()).player = nil
+
+	if len(g.online()) == 0 {
+		w.disbandGroup(g)
+		return
+	}
+
+	g.broadcast(realmd.OpServerPartyMemberStats, offlineStatsBytes(p.GUID()))
+
+	if g.Leader == p.GUID() {
+		w.setGroupLeader(g, g.nextLeader())
+	}
+
+	w.sendGroupList(g)
+}
@@ -0,0 +1,218 @@
+package world
+
+import (
+	"testing"
+
+	"github.com/kangaroux/gomaggus/model"
+	"github.com/kangaroux/gomaggus/realmd"
+	"github.com/stretchr/testify/assert"
+)
+
+// newTestGroup adds the players to the world and puts them in a group led by the first player.
+func newTestGroup(w *World, players ...*Player) *Group {
+	for _, p := range players {
+		if err := w.AddPlayer(p); err != nil {
+			panic(err)
+		}
+	}
+
+	leader := players[0]
+	for _, p := range players[1:] {
+		w.InviteToGroup(leader, p.Character.Name)
+		w.AcceptGroupInvite(p)
+	}
+
+	return w.Group(leader)
+}
+
+func TestGroupInvite(t *testing.T) {
+	w := New(nil)
+	leader, leaderConn := newTestPlayer(1, 0, 0)
+	other, _ := newTestPlayer(2, 0, 0)
+	leader.Character.Name = "Alice"
+	other.Character.Name = "Bob"
+	assert.NoError(t, w.AddPlayer(leader))
+	assert.NoError(t, w.AddPlayer(other))
+
+	result := func() PartyResult {
+		// operation (4) + name + result
+		data := leaderConn.last[4:]
+		for i := 4; i < len(data); i++ {
+			if data[i] == 0 {
+				return PartyResult(data[i+1])
+			}
+		}
+		return 0xFF
+	}
+
+	t.Run("not found", func(t *testing.T) {
+		w.InviteToGroup(leader, "Nobody")
+		assert.Equal(t, PartyResultBadPlayerName, result())
+	})
+
+	t.Run("ignored", func(t *testing.T) {
+		other.SetContacts([]*model.Contact{{ContactId: 1, Flags: model.ContactFlagIgnored}})
+		w.InviteToGroup(leader, "bob")
+		assert.Equal(t, PartyResultIgnoringYou, result())
+		other.SetContacts(nil)
+	})
+
+	t.Run("decline", func(t *testing.T) {
+		w.InviteToGroup(leader, "bob")
+		assert.Equal(t, PartyResultOK, result())
+
+		w.InviteToGroup(leader, "bob")
+		assert.Equal(t, PartyResultAlreadyInGroup, result(), "invite is pending")
+
+		w.DeclineGroupInvite(other)
+		assert.Equal(t, realmd.OpServerGroupDecline, realmd.ServerOpcode(leaderConn.last[2]))
+		assert.Nil(t, w.Group(leader))
+	})
+
+	t.Run("accept", func(t *testing.T) {
+		w.InviteToGroup(leader, "bob")
+		w.AcceptGroupInvite(other)
+
+		g := w.Group(leader)
+		if !assert.NotNil(t, g) {
+			return
+		}
+		assert.Same(t, g, w.Group(other))
+		assert.Equal(t, leader.GUID(), g.Leader)
+		assert.True(t, leader.Values.GroupLeader())
+		assert.False(t, other.Values.GroupLeader())
+	})
+}
+
+func TestGroupLeave(t *testing.T) {
+	w := New(nil)
+	a, _ := newTestPlayer(1, 0, 0)
+	b, _ := newTestPlayer(2, 0, 0)
+	c, _ := newTestPlayer(3, 0, 0)
+	a.Character.Name = "Alice"
+	b.Character.Name = "Bob"
+	c.Character.Name = "Carol"
+
+	g := newTestGroup(w, a, b, c)
+	assert.Len(t, g.Members(), 3)
+
+	t.Run("leader leaves", func(t *testing.T) {
+		w.LeaveGroup(a)
+		assert.Nil(t, w.Group(a))
+		assert.False(t, a.Values.GroupLeader())
+		assert.Equal(t, b.GUID(), g.Leader)
+		assert.True(t, b.Values.GroupLeader())
+	})
+
+	t.Run("disbanded when one member is left", func(t *testing.T) {
+		w.UninviteFromGroup(b, "carol")
+		assert.Nil(t, w.Group(b))
+		assert.Nil(t, w.Group(c))
+		assert.False(t, b.Values.GroupLeader())
+	})
+}
+
+func TestGroupReconnect(t *testing.T) {
+	w := New(nil)
+	a, _ := newTestPlayer(1, 0, 0)
+	b, _ := newTestPlayer(2, 0, 0)
+	c, _ := newTestPlayer(3, 0, 0)
+	a.Character.Name = "Alice"
+	b.Character.Name = "Bob"
+	c.Character.Name = "Carol"
+
+	g := newTestGroup(w, a, b, c)
+
+	w.RemovePlayer(a)
+	assert.Same(t, g, w.groups[a.GUID()], "offline members stay in the group")
+	assert.False(t, g.Member(a.GUID()).Online())
+	assert.Equal(t, b.GUID(), g.Leader, "leader is handed to an online member")
+
+	assert.NoError(t, w.AddPlayer(a))
+	assert.Same(t, g, w.Group(a))
+	assert.True(t, g.Member(a.GUID()).Online())
+	assert.False(t, a.Values.GroupLeader())
+
+	t.Run("disbanded when everyone is offline", func(t *testing.T) {
+		w.RemovePlayer(a)
+		w.RemovePlayer(b)
+		w.RemovePlayer(c)
+		assert.Empty(t, w.groups)
+	})
+}
+
+func TestRaid(t *testing.T) {
+	w := New(nil)
+	var players []*Player
+	for i := 1; i <= 6; i++ {
+		p, _ := newTestPlayer(uint32(i), 0, 0)
+		p.Character.Name = string(rune('A' + i))
+		players = append(players, p)
+	}
+
+	g := newTestGroup(w, players[:MaxPartyMembers]...)
+	leader := players[0]
+	assert.NoError(t, w.AddPlayer(players[5]))
+
+	w.InviteToGroup(leader, players[5].Character.Name)
+	w.AcceptGroupInvite(players[5])
+	assert.Nil(t, w.Group(players[5]), "party is full")
+
+	w.ConvertToRaid(leader)
+	assert.True(t, g.Raid())
+
+	w.InviteToGroup(leader, players[5].Character.Name)
+	w.AcceptGroupInvite(players[5])
+	assert.Equal(t, uint8(1), g.Member(players[5].GUID()).Subgroup)
+
+	t.Run("assistant", func(t *testing.T) {
+		assistant := players[1]
+		w.ChangeSubgroup(assistant, players[2].Character.Name, 2)
+		assert.Equal(t, uint8(0), g.Member(players[2].GUID()).Subgroup, "not an assistant")
+
+		w.SetGroupAssistant(leader, assistant.GUID(), true)
+		w.ChangeSubgroup(assistant, players[2].Character.Name, 2)
+		assert.Equal(t, uint8(2), g.Member(players[2].GUID()).Subgroup)
+
+		w.SwapSubgroups(assistant, players[2].Character.Name, players[5].Character.Name)
+		assert.Equal(t, uint8(1), g.Member(players[2].GUID()).Subgroup)
+		assert.Equal(t, uint8(2), g.Member(players[5].GUID()).Subgroup)
+	})
+
+	t.Run("target icons", func(t *testing.T) {
+		w.SetRaidTargetIcon(leader, 0, 100)
+		w.SetRaidTargetIcon(leader, 1, 100)
+		assert.Equal(t, realmd.Guid(0), g.icons[0], "target can only have one icon")
+		assert.Equal(t, realmd.Guid(100), g.icons[1])
+
+		w.SetRaidTargetIcon(players[3], 2, 200)
+		assert.Equal(t, realmd.Guid(0), g.icons[2], "only assistants can mark targets in a raid")
+	})
+
+	t.Run("set leader", func(t *testing.T) {
+		w.SetGroupLeader(leader, players[3].GUID())
+		assert.Equal(t, players[3].GUID(), g.Leader)
+		assert.False(t, leader.Values.GroupLeader())
+		assert.True(t, players[3].Values.GroupLeader())
+	})
+}
+
+func TestGroupStats(t *testing.T) {
+	w := New(nil)
+	a, aConn := newTestPlayer(1, 0, 0)
+	b, _ := newTestPlayer(2, 0, 0)
+	a.Character.Name = "Alice"
+	b.Character.Name = "Bob"
+	newTestGroup(w, a, b)
+
+	w.updateGroupStats()
+	assert.Equal(t, realmd.OpServerPartyMemberStats, realmd.ServerOpcode(aConn.last[2]))
+
+	writes := aConn.writes
+	w.updateGroupStats()
+	assert.Equal(t, writes, aConn.writes, "nothing changed")
+
+	b.Values.SetHealth(1)
+	w.updateGroupStats()
+	assert.Equal(t, writes+1, aConn.writes)
+}
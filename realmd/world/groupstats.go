@@ -0,0 +1,223 @@
+package world
+
+import (
+	"bytes"
+	"encoding/binary"
+	"time"
+
+	"github.com/kangaroux/gomaggus/realmd"
+)
+
+// groupStatsInterval is how often group members are sent each other's stats.
+const groupStatsInterval = time.Second
+
+// MemberStatus is shown on a group member's portrait.
+type MemberStatus uint16
+
+const (
+	MemberStatusOffline MemberStatus = 0x00
+	MemberStatusOnline  MemberStatus = 0x01
+	MemberStatusPVP     MemberStatus = 0x02
+	MemberStatusDead    MemberStatus = 0x04
+	MemberStatusGhost   MemberStatus = 0x08
+	MemberStatusAFK     MemberStatus = 0x40
+	MemberStatusDND     MemberStatus = 0x80
+)
+
+// partyStatsMask has a bit set for each of the stats in a party member stats packet.
+type partyStatsMask uint32
+
+const (
+	partyStatsStatus    partyStatsMask = 0x001
+	partyStatsHealth    partyStatsMask = 0x002
+	partyStatsMaxHealth partyStatsMask = 0x004
+	partyStatsPowerType partyStatsMask = 0x008
+	partyStatsPower     partyStatsMask = 0x010
+	partyStatsMaxPower  partyStatsMask = 0x020
+	partyStatsLevel     partyStatsMask = 0x040
+	partyStatsZone      partyStatsMask = 0x080
+	partyStatsPosition  partyStatsMask = 0x100
+
+	partyStatsAll = partyStatsStatus | partyStatsHealth | partyStatsMaxHealth | partyStatsPowerType |
+		partyStatsPower | partyStatsMaxPower | partyStatsLevel | partyStatsZone | partyStatsPosition
+)
+
+// partyStats are the stats that group members see in each other's portraits and on the map.
+type partyStats struct {
+	status    MemberStatus
+	health    uint32
+	maxHealth uint32
+	powerType realmd.PowerType
+	power     uint16
+	maxPower  uint16
+	level     uint16
+	zone      uint16
+	x         int16
+	y         int16
+}
+
+// currentPartyStats returns the player's stats as they are now.
+func (p *Player) currentPartyStats() partyStats {
+	v := p.Values
+	pos := p.Position()
+
+	status := MemberStatusOnline
+	if v.Ghost() {
+		status |= MemberStatusGhost
+	} else if v.Health() == 0 {
+		status |= MemberStatusDead
+	}
+	if v.AFK() {
+		status |= MemberStatusAFK
+	}
+	if v.DND() {
+		status |= MemberStatusDND
+	}
+
+	return partyStats{
+		status:    status,
+		health:    v.Health(),
+		maxHealth: v.MaxHealth(),
+		powerType: v.PowerType(),
+		power:     uint16(p.Power()),
+		maxPower:  uint16(p.MaxPower()),
+		level:     uint16(v.Level()),
+		zone:      uint16(p.Zone),
+		x:         int16(pos.X),
+		y:         int16(pos.Y),
+	}
+}
+
+// changed returns a mask of the stats that are different in other.
+func (s *partyStats) changed(other *partyStats) partyStatsMask {
+	var mask partyStatsMask
+
+	if s.status != other.status {
+		mask |= partyStatsStatus
+	}
+	if s.health != other.health {
+		mask |= partyStatsHealth
+	}
+	if s.maxHealth != other.maxHealth {
+		mask |= partyStatsMaxHealth
+	}
+	if s.powerType != other.powerType {
+		mask |= partyStatsPowerType
+	}
+	if s.power != other.power {
+		mask |= partyStatsPower
+	}
+	if s.maxPower != other.maxPower {
+		mask |= partyStatsMaxPower
+	}
+	if s.level != other.level {
+		mask |= partyStatsLevel
+	}
+	if s.zone != other.zone {
+		mask |= partyStatsZone
+	}
+	if s.x != other.x || s.y != other.y {
+		mask |= partyStatsPosition
+	}
+
+	return mask
+}
+
+// write writes the stats in mask. The stats must be written in the same order as the mask bits.
+func (s *partyStats) write(buf *bytes.Buffer, mask partyStatsMask) {
+	binary.Write(buf, binary.LittleEndian, mask)
+
+	if mask&partyStatsStatus != 0 {
+		binary.Write(buf, binary.LittleEndian, s.status)
+	}
+	if mask&partyStatsHealth != 0 {
+		binary.Write(buf, binary.LittleEndian, s.health)
+	}
+	if mask&partyStatsMaxHealth != 0 {
+		binary.Write(buf, binary.LittleEndian, s.maxHealth)
+	}
+	if mask&partyStatsPowerType != 0 {
+		buf.WriteByte(byte(s.powerType))
+	}
+	if mask&partyStatsPower != 0 {
+		binary.Write(buf, binary.LittleEndian, s.power)
+	}
+	if mask&partyStatsMaxPower != 0 {
+		binary.Write(buf, binary.LittleEndian, s.maxPower)
+	}
+	if mask&partyStatsLevel != 0 {
+		binary.Write(buf, binary.LittleEndian, s.level)
+	}
+	if mask&partyStatsZone != 0 {
+		binary.Write(buf, binary.LittleEndian, s.zone)
+	}
+	if mask&partyStatsPosition != 0 {
+		binary.Write(buf, binary.LittleEndian, s.x)
+		binary.Write(buf, binary.LittleEndian, s.y)
+	}
+}
+
+// offlineStatsBytes returns the SMSG_PARTY_MEMBER_STATS payload for a member that went offline.
+func offlineStatsBytes(guid realmd.Guid) []byte {
+	buf := bytes.Buffer{}
+	buf.Write(realmd.PackGuid(uint64(guid)))
+	stats := partyStats{status: MemberStatusOffline}
+	stats.write(&buf, partyStatsStatus)
+	return buf.Bytes()
+}
+
+// updateGroupStats sends each group member's changed stats to the rest of their group.
+// https://gtker.com/wow_messages/docs/smsg_party_member_stats.html#client-version-335
+func (w *World) updateGroupStats() {
+	for _, p := range w.players {
+		g := w.groups[p.GUID()]
+		if g == nil {
+			continue
+		}
+
+		stats := p.currentPartyStats()
+		mask := stats.changed(&p.partyStats)
+		if mask == 0 {
+			continue
+		}
+		p.partyStats = stats
+
+		buf := bytes.Buffer{}
+		buf.Write(realmd.PackGuid(uint64(p.GUID())))
+		stats.write(&buf, mask)
+
+		for _, other := range g.online() {
+			if other != p {
+				other.sendPacket(realmd.OpServerPartyMemberStats, buf.Bytes())
+			}
+		}
+	}
+}
+
+// SendPartyMemberStats sends p all of the stats for the member of their group with guid.
+// https://gtker.com/wow_messages/docs/smsg_party_member_stats_full.html#client-version-335
+func (w *World) SendPartyMemberStats(p *Player, guid realmd.Guid) {
+	g := w.groups[p.GUID()]
+	if g == nil {
+		return
+	}
+
+	m := g.Member(guid)
+	if m == nil {
+		return
+	}
+
+	buf := bytes.Buffer{}
+	buf.WriteByte(0) // not a pet
+	buf.Write(realmd.PackGuid(uint64(guid)))
+
+	if m.player == nil {
+		stats := partyStats{status: MemberStatusOffline}
+		stats.write(&buf, partyStatsStatus)
+	} else {
+		stats := m.player.currentPartyStats()
+		stats.write(&buf, partyStatsAll)
+	}
+
+	p.sendPacket(realmd.OpServerPartyMemberStatsFull, buf.Bytes())
+}
@@ -0,0 +1,976 @@
+package world
+
+import (
+	"bytes"
+	"encoding/binary"
+	"strings"
+	"time"
+
+	"github.com/kangaroux/gomaggus/model"
+	"github.com/kangaroux/gomaggus/realmd"
+	"github.com/kangaroux/gomaggus/realmd/chat"
+)
+
+const (
+	// A guild has at least MinGuildRanks ranks and at most MaxGuildRanks.
+	MinGuildRanks = 5
+	MaxGuildRanks = 10
+
+	// The client doesn't allow guild names, MOTDs, info, notes or rank names longer than this. Anything
+	// longer is cut off.
+	MaxGuildNameLength     = 24
+	MaxGuildMOTDLength     = 127
+	MaxGuildInfoLength     = 500
+	MaxGuildNoteLength     = 31
+	MaxGuildRankNameLength = 15
+
+	// guildBankTabs is how many bank tabs are sent with each rank. Guild banks aren't supported, so the
+	// tabs are always empty.
+	guildBankTabs = 6
+)
+
+// GuildEvent is a change in the guild that is shown to its members.
+// https://gtker.com/wow_messages/docs/guildevent.html#client-version-335
+type GuildEvent uint8
+
+const (
+	GuildEventPromotion     GuildEvent = 0
+	GuildEventDemotion      GuildEvent = 1
+	GuildEventMOTD          GuildEvent = 2
+	GuildEventJoined        GuildEvent = 3
+	GuildEventLeft          GuildEvent = 4
+	GuildEventRemoved       GuildEvent = 5
+	GuildEventLeaderIs      GuildEvent = 6
+	GuildEventLeaderChanged GuildEvent = 7
+	GuildEventDisbanded     GuildEvent = 8
+	GuildEventRankUpdated   GuildEvent = 10
+	GuildEventRankDeleted   GuildEvent = 11
+	GuildEventSignedOn      GuildEvent = 12
+	GuildEventSignedOff     GuildEvent = 13
+)
+
+// GuildCommand is the guild action that a command result is for.
+// https://gtker.com/wow_messages/docs/guildcommand.html#client-version-335
+type GuildCommand uint32
+
+const (
+	GuildCommandCreate       GuildCommand = 0
+	GuildCommandInvite       GuildCommand = 1
+	GuildCommandQuit         GuildCommand = 3
+	GuildCommandPromote      GuildCommand = 6
+	GuildCommandDemote       GuildCommand = 7
+	GuildCommandRemove       GuildCommand = 8
+	GuildCommandChangeLeader GuildCommand = 10
+	GuildCommandEditMOTD     GuildCommand = 11
+	GuildCommandChat         GuildCommand = 13
+	GuildCommandChangeRank   GuildCommand = 16
+	GuildCommandPublicNote   GuildCommand = 19
+)
+
+// GuildError is the result of a guild action. Errors ending in S use the name in the command result.
+// https://gtker.com/wow_messages/docs/guildcommanderror.html#client-version-335
+type GuildError uint32
+
+const (
+	GuildErrorSuccess           GuildError = 0
+	GuildErrorInternal          GuildError = 1
+	GuildErrorAlreadyInGuild    GuildError = 2
+	GuildErrorAlreadyInGuildS   GuildError = 3
+	GuildErrorInvitedToGuild    GuildError = 4
+	GuildErrorAlreadyInvitedS   GuildError = 5
+	GuildErrorNameInvalid       GuildError = 6
+	GuildErrorNameExistsS       GuildError = 7
+	GuildErrorPermissions       GuildError = 8
+	GuildErrorPlayerNotInGuild  GuildError = 9
+	GuildErrorPlayerNotInGuildS GuildError = 10
+	GuildErrorPlayerNotFoundS   GuildError = 11
+	GuildErrorNotAllied         GuildError = 12
+	GuildErrorRankTooHighS      GuildError = 13
+	GuildErrorRankTooLowS       GuildError = 14
+	GuildErrorRanksLocked       GuildError = 17
+	GuildErrorRankInUse         GuildError = 18
+	GuildErrorIgnoringYouS      GuildError = 19
+	GuildErrorLeaderLeave       GuildError = GuildErrorPermissions
+)
+
+// DefaultGuildRanks returns the ranks that a new guild starts with.
+func DefaultGuildRanks(guildId uint32) []*model.GuildRank {
+	return []*model.GuildRank{
+		{GuildId: guildId, Rank: 0, Name: "Guild Master", Rights: model.GuildRightsAll, MoneyPerDay: 0xFFFFFFFF},
+		{GuildId: guildId, Rank: 1, Name: "Officer", Rights: model.GuildRightsAll},
+		{GuildId: guildId, Rank: 2, Name: "Veteran", Rights: model.GuildRightsMember},
+		{GuildId: guildId, Rank: 3, Name: "Member", Rights: model.GuildRightsMember},
+		{GuildId: guildId, Rank: 4, Name: "Initiate", Rights: model.GuildRightsMember},
+	}
+}
+
+// GuildMember is a character in a guild, along with what their guildmates see in the roster while
+// they are offline.
+type GuildMember struct {
+	*model.GuildMember
+
+	Name   string
+	Level  uint8
+	Class  model.Class
+	Gender model.Gender
+	Zone   uint32
+
+	// LastSeen is when the member was last online.
+	LastSeen time.Time
+
+	// player is nil while the member is offline.
+	player *Player
+}
+
+func (m *GuildMember) GUID() realmd.Guid {
+	return realmd.Guid(m.CharacterId)
+}
+
+// Online reports whether the member is in the world.
+func (m *GuildMember) Online() bool {
+	return m.player != nil
+}
+
+// Guild is a guild that has been loaded into the world. Guilds are loaded the first time one of their
+// members logs in and stay loaded until they are disbanded.
+type Guild struct {
+	*model.Guild
+
+	// Ranks are ordered from highest to lowest, so each rank's index is its rank number.
+	Ranks []*model.GuildRank
+
+	members map[realmd.Guid]*GuildMember
+}
+
+// Member returns the guild member with guid, or nil if they aren't in the guild.
+func (g *Guild) Member(guid realmd.Guid) *GuildMember {
+	return g.members[guid]
+}
+
+// MemberByName returns the guild member with the given name, or nil if they aren't in the guild. The
+// name is case insensitive.
+func (g *Guild) MemberByName(name string) *GuildMember {
+	for _, m := range g.members {
+		if strings.EqualFold(m.Name, name) {
+			return m
+		}
+	}
+	return nil
+}
+
+// Members returns all the guild's members, including those that are offline.
+func (g *Guild) Members() []*GuildMember {
+	result := make([]*GuildMember, 0, len(g.members))
+	for _, m := range g.members {
+		result = append(result, m)
+	}
+	return result
+}
+
+// HasRight reports whether the member's rank has right. The guild master has every right.
+func (g *Guild) HasRight(m *GuildMember, right model.GuildRights) bool {
+	if m.Rank == 0 {
+		return true
+	}
+	if int(m.Rank) >= len(g.Ranks) {
+		return false
+	}
+	return g.Ranks[m.Rank].Rights&right != 0
+}
+
+// lowestRank returns the rank number of the guild's lowest rank, which new members join at.
+func (g *Guild) lowestRank() uint8 {
+	return uint8(len(g.Ranks) - 1)
+}
+
+func (g *Guild) rankName(rank uint8) string {
+	if int(rank) >= len(g.Ranks) {
+		return ""
+	}
+	return g.Ranks[rank].Name
+}
+
+// online returns the guild's members that are in the world.
+func (g *Guild) online() []*Player {
+	var result []*Player
+	for _, m := range g.members {
+		if m.player != nil {
+			result = append(result, m.player)
+		}
+	}
+	return result
+}
+
+// addMember adds the character to the guild.
+func (g *Guild) addMember(gm *model.GuildMember, char *model.Character) *GuildMember {
+	m := &GuildMember{
+		GuildMember: gm,
+		Name:        char.Name,
+		Level:       char.Level,
+		Class:       char.Class,
+		Gender:      char.Gender,
+		Zone:        char.Zone,
+	}
+	if char.LastLogin.Valid {
+		m.LastSeen = char.LastLogin.Time
+	}
+
+	g.members[m.GUID()] = m
+	return m
+}
+
+// broadcastEvent sends an event to the guild's online members. Some events also include the guid of
+// the member the event is for.
+// https://gtker.com/wow_messages/docs/smsg_guild_event.html
+func (g *Guild) broadcastEvent(event GuildEvent, guid realmd.Guid, args ...string) {
+	buf := bytes.Buffer{}
+	buf.WriteByte(byte(event))
+	buf.WriteByte(byte(len(args)))
+	for _, s := range args {
+		buf.WriteString(s)
+		buf.WriteByte(0)
+	}
+
+	switch event {
+	case GuildEventJoined, GuildEventLeft, GuildEventSignedOn, GuildEventSignedOff:
+		binary.Write(&buf, binary.LittleEndian, guid)
+	}
+
+	for _, p := range g.online() {
+		p.sendPacket(realmd.OpServerGuildEvent, buf.Bytes())
+	}
+}
+
+// sendGuildResult sends the result of a guild action to the player. name is the player or guild the
+// action was for.
+// https://gtker.com/wow_messages/docs/smsg_guild_command_result.html#client-version-335
+func (p *Player) sendGuildResult(cmd GuildCommand, name string, result GuildError) {
+	buf := bytes.Buffer{}
+	binary.Write(&buf, binary.LittleEndian, cmd)
+	buf.WriteString(name)
+	buf.WriteByte(0)
+	binary.Write(&buf, binary.LittleEndian, result)
+	p.sendPacket(realmd.OpServerGuildCommandResult, buf.Bytes())
+}
+
+// Guild returns the guild the player is in, or nil if they aren't in a guild.
+func (p *Player) Guild() *Guild {
+	return p.guild
+}
+
+// setGuild updates the player's guild and rank, which are shown to other players.
+func (p *Player) setGuild(g *Guild, rank uint8) {
+	p.guild = g
+
+	if g == nil {
+		p.Values.SetGuildID(0)
+		p.Values.SetGuildRank(0)
+		return
+	}
+
+	p.Values.SetGuildID(g.Id)
+	p.Values.SetGuildRank(uint32(rank))
+}
+
+// Guild returns the loaded guild with id, or nil if it isn't loaded.
+func (w *World) Guild(id uint32) *Guild {
+	return w.guilds[id]
+}
+
+// LoadGuild adds a guild and its members to the world. chars are the characters of the guild's members.
+func (w *World) LoadGuild(mg *model.Guild, ranks []*model.GuildRank, members []*model.GuildMember, chars []*model.Character) *Guild {
+	g := &Guild{
+		Guild:   mg,
+		Ranks:   ranks,
+		members: make(map[realmd.Guid]*GuildMember, len(members)),
+	}
+
+	byId := make(map[uint32]*model.Character, len(chars))
+	for _, c := range chars {
+		byId[c.Id] = c
+	}
+
+	for _, m := range members {
+		if char, ok := byId[m.CharacterId]; ok {
+			g.addMember(m, char)
+		}
+	}
+
+	w.guilds[g.Id] = g
+	return g
+}
+
+// GuildSignOn marks p as online in their guild. The player is shown the guild's MOTD, and the rest of
+// the guild is told they signed on.
+func (w *World) GuildSignOn(p *Player, g *Guild) {
+	m := g.Member(p.GUID())
+	if m == nil {
+		return
+	}
+
+	m.player = p
+	p.setGuild(g, m.Rank)
+
+	buf := bytes.Buffer{}
+	buf.WriteByte(byte(GuildEventMOTD))
+	buf.WriteByte(1)
+	buf.WriteString(g.MOTD)
+	buf.WriteByte(0)
+	p.sendPacket(realmd.OpServerGuildEvent, buf.Bytes())
+
+	g.broadcastEvent(GuildEventSignedOn, p.GUID(), p.Character.Name)
+}
+
+// guildSignOff marks p as offline in their guild and tells the rest of the guild.
+func (w *World) guildSignOff(p *Player) {
+	p.guildInvite = 0
+	p.guildInviter = 0
+
+	g := p.guild
+	if g == nil {
+		return
+	}
+
+	if m := g.Member(p.GUID()); m != nil {
+		m.player = nil
+		m.Level = uint8(p.Values.Level())
+		m.Zone = p.Zone
+		m.LastSeen = time.Now()
+	}
+
+	g.broadcastEvent(GuildEventSignedOff, p.GUID(), p.Character.Name)
+}
+
+// InviteToGuild invites the player named target to join p's guild.
+func (w *World) InviteToGuild(p *Player, target string) {
+	g := p.guild
+	if g == nil {
+		p.sendGuildResult(GuildCommandInvite, "", GuildErrorPlayerNotInGuild)
+		return
+	}
+
+	if !g.HasRight(g.Member(p.GUID()), model.GuildRightInvite) {
+		p.sendGuildResult(GuildCommandInvite, "", GuildErrorPermissions)
+		return
+	}
+
+	other := w.PlayerByName(target)
+	if other == nil || other == p {
+		p.sendGuildResult(GuildCommandInvite, target, GuildErrorPlayerNotFoundS)
+		return
+	}
+
+	if other.Horde != p.Horde {
+		p.sendGuildResult(GuildCommandInvite, other.Character.Name, GuildErrorNotAllied)
+		return
+	}
+
+	if other.Ignores(p) {
+		p.sendGuildResult(GuildCommandInvite, other.Character.Name, GuildErrorIgnoringYouS)
+		return
+	}
+
+	if other.guild != nil {
+		p.sendGuildResult(GuildCommandInvite, other.Character.Name, GuildErrorAlreadyInGuildS)
+		return
+	}
+
+	if other.guildInvite != 0 {
+		p.sendGuildResult(GuildCommandInvite, other.Character.Name, GuildErrorAlreadyInvitedS)
+		return
+	}
+
+	other.guildInvite = g.Id
+	other.guildInviter = p.GUID()
+
+	// https://gtker.com/wow_messages/docs/smsg_guild_invite.html
+	buf := bytes.Buffer{}
+	buf.WriteString(p.Character.Name)
+	buf.WriteByte(0)
+	buf.WriteString(g.Name)
+	buf.WriteByte(0)
+	other.sendPacket(realmd.OpServerGuildInvite, buf.Bytes())
+
+	p.sendGuildResult(GuildCommandInvite, other.Character.Name, GuildErrorSuccess)
+}
+
+// AcceptGuildInvite adds p to the guild they were invited to at the guild's lowest rank. Returns the new
+// membership, or nil if p couldn't join.
+func (w *World) AcceptGuildInvite(p *Player) *model.GuildMember {
+	g := w.guilds[p.guildInvite]
+	p.guildInvite = 0
+	p.guildInviter = 0
+
+	if g == nil || p.guild != nil {
+		return nil
+	}
+
+	m := g.addMember(&model.GuildMember{
+		CharacterId: p.Character.Id,
+		GuildId:     g.Id,
+		Rank:        g.lowestRank(),
+		JoinedAt:    time.Now(),
+	}, p.Character)
+	m.player = p
+	p.setGuild(g, m.Rank)
+
+	g.broadcastEvent(GuildEventJoined, p.GUID(), p.Character.Name)
+
+	return m.GuildMember
+}
+
+// DeclineGuildInvite declines p's guild invite. The player that invited them is told.
+func (w *World) DeclineGuildInvite(p *Player) {
+	inviter := w.players[p.guildInviter]
+	p.guildInvite = 0
+	p.guildInviter = 0
+
+	if inviter == nil {
+		return
+	}
+
+	// https://gtker.com/wow_messages/docs/smsg_guild_decline.html
+	inviter.sendPacket(realmd.OpServerGuildDecline, append([]byte(p.Character.Name), 0))
+}
+
+// managedMember returns p's guild and the member named target, if p has right and outranks them. p is
+// sent an error and nil is returned otherwise.
+func (w *World) managedMember(p *Player, cmd GuildCommand, target string, right model.GuildRights) (*Guild, *GuildMember) {
+	g := p.guild
+	if g == nil {
+		p.sendGuildResult(cmd, "", GuildErrorPlayerNotInGuild)
+		return nil, nil
+	}
+
+	self := g.Member(p.GUID())
+	if !g.HasRight(self, right) {
+		p.sendGuildResult(cmd, "", GuildErrorPermissions)
+		return nil, nil
+	}
+
+	m := g.MemberByName(target)
+	if m == nil {
+		p.sendGuildResult(cmd, target, GuildErrorPlayerNotInGuildS)
+		return nil, nil
+	}
+
+	if m.Rank <= self.Rank {
+		p.sendGuildResult(cmd, m.Name, GuildErrorRankTooHighS)
+		return nil, nil
+	}
+
+	return g, m
+}
+
+// setMemberRank changes the member's rank and updates their values if they are online.
+func (g *Guild) setMemberRank(m *GuildMember, rank uint8) {
+	m.Rank = rank
+	if m.player != nil {
+		m.player.Values.SetGuildRank(uint32(rank))
+	}
+}
+
+// PromoteGuildMember moves the member named target up one rank. Players can only promote members to
+// ranks below their own. Returns the updated membership, or nil if it wasn't changed.
+func (w *World) PromoteGuildMember(p *Player, target string) *model.GuildMember {
+	g, m := w.managedMember(p, GuildCommandPromote, target, model.GuildRightPromote)
+	if m == nil {
+		return nil
+	}
+
+	if m.Rank-1 <= g.Member(p.GUID()).Rank {
+		p.sendGuildResult(GuildCommandPromote, m.Name, GuildErrorRankTooHighS)
+		return nil
+	}
+
+	g.setMemberRank(m, m.Rank-1)
+	g.broadcastEvent(GuildEventPromotion, 0, p.Character.Name, m.Name, g.rankName(m.Rank))
+
+	return m.GuildMember
+}
+
+// DemoteGuildMember moves the member named target down one rank. Returns the updated membership, or nil
+// if it wasn't changed.
+func (w *World) DemoteGuildMember(p *Player, target string) *model.GuildMember {
+	g, m := w.managedMember(p, GuildCommandDemote, target, model.GuildRightDemote)
+	if m == nil {
+		return nil
+	}
+
+	if m.Rank >= g.lowestRank() {
+		p.sendGuildResult(GuildCommandDemote, m.Name, GuildErrorRankTooLowS)
+		return nil
+	}
+
+	g.setMemberRank(m, m.Rank+1)
+	g.broadcastEvent(GuildEventDemotion, 0, p.Character.Name, m.Name, g.rankName(m.Rank))
+
+	return m.GuildMember
+}
+
+// removeMember removes the member from the guild. If they are online, they are no longer shown as
+// being in the guild.
+func (g *Guild) removeMember(m *GuildMember) {
+	delete(g.members, m.GUID())
+	if m.player != nil {
+		m.player.setGuild(nil, 0)
+	}
+}
+
+// LeaveGuild removes p from their guild. The guild master can't leave, they have to pass on leadership
+// or disband the guild. Returns the membership that was removed, or nil if p didn't leave.
+func (w *World) LeaveGuild(p *Player) *model.GuildMember {
+	g := p.guild
+	if g == nil {
+		p.sendGuildResult(GuildCommandQuit, "", GuildErrorPlayerNotInGuild)
+		return nil
+	}
+
+	if g.LeaderId == p.Character.Id {
+		p.sendGuildResult(GuildCommandQuit, "", GuildErrorLeaderLeave)
+		return nil
+	}
+
+	m := g.Member(p.GUID())
+	g.broadcastEvent(GuildEventLeft, p.GUID(), p.Character.Name)
+	g.removeMember(m)
+	p.sendGuildResult(GuildCommandQuit, g.Name, GuildErrorSuccess)
+
+	return m.GuildMember
+}
+
+// RemoveFromGuild kicks the member named target from p's guild. Returns the membership that was removed,
+// or nil if the member wasn't removed.
+func (w *World) RemoveFromGuild(p *Player, target string) *model.GuildMember {
+	g, m := w.managedMember(p, GuildCommandRemove, target, model.GuildRightRemove)
+	if m == nil {
+		return nil
+	}
+
+	g.broadcastEvent(GuildEventRemoved, 0, m.Name, p.Character.Name)
+	g.removeMember(m)
+
+	return m.GuildMember
+}
+
+// SetGuildLeader makes the member named target the guild master of p's guild. p must be the guild
+// master, and is moved down to the next rank. Returns the memberships that changed, or nil if the
+// leader wasn't changed.
+func (w *World) SetGuildLeader(p *Player, target string) []*model.GuildMember {
+	g := p.guild
+	if g == nil {
+		p.sendGuildResult(GuildCommandChangeLeader, "", GuildErrorPlayerNotInGuild)
+		return nil
+	}
+
+	if g.LeaderId != p.Character.Id {
+		p.sendGuildResult(GuildCommandChangeLeader, "", GuildErrorPermissions)
+		return nil
+	}
+
+	m := g.MemberByName(target)
+	if m == nil {
+		p.sendGuildResult(GuildCommandChangeLeader, target, GuildErrorPlayerNotInGuildS)
+		return nil
+	}
+
+	if m.CharacterId == p.Character.Id {
+		return nil
+	}
+
+	self := g.Member(p.GUID())
+	g.setMemberRank(self, 1)
+	g.setMemberRank(m, 0)
+	g.LeaderId = m.CharacterId
+
+	g.broadcastEvent(GuildEventLeaderChanged, 0, p.Character.Name, m.Name)
+
+	return []*model.GuildMember{self.GuildMember, m.GuildMember}
+}
+
+// DisbandGuild removes every member from p's guild and unloads it. Only the guild master can disband
+// the guild. Returns the guild that was disbanded, or nil if it wasn't.
+func (w *World) DisbandGuild(p *Player) *Guild {
+	g := p.guild
+	if g == nil {
+		p.sendGuildResult(GuildCommandQuit, "", GuildErrorPlayerNotInGuild)
+		return nil
+	}
+
+	if g.LeaderId != p.Character.Id {
+		p.sendGuildResult(GuildCommandQuit, "", GuildErrorPermissions)
+		return nil
+	}
+
+	g.broadcastEvent(GuildEventDisbanded, 0)
+
+	for _, m := range g.members {
+		g.removeMember(m)
+	}
+	delete(w.guilds, g.Id)
+
+	return g
+}
+
+// SetGuildMOTD changes the MOTD of p's guild and shows it to the guild's online members. Returns the
+// guild, or nil if the MOTD wasn't changed.
+func (w *World) SetGuildMOTD(p *Player, motd string) *Guild {
+	g := p.guild
+	if g == nil {
+		p.sendGuildResult(GuildCommandEditMOTD, "", GuildErrorPlayerNotInGuild)
+		return nil
+	}
+
+	if !g.HasRight(g.Member(p.GUID()), model.GuildRightSetMOTD) {
+		p.sendGuildResult(GuildCommandEditMOTD, "", GuildErrorPermissions)
+		return nil
+	}
+
+	g.MOTD = truncate(motd, MaxGuildMOTDLength)
+	g.broadcastEvent(GuildEventMOTD, 0, g.MOTD)
+
+	return g
+}
+
+// SetGuildInfo changes the info text of p's guild. Returns the guild, or nil if the info wasn't changed.
+func (w *World) SetGuildInfo(p *Player, info string) *Guild {
+	g := p.guild
+	if g == nil {
+		return nil
+	}
+
+	if !g.HasRight(g.Member(p.GUID()), model.GuildRightModifyGuildInfo) {
+		p.sendGuildResult(GuildCommandEditMOTD, "", GuildErrorPermissions)
+		return nil
+	}
+
+	g.Info = truncate(info, MaxGuildInfoLength)
+
+	return g
+}
+
+// SetGuildNote changes the public or officer note of the member named target. Returns the updated
+// membership, or nil if the note wasn't changed.
+func (w *World) SetGuildNote(p *Player, target string, note string, officer bool) *model.GuildMember {
+	g := p.guild
+	if g == nil {
+		p.sendGuildResult(GuildCommandPublicNote, "", GuildErrorPlayerNotInGuild)
+		return nil
+	}
+
+	right := model.GuildRightEditPublicNote
+	if officer {
+		right = model.GuildRightEditOfficerNote
+	}
+
+	if !g.HasRight(g.Member(p.GUID()), right) {
+		p.sendGuildResult(GuildCommandPublicNote, "", GuildErrorPermissions)
+		return nil
+	}
+
+	m := g.MemberByName(target)
+	if m == nil {
+		p.sendGuildResult(GuildCommandPublicNote, target, GuildErrorPlayerNotInGuildS)
+		return nil
+	}
+
+	note = truncate(note, MaxGuildNoteLength)
+	if officer {
+		m.OfficerNote = note
+	} else {
+		m.PublicNote = note
+	}
+
+	w.SendGuildRoster(p)
+
+	return m.GuildMember
+}
+
+// guildLeader returns p's guild if they are its guild master. Only the guild master can change ranks.
+func (w *World) guildLeader(p *Player) *Guild {
+	g := p.guild
+	if g == nil {
+		p.sendGuildResult(GuildCommandChangeRank, "", GuildErrorPlayerNotInGuild)
+		return nil
+	}
+
+	if g.LeaderId != p.Character.Id {
+		p.sendGuildResult(GuildCommandChangeRank, "", GuildErrorPermissions)
+		return nil
+	}
+
+	return g
+}
+
+// rankChanged tells the guild's online members that its ranks changed, and sends them the new roster.
+func (w *World) rankChanged(g *Guild, event GuildEvent, name string) {
+	g.broadcastEvent(event, 0, name)
+	for _, p := range g.online() {
+		w.SendGuildRoster(p)
+	}
+}
+
+// SetGuildRank changes the name and rights of one of the ranks in p's guild. The guild master's rank
+// always has every right. Returns the updated rank, or nil if it wasn't changed.
+func (w *World) SetGuildRank(p *Player, rank uint8, name string, rights model.GuildRights, moneyPerDay uint32) *model.GuildRank {
+	g := w.guildLeader(p)
+	if g == nil || int(rank) >= len(g.Ranks) {
+		return nil
+	}
+
+	if rank == 0 {
+		rights = model.GuildRightsAll
+	}
+
+	r := g.Ranks[rank]
+	r.Name = truncate(name, MaxGuildRankNameLength)
+	r.Rights = rights
+	r.MoneyPerDay = moneyPerDay
+
+	w.rankChanged(g, GuildEventRankUpdated, r.Name)
+
+	return r
+}
+
+// AddGuildRank adds a new lowest rank to p's guild. Returns the new rank, or nil if the guild already
+// has the most ranks allowed.
+func (w *World) AddGuildRank(p *Player, name string) *model.GuildRank {
+	g := w.guildLeader(p)
+	if g == nil || len(g.Ranks) >= MaxGuildRanks {
+		return nil
+	}
+
+	r := &model.GuildRank{
+		GuildId: g.Id,
+		Rank:    uint8(len(g.Ranks)),
+		Name:    truncate(name, MaxGuildRankNameLength),
+		Rights:  model.GuildRightsMember,
+	}
+	g.Ranks = append(g.Ranks, r)
+
+	w.rankChanged(g, GuildEventRankUpdated, r.Name)
+
+	return r
+}
+
+// DeleteGuildRank deletes the lowest rank of p's guild. A rank can't be deleted while anyone has it.
+// Returns the deleted rank, or nil if the guild already has the fewest ranks allowed.
+func (w *World) DeleteGuildRank(p *Player) *model.GuildRank {
+	g := w.guildLeader(p)
+	if g == nil || len(g.Ranks) <= MinGuildRanks {
+		return nil
+	}
+
+	lowest := g.lowestRank()
+	for _, m := range g.members {
+		if m.Rank == lowest {
+			p.sendGuildResult(GuildCommandChangeRank, "", GuildErrorRankInUse)
+			return nil
+		}
+	}
+
+	r := g.Ranks[lowest]
+	g.Ranks = g.Ranks[:lowest]
+
+	w.rankChanged(g, GuildEventRankDeleted, r.Name)
+
+	return r
+}
+
+// GuildChat sends a message from p to the members of their guild that can hear it. Officer messages are
+// only heard by members that can listen to officer chat.
+func (w *World) GuildChat(p *Player, officer bool, lang chat.Language, text string) {
+	g := p.guild
+	if g == nil {
+		p.sendGuildResult(GuildCommandChat, "", GuildErrorPlayerNotInGuild)
+		return
+	}
+
+	msgType, speak, listen := chat.MessageTypeGuild, model.GuildRightChatSpeak, model.GuildRightChatListen
+	if officer {
+		msgType, speak, listen = chat.MessageTypeOfficer, model.GuildRightOfficerChatSpeak, model.GuildRightOfficerChatListen
+	}
+
+	if !g.HasRight(g.Member(p.GUID()), speak) {
+		p.sendGuildResult(GuildCommandChat, "", GuildErrorPermissions)
+		return
+	}
+
+	msg := &chat.Message{
+		Type:     msgType,
+		Language: lang,
+		Sender:   p.GUID(),
+		Target:   p.GUID(),
+		Text:     text,
+		Tag:      p.ChatTag(),
+	}
+
+	for _, m := range g.members {
+		if m.player == nil || !g.HasRight(m, listen) || m.player.Ignores(p) {
+			continue
+		}
+
+		if err := m.player.SendChat(msg); err != nil {
+			m.player.Client.Log.Error().Err(err).Msg("error sending guild chat")
+		}
+	}
+}
+
+// writeRank writes a rank's rights, followed by empty guild bank tabs.
+func writeRank(buf *bytes.Buffer, r *model.GuildRank) {
+	binary.Write(buf, binary.LittleEndian, r.Rights)
+	binary.Write(buf, binary.LittleEndian, r.MoneyPerDay)
+	for i := 0; i < guildBankTabs; i++ {
+		binary.Write(buf, binary.LittleEndian, uint32(0)) // tab rights
+		binary.Write(buf, binary.LittleEndian, uint32(0)) // slots per day
+	}
+}
+
+// SendGuildRoster sends p the members of their guild. Officer notes are only sent if p is allowed to
+// see them.
+// https://gtker.com/wow_messages/docs/smsg_guild_roster.html#client-version-335
+func (w *World) SendGuildRoster(p *Player) {
+	g := p.guild
+	if g == nil {
+		return
+	}
+
+	officerNotes := g.HasRight(g.Member(p.GUID()), model.GuildRightViewOfficerNote)
+
+	buf := bytes.Buffer{}
+	binary.Write(&buf, binary.LittleEndian, uint32(len(g.members)))
+	buf.WriteString(g.MOTD)
+	buf.WriteByte(0)
+	buf.WriteString(g.Info)
+	buf.WriteByte(0)
+
+	binary.Write(&buf, binary.LittleEndian, uint32(len(g.Ranks)))
+	for _, r := range g.Ranks {
+		writeRank(&buf, r)
+	}
+
+	for _, m := range g.members {
+		binary.Write(&buf, binary.LittleEndian, m.GUID())
+
+		level, zone := m.Level, m.Zone
+		if m.player != nil {
+			level, zone = uint8(m.player.Values.Level()), m.player.Zone
+			buf.WriteByte(1)
+		} else {
+			buf.WriteByte(0)
+		}
+
+		buf.WriteString(m.Name)
+		buf.WriteByte(0)
+		binary.Write(&buf, binary.LittleEndian, uint32(m.Rank))
+		buf.WriteByte(level)
+		buf.WriteByte(byte(m.Class))
+		buf.WriteByte(byte(m.Gender))
+		binary.Write(&buf, binary.LittleEndian, zone)
+
+		if m.player == nil {
+			days := float32(time.Since(m.LastSeen).Hours() / 24)
+			binary.Write(&buf, binary.LittleEndian, days)
+		}
+
+		buf.WriteString(m.PublicNote)
+		buf.WriteByte(0)
+		if officerNotes {
+			buf.WriteString(m.OfficerNote)
+		}
+		buf.WriteByte(0)
+	}
+
+	p.sendPacket(realmd.OpServerGuildRoster, buf.Bytes())
+}
+
+// SendGuildInfo sends p when their guild was created and how many members it has.
+// https://gtker.com/wow_messages/docs/smsg_guild_info.html#client-version-335
+func (w *World) SendGuildInfo(p *Player) {
+	g := p.guild
+	if g == nil {
+		p.sendGuildResult(GuildCommandCreate, "", GuildErrorPlayerNotInGuild)
+		return
+	}
+
+	buf := bytes.Buffer{}
+	buf.WriteString(g.Name)
+	buf.WriteByte(0)
+	binary.Write(&buf, binary.LittleEndian, packTime(g.CreatedAt))
+	binary.Write(&buf, binary.LittleEndian, uint32(len(g.members)))
+	binary.Write(&buf, binary.LittleEndian, uint32(len(g.members))) // accounts
+
+	p.sendPacket(realmd.OpServerGuildInfo, buf.Bytes())
+}
+
+// SendGuildQuery sends p the name and rank names of the guild with id. The client uses this to show a
+// player's guild above their head and in the roster.
+// https://gtker.com/wow_messages/docs/smsg_guild_query_response.html#client-version-335
+func (w *World) SendGuildQuery(p *Player, id uint32) {
+	g := w.guilds[id]
+	if g == nil {
+		return
+	}
+
+	buf := bytes.Buffer{}
+	binary.Write(&buf, binary.LittleEndian, g.Id)
+	buf.WriteString(g.Name)
+	buf.WriteByte(0)
+
+	for i := 0; i < MaxGuildRanks; i++ {
+		if i < len(g.Ranks) {
+			buf.WriteString(g.Ranks[i].Name)
+		}
+		buf.WriteByte(0)
+	}
+
+	// Emblem style, color, border style, border color and background color
+	for i := 0; i < 5; i++ {
+		binary.Write(&buf, binary.LittleEndian, uint32(0))
+	}
+	binary.Write(&buf, binary.LittleEndian, uint32(len(g.Ranks)))
+
+	p.sendPacket(realmd.OpServerGuildQueryResponse, buf.Bytes())
+}
+
+// SendGuildPermissions sends p the rights of their rank.
+// https://gtker.com/wow_messages/docs/msg_guild_permissions_server.html
+func (w *World) SendGuildPermissions(p *Player) {
+	g := p.guild
+	if g == nil {
+		return
+	}
+
+	m := g.Member(p.GUID())
+	r := g.Ranks[m.Rank]
+
+	buf := bytes.Buffer{}
+	binary.Write(&buf, binary.LittleEndian, uint32(m.Rank))
+	binary.Write(&buf, binary.LittleEndian, r.Rights)
+	binary.Write(&buf, binary.LittleEndian, r.MoneyPerDay)
+	buf.WriteByte(0) // purchased bank tabs
+	for i := 0; i < guildBankTabs; i++ {
+		binary.Write(&buf, binary.LittleEndian, uint32(0)) // tab rights
+		binary.Write(&buf, binary.LittleEndian, uint32(0)) // slots remaining
+	}
+
+	p.sendPacket(realmd.OpServerGuildPermissions, buf.Bytes())
+}
+
+// truncate cuts s off at n bytes.
+func truncate(s string, n int) string {
+	if len(s) > n {
+		return s[:n]
+	}
+	return s
+}
+
+// packTime returns t in the packed format the client uses for dates.
+func packTime(t time.Time) uint32 {
+	return uint32(t.Year()-2000)<<24 |
+		uint32(t.Month()-1)<<20 |
+		uint32(t.Day()-1)<<14 |
+		uint32(t.Weekday())<<11 |
+		uint32(t.Hour())<<6 |
+		uint32(t.Minute())
+}
@@ -0,0 +1,215 @@
+package world
+
+import (
+	"testing"
+
+	"github.com/kangaroux/gomaggus/model"
+	"github.com/kangaroux/gomaggus/realmd"
+	"github.com/stretchr/testify/assert"
+)
+
+// newTestGuild adds the players to the world and puts them in a guild led by the first player.
+func newTestGuild(w *World, players ...*Player) *Guild {
+	leader := players[0]
+	g := w.LoadGuild(
+		&model.Guild{Id: 1, Name: "Test Guild", LeaderId: leader.Character.Id},
+		DefaultGuildRanks(1),
+		[]*model.GuildMember{{CharacterId: leader.Character.Id, GuildId: 1}},
+		[]*model.Character{leader.Character},
+	)
+
+	for _, p := range players {
+		if err := w.AddPlayer(p); err != nil {
+			panic(err)
+		}
+	}
+	w.GuildSignOn(leader, g)
+
+	for _, p := range players[1:] {
+		w.InviteToGuild(leader, p.Character.Name)
+		w.AcceptGuildInvite(p)
+	}
+
+	return g
+}
+
+func TestGuildInvite(t *testing.T) {
+	w := New(nil)
+	leader, leaderConn := newTestPlayer(1, 0, 0)
+	other, otherConn := newTestPlayer(2, 1000, 1000)
+	leader.Character.Name = "Alice"
+	other.Character.Name = "Bob"
+	g := newTestGuild(w, leader, other)
+
+	result := func() GuildError {
+		// command (4) + name + result
+		data := leaderConn.last[4:]
+		for i := 4; i < len(data); i++ {
+			if data[i] == 0 {
+				return GuildError(data[i+1])
+			}
+		}
+		return 0xFF
+	}
+
+	m := g.Member(other.GUID())
+	if !assert.NotNil(t, m) {
+		return
+	}
+	assert.Equal(t, uint8(4), m.Rank, "joins at the lowest rank")
+	assert.Same(t, g, other.Guild())
+	assert.Equal(t, uint32(1), other.Values.GuildID())
+	assert.Equal(t, uint32(4), other.Values.GuildRank())
+
+	t.Run("already in guild", func(t *testing.T) {
+		w.InviteToGuild(leader, "bob")
+		assert.Equal(t, GuildErrorAlreadyInGuildS, result())
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		w.InviteToGuild(leader, "Nobody")
+		assert.Equal(t, GuildErrorPlayerNotFoundS, result())
+	})
+
+	t.Run("no permission", func(t *testing.T) {
+		carol, _ := newTestPlayer(3, 2000, 2000)
+		carol.Character.Name = "Carol"
+		assert.NoError(t, w.AddPlayer(carol))
+
+		w.InviteToGuild(other, "carol")
+		assert.Equal(t, realmd.OpServerGuildCommandResult, realmd.ServerOpcode(otherConn.last[2]))
+		assert.Equal(t, uint32(0), carol.guildInvite)
+
+		w.InviteToGuild(leader, "carol")
+		assert.Equal(t, GuildErrorSuccess, result())
+		w.DeclineGuildInvite(carol)
+		assert.Equal(t, realmd.OpServerGuildDecline, realmd.ServerOpcode(leaderConn.last[2]))
+		assert.Nil(t, carol.Guild())
+	})
+}
+
+func TestGuildRanks(t *testing.T) {
+	w := New(nil)
+	a, _ := newTestPlayer(1, 0, 0)
+	b, _ := newTestPlayer(2, 1000, 1000)
+	c, _ := newTestPlayer(3, 2000, 2000)
+	a.Character.Name = "Alice"
+	b.Character.Name = "Bob"
+	c.Character.Name = "Carol"
+	g := newTestGuild(w, a, b, c)
+
+	t.Run("promote", func(t *testing.T) {
+		assert.NotNil(t, w.PromoteGuildMember(a, "bob"))
+		assert.NotNil(t, w.PromoteGuildMember(a, "bob"))
+		assert.NotNil(t, w.PromoteGuildMember(a, "bob"))
+		assert.Equal(t, uint8(1), g.Member(b.GUID()).Rank)
+		assert.Equal(t, uint32(1), b.Values.GuildRank())
+
+		assert.Nil(t, w.PromoteGuildMember(a, "bob"), "can't promote to guild master")
+		assert.Nil(t, w.PromoteGuildMember(c, "bob"), "can't promote a higher rank")
+	})
+
+	t.Run("demote", func(t *testing.T) {
+		assert.NotNil(t, w.PromoteGuildMember(a, "carol"))
+		assert.NotNil(t, w.DemoteGuildMember(b, "carol"))
+		assert.Nil(t, w.DemoteGuildMember(b, "carol"), "already the lowest rank")
+		assert.Nil(t, w.DemoteGuildMember(b, "alice"), "can't demote a higher rank")
+	})
+
+	t.Run("edit ranks", func(t *testing.T) {
+		assert.Nil(t, w.AddGuildRank(b, "Alt"), "only the guild master can change ranks")
+
+		r := w.AddGuildRank(a, "Alt")
+		if assert.NotNil(t, r) {
+			assert.Equal(t, uint8(5), r.Rank)
+		}
+
+		r = w.SetGuildRank(a, 0, "Boss", 0, 0)
+		if assert.NotNil(t, r) {
+			assert.Equal(t, "Boss", r.Name)
+			assert.Equal(t, model.GuildRightsAll, r.Rights, "guild master always has every right")
+		}
+
+		assert.NotNil(t, w.DeleteGuildRank(a))
+		assert.Nil(t, w.DeleteGuildRank(a), "guild needs at least 5 ranks")
+		assert.Len(t, g.Ranks, MinGuildRanks)
+	})
+}
+
+func TestGuildLeave(t *testing.T) {
+	w := New(nil)
+	a, _ := newTestPlayer(1, 0, 0)
+	b, _ := newTestPlayer(2, 1000, 1000)
+	c, _ := newTestPlayer(3, 2000, 2000)
+	a.Character.Name = "Alice"
+	b.Character.Name = "Bob"
+	c.Character.Name = "Carol"
+	g := newTestGuild(w, a, b, c)
+
+	assert.Nil(t, w.LeaveGuild(a), "guild master can't leave")
+
+	assert.NotNil(t, w.LeaveGuild(b))
+	assert.Nil(t, b.Guild())
+	assert.Equal(t, uint32(0), b.Values.GuildID())
+	assert.Nil(t, g.Member(b.GUID()))
+
+	assert.Nil(t, w.RemoveFromGuild(c, "alice"))
+	assert.NotNil(t, w.RemoveFromGuild(a, "carol"))
+	assert.Nil(t, c.Guild())
+
+	t.Run("disband", func(t *testing.T) {
+		assert.Same(t, g, w.DisbandGuild(a))
+		assert.Nil(t, a.Guild())
+		assert.Nil(t, w.Guild(g.Id))
+	})
+}
+
+func TestGuildLeader(t *testing.T) {
+	w := New(nil)
+	a, _ := newTestPlayer(1, 0, 0)
+	b, _ := newTestPlayer(2, 1000, 1000)
+	a.Character.Name = "Alice"
+	b.Character.Name = "Bob"
+	g := newTestGuild(w, a, b)
+
+	assert.Nil(t, w.SetGuildLeader(b, "alice"))
+	assert.Len(t, w.SetGuildLeader(a, "bob"), 2)
+	assert.Equal(t, b.Character.Id, g.LeaderId)
+	assert.Equal(t, uint8(0), g.Member(b.GUID()).Rank)
+	assert.Equal(t, uint8(1), g.Member(a.GUID()).Rank)
+}
+
+func TestGuildChat(t *testing.T) {
+	w := New(nil)
+	a, _ := newTestPlayer(1, 0, 0)
+	b, bConn := newTestPlayer(2, 1000, 1000)
+	a.Character.Name = "Alice"
+	b.Character.Name = "Bob"
+	newTestGuild(w, a, b)
+
+	writes := bConn.writes
+	w.GuildChat(a, false, 0, "hello")
+	assert.Equal(t, writes+1, bConn.writes)
+	assert.Equal(t, realmd.OpServerMessageChat, realmd.ServerOpcode(bConn.last[2]))
+
+	writes = bConn.writes
+	w.GuildChat(a, true, 0, "officers only")
+	assert.Equal(t, writes, bConn.writes, "members can't hear officer chat")
+}
+
+func TestGuildSignOff(t *testing.T) {
+	w := New(nil)
+	a, _ := newTestPlayer(1, 0, 0)
+	b, _ := newTestPlayer(2, 1000, 1000)
+	a.Character.Name = "Alice"
+	b.Character.Name = "Bob"
+	g := newTestGuild(w, a, b)
+
+	w.RemovePlayer(b)
+	m := g.Member(b.GUID())
+	assert.False(t, m.Online())
+	assert.False(t, m.LastSeen.IsZero())
+
+	w.GuildSignOn(b, g)
+	assert.True(t, m.Online())
+}
@@ -0,0 +1,981 @@
+package world
+
+import (
+	"bytes"
+	"encoding/binary"
+
+	"github.com/kangaroux/gomaggus/model"
+	"github.com/kangaroux/gomaggus/realmd"
+	"github.com/kangaroux/gomaggus/realmd/values"
+)
+
+// InventorySlotBag0 is the bag id the client uses for the player's own slots. Any other bag id is the
+// slot that a bag is equipped in.
+const InventorySlotBag0 = 255
+
+// The player's own slots, in the order they appear in the player's values.
+const (
+	EquipmentSlotStart = 0
+	EquipmentSlotEnd   = 19
+	BagSlotStart       = 19
+	BagSlotEnd         = 23
+	BackpackSlotStart  = 23
+	BackpackSlotEnd    = 39
+	BankSlotStart      = 39
+	BankSlotEnd        = 67
+	BankBagSlotStart   = 67
+	BankBagSlotEnd     = 74
+	BuybackSlotStart   = 74
+	BuybackSlotEnd     = 86
+	KeyringSlotStart   = 86
+	KeyringSlotEnd     = 118
+)
+
+// https://gtker.com/wow_messages/docs/equipmentslot.html
+const (
+	EquipmentSlotHead      = 0
+	EquipmentSlotNeck      = 1
+	EquipmentSlotShoulders = 2
+	EquipmentSlotBody      = 3
+	EquipmentSlotChest     = 4
+	EquipmentSlotWaist     = 5
+	EquipmentSlotLegs      = 6
+	EquipmentSlotFeet      = 7
+	EquipmentSlotWrists    = 8
+	EquipmentSlotHands     = 9
+	EquipmentSlotFinger1   = 10
+	EquipmentSlotFinger2   = 11
+	EquipmentSlotTrinket1  = 12
+	EquipmentSlotTrinket2  = 13
+	EquipmentSlotBack      = 14
+	EquipmentSlotMainHand  = 15
+	EquipmentSlotOffHand   = 16
+	EquipmentSlotRanged    = 17
+	EquipmentSlotTabard    = 18
+)
+
+// https://gtker.com/wow_messages/docs/inventoryresult.html
+type InventoryResult uint8
+
+const (
+	InventoryResultOK                        InventoryResult = 0
+	InventoryResultCantEquipLevel            InventoryResult = 1
+	InventoryResultCantEquipSkill            InventoryResult = 2
+	InventoryResultItemDoesntGoToSlot        InventoryResult = 3
+	InventoryResultBagFull                   InventoryResult = 4
+	InventoryResultNonEmptyBagOverOtherBag   InventoryResult = 5
+	InventoryResultCantTradeEquipBags        InventoryResult = 6
+	InventoryResultOnlyAmmoCanGoHere         InventoryResult = 7
+	InventoryResultNoRequiredProficiency     InventoryResult = 8
+	InventoryResultNoEquipmentSlotAvailable  InventoryResult = 9
+	InventoryResultYouCanNeverUseThatItem    InventoryResult = 10
+	InventoryResultCantEquipWithTwoHanded    InventoryResult = 13
+	InventoryResultCantDualWield             InventoryResult = 14
+	InventoryResultItemDoesntGoIntoBag       InventoryResult = 15
+	InventoryResultCantCarryMoreOfThis       InventoryResult = 17
+	InventoryResultItemCantStack             InventoryResult = 19
+	InventoryResultItemCantBeEquipped        InventoryResult = 20
+	InventoryResultItemsCantBeSwapped        InventoryResult = 21
+	InventoryResultSlotIsEmpty               InventoryResult = 22
+	InventoryResultItemNotFound              InventoryResult = 23
+	InventoryResultCantDropSoulbound         InventoryResult = 24
+	InventoryResultTriedToSplitMoreThanCount InventoryResult = 26
+	InventoryResultCouldntSplitItems         InventoryResult = 27
+	InventoryResultNotABag                   InventoryResult = 30
+	InventoryResultCanOnlyDoWithEmptyBags    InventoryResult = 31
+	InventoryResultTooFarAwayFromBank        InventoryResult = 35
+)
+
+// ItemChanges are the items that were changed by moving items around. Updated items should be saved
+// and removed items should be deleted.
+type ItemChanges struct {
+	Updated []*Item
+	Removed []*Item
+}
+
+func (c *ItemChanges) update(items ...*Item) {
+	for _, item := range items {
+		if item != nil {
+			c.Updated = append(c.Updated, item)
+		}
+	}
+}
+
+// equipSlots maps an inventory type to the equipment slots it can be equipped in. Bags aren't included
+// since they go in the bag slots instead.
+var equipSlots = map[model.InventoryType][]uint8{
+	model.InventoryTypeHead:           {EquipmentSlotHead},
+	model.InventoryTypeNeck:           {EquipmentSlotNeck},
+	model.InventoryTypeShoulders:      {EquipmentSlotShoulders},
+	model.InventoryTypeBody:           {EquipmentSlotBody},
+	model.InventoryTypeChest:          {EquipmentSlotChest},
+	model.InventoryTypeRobe:           {EquipmentSlotChest},
+	model.InventoryTypeWaist:          {EquipmentSlotWaist},
+	model.InventoryTypeLegs:           {EquipmentSlotLegs},
+	model.InventoryTypeFeet:           {EquipmentSlotFeet},
+	model.InventoryTypeWrists:         {EquipmentSlotWrists},
+	model.InventoryTypeHands:          {EquipmentSlotHands},
+	model.InventoryTypeFinger:         {EquipmentSlotFinger1, EquipmentSlotFinger2},
+	model.InventoryTypeTrinket:        {EquipmentSlotTrinket1, EquipmentSlotTrinket2},
+	model.InventoryTypeCloak:          {EquipmentSlotBack},
+	model.InventoryTypeWeapon:         {EquipmentSlotMainHand, EquipmentSlotOffHand},
+	model.InventoryTypeShield:         {EquipmentSlotOffHand},
+	model.InventoryTypeWeaponMainHand: {EquipmentSlotMainHand},
+	model.InventoryTypeWeaponOffHand:  {EquipmentSlotOffHand},
+	model.InventoryTypeHoldable:       {EquipmentSlotOffHand},
+	model.InventoryTypeTwoHandWeapon:  {EquipmentSlotMainHand},
+	model.InventoryTypeRanged:         {EquipmentSlotRanged},
+	model.InventoryTypeThrown:         {EquipmentSlotRanged},
+	model.InventoryTypeRangedRight:    {EquipmentSlotRanged},
+	model.InventoryTypeRelic:          {EquipmentSlotRanged},
+	model.InventoryTypeTabard:         {EquipmentSlotTabard},
+}
+
+// Item returns the item in the slot, or nil if the slot is empty or doesn't exist. bag is either
+// InventorySlotBag0 or the slot of an equipped bag.
+func (p *Player) Item(bag, slot uint8) *Item {
+	if bag == InventorySlotBag0 {
+		if slot >= KeyringSlotEnd {
+			return nil
+		}
+		return p.items[slot]
+	}
+
+	b := p.bag(bag)
+	if b == nil || slot >= b.BagSize() {
+		return nil
+	}
+	return b.slots[slot]
+}
+
+// ItemByGUID returns the item with the guid, or nil if the player doesn't have it.
+func (p *Player) ItemByGUID(guid realmd.Guid) *Item {
+	for _, item := range p.Items() {
+		if item.GUID() == guid {
+			return item
+		}
+	}
+	return nil
+}
+
+// Items returns all of the player's items. Bags come before the items inside of them.
+func (p *Player) Items() []*Item {
+	var result []*Item
+	for _, item := range p.items {
+		if item == nil {
+			continue
+		}
+		result = append(result, item)
+		for _, inside := range item.slots {
+			if inside != nil {
+				result = append(result, inside)
+			}
+		}
+	}
+	return result
+}
+
+// CountItem returns how many of the item the player has.
+func (p *Player) CountItem(entry uint32) uint32 {
+	var count uint32
+	for _, item := range p.Items() {
+		if item.TemplateId == entry {
+			count += item.Count
+		}
+	}
+	return count
+}
+
+// SetInventory puts the items in the slots they were saved in. Items that don't fit in their slot, such
+// as items in a bag that doesn't exist, are returned.
+func (p *Player) SetInventory(items []*Item) []*Item {
+	var invalid, inBags []*Item
+
+	for _, item := range items {
+		if item.ContainerId != 0 {
+			inBags = append(inBags, item)
+		} else if item.Slot >= KeyringSlotEnd || p.items[item.Slot] != nil {
+			invalid = append(invalid, item)
+		} else {
+			p.setItem(InventorySlotBag0, item.Slot, item)
+		}
+	}
+
+	for _, item := range inBags {
+		bag, _ := p.locate(item)
+		if bag == 0 || p.Item(bag, item.Slot) != nil || item.Slot >= p.bag(bag).BagSize() {
+			invalid = append(invalid, item)
+		} else {
+			p.setItem(bag, item.Slot, item)
+		}
+	}
+
+	return invalid
+}
+
+// bag returns the bag equipped in the slot, or nil if there isn't one.
+func (p *Player) bag(slot uint8) *Item {
+	if !isBagSlot(slot) {
+		return nil
+	}
+
+	b := p.items[slot]
+	if b == nil || !b.IsBag() {
+		return nil
+	}
+	return b
+}
+
+func isBagSlot(slot uint8) bool {
+	return (slot >= BagSlotStart && slot < BagSlotEnd) || (slot >= BankBagSlotStart && slot < BankBagSlotEnd)
+}
+
+// locate returns the bag and slot that the item should be in based on its ContainerId and Slot. The bag
+// is 0 if the item is in a bag that the player doesn't have.
+func (p *Player) locate(item *Item) (uint8, uint8) {
+	if item.ContainerId == 0 {
+		return InventorySlotBag0, item.Slot
+	}
+
+	for slot := uint8(BagSlotStart); slot < BankBagSlotEnd; slot++ {
+		if b := p.bag(slot); b != nil && b.Id == item.ContainerId {
+			return slot, item.Slot
+		}
+	}
+
+	return 0, 0
+}
+
+// setItem puts the item in the slot, or empties the slot if item is nil.
+func (p *Player) setItem(bag, slot uint8, item *Item) {
+	var guid realmd.Guid
+	if item != nil {
+		guid = item.GUID()
+	}
+
+	if bag == InventorySlotBag0 {
+		p.items[slot] = item
+		p.setSlotValue(slot, guid)
+
+		if slot < EquipmentSlotEnd {
+			p.setVisibleItem(slot, item)
+		}
+		if slot == EquipmentSlotMainHand || slot == EquipmentSlotOffHand {
+			p.updateCombatStats()
+		}
+
+		if item != nil {
+			item.ContainerId = 0
+			item.Slot = slot
+			item.Values.SetContained(p.GUID())
+		}
+		return
+	}
+
+	b := p.bag(bag)
+	b.slots[slot] = item
+	slots := b.Values.Slots()
+	slots[slot] = guid
+	b.Values.SetSlots(slots)
+
+	if item != nil {
+		item.ContainerId = b.Id
+		item.Slot = slot
+		item.Values.SetContained(b.GUID())
+	}
+}
+
+// setSlotValue updates the guid in the player's values for one of their own slots.
+func (p *Player) setSlotValue(slot uint8, guid realmd.Guid) {
+	v := p.Values
+
+	switch {
+	case slot < BackpackSlotStart:
+		slots := v.InventorySlots()
+		slots[slot] = uint64(guid)
+		v.SetInventorySlots(slots)
+	case slot < BankSlotStart:
+		slots := v.PackSlots()
+		slots[slot-BackpackSlotStart] = uint64(guid)
+		v.SetPackSlots(slots)
+	case slot < BankBagSlotStart:
+		slots := v.BankSlots()
+		slots[slot-BankSlotStart] = uint64(guid)
+		v.SetBankSlots(slots)
+	case slot < BuybackSlotStart:
+		slots := v.BankBagSlots()
+		slots[slot-BankBagSlotStart] = uint64(guid)
+		v.SetBankBagSlots(slots)
+	case slot < KeyringSlotStart:
+		slots := v.VendorBuybackSlots()
+		slots[slot-BuybackSlotStart] = uint64(guid)
+		v.SetVendorBuybackSlots(slots)
+	default:
+		slots := v.KeyringSlots()
+		slots[slot-KeyringSlotStart] = uint64(guid)
+		v.SetKeyringSlots(slots)
+	}
+}
+
+// setVisibleItem updates the equipment that other players see in the slot.
+func (p *Player) setVisibleItem(slot uint8, item *Item) {
+	visible := p.Values.VisibleItems()
+	visible[slot] = values.VisibleItem{}
+	if item != nil {
+		visible[slot].ID = item.TemplateId
+		visible[slot].Enchantment = item.Enchant
+	}
+	p.Values.SetVisibleItems(visible)
+}
+
+// EnchantItem changes the item's permanent enchantment, or removes it if enchant is 0. Players nearby see
+// the enchantment if the item is equipped. The item must be saved.
+func (w *World) EnchantItem(p *Player, item *Item, enchant uint32) {
+	item.Enchant = enchant
+	item.setEnchantValue()
+
+	if bag, slot := p.locate(item); bag == InventorySlotBag0 && slot < EquipmentSlotEnd && p.items[slot] == item {
+		p.setVisibleItem(slot, item)
+	}
+}
+
+// StartingItems creates the items in a new character's starting outfit. Equipment is equipped and everything
+// else goes in the backpack, in the order it appears in templates. Items that don't fit are left out.
+// Consumables come in a small stack.
+func StartingItems(ownerId uint32, templates []*model.ItemTemplate) []*model.Item {
+	var used [BackpackSlotEnd]bool
+	var items []*model.Item
+
+	for _, t := range templates {
+		slot, ok := startingSlot(t, &used)
+		if !ok {
+			continue
+		}
+		used[slot] = true
+
+		count := uint32(1)
+		if t.Class == model.ItemClassConsumable && t.Stackable > 1 {
+			count = startingConsumableCount
+			if count > t.Stackable {
+				count = t.Stackable
+			}
+		}
+
+		items = append(items, &model.Item{
+			OwnerId:    ownerId,
+			TemplateId: t.Id,
+			Slot:       slot,
+			Count:      count,
+			Durability: t.MaxDurability,
+		})
+	}
+
+	return items
+}
+
+// startingConsumableCount is how many of each consumable a new character starts with.
+const startingConsumableCount = 4
+
+// startingSlot returns the first free slot that a starting item can go in.
+func startingSlot(t *model.ItemTemplate, used *[BackpackSlotEnd]bool) (uint8, bool) {
+	var slots []uint8
+	if t.ContainerSlots > 0 {
+		slots = []uint8{BagSlotStart, BagSlotStart + 1, BagSlotStart + 2, BagSlotStart + 3}
+	} else {
+		slots = equipSlots[t.InventoryType]
+	}
+
+	for _, slot := range slots {
+		if !used[slot] {
+			return slot, true
+		}
+	}
+
+	for slot := uint8(BackpackSlotStart); slot < BackpackSlotEnd; slot++ {
+		if !used[slot] {
+			return slot, true
+		}
+	}
+
+	return 0, false
+}
+
+// canUse reports whether the player's race, class and level allow them to use the item.
+func (p *Player) canUse(item *Item) InventoryResult {
+	t := item.Template
+
+	if !inMask(t.AllowableClass, uint32(p.Character.Class)) || !inMask(t.AllowableRace, uint32(p.Character.Race)) {
+		return InventoryResultYouCanNeverUseThatItem
+	}
+
+	if t.RequiredLevel > p.Values.Level() {
+		return InventoryResultCantEquipLevel
+	}
+
+	return InventoryResultOK
+}
+
+// inMask reports whether a class or race is in an item's allowable mask. A mask of 0 or -1 allows
+// everyone.
+func inMask(mask int32, id uint32) bool {
+	return mask == 0 || uint32(mask)&(1<<(id-1)) != 0
+}
+
+// canDualWield reports whether the player can equip a one-handed weapon in their off hand.
+func (p *Player) canDualWield() bool {
+	switch p.Character.Class {
+	case model.ClassWarrior, model.ClassHunter, model.ClassRogue, model.ClassDeathKnight:
+		return true
+	}
+	return false
+}
+
+// canEquip reports whether the item can be equipped in the equipment slot.
+func (p *Player) canEquip(item *Item, slot uint8) InventoryResult {
+	t := item.Template
+
+	if t.InventoryType == model.InventoryTypeNonEquip {
+		return InventoryResultItemCantBeEquipped
+	}
+
+	found := false
+	for _, s := range equipSlots[t.InventoryType] {
+		if s == slot {
+			found = true
+			break
+		}
+	}
+	if !found {
+		return InventoryResultItemDoesntGoToSlot
+	}
+
+	if result := p.canUse(item); result != InventoryResultOK {
+		return result
+	}
+
+	switch slot {
+	case EquipmentSlotMainHand:
+		if offHand := p.items[EquipmentSlotOffHand]; offHand != nil && offHand != item &&
+			t.InventoryType == model.InventoryTypeTwoHandWeapon {
+			return InventoryResultCantEquipWithTwoHanded
+		}
+	case EquipmentSlotOffHand:
+		if mainHand := p.items[EquipmentSlotMainHand]; mainHand != nil && mainHand != item &&
+			mainHand.Template.InventoryType == model.InventoryTypeTwoHandWeapon {
+			return InventoryResultCantEquipWithTwoHanded
+		}
+		if t.InventoryType == model.InventoryTypeWeapon && !p.canDualWield() {
+			return InventoryResultCantDualWield
+		}
+	}
+
+	return InventoryResultOK
+}
+
+// canStore reports whether the item can be put in the slot. Whatever is already in the slot is ignored.
+func (p *Player) canStore(item *Item, bag, slot uint8) InventoryResult {
+	if bag != InventorySlotBag0 {
+		if isBankSlot(bag) {
+			return InventoryResultTooFarAwayFromBank
+		}
+
+		b := p.bag(bag)
+		if b == nil {
+			return InventoryResultItemNotFound
+		} else if b == item {
+			return InventoryResultItemDoesntGoIntoBag
+		} else if slot >= b.BagSize() {
+			return InventoryResultItemDoesntGoToSlot
+		} else if item.IsBag() && !item.Empty() {
+			return InventoryResultNonEmptyBagOverOtherBag
+		} else if family := b.Template.BagFamily; family != 0 && item.Template.BagFamily&family == 0 {
+			return InventoryResultItemDoesntGoIntoBag
+		}
+
+		return InventoryResultOK
+	}
+
+	switch {
+	case slot < EquipmentSlotEnd:
+		return p.canEquip(item, slot)
+
+	case slot < BagSlotEnd:
+		if !item.IsBag() {
+			return InventoryResultNotABag
+		}
+		return p.canUse(item)
+
+	case slot < BackpackSlotEnd:
+		if item.IsBag() && !item.Empty() {
+			return InventoryResultCanOnlyDoWithEmptyBags
+		}
+		return InventoryResultOK
+
+	case isBankSlot(slot):
+		return InventoryResultTooFarAwayFromBank
+
+	case slot >= KeyringSlotStart && slot < KeyringSlotEnd:
+		if item.Template.Class != model.ItemClassKey {
+			return InventoryResultItemDoesntGoIntoBag
+		}
+		return InventoryResultOK
+	}
+
+	return InventoryResultItemDoesntGoToSlot
+}
+
+// isBankSlot reports whether the slot is a bank slot, which can only be used at a banker.
+// TODO: allow using the bank once there are bankers
+func isBankSlot(slot uint8) bool {
+	return slot >= BankSlotStart && slot < BuybackSlotStart
+}
+
+// freeSlots returns the empty slots that an item can be stored in: the keyring for keys, then the
+// backpack, then the equipped bags.
+func (p *Player) freeSlots(item *Item) [][2]uint8 {
+	var result [][2]uint8
+
+	if item.Template.Class == model.ItemClassKey {
+		for slot := uint8(KeyringSlotStart); slot < KeyringSlotEnd; slot++ {
+			if p.items[slot] == nil {
+				result = append(result, [2]uint8{InventorySlotBag0, slot})
+			}
+		}
+	}
+
+	for slot := uint8(BackpackSlotStart); slot < BackpackSlotEnd; slot++ {
+		if p.items[slot] == nil {
+			result = append(result, [2]uint8{InventorySlotBag0, slot})
+		}
+	}
+
+	for bag := uint8(BagSlotStart); bag < BagSlotEnd; bag++ {
+		b := p.bag(bag)
+		if b == nil {
+			continue
+		}
+		for slot := uint8(0); slot < b.BagSize(); slot++ {
+			if b.slots[slot] == nil && p.canStore(item, bag, slot) == InventoryResultOK {
+				result = append(result, [2]uint8{bag, slot})
+			}
+		}
+	}
+
+	return result
+}
+
+// stacks returns the stacks of the item the player has in their bags that aren't full.
+func (p *Player) stacks(entry uint32) []*Item {
+	var result []*Item
+	for _, item := range p.Items() {
+		bag, slot := p.locate(item)
+		if bag == InventorySlotBag0 && (slot < BackpackSlotStart || isBankSlot(slot)) {
+			continue
+		}
+		if item.TemplateId == entry && item.Count < item.MaxStack() {
+			result = append(result, item)
+		}
+	}
+	return result
+}
+
+// SwapItems moves the item in the source slot to the destination slot. If the destination has an item,
+// it's either moved to the source slot, or the source item is added to it if they stack.
+func (w *World) SwapItems(p *Player, srcBag, srcSlot, dstBag, dstSlot uint8) *ItemChanges {
+	src := p.Item(srcBag, srcSlot)
+	dst := p.Item(dstBag, dstSlot)
+
+	if src == nil {
+		p.sendInventoryResult(InventoryResultSlotIsEmpty, nil, nil)
+		return nil
+	} else if src == dst {
+		return nil
+	}
+
+	// Merge the stacks
+	if dst != nil && dst.TemplateId == src.TemplateId && dst.Count < dst.MaxStack() {
+		changes := &ItemChanges{}
+		moved := dst.MaxStack() - dst.Count
+		if moved > src.Count {
+			moved = src.Count
+		}
+
+		dst.SetCount(dst.Count + moved)
+		changes.update(dst)
+
+		if moved == src.Count {
+			w.RemoveItem(p, src)
+			changes.Removed = append(changes.Removed, src)
+		} else {
+			src.SetCount(src.Count - moved)
+			changes.update(src)
+		}
+
+		return changes
+	}
+
+	if result := p.canStore(src, dstBag, dstSlot); result != InventoryResultOK {
+		p.sendInventoryResult(result, src, dst)
+		return nil
+	}
+
+	if dst != nil {
+		if result := p.canStore(dst, srcBag, srcSlot); result != InventoryResultOK {
+			p.sendInventoryResult(result, src, dst)
+			return nil
+		}
+	}
+
+	p.setItem(srcBag, srcSlot, dst)
+	p.setItem(dstBag, dstSlot, src)
+
+	changes := &ItemChanges{}
+	changes.update(src, dst)
+	return changes
+}
+
+// AutoEquipItem equips the item in the slot. Anything that was already equipped is moved to where the
+// item was.
+func (w *World) AutoEquipItem(p *Player, bag, slot uint8) *ItemChanges {
+	item := p.Item(bag, slot)
+	if item == nil {
+		p.sendInventoryResult(InventoryResultItemNotFound, nil, nil)
+		return nil
+	}
+
+	dst, result := p.equipSlot(item)
+	if result != InventoryResultOK {
+		p.sendInventoryResult(result, item, nil)
+		return nil
+	}
+
+	return w.SwapItems(p, bag, slot, InventorySlotBag0, dst)
+}
+
+// equipSlot returns the slot the item should be equipped in. Empty slots are used first.
+func (p *Player) equipSlot(item *Item) (uint8, InventoryResult) {
+	if item.IsBag() {
+		for slot := uint8(BagSlotStart); slot < BagSlotEnd; slot++ {
+			if p.items[slot] == nil {
+				return slot, p.canUse(item)
+			}
+		}
+		return 0, InventoryResultNoEquipmentSlotAvailable
+	}
+
+	slots := equipSlots[item.Template.InventoryType]
+	if len(slots) == 0 {
+		return 0, InventoryResultItemCantBeEquipped
+	}
+
+	var result InventoryResult
+	for _, slot := range slots {
+		if p.items[slot] == nil {
+			if result = p.canEquip(item, slot); result == InventoryResultOK {
+				return slot, result
+			}
+		}
+	}
+
+	if result = p.canEquip(item, slots[0]); result != InventoryResultOK {
+		return 0, result
+	}
+	return slots[0], result
+}
+
+// AutoStoreItem moves the item in the source slot to the first free slot in the bag.
+func (w *World) AutoStoreItem(p *Player, srcBag, srcSlot, dstBag uint8) *ItemChanges {
+	item := p.Item(srcBag, srcSlot)
+	if item == nil {
+		p.sendInventoryResult(InventoryResultItemNotFound, nil, nil)
+		return nil
+	}
+
+	var slots []uint8
+	if dstBag == InventorySlotBag0 {
+		for slot := uint8(BackpackSlotStart); slot < BackpackSlotEnd; slot++ {
+			slots = append(slots, slot)
+		}
+	} else if b := p.bag(dstBag); b != nil {
+		for slot := uint8(0); slot < b.BagSize(); slot++ {
+			slots = append(slots, slot)
+		}
+	}
+
+	result := InventoryResultBagFull
+	for _, slot := range slots {
+		if p.Item(dstBag, slot) != nil {
+			continue
+		}
+		if result = p.canStore(item, dstBag, slot); result == InventoryResultOK {
+			return w.SwapItems(p, srcBag, srcSlot, dstBag, slot)
+		}
+	}
+
+	p.sendInventoryResult(result, item, nil)
+	return nil
+}
+
+// SplitItem moves count of the item in the source slot to the destination slot. If the destination
+// is empty, the new stack is returned. It must be saved and added with PlaceItem.
+func (w *World) SplitItem(p *Player, srcBag, srcSlot, dstBag, dstSlot uint8, count uint32) (*ItemChanges, *Item) {
+	src := p.Item(srcBag, srcSlot)
+	dst := p.Item(dstBag, dstSlot)
+
+	if src == nil {
+		p.sendInventoryResult(InventoryResultItemNotFound, nil, nil)
+		return nil, nil
+	} else if count == 0 || src == dst {
+		p.sendInventoryResult(InventoryResultCouldntSplitItems, src, nil)
+		return nil, nil
+	} else if count >= src.Count {
+		p.sendInventoryResult(InventoryResultTriedToSplitMoreThanCount, src, nil)
+		return nil, nil
+	}
+
+	if dst != nil {
+		if dst.TemplateId != src.TemplateId {
+			p.sendInventoryResult(InventoryResultCouldntSplitItems, src, dst)
+			return nil, nil
+		} else if dst.Count+count > dst.MaxStack() {
+			p.sendInventoryResult(InventoryResultItemCantStack, src, dst)
+			return nil, nil
+		}
+
+		src.SetCount(src.Count - count)
+		dst.SetCount(dst.Count + count)
+
+		changes := &ItemChanges{}
+		changes.update(src, dst)
+		return changes, nil
+	}
+
+	split := NewItem(&model.Item{
+		OwnerId:    p.Character.Id,
+		TemplateId: src.TemplateId,
+		Count:      count,
+		Flags:      src.Flags,
+		Durability: src.Durability,
+	}, src.Template, p.GUID())
+
+	if result := p.canStore(split, dstBag, dstSlot); result != InventoryResultOK {
+		p.sendInventoryResult(result, src, nil)
+		return nil, nil
+	}
+
+	// The item's location is kept on the model until it's placed
+	bag := p.bag(dstBag)
+	if bag != nil {
+		split.ContainerId = bag.Id
+	}
+	split.Slot = dstSlot
+
+	src.SetCount(src.Count - count)
+
+	changes := &ItemChanges{}
+	changes.update(src)
+	return changes, split
+}
+
+// DestroyItem destroys count of the item in the slot, or the whole stack if count is 0. Bags must be
+// empty to be destroyed.
+func (w *World) DestroyItem(p *Player, bag, slot uint8, count uint32) *ItemChanges {
+	item := p.Item(bag, slot)
+	if item == nil {
+		p.sendInventoryResult(InventoryResultItemNotFound, nil, nil)
+		return nil
+	} else if !item.Empty() {
+		p.sendInventoryResult(InventoryResultCanOnlyDoWithEmptyBags, item, nil)
+		return nil
+	}
+
+	changes := &ItemChanges{}
+
+	if count > 0 && count < item.Count {
+		item.SetCount(item.Count - count)
+		changes.update(item)
+	} else {
+		w.RemoveItem(p, item)
+		changes.Removed = append(changes.Removed, item)
+	}
+
+	return changes
+}
+
+// StoreNewItem finds room in the player's bags for count of the item. Stacks the player already has are
+// filled first. Any new stacks that are needed are returned, they must be saved and added with PlaceItem.
+// Nothing is changed if there isn't enough room.
+func (w *World) StoreNewItem(p *Player, t *model.ItemTemplate, count uint32) (*ItemChanges, []*Item, InventoryResult) {
+	if count == 0 {
+		return &ItemChanges{}, nil, InventoryResultOK
+	}
+
+	if t.MaxCount > 0 && p.CountItem(t.Id)+count > t.MaxCount {
+		return nil, nil, InventoryResultCantCarryMoreOfThis
+	}
+
+	newItem := func(n uint32) *Item {
+		return NewItem(&model.Item{
+			OwnerId:    p.Character.Id,
+			TemplateId: t.Id,
+			Count:      n,
+			Durability: t.MaxDurability,
+		}, t, p.GUID())
+	}
+
+	// Figure out where everything goes before changing anything
+	remaining := count
+	stacks := p.stacks(t.Id)
+	fill := make([]uint32, len(stacks))
+	for i, stack := range stacks {
+		if remaining == 0 {
+			break
+		}
+		fill[i] = stack.MaxStack() - stack.Count
+		if fill[i] > remaining {
+			fill[i] = remaining
+		}
+		remaining -= fill[i]
+	}
+
+	var created []*Item
+	if remaining > 0 {
+		probe := newItem(remaining)
+		for _, free := range p.freeSlots(probe) {
+			if remaining == 0 {
+				break
+			}
+
+			n := probe.MaxStack()
+			if n > remaining {
+				n = remaining
+			}
+			remaining -= n
+
+			item := newItem(n)
+			if b := p.bag(free[0]); b != nil {
+				item.ContainerId = b.Id
+			}
+			item.Slot = free[1]
+			created = append(created, item)
+		}
+	}
+
+	if remaining > 0 {
+		return nil, nil, InventoryResultBagFull
+	}
+
+	changes := &ItemChanges{}
+	for i, stack := range stacks {
+		if fill[i] > 0 {
+			stack.SetCount(stack.Count + fill[i])
+			changes.update(stack)
+		}
+	}
+
+	return changes, created, InventoryResultOK
+}
+
+// StoreItem finds an empty slot in the player's bags for an item they didn't have, like an item taken from a
+// mail. The item's owner, ContainerId and Slot are changed, and it must be saved and added with PlaceItem.
+// Nothing is changed if there isn't room.
+func (w *World) StoreItem(p *Player, item *Item) InventoryResult {
+	if t := item.Template; t.MaxCount > 0 && p.CountItem(t.Id)+item.Count > t.MaxCount {
+		return InventoryResultCantCarryMoreOfThis
+	}
+
+	free := p.freeSlots(item)
+	if len(free) == 0 {
+		return InventoryResultBagFull
+	}
+
+	item.OwnerId = p.Character.Id
+	item.ContainerId = 0
+	if b := p.bag(free[0][0]); b != nil {
+		item.ContainerId = b.Id
+	}
+	item.Slot = free[0][1]
+
+	return InventoryResultOK
+}
+
+// PlaceItem puts a new item in the slot given by its ContainerId and Slot and creates it for the player.
+// The item must be saved first so it has an id.
+func (w *World) PlaceItem(p *Player, item *Item) {
+	item.Values.SetGUID(item.GUID())
+
+	bag, slot := p.locate(item)
+	if bag == 0 {
+		p.Client.Log.Error().Uint32("item", item.Id).Msg("tried to place item in a missing bag")
+		return
+	}
+
+	p.setItem(bag, slot, item)
+
+	u := values.UpdateBuilder{}
+	item.WriteCreate(&u)
+	if err := p.SendUpdate(&u); err != nil {
+		p.Client.Log.Error().Err(err).Msg("error sending item create")
+	}
+}
+
+// RemoveItem takes the item out of the player's inventory and destroys it for the player. The item
+// should be deleted or given to someone else.
+func (w *World) RemoveItem(p *Player, item *Item) {
+	bag, slot := p.locate(item)
+	if bag == 0 || p.Item(bag, slot) != item {
+		return
+	}
+
+	p.setItem(bag, slot, nil)
+
+	u := values.UpdateBuilder{}
+	u.OutOfRange(item.GUID())
+	if err := p.SendUpdate(&u); err != nil {
+		p.Client.Log.Error().Err(err).Msg("error sending item destroy")
+	}
+}
+
+// writeItems adds a block to u for each of the player's items, which creates them for the player.
+func (p *Player) writeItems(u *values.UpdateBuilder) {
+	for _, item := range p.Items() {
+		item.WriteCreate(u)
+	}
+}
+
+// flushItems sends the player any of their items' values that changed.
+func (p *Player) flushItems() {
+	u := values.UpdateBuilder{}
+	for _, item := range p.Items() {
+		item.WriteValues(&u)
+	}
+
+	if err := p.SendUpdate(&u); err != nil {
+		p.Client.Log.Error().Err(err).Msg("error sending item values")
+	}
+}
+
+// https://gtker.com/wow_messages/docs/smsg_inventory_change_failure.html#client-version-335
+func (p *Player) sendInventoryResult(result InventoryResult, item1, item2 *Item) {
+	buf := bytes.Buffer{}
+	buf.WriteByte(uint8(result))
+
+	if result != InventoryResultOK {
+		var guid1, guid2 realmd.Guid
+		if item1 != nil {
+			guid1 = item1.GUID()
+		}
+		if item2 != nil {
+			guid2 = item2.GUID()
+		}
+		binary.Write(&buf, binary.LittleEndian, guid1)
+		binary.Write(&buf, binary.LittleEndian, guid2)
+		buf.WriteByte(0) // Bag type subclass
+
+		if result == InventoryResultCantEquipLevel && item1 != nil {
+			binary.Write(&buf, binary.LittleEndian, item1.Template.RequiredLevel)
+		}
+	}
+
+	p.sendPacket(realmd.OpServerInventoryChangeFail, buf.Bytes())
+}
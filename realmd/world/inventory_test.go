@@ -0,0 +1,287 @@
+package world
+
+import (
+	"testing"
+
+	"github.com/kangaroux/gomaggus/model"
+	"github.com/kangaroux/gomaggus/realmd"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	testSword = &model.ItemTemplate{Id: 25, Name: "Worn Shortsword", Class: model.ItemClassWeapon,
+		InventoryType: model.InventoryTypeWeaponMainHand, AllowableClass: -1, AllowableRace: -1, Stackable: 1}
+	testStaff = &model.ItemTemplate{Id: 35, Name: "Bent Staff", Class: model.ItemClassWeapon,
+		InventoryType: model.InventoryTypeTwoHandWeapon, AllowableClass: -1, AllowableRace: -1, Stackable: 1}
+	testShield = &model.ItemTemplate{Id: 2362, Name: "Worn Wooden Shield", Class: model.ItemClassArmor,
+		InventoryType: model.InventoryTypeShield, AllowableClass: -1, AllowableRace: -1, Stackable: 1}
+	testBread = &model.ItemTemplate{Id: 4540, Name: "Tough Hunk of Bread", Class: model.ItemClassConsumable,
+		AllowableClass: -1, AllowableRace: -1, Stackable: 20}
+	testBag = &model.ItemTemplate{Id: 4496, Name: "Small Brown Pouch", Class: model.ItemClassContainer,
+		InventoryType: model.InventoryTypeBag, AllowableClass: -1, AllowableRace: -1, Stackable: 1,
+		ContainerSlots: 6}
+)
+
+// newTestItem creates an item in the player's slot. Items are given ids in the order they're created.
+func newTestItem(p *Player, t *model.ItemTemplate, bag, slot uint8, count uint32) *Item {
+	item := NewItem(&model.Item{
+		Id:         uint32(len(p.Items()) + 1),
+		OwnerId:    p.Character.Id,
+		TemplateId: t.Id,
+		Count:      count,
+	}, t, p.GUID())
+	p.setItem(bag, slot, item)
+	return item
+}
+
+func inventoryResult(conn *testConn) InventoryResult {
+	if realmd.ServerOpcode(uint16(conn.last[2])|uint16(conn.last[3])<<8) != realmd.OpServerInventoryChangeFail {
+		return 0xFF
+	}
+	return InventoryResult(conn.last[4])
+}
+
+func TestInventoryEquip(t *testing.T) {
+	w := New(nil)
+	p, conn := newTestPlayer(1, 0, 0)
+
+	sword := newTestItem(p, testSword, InventorySlotBag0, BackpackSlotStart, 1)
+	staff := newTestItem(p, testStaff, InventorySlotBag0, BackpackSlotStart+1, 1)
+	shield := newTestItem(p, testShield, InventorySlotBag0, BackpackSlotStart+2, 1)
+
+	t.Run("wrong slot", func(t *testing.T) {
+		assert.Nil(t, w.SwapItems(p, InventorySlotBag0, BackpackSlotStart, InventorySlotBag0, EquipmentSlotHead))
+		assert.Equal(t, InventoryResultItemDoesntGoToSlot, inventoryResult(conn))
+	})
+
+	t.Run("auto equip", func(t *testing.T) {
+		changes := w.AutoEquipItem(p, InventorySlotBag0, BackpackSlotStart)
+		if assert.NotNil(t, changes) {
+			assert.Len(t, changes.Updated, 1)
+		}
+		assert.Same(t, sword, p.Item(InventorySlotBag0, EquipmentSlotMainHand))
+		assert.Nil(t, p.Item(InventorySlotBag0, BackpackSlotStart))
+		assert.Equal(t, uint64(sword.GUID()), p.Values.InventorySlots()[EquipmentSlotMainHand])
+		assert.Equal(t, uint8(EquipmentSlotMainHand), sword.Slot)
+	})
+
+	t.Run("swap equipped", func(t *testing.T) {
+		assert.NotNil(t, w.AutoEquipItem(p, InventorySlotBag0, BackpackSlotStart+1))
+		assert.Same(t, staff, p.Item(InventorySlotBag0, EquipmentSlotMainHand))
+		assert.Same(t, sword, p.Item(InventorySlotBag0, BackpackSlotStart+1))
+	})
+
+	t.Run("two handed", func(t *testing.T) {
+		assert.Nil(t, w.AutoEquipItem(p, InventorySlotBag0, BackpackSlotStart+2))
+		assert.Equal(t, InventoryResultCantEquipWithTwoHanded, inventoryResult(conn))
+		assert.Same(t, shield, p.Item(InventorySlotBag0, BackpackSlotStart+2))
+	})
+
+	t.Run("level", func(t *testing.T) {
+		helm := newTestItem(p, &model.ItemTemplate{Id: 1, InventoryType: model.InventoryTypeHead,
+			RequiredLevel: 80, Stackable: 1}, InventorySlotBag0, BackpackSlotStart+3, 1)
+		p.Values.SetLevel(1)
+
+		assert.Nil(t, w.AutoEquipItem(p, InventorySlotBag0, BackpackSlotStart+3))
+		assert.Equal(t, InventoryResultCantEquipLevel, inventoryResult(conn))
+		assert.Same(t, helm, p.Item(InventorySlotBag0, BackpackSlotStart+3))
+	})
+
+	t.Run("class", func(t *testing.T) {
+		plate := newTestItem(p, &model.ItemTemplate{Id: 2, InventoryType: model.InventoryTypeChest,
+			AllowableClass: 1 << (model.ClassWarrior - 1), Stackable: 1}, InventorySlotBag0, BackpackSlotStart+4, 1)
+
+		assert.Nil(t, w.AutoEquipItem(p, InventorySlotBag0, BackpackSlotStart+4))
+		assert.Equal(t, InventoryResultYouCanNeverUseThatItem, inventoryResult(conn))
+		assert.Same(t, plate, p.Item(InventorySlotBag0, BackpackSlotStart+4))
+	})
+}
+
+func TestInventoryBags(t *testing.T) {
+	w := New(nil)
+	p, conn := newTestPlayer(1, 0, 0)
+
+	bag := newTestItem(p, testBag, InventorySlotBag0, BackpackSlotStart, 1)
+	bread := newTestItem(p, testBread, InventorySlotBag0, BackpackSlotStart+1, 5)
+
+	assert.Nil(t, w.SwapItems(p, InventorySlotBag0, BackpackSlotStart+1, InventorySlotBag0, BagSlotStart))
+	assert.Equal(t, InventoryResultNotABag, inventoryResult(conn))
+
+	assert.NotNil(t, w.AutoEquipItem(p, InventorySlotBag0, BackpackSlotStart))
+	assert.Same(t, bag, p.Item(InventorySlotBag0, BagSlotStart))
+
+	assert.NotNil(t, w.AutoStoreItem(p, InventorySlotBag0, BackpackSlotStart+1, BagSlotStart))
+	assert.Same(t, bread, p.Item(BagSlotStart, 0))
+	assert.Equal(t, bag.Id, bread.ContainerId)
+	assert.Equal(t, bag.GUID(), bread.Values.Contained())
+	assert.Equal(t, bread.GUID(), bag.Values.Slots()[0])
+
+	t.Run("non-empty bag", func(t *testing.T) {
+		assert.Nil(t, w.SwapItems(p, InventorySlotBag0, BagSlotStart, InventorySlotBag0, BackpackSlotStart))
+		assert.Equal(t, InventoryResultCanOnlyDoWithEmptyBags, inventoryResult(conn))
+
+		assert.Nil(t, w.DestroyItem(p, InventorySlotBag0, BagSlotStart, 0))
+		assert.Equal(t, InventoryResultCanOnlyDoWithEmptyBags, inventoryResult(conn))
+
+		assert.NotNil(t, w.SwapItems(p, InventorySlotBag0, BagSlotStart, InventorySlotBag0, BagSlotStart+1))
+		assert.Same(t, bag, p.Item(InventorySlotBag0, BagSlotStart+1))
+		assert.Same(t, bread, p.Item(BagSlotStart+1, 0))
+	})
+
+	t.Run("bag into itself", func(t *testing.T) {
+		assert.Nil(t, w.SwapItems(p, BagSlotStart+1, 0, InventorySlotBag0, BagSlotStart+1))
+		assert.Same(t, bread, p.Item(BagSlotStart+1, 0))
+	})
+}
+
+func TestInventoryStacks(t *testing.T) {
+	w := New(nil)
+	p, conn := newTestPlayer(1, 0, 0)
+
+	a := newTestItem(p, testBread, InventorySlotBag0, BackpackSlotStart, 15)
+	b := newTestItem(p, testBread, InventorySlotBag0, BackpackSlotStart+1, 10)
+
+	t.Run("merge", func(t *testing.T) {
+		changes := w.SwapItems(p, InventorySlotBag0, BackpackSlotStart+1, InventorySlotBag0, BackpackSlotStart)
+		if assert.NotNil(t, changes) {
+			assert.Len(t, changes.Updated, 2)
+			assert.Empty(t, changes.Removed)
+		}
+		assert.Equal(t, uint32(20), a.Count)
+		assert.Equal(t, uint32(5), b.Count)
+		assert.Equal(t, uint32(20), a.Values.StackCount())
+	})
+
+	t.Run("split", func(t *testing.T) {
+		_, split := w.SplitItem(p, InventorySlotBag0, BackpackSlotStart, InventorySlotBag0, BackpackSlotStart+2, 20)
+		assert.Nil(t, split)
+		assert.Equal(t, InventoryResultTriedToSplitMoreThanCount, inventoryResult(conn))
+
+		changes, split := w.SplitItem(p, InventorySlotBag0, BackpackSlotStart, InventorySlotBag0, BackpackSlotStart+2, 8)
+		if !assert.NotNil(t, split) {
+			return
+		}
+		assert.Len(t, changes.Updated, 1)
+		assert.Equal(t, uint32(12), a.Count)
+		assert.Equal(t, uint32(8), split.Count)
+		assert.Nil(t, p.Item(InventorySlotBag0, BackpackSlotStart+2), "not placed until saved")
+
+		split.Id = 100
+		w.PlaceItem(p, split)
+		assert.Same(t, split, p.Item(InventorySlotBag0, BackpackSlotStart+2))
+		assert.Equal(t, realmd.NewGuid(realmd.HighGuidItem, 100), split.Values.GUID())
+		assert.Equal(t, uint32(25), p.CountItem(testBread.Id))
+	})
+
+	t.Run("store new", func(t *testing.T) {
+		changes, created, result := w.StoreNewItem(p, testBread, 40)
+		assert.Equal(t, InventoryResultOK, result)
+		assert.Len(t, changes.Updated, 3, "existing stacks are filled first")
+		if assert.Len(t, created, 1) {
+			assert.Equal(t, uint32(5), created[0].Count)
+			assert.Equal(t, uint8(BackpackSlotStart+3), created[0].Slot)
+		}
+	})
+
+	t.Run("destroy", func(t *testing.T) {
+		changes := w.DestroyItem(p, InventorySlotBag0, BackpackSlotStart, 5)
+		if assert.NotNil(t, changes) {
+			assert.Len(t, changes.Updated, 1)
+		}
+		assert.Equal(t, uint32(15), a.Count)
+
+		changes = w.DestroyItem(p, InventorySlotBag0, BackpackSlotStart, 0)
+		if assert.NotNil(t, changes) {
+			assert.Equal(t, []*Item{a}, changes.Removed)
+		}
+		assert.Nil(t, p.Item(InventorySlotBag0, BackpackSlotStart))
+	})
+}
+
+func TestInventoryFull(t *testing.T) {
+	w := New(nil)
+	p, _ := newTestPlayer(1, 0, 0)
+
+	for slot := uint8(BackpackSlotStart); slot < BackpackSlotEnd; slot++ {
+		newTestItem(p, testSword, InventorySlotBag0, slot, 1)
+	}
+
+	_, created, result := w.StoreNewItem(p, testBread, 1)
+	assert.Equal(t, InventoryResultBagFull, result)
+	assert.Empty(t, created)
+
+	limited := &model.ItemTemplate{Id: 3, Stackable: 1, MaxCount: 1}
+	_, _, result = w.StoreNewItem(p, limited, 2)
+	assert.Equal(t, InventoryResultCantCarryMoreOfThis, result)
+}
+
+func TestStoreItem(t *testing.T) {
+	w := New(nil)
+	p, _ := newTestPlayer(1, 0, 0)
+	newTestItem(p, testSword, InventorySlotBag0, BackpackSlotStart, 1)
+
+	// An item that was mailed by another player
+	mailed := NewItem(&model.Item{Id: 100, OwnerId: 2, ContainerId: 7, Slot: 3, Count: 1, Durability: 12}, testStaff, p.GUID())
+	assert.Equal(t, InventoryResultOK, w.StoreItem(p, mailed))
+	assert.Equal(t, p.Character.Id, mailed.OwnerId)
+	assert.Equal(t, uint32(0), mailed.ContainerId)
+	assert.Equal(t, uint8(BackpackSlotStart+1), mailed.Slot)
+	assert.Equal(t, uint32(12), mailed.Durability)
+
+	w.PlaceItem(p, mailed)
+	assert.Same(t, mailed, p.Item(InventorySlotBag0, BackpackSlotStart+1))
+
+	for slot := uint8(BackpackSlotStart + 2); slot < BackpackSlotEnd; slot++ {
+		newTestItem(p, testSword, InventorySlotBag0, slot, 1)
+	}
+	another := NewItem(&model.Item{Id: 101, Count: 1}, testStaff, p.GUID())
+	assert.Equal(t, InventoryResultBagFull, w.StoreItem(p, another))
+}
+
+func TestSetInventory(t *testing.T) {
+	p, _ := newTestPlayer(1, 0, 0)
+
+	bag := NewItem(&model.Item{Id: 1, Slot: BagSlotStart, Count: 1}, testBag, p.GUID())
+	inBag := NewItem(&model.Item{Id: 2, ContainerId: 1, Slot: 3, Count: 1}, testBread, p.GUID())
+	missingBag := NewItem(&model.Item{Id: 3, ContainerId: 50, Slot: 0, Count: 1}, testBread, p.GUID())
+
+	invalid := p.SetInventory([]*Item{inBag, missingBag, bag})
+	assert.Equal(t, []*Item{missingBag}, invalid)
+	assert.Same(t, bag, p.Item(InventorySlotBag0, BagSlotStart))
+	assert.Same(t, inBag, p.Item(BagSlotStart, 3))
+	assert.Equal(t, []*Item{bag, inBag}, p.Items())
+}
+
+func TestStartingItems(t *testing.T) {
+	templates := []*model.ItemTemplate{testSword, testShield, testBread, testBag, testStaff}
+	items := StartingItems(7, templates)
+
+	if assert.Len(t, items, 5) {
+		assert.Equal(t, uint8(EquipmentSlotMainHand), items[0].Slot)
+		assert.Equal(t, uint8(EquipmentSlotOffHand), items[1].Slot)
+		assert.Equal(t, uint8(BackpackSlotStart), items[2].Slot)
+		assert.Equal(t, uint32(startingConsumableCount), items[2].Count)
+		assert.Equal(t, uint8(BagSlotStart), items[3].Slot)
+		assert.Equal(t, uint8(BackpackSlotStart+1), items[4].Slot, "main hand is taken")
+		assert.Equal(t, uint32(7), items[4].OwnerId)
+		assert.Equal(t, uint32(1), items[4].Count)
+	}
+}
+
+func TestVisibleItems(t *testing.T) {
+	w := New(nil)
+	p, _ := newTestPlayer(1, 0, 0)
+
+	sword := newTestItem(p, testSword, InventorySlotBag0, BackpackSlotStart, 1)
+	assert.Zero(t, p.Values.VisibleItems()[EquipmentSlotMainHand].ID)
+
+	w.AutoEquipItem(p, InventorySlotBag0, BackpackSlotStart)
+	assert.Equal(t, sword.TemplateId, p.Values.VisibleItems()[EquipmentSlotMainHand].ID)
+
+	w.EnchantItem(p, sword, 1897)
+	assert.Equal(t, uint32(1897), sword.Values.Enchantments()[0].ID)
+	assert.Equal(t, uint32(1897), p.Values.VisibleItems()[EquipmentSlotMainHand].Enchantment)
+
+	w.SwapItems(p, InventorySlotBag0, EquipmentSlotMainHand, InventorySlotBag0, BackpackSlotStart)
+	assert.Zero(t, p.Values.VisibleItems()[EquipmentSlotMainHand].ID)
+}
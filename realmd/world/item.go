@@ -0,0 +1,115 @@
+package world
+
+import (
+	"github.com/kangaroux/gomaggus/model"
+	"github.com/kangaroux/gomaggus/realmd"
+	"github.com/kangaroux/gomaggus/realmd/values"
+)
+
+// Item is an item in a player's inventory. Items are only visible to the player that owns them, other
+// players see the player's equipment through the player's values.
+type Item struct {
+	*model.Item
+	Template *model.ItemTemplate
+	Values   *values.Item
+
+	// slots are the items inside of the bag. It's nil if the item isn't a bag.
+	slots []*Item
+}
+
+// NewItem creates an item owned by owner. The item must already be saved so it has an id.
+func NewItem(m *model.Item, t *model.ItemTemplate, owner realmd.Guid) *Item {
+	i := &Item{
+		Item:     m,
+		Template: t,
+	}
+
+	if t.ContainerSlots > 0 {
+		i.Values = values.NewContainer()
+		i.Values.SetType(values.ObjectTypeObject, values.ObjectTypeItem, values.ObjectTypeContainer)
+		i.Values.SetNumSlots(uint32(t.ContainerSlots))
+		i.slots = make([]*Item, t.ContainerSlots)
+	} else {
+		i.Values = values.NewItem()
+		i.Values.SetType(values.ObjectTypeObject, values.ObjectTypeItem)
+	}
+
+	i.Values.SetGUID(i.GUID())
+	i.Values.SetEntry(t.Id)
+	i.Values.SetScaleX(1)
+	i.Values.SetOwner(owner)
+	i.Values.SetContained(owner)
+	i.Values.SetStackCount(m.Count)
+	i.Values.SetFlags(m.Flags)
+	i.Values.SetDurability(m.Durability)
+	i.Values.SetMaxDurability(t.MaxDurability)
+	i.setEnchantValue()
+
+	return i
+}
+
+func (i *Item) GUID() realmd.Guid {
+	return realmd.NewGuid(realmd.HighGuidItem, i.Id)
+}
+
+// IsBag reports whether the item can hold other items.
+func (i *Item) IsBag() bool {
+	return i.slots != nil
+}
+
+// BagSize returns how many slots the bag has, or 0 if the item isn't a bag.
+func (i *Item) BagSize() uint8 {
+	return uint8(len(i.slots))
+}
+
+// Empty reports whether the bag has no items in it. Items that aren't bags are always empty.
+func (i *Item) Empty() bool {
+	for _, item := range i.slots {
+		if item != nil {
+			return false
+		}
+	}
+	return true
+}
+
+// MaxStack returns how many of the item fit in one stack.
+func (i *Item) MaxStack() uint32 {
+	if i.Template.Stackable == 0 {
+		return 1
+	}
+	return i.Template.Stackable
+}
+
+// SetCount changes how many items are in the stack.
+func (i *Item) SetCount(count uint32) {
+	i.Count = count
+	i.Values.SetStackCount(count)
+}
+
+// setEnchantValue puts the item's permanent enchantment in its first enchantment slot.
+func (i *Item) setEnchantValue() {
+	enchantments := i.Values.Enchantments()
+	enchantments[0] = values.ItemEnchantment{ID: i.Enchant}
+	i.Values.SetEnchantments(enchantments)
+}
+
+// WriteCreate adds a block to u which creates the item for its owner.
+func (i *Item) WriteCreate(u *values.UpdateBuilder) {
+	objType := values.ObjectTypeItem
+	if i.IsBag() {
+		objType = values.ObjectTypeContainer
+	}
+
+	u.CreateObject(i.GUID(), objType, &values.MovementValues{}, i.Values.Marshal(false))
+}
+
+// WriteValues adds a block to u containing the item's values that changed since they were last written.
+// WriteValues reports whether a block was added.
+func (i *Item) WriteValues(u *values.UpdateBuilder) bool {
+	if !i.Values.Dirty() {
+		return false
+	}
+
+	u.Values(i.GUID(), i.Values.Marshal(true))
+	return true
+}
@@ -0,0 +1,105 @@
+package world
+
+import (
+	"github.com/kangaroux/gomaggus/realmd"
+)
+
+// Map is a single map (continent, instance, etc.) in the world. Objects on the map are tracked
+// using a grid of cells, which makes finding nearby objects cheap.
+type Map struct {
+	ID uint32
+
+	cells map[cellCoord]*cell
+
+	// objectCells tracks which cell each object is in.
+	objectCells map[realmd.Guid]cellCoord
+}
+
+func newMap(id uint32) *Map {
+	return &Map{
+		ID:          id,
+		cells:       make(map[cellCoord]*cell),
+		objectCells: make(map[realmd.Guid]cellCoord),
+	}
+}
+
+// Add adds obj to the map using its current position.
+func (m *Map) Add(obj Object) {
+	pos := obj.Position()
+	coord := cellAt(pos.X, pos.Y)
+
+	c, ok := m.cells[coord]
+	if !ok {
+		c = newCell()
+		m.cells[coord] = c
+	}
+
+	c.objects[obj.GUID()] = obj
+	m.objectCells[obj.GUID()] = coord
+}
+
+// Remove removes obj from the map. Does nothing if obj is not on the map.
+func (m *Map) Remove(obj Object) {
+	coord, ok := m.objectCells[obj.GUID()]
+	if !ok {
+		return
+	}
+
+	c := m.cells[coord]
+	delete(c.objects, obj.GUID())
+	delete(m.objectCells, obj.GUID())
+
+	// Don't keep empty cells around
+	if len(c.objects) == 0 {
+		delete(m.cells, coord)
+	}
+}
+
+// Relocate moves obj to the cell for its current position, if it changed cells.
+func (m *Map) Relocate(obj Object) {
+	pos := obj.Position()
+	if coord, ok := m.objectCells[obj.GUID()]; ok && coord == cellAt(pos.X, pos.Y) {
+		return
+	}
+
+	m.Remove(obj)
+	m.Add(obj)
+}
+
+// Len returns the number of objects on the map.
+func (m *Map) Len() int {
+	return len(m.objectCells)
+}
+
+// ObjectsInRange returns all the objects within dist of pos.
+func (m *Map) ObjectsInRange(pos realmd.Vector4, dist float32) []Object {
+	var result []Object
+
+	for _, coord := range cellsInRange(pos, dist) {
+		c, ok := m.cells[coord]
+		if !ok {
+			continue
+		}
+
+		for _, obj := range c.objects {
+			if distance(pos, obj.Position()) <= dist {
+				result = append(result, obj)
+			}
+		}
+	}
+
+	return result
+}
+
+// PlayersInRange returns all the players within dist of pos.
+func (m *Map) PlayersInRange(pos realmd.Vector4, dist float32) []*Player {
+	var result []*Player
+
+	for _, obj := range m.ObjectsInRange(pos, dist) {
+		if p, ok := obj.(*Player); ok {
+			result = append(result, p)
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,321 @@
+package world
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"math"
+	"time"
+
+	"github.com/kangaroux/gomaggus/realmd"
+	"github.com/kangaroux/gomaggus/realmd/values"
+)
+
+const (
+	// maxMovementDistance is the furthest a player can move between two movement packets. Anything
+	// further than this is considered a teleport.
+	maxMovementDistance = 50
+
+	// The speed check allows some leeway to account for latency and floating point imprecision.
+	movementSpeedTolerance = 1.2
+	movementDistanceSlack  = 2
+	movementLatencySlack   = time.Second
+)
+
+var (
+	ErrMovementTooFar  = errors.New("movement distance is too far")
+	ErrMovementTooFast = errors.New("movement speed is faster than allowed")
+)
+
+// Speeds are a unit's movement speeds in yards per second. Turn and pitch rates are in radians per second.
+type Speeds struct {
+	Walk       float32
+	Run        float32
+	RunBack    float32
+	Swim       float32
+	SwimBack   float32
+	Flight     float32
+	FlightBack float32
+	Turn       float32
+	Pitch      float32
+}
+
+// DefaultSpeeds are the speeds of a player without any speed modifiers.
+var DefaultSpeeds = Speeds{
+	Walk:       2.5,
+	Run:        7,
+	RunBack:    4.5,
+	Swim:       4.722222,
+	SwimBack:   2.5,
+	Flight:     7,
+	FlightBack: 4.5,
+	Turn:       math.Pi,
+	Pitch:      math.Pi,
+}
+
+// ForFlags returns the speed that applies to a unit moving with flags.
+func (s *Speeds) ForFlags(flags values.LivingMovementFlag) float32 {
+	backward := flags&values.LivingMovementFlagBackward > 0
+
+	switch {
+	case flags&values.LivingMovementFlagFlying > 0:
+		if backward {
+			return s.FlightBack
+		}
+		return s.Flight
+
+	case flags&values.LivingMovementFlagSwimming > 0:
+		if backward {
+			return s.SwimBack
+		}
+		return s.Swim
+
+	case flags&values.LivingMovementFlagWalking > 0:
+		return s.Walk
+
+	case backward:
+		return s.RunBack
+
+	default:
+		return s.Run
+	}
+}
+
+// checkMovement reports whether moving from prev to next is possible. elapsed is the time that passed on
+// the server between the two movements. The client's timestamps are used when they are reasonable, since
+// packets can arrive in bursts.
+func checkMovement(prev, next *values.MovementInfo, speeds *Speeds, elapsed time.Duration) error {
+	dx := float64(next.Position.X - prev.Position.X)
+	dy := float64(next.Position.Y - prev.Position.Y)
+	dz := float64(next.Position.Z - prev.Position.Z)
+
+	// Falling can cover any distance downward, so only the horizontal distance is checked while the unit
+	// falls. Moving up is always checked.
+	falling := (prev.Flags|next.Flags)&values.LivingMovementFlagFalling > 0 && dz < 0
+	if falling {
+		dz = 0
+	}
+
+	dist := float32(math.Sqrt(dx*dx + dy*dy + dz*dz))
+
+	if dist > maxMovementDistance {
+		return ErrMovementTooFar
+	}
+
+	// The client's timestamp can't be trusted for being further apart than the server saw them
+	clientElapsed := time.Duration(next.Time-prev.Time) * time.Millisecond
+	if clientElapsed > elapsed+movementLatencySlack {
+		clientElapsed = elapsed + movementLatencySlack
+	}
+
+	// The unit may have changed what it was doing between the two packets, so use whichever speed
+	// is faster
+	speed := speeds.ForFlags(prev.Flags)
+	if s := speeds.ForFlags(next.Flags); s > speed {
+		speed = s
+	}
+
+	allowed := speed*float32(clientElapsed.Seconds())*movementSpeedTolerance + movementDistanceSlack
+
+	if dist > allowed {
+		return ErrMovementTooFast
+	}
+
+	return nil
+}
+
+// MovePlayer updates the player's movement and relays it to nearby players. If the movement isn't
+// valid, the player is teleported back to their last known position.
+func (w *World) MovePlayer(p *Player, opcode realmd.ServerOpcode, info *values.MovementInfo) error {
+	// Movement sent before the client acknowledged a teleport is from where the player used to be
+	if p.teleportPending || p.transferPending {
+		return nil
+	}
+
+	now := time.Now()
+
+	if err := checkMovement(&p.Movement, info, &p.Speeds, now.Sub(p.lastMove)); err != nil {
+		p.Client.Log.Warn().
+			Err(err).
+			Str("char", p.Character.String()).
+			Msg("rejected movement")
+
+		return w.TeleportPlayer(p, p.Movement.Position)
+	}
+
+	prev := p.Movement.Position
+	p.Movement = *info
+	p.lastMove = now
+	w.Map(p.MapID).Relocate(p)
+	w.UpdateVisibility(p)
+	w.interruptMovedCast(p, prev)
+
+	data, err := p.movementPacket()
+	if err != nil {
+		return err
+	}
+	w.Broadcast(p, true, opcode, data)
+
+	return nil
+}
+
+// https://gtker.com/wow_messages/docs/msg_move_teleport_ack_server.html
+// TeleportPlayer moves the player to pos on their current map. Any movement from the client is ignored
+// until they acknowledge the teleport.
+func (w *World) TeleportPlayer(p *Player, pos realmd.Vector4) error {
+	p.teleportCounter++
+	p.teleportPending = true
+
+	p.Movement.Flags = values.LivingMovementFlagNone
+	p.Movement.Position = pos
+	p.Movement.Transport = nil
+	p.Movement.Fall = nil
+	p.Movement.FallTime = 0
+	p.Movement.Time = uint32(time.Now().UnixMilli())
+
+	info, err := p.Movement.Bytes()
+	if err != nil {
+		return err
+	}
+
+	data := bytes.Buffer{}
+	data.Write(realmd.PackGuid(uint64(p.GUID())))
+	binary.Write(&data, binary.LittleEndian, p.teleportCounter)
+	data.Write(info)
+
+	w.Map(p.MapID).Relocate(p)
+
+	return p.Client.SendPacketBytes(realmd.OpServerMoveTeleportAck, data.Bytes())
+}
+
+// AckTeleport is called when the client acknowledges a teleport. Once the client has acknowledged the
+// latest teleport, movement from the client is accepted again.
+func (w *World) AckTeleport(p *Player, counter uint32) error {
+	if !p.teleportPending || counter != p.teleportCounter {
+		return nil
+	}
+
+	p.teleportPending = false
+	p.lastMove = time.Now()
+	w.UpdateVisibility(p)
+
+	data, err := p.movementPacket()
+	if err != nil {
+		return err
+	}
+	w.Broadcast(p, true, realmd.OpServerMoveHeartbeat, data)
+
+	return nil
+}
+
+type transferPending struct {
+	Map uint32
+}
+
+type newWorld struct {
+	Map      uint32
+	Position realmd.Vector4
+}
+
+// TransferPlayer moves the player to pos on another map. If the map is the player's current map, this
+// is the same as TeleportPlayer. The player is removed from the world until the client finishes loading
+// the new map.
+// https://gtker.com/wow_messages/docs/smsg_new_world.html#client-version-335
+func (w *World) TransferPlayer(p *Player, mapID uint32, pos realmd.Vector4) error {
+	if mapID == p.MapID {
+		return w.TeleportPlayer(p, pos)
+	}
+
+	w.InterruptCast(p, SpellCastResultInterrupted)
+
+	if err := p.Client.SendPacket(realmd.OpServerTransferPending, &transferPending{Map: mapID}); err != nil {
+		return err
+	}
+
+	for _, other := range p.VisiblePlayers() {
+		if other != p {
+			w.forget(other, p)
+		}
+	}
+
+	w.Map(p.MapID).Remove(p)
+	p.visible = make(map[realmd.Guid]Object)
+
+	p.transferPending = true
+	p.MapID = mapID
+	p.Movement = values.MovementInfo{Position: pos}
+
+	return p.Client.SendPacket(realmd.OpServerNewWorld, &newWorld{Map: mapID, Position: pos})
+}
+
+// AckTransfer is called when the client has finished loading the map it was transferred to. The player
+// is spawned on the new map.
+func (w *World) AckTransfer(p *Player) error {
+	if !p.transferPending {
+		return nil
+	}
+
+	p.transferPending = false
+	p.lastMove = time.Now()
+
+	return w.spawn(p)
+}
+
+// speedChange contains the opcodes used for changing one of the player's speeds.
+type speedChange struct {
+	force  realmd.ServerOpcode
+	spline realmd.ServerOpcode
+	speed  func(*Speeds) *float32
+}
+
+var speedChanges = []speedChange{
+	{realmd.OpServerForceRunSpeedChange, realmd.OpServerSplineSetRunSpeed, func(s *Speeds) *float32 { return &s.Run }},
+	{realmd.OpServerForceSwimSpeedChange, realmd.OpServerSplineSetSwimSpeed, func(s *Speeds) *float32 { return &s.Swim }},
+	{realmd.OpServerForceFlightSpeedChange, realmd.OpServerSplineSetFlightSpeed, func(s *Speeds) *float32 { return &s.Flight }},
+}
+
+// SetSpeedRate changes the player's run, swim and flight speeds to rate times the default speeds. The
+// player is told to use the new speeds, and nearby players are told the player's new speeds.
+// https://gtker.com/wow_messages/docs/smsg_force_run_speed_change.html#client-version-335
+func (w *World) SetSpeedRate(p *Player, rate float32) error {
+	defaults := DefaultSpeeds
+
+	for _, change := range speedChanges {
+		speed := *change.speed(&defaults) * rate
+		*change.speed(&p.Speeds) = speed
+		p.speedCounter++
+
+		force := bytes.Buffer{}
+		force.Write(realmd.PackGuid(uint64(p.GUID())))
+		binary.Write(&force, binary.LittleEndian, p.speedCounter)
+
+		// Only the run speed change has this field
+		if change.force == realmd.OpServerForceRunSpeedChange {
+			force.WriteByte(1)
+		}
+
+		binary.Write(&force, binary.LittleEndian, speed)
+
+		if err := p.Client.SendPacketBytes(change.force, force.Bytes()); err != nil {
+			return err
+		}
+
+		spline := bytes.Buffer{}
+		spline.Write(realmd.PackGuid(uint64(p.GUID())))
+		binary.Write(&spline, binary.LittleEndian, speed)
+
+		w.Broadcast(p, true, change.spline, spline.Bytes())
+	}
+
+	return nil
+}
+
+// movementPacket returns the payload for relaying the player's movement to other players.
+func (p *Player) movementPacket() ([]byte, error) {
+	info, err := p.Movement.Bytes()
+	if err != nil {
+		return nil, err
+	}
+
+	return append(realmd.PackGuid(uint64(p.GUID())), info...), nil
+}
@@ -0,0 +1,66 @@
+package world
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kangaroux/gomaggus/realmd"
+	"github.com/kangaroux/gomaggus/realmd/values"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSpeedsForFlags(t *testing.T) {
+	s := Speeds{Walk: 1, Run: 2, RunBack: 3, Swim: 4, SwimBack: 5, Flight: 6, FlightBack: 7}
+
+	assert.Equal(t, float32(2), s.ForFlags(values.LivingMovementFlagNone))
+	assert.Equal(t, float32(2), s.ForFlags(values.LivingMovementFlagForward))
+	assert.Equal(t, float32(1), s.ForFlags(values.LivingMovementFlagWalking))
+	assert.Equal(t, float32(3), s.ForFlags(values.LivingMovementFlagBackward))
+	assert.Equal(t, float32(4), s.ForFlags(values.LivingMovementFlagSwimming))
+	assert.Equal(t, float32(5), s.ForFlags(values.LivingMovementFlagSwimming|values.LivingMovementFlagBackward))
+	assert.Equal(t, float32(6), s.ForFlags(values.LivingMovementFlagFlying|values.LivingMovementFlagSwimming))
+	assert.Equal(t, float32(7), s.ForFlags(values.LivingMovementFlagFlying|values.LivingMovementFlagBackward))
+}
+
+func TestCheckMovement(t *testing.T) {
+	speeds := &Speeds{Walk: 2.5, Run: 7, RunBack: 4.5}
+	prev := &values.MovementInfo{Time: 1000}
+
+	move := func(x, z float32, ms uint32, flags values.LivingMovementFlag) *values.MovementInfo {
+		return &values.MovementInfo{
+			Flags:    flags,
+			Time:     prev.Time + ms,
+			Position: realmd.Vector4{X: x, Z: z},
+		}
+	}
+
+	testCases := []struct {
+		name     string
+		next     *values.MovementInfo
+		elapsed  time.Duration
+		expected error
+	}{
+		{"standing still", move(0, 0, 1000, 0), time.Second, nil},
+		{"running", move(7, 0, 1000, values.LivingMovementFlagForward), time.Second, nil},
+		{"falling", move(0, -500, 1000, values.LivingMovementFlagFalling), time.Second, nil},
+		{"falling up", move(0, 500, 1000, values.LivingMovementFlagFalling), time.Second, ErrMovementTooFar},
+		{"flying up", move(0, 20, 1000, values.LivingMovementFlagForward), time.Second, ErrMovementTooFast},
+		{"teleport down", move(0, -500, 1000, values.LivingMovementFlagForward), time.Second, ErrMovementTooFar},
+		{"too fast", move(20, 0, 1000, values.LivingMovementFlagForward), time.Second, ErrMovementTooFast},
+		{"teleport", move(1000, 0, 100000, values.LivingMovementFlagForward), 100 * time.Second, ErrMovementTooFar},
+		{"client time ahead of server", move(35, 0, 5000, values.LivingMovementFlagForward), time.Second, ErrMovementTooFast},
+		{"packets arrived together", move(7, 0, 1000, values.LivingMovementFlagForward), 10 * time.Millisecond, nil},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, checkMovement(prev, tc.next, speeds, tc.elapsed))
+		})
+	}
+
+	t.Run("walking too fast", func(t *testing.T) {
+		walking := &values.MovementInfo{Flags: values.LivingMovementFlagWalking, Time: 1000}
+		next := &values.MovementInfo{Flags: values.LivingMovementFlagWalking, Time: 2000, Position: realmd.Vector4{X: 7}}
+		assert.Equal(t, ErrMovementTooFast, checkMovement(walking, next, speeds, time.Second))
+	})
+}
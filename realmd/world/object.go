@@ -0,0 +1,19 @@
+package world
+
+import (
+	"github.com/kangaroux/gomaggus/realmd"
+	"github.com/kangaroux/gomaggus/realmd/values"
+)
+
+// Object is anything that exists on a map and can be seen by players.
+type Object interface {
+	GUID() realmd.Guid
+	Position() realmd.Vector4
+
+	// WriteCreate adds a block to u which spawns the object for viewer.
+	WriteCreate(u *values.UpdateBuilder, viewer *Player)
+
+	// WriteValues adds a block to u containing the object's values that changed since they
+	// were last written. WriteValues reports whether a block was added.
+	WriteValues(u *values.UpdateBuilder) bool
+}
@@ -0,0 +1,32 @@
+package world
+
+import "time"
+
+// PlayedTime returns how long the player has been played in total, and at their current level.
+func (p *Player) PlayedTime() (total, level time.Duration) {
+	p.updatePlayedTime()
+	return p.playedTotal, p.playedLevel
+}
+
+// updatePlayedTime adds the time since it was last updated to the player's played time.
+func (p *Player) updatePlayedTime() {
+	now := time.Now()
+	diff := now.Sub(p.playedSince)
+	p.playedSince = now
+
+	p.playedTotal += diff
+	p.playedLevel += diff
+}
+
+// setPlayedTime restores the played time that was saved for the player's character.
+func (p *Player) setPlayedTime(total, level uint32) {
+	p.playedSince = time.Now()
+	p.playedTotal = time.Duration(total) * time.Second
+	p.playedLevel = time.Duration(level) * time.Second
+}
+
+// resetLevelPlayedTime is called when the player's level changes.
+func (p *Player) resetLevelPlayedTime() {
+	p.updatePlayedTime()
+	p.playedLevel = 0
+}
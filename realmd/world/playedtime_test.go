@@ -0,0 +1,29 @@
+package world
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPlayedTime(t *testing.T) {
+	p, _ := newTestPlayer(1, 0, 0)
+	p.setPlayedTime(100, 10)
+
+	total, level := p.PlayedTime()
+	assert.Equal(t, 100*time.Second, total.Truncate(time.Second))
+	assert.Equal(t, 10*time.Second, level.Truncate(time.Second))
+
+	p.playedSince = p.playedSince.Add(-time.Minute)
+	assert.NoError(t, p.SetLevel(2))
+	total, level = p.PlayedTime()
+	assert.Equal(t, 160*time.Second, total.Truncate(time.Second))
+	assert.Less(t, level, time.Second, "level time starts over")
+
+	assert.NoError(t, p.SetLevel(2))
+	p.playedSince = p.playedSince.Add(-time.Minute)
+	p.SyncCharacter()
+	assert.Equal(t, uint32(160+60), p.Character.PlayedTotal)
+	assert.Equal(t, uint32(60), p.Character.PlayedLevel)
+}
@@ -0,0 +1,544 @@
+package world
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/kangaroux/gomaggus/model"
+	"github.com/kangaroux/gomaggus/realmd"
+	"github.com/kangaroux/gomaggus/realmd/dbc"
+	"github.com/kangaroux/gomaggus/realmd/player"
+	"github.com/kangaroux/gomaggus/realmd/values"
+)
+
+// MaxMoney is the most copper a player can carry.
+const MaxMoney = 0x7FFFFFFF
+
+// The primary stats, in the order they're stored in the player's values.
+const (
+	StatStrength = iota
+	StatAgility
+	StatStamina
+	StatIntellect
+	StatSpirit
+)
+
+// Player is a character that is logged in to the world.
+type Player struct {
+	Client    *realmd.Client
+	Character *model.Character
+	Values    *values.Player
+
+	MapID    uint32
+	Zone     uint32
+	Horde    bool
+	Movement values.MovementInfo
+	Speeds   Speeds
+
+	// lastMove is when the server last accepted a movement from the player.
+	lastMove time.Time
+
+	// teleportPending is true while the server is waiting for the client to acknowledge a teleport.
+	// Movement from the client is ignored until then, since it's relative to the old position.
+	teleportPending bool
+	teleportCounter uint32
+
+	// transferPending is true while the client is loading a new map. The player isn't on any map until
+	// the client finishes loading.
+	transferPending bool
+
+	// speedCounter is incremented every time the server changes the player's speed.
+	speedCounter uint32
+
+	// AutoReply is sent to anyone who whispers the player while they are away or don't want to be
+	// disturbed.
+	AutoReply string
+
+	// channels are the chat channels the player is in, in the order they were joined.
+	channels []*Channel
+
+	// contacts are the player's friends and ignored players.
+	contacts map[realmd.Guid]*model.Contact
+
+	// groupInvite is the guid of the player that invited this player to a group, or 0 if there's no
+	// pending invite.
+	groupInvite realmd.Guid
+
+	// partyStats are the stats that were last sent to the player's group.
+	partyStats partyStats
+
+	// guild is the guild the player is in, or nil if they aren't in one.
+	guild *Guild
+
+	// guildInvite is the id of the guild the player was invited to, and guildInviter is who invited them.
+	// Both are 0 if there's no pending invite.
+	guildInvite  uint32
+	guildInviter realmd.Guid
+
+	// spells are the ids of the spells the player knows.
+	spells map[uint32]bool
+
+	// cooldowns are the player's spell cooldowns by spell id, and globalCooldowns are when each start
+	// recovery category can be used again.
+	cooldowns       map[uint32]*model.SpellCooldown
+	globalCooldowns map[uint32]time.Time
+
+	// cast is the spell the player is casting or channeling, or nil if they aren't casting anything.
+	cast *Cast
+
+	// auras are the auras on the player by slot.
+	auras map[uint8]*Aura
+
+	// specs are the player's talent specs, and activeSpec is the index of the one they're using.
+	specs      []*TalentSpec
+	activeSpec uint8
+
+	// reputations are the player's reputations by their index in the reputation list.
+	reputations [MaxReputations]*Reputation
+
+	// baseStats are the player's stats at their level, and statMods are added to them by auras.
+	baseStats player.Stats
+	statMods  [5]int32
+
+	// items are the items in the player's own slots. Items in bags are stored in the bag.
+	items [KeyringSlotEnd]*Item
+
+	// visible contains the objects this player can see.
+	visible map[realmd.Guid]Object
+
+	// How long the player has been played in total and at their current level, as of playedSince
+	playedTotal time.Duration
+	playedLevel time.Duration
+	playedSince time.Time
+
+	// restBonus is the player's rested experience. restFlags are the places they're resting in, inn is the
+	// trigger of the inn they're in, and city is the map of the capital city they're in.
+	restBonus float64
+	restFlags restFlag
+	inn       *dbc.AreaTrigger
+	city      *dbc.WorldMapArea
+
+	// attackTarget is who the player is auto-attacking, or 0 if they aren't attacking anyone. attackTimers
+	// are how long until each hand can swing again, and swingError is the last reason the player was told
+	// they can't swing.
+	attackTarget realmd.Guid
+	attackTimers [2]time.Duration
+	swingError   realmd.ServerOpcode
+
+	// combatTimer is how long until the player leaves combat once they stop attacking. opponents are the
+	// players they've been fighting since they entered combat.
+	combatTimer time.Duration
+	opponents   map[realmd.Guid]bool
+
+	// corpse is the corpse the player left when they released their spirit, or nil if they aren't a ghost.
+	corpse *Corpse
+}
+
+var _ Object = (*Player)(nil)
+
+// NewPlayer creates a player for char using the race and class data in data.
+func NewPlayer(client *realmd.Client, char *model.Character, data *dbc.Store) (*Player, error) {
+	race, ok := data.ChrRaces[char.Race]
+	if !ok {
+		return nil, fmt.Errorf("world: unknown race %d", char.Race)
+	}
+
+	class, ok := data.ChrClasses[char.Class]
+	if !ok {
+		return nil, fmt.Errorf("world: unknown class %d", char.Class)
+	}
+
+	p := &Player{
+		Client:    client,
+		Character: char,
+		Values:    values.NewPlayer(),
+		MapID:     char.Map,
+		Zone:      char.Zone,
+		Horde:     race.Horde,
+		Movement: values.MovementInfo{
+			Position: realmd.Vector4{
+				X:        char.X,
+				Y:        char.Y,
+				Z:        char.Z,
+				Rotation: char.Orientation,
+			},
+		},
+		Speeds:   DefaultSpeeds,
+		lastMove: time.Now(),
+		contacts: make(map[realmd.Guid]*model.Contact),
+		visible:  make(map[realmd.Guid]Object),
+
+		spells:          make(map[uint32]bool),
+		cooldowns:       make(map[uint32]*model.SpellCooldown),
+		globalCooldowns: make(map[uint32]time.Time),
+		auras:           make(map[uint8]*Aura),
+		opponents:       make(map[realmd.Guid]bool),
+		specs:           []*TalentSpec{NewTalentSpec()},
+	}
+
+	v := p.Values
+	v.SetGUID(p.GUID())
+	v.SetType(values.ObjectTypeObject, values.ObjectTypeUnit, values.ObjectTypePlayer)
+	v.SetScaleX(1)
+
+	v.SetRace(char.Race)
+	v.SetClass(char.Class)
+	v.SetGender(char.Gender)
+	v.SetFaction(race.FactionTemplate)
+	v.SetDisplayID(race.DisplayID(char.Gender))
+	v.SetNativeDisplayID(race.DisplayID(char.Gender))
+	v.SetPlayerControlled(true)
+	v.SetBoundingRadius(playerBoundingRadius)
+	v.SetCombatReach(playerCombatReach)
+	v.SetAurasVisible(true)
+	v.SetPowerType(realmd.PowerType(class.DisplayPower))
+	v.SetActionBarToggles(char.ActionBars)
+
+	// Characters created before levels were saved don't have one
+	level := uint32(char.Level)
+	if level == 0 {
+		level = player.StartLevel(char.Class)
+	}
+	v.SetMaxLevel(player.MaxLevel)
+	if err := p.SetLevel(level); err != nil {
+		return nil, err
+	}
+	p.Revive()
+
+	// Players that logged out dead are still dead. A ghost's corpse is put back when they're added to the
+	// world.
+	if char.Ghost {
+		v.SetGhost(true)
+		v.SetHealth(1)
+		if ghost, ok := data.Spells[SpellGhost]; ok {
+			p.addAura(NewAura(ghost, p.GUID(), level, 0))
+		}
+	} else if char.Dead {
+		v.SetHealth(0)
+	}
+
+	v.SetFace(char.Face)
+	v.SetSkin(char.SkinColor)
+	v.SetHairStyle(char.HairStyle)
+	v.SetHairColor(char.HairColor)
+	v.SetExtraCosmetic(char.ExtraCosmetic)
+	v.SetBankBagSlotCount(8)
+	v.SetExperience(char.Experience)
+	p.setPlayedTime(char.PlayedTotal, char.PlayedLevel)
+
+	// Players keep resting while they're offline
+	p.setRestBonus(float64(char.RestBonus))
+	if char.LogoutAt.Valid {
+		rate := wildernessRestRate
+		if char.LogoutResting {
+			rate = 1
+		}
+		p.addRest(time.Since(char.LogoutAt.Time), rate)
+	}
+	p.setExploredZonesBytes(char.ExploredZones)
+	v.SetWealth(int32(char.Money))
+
+	p.SetSkills(player.StartingSkills(char.Race, char.Class, v.Level()))
+
+	return p, nil
+}
+
+// SetLevel changes the player's level and updates their stats, health and power for the new level. The
+// level is kept between the level the player's class starts at and MaxLevel.
+func (p *Player) SetLevel(level uint32) error {
+	char := p.Character
+
+	if start := player.StartLevel(char.Class); level < start {
+		level = start
+	} else if level > player.MaxLevel {
+		level = player.MaxLevel
+	}
+
+	stats, ok := player.BaseStats(char.Race, char.Class, level)
+	if !ok {
+		return fmt.Errorf("world: no stats for race %d and class %d", char.Race, char.Class)
+	}
+
+	base, ok := player.BaseHealthMana(char.Class, level)
+	if !ok {
+		return fmt.Errorf("world: no health or mana for class %d", char.Class)
+	}
+
+	v := p.Values
+	if level != v.Level() {
+		p.resetLevelPlayedTime()
+	}
+	v.SetLevel(level)
+	v.SetNextLevelExperience(player.XPForLevel(level))
+	v.SetBaseHealth(base.Health)
+	v.SetBaseMana(base.Mana)
+	p.baseStats = stats
+
+	switch v.PowerType() {
+	case realmd.PowerTypeRage:
+		// Rage is stored as 10x the value shown in the client
+		v.SetMaxRage(1000)
+
+	case realmd.PowerTypeEnergy:
+		v.SetMaxEnergy(100)
+
+	case realmd.PowerTypeRunicPower:
+		v.SetMaxRunicPower(1000)
+	}
+
+	p.updateStats()
+	p.updateTalentPoints()
+	p.updateSkillsForLevel()
+
+	return nil
+}
+
+// updateStats sets the player's stats from their base stats and the stat modifiers from their auras. Their
+// max health and mana are updated to match.
+func (p *Player) updateStats() {
+	base := [5]uint32{
+		p.baseStats.Strength,
+		p.baseStats.Agility,
+		p.baseStats.Stamina,
+		p.baseStats.Intellect,
+		p.baseStats.Spirit,
+	}
+
+	var stats, pos, neg [5]uint32
+	for i, mod := range p.statMods {
+		if mod > 0 {
+			pos[i] = uint32(mod)
+		} else {
+			neg[i] = uint32(-mod)
+		}
+
+		if val := int64(base[i]) + int64(mod); val > 0 {
+			stats[i] = uint32(val)
+		}
+	}
+
+	v := p.Values
+	v.SetStrength(stats[StatStrength])
+	v.SetAgility(stats[StatAgility])
+	v.SetStamina(stats[StatStamina])
+	v.SetIntellect(stats[StatIntellect])
+	v.SetSpirit(stats[StatSpirit])
+	v.SetUnitPosStats(pos)
+	v.SetUnitNegStats(neg)
+	p.updateCombatStats()
+
+	v.SetMaxHealth(v.BaseHealth() + player.HealthFromStamina(stats[StatStamina]))
+	if v.Health() > v.MaxHealth() {
+		v.SetHealth(v.MaxHealth())
+	}
+
+	if v.PowerType() == realmd.PowerTypeMana {
+		v.SetMaxMana(v.BaseMana() + player.ManaFromIntellect(stats[StatIntellect]))
+		if v.Mana() > v.MaxMana() {
+			v.SetMana(v.MaxMana())
+		}
+	}
+}
+
+// Revive restores the player's health, mana and energy to full. Rage and runic power start empty.
+func (p *Player) Revive() {
+	v := p.Values
+	v.SetHealth(v.MaxHealth())
+	v.SetMana(v.MaxMana())
+	v.SetEnergy(v.MaxEnergy())
+}
+
+// Power returns the player's current amount of their main power type.
+func (p *Player) Power() uint32 {
+	v := p.Values
+
+	switch v.PowerType() {
+	case realmd.PowerTypeMana:
+		return v.Mana()
+	case realmd.PowerTypeRage:
+		return v.Rage()
+	case realmd.PowerTypeFocus:
+		return v.Focus()
+	case realmd.PowerTypeEnergy:
+		return v.Energy()
+	case realmd.PowerTypeRunicPower:
+		return v.RunicPower()
+	default:
+		return 0
+	}
+}
+
+// SetPower changes the player's current amount of their main power type.
+func (p *Player) SetPower(val uint32) {
+	v := p.Values
+
+	switch v.PowerType() {
+	case realmd.PowerTypeMana:
+		v.SetMana(val)
+	case realmd.PowerTypeRage:
+		v.SetRage(val)
+	case realmd.PowerTypeFocus:
+		v.SetFocus(val)
+	case realmd.PowerTypeEnergy:
+		v.SetEnergy(val)
+	case realmd.PowerTypeRunicPower:
+		v.SetRunicPower(val)
+	}
+}
+
+// MaxPower returns the player's maximum amount of their main power type.
+func (p *Player) MaxPower() uint32 {
+	v := p.Values
+
+	switch v.PowerType() {
+	case realmd.PowerTypeMana:
+		return v.MaxMana()
+	case realmd.PowerTypeRage:
+		return v.MaxRage()
+	case realmd.PowerTypeFocus:
+		return v.MaxFocus()
+	case realmd.PowerTypeEnergy:
+		return v.MaxEnergy()
+	case realmd.PowerTypeRunicPower:
+		return v.MaxRunicPower()
+	default:
+		return 0
+	}
+}
+
+// Money returns how much copper the player is carrying.
+func (p *Player) Money() uint32 {
+	return uint32(p.Values.Wealth())
+}
+
+// AddMoney gives the player copper, or takes it away if amount is negative. Returns false without
+// changing the player's money if they don't have enough, or if they would have more than MaxMoney.
+func (p *Player) AddMoney(amount int64) bool {
+	money := int64(p.Money()) + amount
+	if money < 0 || money > MaxMoney {
+		return false
+	}
+
+	p.Values.SetWealth(int32(money))
+	return true
+}
+
+// GMLevel returns the GM level of the player's account.
+func (p *Player) GMLevel() model.GMLevel {
+	if p.Client.Account == nil {
+		return model.GMLevelPlayer
+	}
+	return p.Client.Account.GMLevel
+}
+
+func (p *Player) GUID() realmd.Guid {
+	return realmd.Guid(p.Character.Id)
+}
+
+func (p *Player) Position() realmd.Vector4 {
+	return p.Movement.Position
+}
+
+// SyncCharacter copies the player's state to their character so it can be saved.
+func (p *Player) SyncCharacter() {
+	p.Character.Map = p.MapID
+	p.Character.Zone = p.Zone
+	p.Character.Level = uint8(p.Values.Level())
+	p.Character.Experience = p.Values.Experience()
+	p.Character.ExploredZones = p.exploredZonesBytes()
+
+	p.Character.RestBonus = float32(p.restBonus)
+	p.Character.LogoutResting = p.Values.Resting()
+
+	total, level := p.PlayedTime()
+	p.Character.PlayedTotal = uint32(total.Seconds())
+	p.Character.PlayedLevel = uint32(level.Seconds())
+	p.Character.X = p.Movement.Position.X
+	p.Character.Y = p.Movement.Position.Y
+	p.Character.Z = p.Movement.Position.Z
+	p.Character.Orientation = p.Movement.Position.Rotation
+	p.Character.Money = p.Money()
+	p.Character.ActionBars = p.Values.ActionBarToggles()
+	p.Character.SpecCount = uint8(len(p.specs))
+	p.Character.ActiveSpec = p.activeSpec
+	p.Character.Dead = !p.Alive()
+	p.Character.Ghost = p.Values.Ghost()
+	if c := p.corpse; c != nil {
+		p.Character.CorpseMap = c.MapID
+		p.Character.CorpseX = c.pos.X
+		p.Character.CorpseY = c.pos.Y
+		p.Character.CorpseZ = c.pos.Z
+		p.Character.CorpseOrientation = c.pos.Rotation
+	}
+}
+
+// CanSee reports whether obj is visible to the player.
+func (p *Player) CanSee(obj Object) bool {
+	_, ok := p.visible[obj.GUID()]
+	return ok
+}
+
+// Visible returns the objects the player can see.
+func (p *Player) Visible() []Object {
+	result := make([]Object, 0, len(p.visible))
+	for _, obj := range p.visible {
+		result = append(result, obj)
+	}
+	return result
+}
+
+// VisiblePlayers returns the players that can see this player. Players can always see each other, so
+// these are also the players that this player can see.
+func (p *Player) VisiblePlayers() []*Player {
+	var result []*Player
+	for _, obj := range p.visible {
+		if other, ok := obj.(*Player); ok {
+			result = append(result, other)
+		}
+	}
+	return result
+}
+
+func (p *Player) WriteCreate(u *values.UpdateBuilder, viewer *Player) {
+	movement := values.MovementValues{}
+
+	if viewer == p {
+		movement.Self()
+	}
+
+	living := movement.Living()
+	living.Data(&values.LivingData{
+		Timestamp:          uint32(time.Now().UnixMilli()),
+		PositionRotation:   p.Movement.Position,
+		FallTime:           0,
+		WalkSpeed:          p.Speeds.Walk,
+		RunSpeed:           p.Speeds.Run,
+		ReverseSpeed:       p.Speeds.RunBack,
+		SwimSpeed:          p.Speeds.Swim,
+		SwimReverseSpeed:   p.Speeds.SwimBack,
+		FlightSpeed:        p.Speeds.Flight,
+		FlightReverseSpeed: p.Speeds.FlightBack,
+		TurnRate:           p.Speeds.Turn,
+		PitchRate:          p.Speeds.Pitch,
+	})
+
+	u.CreateObject(p.GUID(), values.ObjectTypePlayer, &movement, p.Values.Marshal(false))
+}
+
+func (p *Player) WriteValues(u *values.UpdateBuilder) bool {
+	if !p.Values.Dirty() {
+		return false
+	}
+
+	u.Values(p.GUID(), p.Values.Marshal(true))
+	return true
+}
+
+// SendUpdate sends the update to the player. Does nothing if the update is empty.
+func (p *Player) SendUpdate(u *values.UpdateBuilder) error {
+	if u.Len() == 0 {
+		return nil
+	}
+
+	return p.Client.SendPacketBytes(realmd.OpServerUpdateObject, u.Bytes())
+}
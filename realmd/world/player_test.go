@@ -0,0 +1,140 @@
+package world
+
+import (
+	"testing"
+
+	"github.com/kangaroux/gomaggus/model"
+	"github.com/kangaroux/gomaggus/realmd"
+	"github.com/kangaroux/gomaggus/realmd/player"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewPlayer(t *testing.T) {
+	tests := []struct {
+		name        string
+		char        model.Character
+		displayID   uint32
+		level       uint32
+		health      uint32
+		powerType   realmd.PowerType
+		maxPower    uint32
+		faction     uint32
+		firstSkills []uint16
+	}{
+		{
+			name:        "human female warrior",
+			char:        model.Character{Race: model.RaceHuman, Class: model.ClassWarrior, Gender: model.GenderFemale},
+			displayID:   50,
+			level:       1,
+			health:      60,
+			powerType:   realmd.PowerTypeRage,
+			maxPower:    1000,
+			faction:     1,
+			firstSkills: []uint16{player.SkillLanguageCommon, player.SkillRacialHuman},
+		},
+		{
+			name:        "orc male warrior",
+			char:        model.Character{Race: model.RaceOrc, Class: model.ClassWarrior, Gender: model.GenderMale},
+			displayID:   51,
+			level:       1,
+			health:      80,
+			powerType:   realmd.PowerTypeRage,
+			maxPower:    1000,
+			faction:     2,
+			firstSkills: []uint16{player.SkillLanguageOrcish, player.SkillRacialOrc},
+		},
+		{
+			name:        "gnome male mage",
+			char:        model.Character{Race: model.RaceGnome, Class: model.ClassMage, Gender: model.GenderMale},
+			displayID:   1563,
+			level:       1,
+			health:      51,
+			powerType:   realmd.PowerTypeMana,
+			maxPower:    225,
+			faction:     115,
+			firstSkills: []uint16{player.SkillLanguageCommon, player.SkillLanguageGnomish, player.SkillRacialGnome},
+		},
+		{
+			name:      "blood elf female death knight",
+			char:      model.Character{Race: model.RaceBloodElf, Class: model.ClassDeathKnight, Gender: model.GenderFemale},
+			displayID: 15475,
+			level:     55,
+			powerType: realmd.PowerTypeRunicPower,
+			maxPower:  1000,
+			faction:   1610,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := NewPlayer(nil, &tt.char, testData)
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			v := p.Values
+			assert.Equal(t, tt.displayID, v.DisplayID())
+			assert.Equal(t, tt.displayID, v.NativeDisplayID())
+			assert.Equal(t, tt.level, v.Level())
+			assert.Equal(t, tt.powerType, v.PowerType())
+			assert.Equal(t, tt.faction, v.Faction())
+			assert.Equal(t, DefaultSpeeds, p.Speeds)
+
+			if tt.health != 0 {
+				assert.Equal(t, tt.health, v.MaxHealth())
+			}
+			assert.Equal(t, v.MaxHealth(), v.Health())
+
+			switch tt.powerType {
+			case realmd.PowerTypeMana:
+				assert.Equal(t, tt.maxPower, v.MaxMana())
+			case realmd.PowerTypeRage:
+				assert.Equal(t, tt.maxPower, v.MaxRage())
+			case realmd.PowerTypeRunicPower:
+				assert.Equal(t, tt.maxPower, v.MaxRunicPower())
+			}
+
+			skills := v.Skills()
+			for i, id := range tt.firstSkills {
+				assert.Equal(t, id, skills[i].ID)
+			}
+		})
+	}
+
+	t.Run("unknown race", func(t *testing.T) {
+		_, err := NewPlayer(nil, &model.Character{Race: model.RaceGoblin, Class: model.ClassWarrior}, testData)
+		assert.Error(t, err)
+	})
+}
+
+func TestPlayerMoney(t *testing.T) {
+	p, _ := newTestPlayer(1, 0, 0)
+
+	assert.True(t, p.AddMoney(100))
+	assert.Equal(t, uint32(100), p.Money())
+
+	assert.False(t, p.AddMoney(-101), "can't go below zero")
+	assert.Equal(t, uint32(100), p.Money())
+
+	assert.True(t, p.AddMoney(-100))
+	assert.Equal(t, uint32(0), p.Money())
+
+	assert.True(t, p.AddMoney(MaxMoney))
+	assert.False(t, p.AddMoney(1), "can't go over the cap")
+
+	p.SyncCharacter()
+	assert.Equal(t, uint32(MaxMoney), p.Character.Money)
+}
+
+func TestSetLevel(t *testing.T) {
+	p, err := NewPlayer(nil, &model.Character{Race: model.RaceHuman, Class: model.ClassDeathKnight}, testData)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.NoError(t, p.SetLevel(45), "death knights can't go below their start level")
+	assert.Equal(t, uint32(55), p.Values.Level())
+
+	assert.NoError(t, p.SetLevel(player.MaxLevel+1))
+	assert.Equal(t, uint32(player.MaxLevel), p.Values.Level())
+}
@@ -0,0 +1,254 @@
+package world
+
+import (
+	"bytes"
+	"encoding/binary"
+
+	"github.com/kangaroux/gomaggus/realmd"
+)
+
+const (
+	MaxRaidSubgroups     = 8
+	MaxSubgroupMembers   = 5
+	MaxRaidTargetIcons   = 8
+	RaidTargetIconsQuery = 0xFF
+)
+
+// raidTargetUpdate is the type of MSG_RAID_TARGET_UPDATE that is sent.
+const (
+	raidTargetUpdateSingle = 0
+	raidTargetUpdateList   = 1
+)
+
+// subgroupSize returns how many members are in subgroup.
+func (g *Group) subgroupSize(subgroup uint8) int {
+	n := 0
+	for _, m := range g.members {
+		if m.Subgroup == subgroup {
+			n++
+		}
+	}
+	return n
+}
+
+// openSubgroup returns the first subgroup that has room for another member.
+func (g *Group) openSubgroup() uint8 {
+	for i := uint8(0); i < MaxRaidSubgroups; i++ {
+		if g.subgroupSize(i) < MaxSubgroupMembers {
+			return i
+		}
+	}
+	return 0
+}
+
+// ConvertToRaid turns p's party into a raid. Only the leader can do this. The members stay in the first
+// subgroup.
+func (w *World) ConvertToRaid(p *Player) {
+	g := w.groups[p.GUID()]
+	if g == nil {
+		p.sendPartyResult(PartyOperationInvite, "", PartyResultNotInGroup)
+		return
+	}
+
+	if g.Leader != p.GUID() {
+		p.sendPartyResult(PartyOperationInvite, "", PartyResultNotLeader)
+		return
+	}
+
+	if g.Raid() {
+		return
+	}
+
+	g.Type = GroupTypeRaid
+
+	p.sendPartyResult(PartyOperationInvite, "", PartyResultOK)
+	w.sendGroupList(g)
+}
+
+// managedRaid returns p's group if it's a raid and p is the leader or an assistant, otherwise nil.
+func (w *World) managedRaid(p *Player) *Group {
+	g := w.groups[p.GUID()]
+	if g == nil || !g.Raid() || !g.canManage(p.GUID()) {
+		return nil
+	}
+	return g
+}
+
+// ChangeSubgroup moves the member named target to another subgroup in p's raid.
+func (w *World) ChangeSubgroup(p *Player, target string, subgroup uint8) {
+	g := w.managedRaid(p)
+	if g == nil || subgroup >= MaxRaidSubgroups {
+		return
+	}
+
+	m := g.MemberByName(target)
+	if m == nil {
+		p.sendPartyResult(PartyOperationSwap, target, PartyResultTargetNotInGroup)
+		return
+	}
+
+	if m.Subgroup == subgroup {
+		return
+	}
+
+	if g.subgroupSize(subgroup) >= MaxSubgroupMembers {
+		p.sendPartyResult(PartyOperationSwap, "", PartyResultGroupFull)
+		return
+	}
+
+	m.Subgroup = subgroup
+	w.sendGroupList(g)
+}
+
+// SwapSubgroups swaps the subgroups of two members of p's raid.
+func (w *World) SwapSubgroups(p *Player, first, second string) {
+	g := w.managedRaid(p)
+	if g == nil {
+		return
+	}
+
+	a := g.MemberByName(first)
+	if a == nil {
+		p.sendPartyResult(PartyOperationSwap, first, PartyResultTargetNotInGroup)
+		return
+	}
+
+	b := g.MemberByName(second)
+	if b == nil {
+		p.sendPartyResult(PartyOperationSwap, second, PartyResultTargetNotInGroup)
+		return
+	}
+
+	a.Subgroup, b.Subgroup = b.Subgroup, a.Subgroup
+	w.sendGroupList(g)
+}
+
+// SetGroupAssistant promotes the member with guid to assistant in p's raid, or demotes them if assistant
+// is false. Only the leader can do this.
+func (w *World) SetGroupAssistant(p *Player, guid realmd.Guid, assistant bool) {
+	g := w.groups[p.GUID()]
+	if g == nil || !g.Raid() || g.Leader != p.GUID() {
+		return
+	}
+
+	m := g.Member(guid)
+	if m == nil || m.Guid == g.Leader {
+		return
+	}
+
+	if assistant {
+		m.Flags |= GroupMemberFlagAssistant
+	} else {
+		m.Flags &^= GroupMemberFlagAssistant
+	}
+
+	w.sendGroupList(g)
+}
+
+// StartReadyCheck asks everyone in p's group if they are ready. Only the leader and assistants can do
+// this.
+// https://gtker.com/wow_messages/docs/msg_raid_ready_check_server.html
+func (w *World) StartReadyCheck(p *Player) {
+	g := w.groups[p.GUID()]
+	if g == nil || !g.canManage(p.GUID()) {
+		return
+	}
+
+	g.readyCheck = true
+
+	data := make([]byte, 8)
+	binary.LittleEndian.PutUint64(data, uint64(p.GUID()))
+	g.broadcast(realmd.OpServerRaidReadyCheck, data)
+}
+
+// ReadyCheckResponse tells the leader and assistants of p's group whether p is ready.
+// https://gtker.com/wow_messages/docs/msg_raid_ready_check_confirm_server.html
+func (w *World) ReadyCheckResponse(p *Player, ready bool) {
+	g := w.groups[p.GUID()]
+	if g == nil || !g.readyCheck {
+		return
+	}
+
+	buf := bytes.Buffer{}
+	binary.Write(&buf, binary.LittleEndian, p.GUID())
+	binary.Write(&buf, binary.LittleEndian, ready)
+
+	for _, m := range g.members {
+		if m.player != nil && g.canManage(m.Guid) {
+			m.player.sendPacket(realmd.OpServerRaidReadyCheckConfirm, buf.Bytes())
+		}
+	}
+}
+
+// FinishReadyCheck ends the ready check in p's group. This is sent by the client that started the check
+// once its timer runs out.
+func (w *World) FinishReadyCheck(p *Player) {
+	g := w.groups[p.GUID()]
+	if g == nil || !g.readyCheck || !g.canManage(p.GUID()) {
+		return
+	}
+
+	g.readyCheck = false
+
+	// https://gtker.com/wow_messages/docs/msg_raid_ready_check_finished.html
+	g.broadcast(realmd.OpServerRaidReadyCheckFinished, nil)
+}
+
+// SetRaidTargetIcon marks target with one of the raid target icons, or clears the icon if target is 0. A
+// target can only have one icon, and each icon can only be on one target. In a raid, only the leader and
+// assistants can mark targets.
+func (w *World) SetRaidTargetIcon(p *Player, icon uint8, target realmd.Guid) {
+	g := w.groups[p.GUID()]
+	if g == nil || icon >= MaxRaidTargetIcons {
+		return
+	}
+
+	if g.Raid() && !g.canManage(p.GUID()) {
+		return
+	}
+
+	if target != 0 {
+		for i, guid := range g.icons {
+			if guid == target && uint8(i) != icon {
+				g.setIcon(p, uint8(i), 0)
+			}
+		}
+	}
+
+	g.setIcon(p, icon, target)
+}
+
+// setIcon changes which target has icon and tells the group.
+// https://gtker.com/wow_messages/docs/msg_raid_target_update_server.html
+func (g *Group) setIcon(p *Player, icon uint8, target realmd.Guid) {
+	g.icons[icon] = target
+
+	buf := bytes.Buffer{}
+	buf.WriteByte(raidTargetUpdateSingle)
+	binary.Write(&buf, binary.LittleEndian, p.GUID())
+	buf.WriteByte(icon)
+	binary.Write(&buf, binary.LittleEndian, target)
+
+	g.broadcast(realmd.OpServerRaidTargetUpdate, buf.Bytes())
+}
+
+// SendRaidTargetIcons sends p the targets that are marked in their group.
+func (w *World) SendRaidTargetIcons(p *Player) {
+	g := w.groups[p.GUID()]
+	if g == nil {
+		return
+	}
+
+	buf := bytes.Buffer{}
+	buf.WriteByte(raidTargetUpdateList)
+
+	for i, guid := range g.icons {
+		if guid == 0 {
+			continue
+		}
+		buf.WriteByte(uint8(i))
+		binary.Write(&buf, binary.LittleEndian, guid)
+	}
+
+	p.sendPacket(realmd.OpServerRaidTargetUpdate, buf.Bytes())
+}
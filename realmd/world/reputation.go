@@ -0,0 +1,220 @@
+package world
+
+import (
+	"encoding/binary"
+
+	"github.com/kangaroux/gomaggus/realmd"
+	"github.com/kangaroux/gomaggus/realmd/dbc"
+)
+
+// FactionFlag describes how a faction is shown in the player's reputation list.
+// https://gtker.com/wow_messages/docs/factionflag.html
+type FactionFlag uint8
+
+const (
+	FactionFlagVisible         FactionFlag = 0x01
+	FactionFlagAtWar           FactionFlag = 0x02
+	FactionFlagHidden          FactionFlag = 0x04
+	FactionFlagInvisibleForced FactionFlag = 0x08
+	FactionFlagPeaceForced     FactionFlag = 0x10
+	FactionFlagInactive        FactionFlag = 0x20
+	FactionFlagRival           FactionFlag = 0x40
+	FactionFlagSpecial         FactionFlag = 0x80
+)
+
+const (
+	// MaxReputations is how many factions are in the client's reputation list.
+	MaxReputations = 128
+
+	// A player's reputation with a faction can't go outside of MinReputation and MaxReputation.
+	MinReputation = -42000
+	MaxReputation = 42999
+
+	// Players are at war with factions once their reputation drops below reputationHostile.
+	reputationHostile = -6000
+
+	SpellEffectReputation = 103
+)
+
+// Reputation is the player's reputation with a faction.
+type Reputation struct {
+	Faction *dbc.Faction
+
+	// Base and BaseFlags are what characters of the player's race and class start with.
+	Base      int32
+	BaseFlags FactionFlag
+
+	// Standing is how much the player's reputation changed from Base.
+	Standing int32
+	Flags    FactionFlag
+}
+
+// NewReputation creates a reputation for faction with the starting reputation for a race and class.
+func NewReputation(faction *dbc.Faction, base int32, flags FactionFlag) *Reputation {
+	return &Reputation{Faction: faction, Base: base, BaseFlags: flags, Flags: flags}
+}
+
+// Value returns the player's reputation with the faction.
+func (r *Reputation) Value() int32 {
+	return r.Base + r.Standing
+}
+
+// Changed reports whether the reputation is different from what the player started with.
+func (r *Reputation) Changed() bool {
+	return r.Standing != 0 || r.Flags != r.BaseFlags
+}
+
+// SetReputations sets the player's reputations. Factions that aren't in the reputation list are ignored.
+func (p *Player) SetReputations(reps []*Reputation) {
+	p.reputations = [MaxReputations]*Reputation{}
+	for _, r := range reps {
+		if i := r.Faction.ReputationIndex; i >= 0 && i < MaxReputations {
+			p.reputations[i] = r
+		}
+	}
+}
+
+// Reputations returns the player's reputations in the order of the reputation list.
+func (p *Player) Reputations() []*Reputation {
+	var result []*Reputation
+	for _, r := range p.reputations {
+		if r != nil {
+			result = append(result, r)
+		}
+	}
+	return result
+}
+
+// Reputation returns the player's reputation with a faction, or nil if the faction doesn't have one.
+func (p *Player) Reputation(faction uint32) *Reputation {
+	for _, r := range p.reputations {
+		if r != nil && r.Faction.ID == faction {
+			return r
+		}
+	}
+	return nil
+}
+
+// ModifyReputation changes the player's reputation with a faction by amount. The faction is shown in their
+// reputation list, and they go to war with it if they become hostile. Returns false if the faction doesn't
+// have a reputation.
+func (w *World) ModifyReputation(p *Player, faction uint32, amount int32) bool {
+	r := p.Reputation(faction)
+	if r == nil {
+		return false
+	}
+
+	value := int64(r.Value()) + int64(amount)
+	if value < MinReputation {
+		value = MinReputation
+	} else if value > MaxReputation {
+		value = MaxReputation
+	}
+	r.Standing = int32(value) - r.Base
+
+	if r.Flags&(FactionFlagVisible|FactionFlagHidden|FactionFlagInvisibleForced) == 0 {
+		r.Flags |= FactionFlagVisible
+		p.sendFactionVisible(r)
+	}
+	if value < reputationHostile && r.Flags&FactionFlagPeaceForced == 0 {
+		r.Flags |= FactionFlagAtWar
+	}
+
+	p.sendFactionStanding(r, amount > 0)
+	return true
+}
+
+// SetFactionAtWar declares or ends war with the faction at index in the player's reputation list. Players
+// can't change it for factions that are always at peace, or that they're hostile with.
+func (w *World) SetFactionAtWar(p *Player, index uint32, atWar bool) {
+	if index >= MaxReputations || p.reputations[index] == nil {
+		return
+	}
+
+	r := p.reputations[index]
+	if r.Flags&(FactionFlagPeaceForced|FactionFlagInvisibleForced) != 0 {
+		return
+	}
+
+	if atWar {
+		r.Flags |= FactionFlagAtWar
+	} else if r.Value() >= reputationHostile {
+		r.Flags &^= FactionFlagAtWar
+	}
+}
+
+// SetFactionInactive moves the faction at index in the player's reputation list to or from the inactive
+// section.
+func (w *World) SetFactionInactive(p *Player, index uint32, inactive bool) {
+	if index >= MaxReputations || p.reputations[index] == nil {
+		return
+	}
+
+	r := p.reputations[index]
+	if r.Flags&(FactionFlagHidden|FactionFlagInvisibleForced) != 0 || r.Flags&FactionFlagVisible == 0 {
+		return
+	}
+
+	if inactive {
+		r.Flags |= FactionFlagInactive
+	} else {
+		r.Flags &^= FactionFlagInactive
+	}
+}
+
+// https://gtker.com/wow_messages/docs/smsg_set_faction_visible.html
+func (p *Player) sendFactionVisible(r *Reputation) {
+	buf := make([]byte, 4)
+	binary.LittleEndian.PutUint32(buf, uint32(r.Faction.ReputationIndex))
+	p.sendPacket(realmd.OpServerSetFactionVisible, buf)
+}
+
+type factionStanding struct {
+	Index    uint32
+	Standing int32
+}
+
+type setFactionStanding struct {
+	ReferAFriendBonus float32
+	Increased         bool
+	Count             uint32
+	Factions          []factionStanding `binary:"[Count]Any"`
+}
+
+// https://gtker.com/wow_messages/docs/smsg_set_faction_standing.html#client-version-335
+func (p *Player) sendFactionStanding(r *Reputation, increased bool) {
+	resp := setFactionStanding{
+		Increased: increased,
+		Count:     1,
+		Factions:  []factionStanding{{Index: uint32(r.Faction.ReputationIndex), Standing: r.Standing}},
+	}
+	if err := p.Client.SendPacket(realmd.OpServerSetFactionStanding, &resp); err != nil {
+		p.Client.Log.Error().Err(err).Msg("error sending faction standing")
+	}
+}
+
+type factionInit struct {
+	Flags    FactionFlag
+	Standing int32
+}
+
+type initializeFactions struct {
+	Count    uint32
+	Factions [MaxReputations]factionInit
+}
+
+// SendInitializeFactions sends the reputation list. The client adds the starting reputation for the
+// player's race and class to each standing. It's sent before the player is added to the world, so it
+// doesn't need one.
+// https://gtker.com/wow_messages/docs/smsg_initialize_factions.html#client-version-3
+func SendInitializeFactions(client *realmd.Client, reps []*Reputation) error {
+	resp := initializeFactions{Count: MaxReputations}
+
+	for _, r := range reps {
+		if i := r.Faction.ReputationIndex; i >= 0 && i < MaxReputations {
+			resp.Factions[i] = factionInit{Flags: r.Flags, Standing: r.Standing}
+		}
+	}
+
+	return client.SendPacket(realmd.OpServerFactionReputation, &resp)
+}
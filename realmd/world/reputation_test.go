@@ -0,0 +1,64 @@
+package world
+
+import (
+	"testing"
+
+	"github.com/kangaroux/gomaggus/realmd/dbc"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestModifyReputation(t *testing.T) {
+	w := New(nil)
+	p, _ := newTestPlayer(1, 0, 0)
+	assert.NoError(t, w.AddPlayer(p))
+
+	stormwind := NewReputation(&dbc.Faction{ID: 72, ReputationIndex: 7}, 3100, FactionFlagVisible)
+	defias := NewReputation(&dbc.Faction{ID: 15, ReputationIndex: 1}, -3000, 0)
+	hidden := NewReputation(&dbc.Faction{ID: 1, ReputationIndex: 2}, 0, FactionFlagHidden)
+	p.SetReputations([]*Reputation{stormwind, defias, hidden, NewReputation(&dbc.Faction{ID: 2, ReputationIndex: -1}, 0, 0)})
+	assert.Len(t, p.Reputations(), 3)
+
+	assert.False(t, w.ModifyReputation(p, 2, 100), "no reputation")
+
+	assert.True(t, w.ModifyReputation(p, 72, 100000))
+	assert.Equal(t, int32(MaxReputation), stormwind.Value())
+	assert.Equal(t, int32(MaxReputation-3100), stormwind.Standing)
+	assert.True(t, stormwind.Changed())
+
+	assert.True(t, w.ModifyReputation(p, 15, -4000))
+	assert.Equal(t, int32(-7000), defias.Value())
+	assert.Equal(t, FactionFlagVisible|FactionFlagAtWar, defias.Flags)
+
+	assert.True(t, w.ModifyReputation(p, 1, 10))
+	assert.Zero(t, hidden.Flags&FactionFlagVisible)
+}
+
+func TestSetFactionFlags(t *testing.T) {
+	w := New(nil)
+	p, _ := newTestPlayer(1, 0, 0)
+	assert.NoError(t, w.AddPlayer(p))
+
+	stormwind := NewReputation(&dbc.Faction{ID: 72, ReputationIndex: 7}, 3100, FactionFlagVisible)
+	darnassus := NewReputation(&dbc.Faction{ID: 69, ReputationIndex: 8}, 3100, FactionFlagVisible|FactionFlagPeaceForced)
+	hostile := NewReputation(&dbc.Faction{ID: 15, ReputationIndex: 1}, -10000, FactionFlagVisible|FactionFlagAtWar)
+	p.SetReputations([]*Reputation{stormwind, darnassus, hostile})
+
+	w.SetFactionAtWar(p, 7, true)
+	assert.NotZero(t, stormwind.Flags&FactionFlagAtWar)
+	w.SetFactionAtWar(p, 7, false)
+	assert.Zero(t, stormwind.Flags&FactionFlagAtWar)
+
+	w.SetFactionAtWar(p, 8, true)
+	assert.Zero(t, darnassus.Flags&FactionFlagAtWar, "peace is forced")
+
+	w.SetFactionAtWar(p, 1, false)
+	assert.NotZero(t, hostile.Flags&FactionFlagAtWar, "still hostile")
+
+	w.SetFactionInactive(p, 7, true)
+	assert.NotZero(t, stormwind.Flags&FactionFlagInactive)
+	w.SetFactionInactive(p, 7, false)
+	assert.Zero(t, stormwind.Flags&FactionFlagInactive)
+
+	w.SetFactionAtWar(p, 200, true)
+	w.SetFactionInactive(p, 3, true)
+}
@@ -0,0 +1,175 @@
+package world
+
+import (
+	"time"
+
+	"github.com/kangaroux/gomaggus/realmd"
+	"github.com/kangaroux/gomaggus/realmd/dbc"
+	"github.com/kangaroux/gomaggus/realmd/player"
+)
+
+const (
+	// Players get restedPercent of a level as rested experience for every restedInterval they spend
+	// resting. Players that logged out outside of a rest area get wildernessRestRate as much while they're
+	// offline.
+	restedPercent      = 5
+	restedInterval     = 8 * time.Hour
+	wildernessRestRate = 0.25
+
+	// Rested experience can't go over maxRestLevels of the player's current level.
+	maxRestLevels = 1.5
+
+	// https://gtker.com/wow_messages/docs/reststate.html
+	restStateRested = 0x01
+	restStateNormal = 0x02
+
+	// The faction group masks of areas that belong to the Alliance and Horde.
+	areaFactionAlliance = 0x2
+	areaFactionHorde    = 0x4
+
+	// SpellFreeze is the aura GMs use to freeze players in place.
+	SpellFreeze = 9454
+)
+
+// restFlag is a place the player can rest in.
+type restFlag uint8
+
+const (
+	restFlagCity restFlag = 1 << iota
+	restFlagInn
+)
+
+// RestBonus returns how much rested experience the player has.
+func (p *Player) RestBonus() float64 {
+	return p.restBonus
+}
+
+// setRestBonus changes how much rested experience the player has, up to the max for their level.
+func (p *Player) setRestBonus(bonus float64) {
+	v := p.Values
+
+	if max := float64(v.NextLevelExperience()) * maxRestLevels; bonus > max {
+		bonus = max
+	} else if bonus < 0 {
+		bonus = 0
+	}
+	p.restBonus = bonus
+
+	v.SetRestStateExperience(uint32(bonus))
+	if bonus >= 1 {
+		v.SetRestState(restStateRested)
+	} else {
+		v.SetRestState(restStateNormal)
+	}
+}
+
+// addRest gives the player rested experience for resting for d. rate is how fast they're resting.
+func (p *Player) addRest(d time.Duration, rate float64) {
+	levels := float64(restedPercent) / 100 * float64(d) / float64(restedInterval) * rate
+	p.setRestBonus(p.restBonus + levels*float64(p.Values.NextLevelExperience()))
+}
+
+// takeRestBonus uses up the player's rested experience for a kill worth xp. Returns the extra experience
+// they get, which is never more than xp.
+func (p *Player) takeRestBonus(xp uint32) uint32 {
+	bonus := uint32(p.restBonus)
+	if bonus > xp {
+		bonus = xp
+	}
+	p.setRestBonus(p.restBonus - float64(bonus))
+	return bonus
+}
+
+// setRestFlags changes where the player is resting. They're resting as long as they're in one place to rest.
+func (p *Player) setRestFlags(flags restFlag) {
+	p.restFlags = flags
+	p.Values.SetResting(flags != 0)
+}
+
+// Frozen reports whether a GM froze the player.
+func (p *Player) Frozen() bool {
+	for _, a := range p.auras {
+		if a.Spell.ID == SpellFreeze {
+			return true
+		}
+	}
+	return false
+}
+
+// UpdateZoneRest is called when the player enters a zone. zoneMap is the map of the zone. Players rest in
+// their own faction's capital cities until they leave the city's map.
+func (w *World) UpdateZoneRest(p *Player, zone *dbc.Area, zoneMap *dbc.WorldMapArea) {
+	capital := zone != nil && zoneMap != nil && zone.Flags&dbc.AreaFlagCapital != 0
+
+	if capital && zone.FactionGroupMask != 0 {
+		team := uint32(areaFactionAlliance)
+		if p.Horde {
+			team = areaFactionHorde
+		}
+		capital = zone.FactionGroupMask&team != 0
+	}
+
+	if capital {
+		p.city = zoneMap
+		p.setRestFlags(p.restFlags | restFlagCity)
+	} else {
+		p.city = nil
+		p.setRestFlags(p.restFlags &^ restFlagCity)
+	}
+}
+
+// EnterInn is called when the player walks into an inn's area trigger. They rest until they leave it.
+func (w *World) EnterInn(p *Player, trigger *dbc.AreaTrigger) {
+	if !player.IsTavern(trigger.ID) {
+		return
+	}
+
+	p.inn = trigger
+	p.setRestFlags(p.restFlags | restFlagInn)
+}
+
+// updateRest gives the player rested experience while they're resting, and checks if they left the inn or
+// city.
+func (w *World) updateRest(p *Player, diff time.Duration) {
+	pos := p.Movement.Position
+
+	if p.inn != nil && !p.inn.Contains(p.MapID, pos.X, pos.Y, pos.Z) {
+		p.inn = nil
+		p.setRestFlags(p.restFlags &^ restFlagInn)
+	}
+
+	if p.city != nil && !p.city.Contains(p.MapID, pos.X, pos.Y) {
+		p.city = nil
+		p.setRestFlags(p.restFlags &^ restFlagCity)
+	}
+
+	if p.restFlags != 0 {
+		p.addRest(diff, 1)
+	}
+}
+
+// StartLogout sits the player down and stops them from moving while they wait to be logged out.
+func (w *World) StartLogout(p *Player) {
+	p.Values.SetIsStunned(true)
+	w.setStandState(p, player.StateSit)
+}
+
+// CancelLogout lets the player move again after they cancelled logging out.
+func (w *World) CancelLogout(p *Player) {
+	p.Values.SetIsStunned(false)
+	w.setStandState(p, player.StateStand)
+}
+
+type standStateUpdate struct {
+	State player.StandState
+}
+
+// https://gtker.com/wow_messages/docs/smsg_standstate_update.html
+func (w *World) setStandState(p *Player, state player.StandState) {
+	p.Values.SetStandState(uint8(state))
+
+	resp := standStateUpdate{State: state}
+	if err := p.Client.SendPacket(realmd.OpServerStandState, &resp); err != nil {
+		p.Client.Log.Error().Err(err).Msg("error sending stand state")
+	}
+}
@@ -0,0 +1,106 @@
+package world
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/kangaroux/gomaggus/realmd/dbc"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRestBonus(t *testing.T) {
+	p, _ := newTestPlayer(1, 0, 0)
+	v := p.Values
+
+	assert.Equal(t, uint8(restStateNormal), v.RestState())
+
+	p.addRest(restedInterval, 1)
+	assert.Equal(t, uint32(20), v.RestStateExperience(), "5% of 400")
+	assert.Equal(t, uint8(restStateRested), v.RestState())
+
+	p.addRest(1000*restedInterval, 1)
+	assert.Equal(t, uint32(600), v.RestStateExperience(), "capped at a level and a half")
+
+	assert.Equal(t, uint32(50), p.takeRestBonus(50))
+	assert.Equal(t, float64(550), p.RestBonus())
+}
+
+func TestOfflineRest(t *testing.T) {
+	p, _ := newTestPlayer(1, 0, 0)
+	char := p.Character
+	char.LogoutAt = sql.NullTime{Time: time.Now().Add(-restedInterval), Valid: true}
+
+	char.LogoutResting = true
+	rested, err := NewPlayer(p.Client, char, testData)
+	assert.NoError(t, err)
+	assert.InDelta(t, 20, rested.RestBonus(), 0.01)
+
+	char.LogoutResting = false
+	wild, err := NewPlayer(p.Client, char, testData)
+	assert.NoError(t, err)
+	assert.InDelta(t, 5, wild.RestBonus(), 0.01)
+}
+
+func TestRewardKillRested(t *testing.T) {
+	w := New(nil)
+	p, _ := newTestPlayer(1, 0, 0)
+	assert.NoError(t, w.AddPlayer(p))
+	p.setRestBonus(30)
+
+	assert.NoError(t, w.RewardKill(p, 99, 1))
+	assert.Equal(t, uint32(80), p.Values.Experience(), "50 for the kill and 30 rested")
+	assert.Equal(t, float64(0), p.RestBonus())
+}
+
+func TestRestAreas(t *testing.T) {
+	w := New(nil)
+	p, _ := newTestPlayer(1, 0, 0)
+	assert.NoError(t, w.AddPlayer(p))
+
+	stormwind := &dbc.Area{ID: 1519, Flags: dbc.AreaFlagCapital, FactionGroupMask: areaFactionAlliance}
+	orgrimmar := &dbc.Area{ID: 1637, Flags: dbc.AreaFlagCapital, FactionGroupMask: areaFactionHorde}
+	cityMap := &dbc.WorldMapArea{Left: 50, Right: -50, Top: 50, Bottom: -50}
+
+	w.UpdateZoneRest(p, orgrimmar, cityMap)
+	assert.False(t, p.Values.Resting(), "enemy capital")
+	w.UpdateZoneRest(p, stormwind, nil)
+	assert.False(t, p.Values.Resting(), "no map")
+	w.UpdateZoneRest(p, stormwind, cityMap)
+	assert.True(t, p.Values.Resting())
+	w.UpdateZoneRest(p, &dbc.Area{ID: 12}, cityMap)
+	assert.False(t, p.Values.Resting())
+
+	w.UpdateZoneRest(p, stormwind, cityMap)
+	p.Movement.Position.X = 100
+	w.updateRest(p, time.Second)
+	assert.False(t, p.Values.Resting(), "left the city")
+	p.Movement.Position.X = 0
+
+	inn := &dbc.AreaTrigger{ID: 562, Radius: 10}
+	w.EnterInn(p, &dbc.AreaTrigger{ID: 1, Radius: 10})
+	assert.False(t, p.Values.Resting(), "not an inn")
+	w.EnterInn(p, inn)
+	assert.True(t, p.Values.Resting())
+
+	w.updateRest(p, restedInterval)
+	assert.InDelta(t, 20, p.RestBonus(), 0.01)
+
+	p.Movement.Position.X = 100
+	w.updateRest(p, time.Second)
+	assert.False(t, p.Values.Resting(), "left the inn")
+}
+
+func TestLogoutStandState(t *testing.T) {
+	w := New(nil)
+	p, _ := newTestPlayer(1, 0, 0)
+	assert.NoError(t, w.AddPlayer(p))
+
+	w.StartLogout(p)
+	assert.True(t, p.Values.IsStunned())
+	assert.Equal(t, uint8(1), p.Values.StandState())
+
+	w.CancelLogout(p)
+	assert.False(t, p.Values.IsStunned())
+	assert.Equal(t, uint8(0), p.Values.StandState())
+}
@@ -0,0 +1,197 @@
+package world
+
+import (
+	"math/rand"
+
+	"github.com/kangaroux/gomaggus/realmd/dbc"
+	"github.com/kangaroux/gomaggus/realmd/player"
+	"github.com/kangaroux/gomaggus/realmd/values"
+)
+
+// MaxSkills is how many skills a player can know.
+const MaxSkills = 128
+
+// SetSkills replaces the skills the player knows. Skills past MaxSkills are ignored.
+func (p *Player) SetSkills(skills []player.Skill) {
+	var entries [MaxSkills]values.SkillEntry
+	for i, s := range skills {
+		if i >= MaxSkills {
+			break
+		}
+		entries[i] = skillEntry(s)
+	}
+	p.Values.SetSkills(entries)
+	p.updateSkillsForLevel()
+}
+
+// Skills returns the skills the player knows.
+func (p *Player) Skills() []player.Skill {
+	var result []player.Skill
+	for _, e := range p.Values.Skills() {
+		if e.ID != 0 {
+			result = append(result, player.Skill{ID: e.ID, Value: e.SkillLevel, Max: e.SkillCap})
+		}
+	}
+	return result
+}
+
+// Skill returns the player's skill with id. ok is false if they don't know it.
+func (p *Player) Skill(id uint16) (skill player.Skill, ok bool) {
+	for _, e := range p.Values.Skills() {
+		if e.ID == id {
+			return player.Skill{ID: e.ID, Value: e.SkillLevel, Max: e.SkillCap}, true
+		}
+	}
+	return player.Skill{}, false
+}
+
+// SetSkill teaches the player a skill, or changes the value and max of one they already know. The value is
+// capped at the max. Returns false if they don't know it and can't learn any more skills.
+func (p *Player) SetSkill(skill player.Skill) bool {
+	if skill.Value > skill.Max {
+		skill.Value = skill.Max
+	}
+
+	entries := p.Values.Skills()
+	free := -1
+	for i, e := range entries {
+		if e.ID == skill.ID {
+			free = i
+			break
+		}
+		if e.ID == 0 && free < 0 {
+			free = i
+		}
+	}
+	if free < 0 {
+		return false
+	}
+
+	entries[free] = skillEntry(skill)
+	p.Values.SetSkills(entries)
+	return true
+}
+
+func skillEntry(s player.Skill) values.SkillEntry {
+	e := values.SkillEntry{ID: s.ID, SkillLevel: s.Value, SkillCap: s.Max}
+
+	// Professions show which rank they're at
+	if t := player.SkillTypeOf(s.ID); t == player.SkillTypeProfession || t == player.SkillTypeRiding {
+		e.Step = s.Max / player.ProfessionRank
+	}
+
+	return e
+}
+
+// updateSkillsForLevel raises the max of the player's weapon and class skills for their level. Class
+// skills are always maxed out.
+func (p *Player) updateSkillsForLevel() {
+	max := player.MaxSkillForLevel(p.Values.Level())
+	entries := p.Values.Skills()
+
+	for i, e := range entries {
+		if e.ID == 0 {
+			continue
+		}
+
+		switch player.SkillTypeOf(e.ID) {
+		case player.SkillTypeWeapon:
+			entries[i].SkillCap = max
+			if e.SkillLevel > max {
+				entries[i].SkillLevel = max
+			}
+
+		case player.SkillTypeClass:
+			entries[i].SkillLevel = max
+			entries[i].SkillCap = max
+		}
+	}
+
+	p.Values.SetSkills(entries)
+}
+
+// SkillGainChance returns the percent chance of a skill going up when using something with the given
+// difficulty. The chance depends on its colour: orange always gives a point, yellow usually does, green
+// rarely does and gray never does.
+func SkillGainChance(value, yellow, green, gray uint16) int {
+	switch {
+	case value >= gray:
+		return 0
+	case value >= green:
+		return 25
+	case value >= yellow:
+		return 75
+	default:
+		return 100
+	}
+}
+
+// gainSkill raises the player's skill by a point with a percent chance. Returns true if it went up.
+func (p *Player) gainSkill(id uint16, chance float64) bool {
+	skill, ok := p.Skill(id)
+	if !ok || skill.Value >= skill.Max || chance <= 0 {
+		return false
+	}
+
+	if chance < 100 && rand.Float64()*100 >= chance {
+		return false
+	}
+
+	skill.Value++
+	return p.SetSkill(skill)
+}
+
+// updateCastSkill gives the player a chance to raise the skill that a recipe or other ability belongs to
+// after they cast it.
+func (p *Player) updateCastSkill(ability *dbc.SkillLineAbility) {
+	if ability.TrivialHigh == 0 {
+		return
+	}
+
+	yellow := uint16(ability.TrivialLow)
+	gray := uint16(ability.TrivialHigh)
+	green := (yellow + gray) / 2
+
+	skill, ok := p.Skill(uint16(ability.SkillLine))
+	if !ok {
+		return
+	}
+
+	p.gainSkill(skill.ID, float64(SkillGainChance(skill.Value, yellow, green, gray)))
+}
+
+// UpdateCombatSkill gives the player a chance to raise a weapon skill or their defense after fighting
+// something at victimLevel. The further the skill is below the max for the player's level, the more likely
+// it is to go up. Returns true if it went up.
+func (w *World) UpdateCombatSkill(p *Player, id uint16, victimLevel uint32) bool {
+	level := p.Values.Level()
+	gray := player.GrayLevel(level)
+	if victimLevel < gray {
+		return false
+	}
+	if victimLevel > level+5 {
+		victimLevel = level + 5
+	}
+
+	skill, ok := p.Skill(id)
+	if !ok {
+		return false
+	}
+
+	max := player.MaxSkillForLevel(level)
+	if skill.Value >= max {
+		return false
+	}
+
+	diff := victimLevel - gray
+	if diff < 3 {
+		diff = 3
+	}
+
+	chance := float64(3*diff*uint32(max-skill.Value)) / float64(level)
+	if chance < 1 {
+		chance = 1
+	}
+
+	return p.gainSkill(id, chance)
+}
@@ -0,0 +1,93 @@
+package world
+
+import (
+	"testing"
+
+	"github.com/kangaroux/gomaggus/realmd/dbc"
+	"github.com/kangaroux/gomaggus/realmd/player"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetSkill(t *testing.T) {
+	p, _ := newTestPlayer(1, 0, 0)
+
+	_, ok := p.Skill(player.SkillMining)
+	assert.False(t, ok)
+
+	assert.True(t, p.SetSkill(player.Skill{ID: player.SkillMining, Value: 200, Max: 150}))
+	skill, ok := p.Skill(player.SkillMining)
+	assert.True(t, ok)
+	assert.Equal(t, player.Skill{ID: player.SkillMining, Value: 150, Max: 150}, skill, "value is capped")
+
+	for _, e := range p.Values.Skills() {
+		if e.ID == player.SkillMining {
+			assert.Equal(t, uint16(2), e.Step)
+		}
+	}
+
+	count := len(p.Skills())
+	assert.True(t, p.SetSkill(player.Skill{ID: player.SkillMining, Value: 1, Max: 75}))
+	assert.Len(t, p.Skills(), count, "updates the skill that's already known")
+}
+
+func TestSkillsForLevel(t *testing.T) {
+	p, _ := newTestPlayer(1, 0, 0)
+	p.SetSkill(player.Skill{ID: 6, Value: 5, Max: 5})
+
+	staves, ok := p.Skill(player.SkillStaves)
+	assert.True(t, ok)
+	assert.Equal(t, player.Skill{ID: player.SkillStaves, Value: 1, Max: 5}, staves)
+
+	assert.NoError(t, p.SetLevel(10))
+	staves, _ = p.Skill(player.SkillStaves)
+	assert.Equal(t, player.Skill{ID: player.SkillStaves, Value: 1, Max: 50}, staves)
+	frost, _ := p.Skill(6)
+	assert.Equal(t, player.Skill{ID: 6, Value: 50, Max: 50}, frost)
+	common, _ := p.Skill(player.SkillLanguageCommon)
+	assert.Equal(t, uint16(300), common.Max, "languages don't change")
+}
+
+func TestSkillGainChance(t *testing.T) {
+	assert.Equal(t, 100, SkillGainChance(1, 50, 75, 100))
+	assert.Equal(t, 75, SkillGainChance(60, 50, 75, 100))
+	assert.Equal(t, 25, SkillGainChance(80, 50, 75, 100))
+	assert.Equal(t, 0, SkillGainChance(100, 50, 75, 100))
+}
+
+func TestUpdateCastSkill(t *testing.T) {
+	p, _ := newTestPlayer(1, 0, 0)
+	p.SetSkill(player.Skill{ID: player.SkillFirstAid, Value: 1, Max: 75})
+
+	bandage := &dbc.SkillLineAbility{SkillLine: uint32(player.SkillFirstAid), TrivialLow: 30, TrivialHigh: 45}
+	p.updateCastSkill(bandage)
+	skill, _ := p.Skill(player.SkillFirstAid)
+	assert.Equal(t, uint16(2), skill.Value, "orange always goes up")
+
+	p.SetSkill(player.Skill{ID: player.SkillFirstAid, Value: 45, Max: 75})
+	p.updateCastSkill(bandage)
+	skill, _ = p.Skill(player.SkillFirstAid)
+	assert.Equal(t, uint16(45), skill.Value, "gray never goes up")
+
+	p.SetSkill(player.Skill{ID: player.SkillFirstAid, Value: 10, Max: 10})
+	p.updateCastSkill(bandage)
+	skill, _ = p.Skill(player.SkillFirstAid)
+	assert.Equal(t, uint16(10), skill.Value, "already at max")
+}
+
+func TestUpdateCombatSkill(t *testing.T) {
+	w := New(nil)
+	p, _ := newTestPlayer(1, 0, 0)
+	assert.NoError(t, w.AddPlayer(p))
+	assert.NoError(t, p.SetLevel(20))
+
+	assert.False(t, w.UpdateCombatSkill(p, player.SkillStaves, 5), "gray victims don't raise skills")
+	assert.False(t, w.UpdateCombatSkill(p, player.SkillSwords, 20), "doesn't know swords")
+
+	// The skill is far enough below the max that it always goes up
+	assert.True(t, w.UpdateCombatSkill(p, player.SkillStaves, 20))
+	staves, _ := p.Skill(player.SkillStaves)
+	assert.Equal(t, uint16(2), staves.Value)
+
+	p.SetSkill(player.Skill{ID: player.SkillStaves, Value: 100, Max: 100})
+	assert.False(t, w.UpdateCombatSkill(p, player.SkillStaves, 20), "already at max")
+}
@@ -0,0 +1,349 @@
+package world
+
+import (
+	"bytes"
+	"encoding/binary"
+
+	"github.com/kangaroux/gomaggus/model"
+	"github.com/kangaroux/gomaggus/realmd"
+	"github.com/kangaroux/gomaggus/realmd/chat"
+	"github.com/kangaroux/gomaggus/realmd/dbc"
+)
+
+const (
+	// The client doesn't allow more friends or ignored players than this.
+	MaxFriends = 50
+	MaxIgnored = 50
+
+	// MaxContactNoteLength is the longest note a player can have for a friend. Longer notes are cut off.
+	MaxContactNoteLength = 48
+)
+
+// FriendResult is the result of changing a player's friend or ignore list.
+// https://gtker.com/wow_messages/docs/friendresult.html#client-version-335
+type FriendResult uint8
+
+const (
+	FriendResultDBError         FriendResult = 0x00
+	FriendResultListFull        FriendResult = 0x01
+	FriendResultOnline          FriendResult = 0x02
+	FriendResultOffline         FriendResult = 0x03
+	FriendResultNotFound        FriendResult = 0x04
+	FriendResultRemoved         FriendResult = 0x05
+	FriendResultAddedOnline     FriendResult = 0x06
+	FriendResultAddedOffline    FriendResult = 0x07
+	FriendResultAlready         FriendResult = 0x08
+	FriendResultSelf            FriendResult = 0x09
+	FriendResultEnemy           FriendResult = 0x0A
+	FriendResultIgnoreFull      FriendResult = 0x0B
+	FriendResultIgnoreSelf      FriendResult = 0x0C
+	FriendResultIgnoreNotFound  FriendResult = 0x0D
+	FriendResultIgnoreAlready   FriendResult = 0x0E
+	FriendResultIgnoreAdded     FriendResult = 0x0F
+	FriendResultIgnoreRemoved   FriendResult = 0x10
+	FriendResultIgnoreAmbiguous FriendResult = 0x11
+)
+
+// FriendStatus is shown next to online friends in the friend list.
+type FriendStatus uint8
+
+const (
+	FriendStatusOffline FriendStatus = 0x00
+	FriendStatusOnline  FriendStatus = 0x01
+	FriendStatusAFK     FriendStatus = 0x02
+	FriendStatusDND     FriendStatus = 0x04
+)
+
+// SetContacts replaces the player's friend and ignore lists.
+func (p *Player) SetContacts(contacts []*model.Contact) {
+	p.contacts = make(map[realmd.Guid]*model.Contact, len(contacts))
+	for _, c := range contacts {
+		p.contacts[realmd.Guid(c.ContactId)] = c
+	}
+}
+
+// Contact returns the player's contact for guid, or nil if guid isn't a friend or ignored.
+func (p *Player) Contact(guid realmd.Guid) *model.Contact {
+	return p.contacts[guid]
+}
+
+// IsFriend reports whether guid is on the player's friend list.
+func (p *Player) IsFriend(guid realmd.Guid) bool {
+	c := p.contacts[guid]
+	return c != nil && c.Flags&model.ContactFlagFriend != 0
+}
+
+// Ignores reports whether the player is ignoring other. Ignored players can't talk to or invite the
+// player.
+func (p *Player) Ignores(other *Player) bool {
+	c := p.contacts[other.GUID()]
+	return c != nil && c.Flags&model.ContactFlagIgnored != 0
+}
+
+// countContacts returns how many of the player's contacts have flag set.
+func (p *Player) countContacts(flag model.ContactFlags) int {
+	n := 0
+	for _, c := range p.contacts {
+		if c.Flags&flag != 0 {
+			n++
+		}
+	}
+	return n
+}
+
+// FriendStatus returns the status shown to the player's friends.
+func (p *Player) FriendStatus() FriendStatus {
+	switch {
+	case p.Values.AFK():
+		return FriendStatusAFK
+	case p.Values.DND():
+		return FriendStatusDND
+	default:
+		return FriendStatusOnline
+	}
+}
+
+// writeFriendInfo writes the player's status, zone, level and class.
+func (p *Player) writeFriendInfo(buf *bytes.Buffer) {
+	buf.WriteByte(byte(p.FriendStatus()))
+	binary.Write(buf, binary.LittleEndian, p.Zone)
+	binary.Write(buf, binary.LittleEndian, p.Values.Level())
+	binary.Write(buf, binary.LittleEndian, uint32(p.Character.Class))
+}
+
+// sendFriendStatus sends the result of a friend or ignore list change to the player. If friend is set,
+// their status is included.
+// https://gtker.com/wow_messages/docs/smsg_friend_status.html#client-version-335
+func (p *Player) sendFriendStatus(result FriendResult, guid realmd.Guid, note string, friend *Player) {
+	buf := bytes.Buffer{}
+	buf.WriteByte(byte(result))
+	binary.Write(&buf, binary.LittleEndian, guid)
+
+	if result == FriendResultAddedOnline || result == FriendResultAddedOffline {
+		buf.WriteString(note)
+		buf.WriteByte(0)
+	}
+
+	if result == FriendResultAddedOnline || result == FriendResultOnline {
+		friend.writeFriendInfo(&buf)
+	}
+
+	if err := p.Client.SendPacketBytes(realmd.OpServerFriendStatus, buf.Bytes()); err != nil {
+		p.Client.Log.Error().Err(err).Msg("error sending friend status")
+	}
+}
+
+// SendContactList sends the player's contacts that have any of the flags in mask. Online friends include
+// their status.
+// https://gtker.com/wow_messages/docs/smsg_contact_list.html#client-version-335
+func (w *World) SendContactList(p *Player, mask model.ContactFlags) error {
+	var contacts []*model.Contact
+	for _, c := range p.contacts {
+		if c.Flags&mask != 0 {
+			contacts = append(contacts, c)
+		}
+	}
+
+	buf := bytes.Buffer{}
+	binary.Write(&buf, binary.LittleEndian, mask)
+	binary.Write(&buf, binary.LittleEndian, uint32(len(contacts)))
+
+	for _, c := range contacts {
+		binary.Write(&buf, binary.LittleEndian, realmd.Guid(c.ContactId))
+		binary.Write(&buf, binary.LittleEndian, c.Flags)
+		buf.WriteString(c.Note)
+		buf.WriteByte(0)
+
+		if c.Flags&model.ContactFlagFriend == 0 {
+			continue
+		}
+
+		if friend := w.visibleFriend(p, realmd.Guid(c.ContactId)); friend != nil {
+			friend.writeFriendInfo(&buf)
+		} else {
+			buf.WriteByte(byte(FriendStatusOffline))
+		}
+	}
+
+	return p.Client.SendPacketBytes(realmd.OpServerContactList, buf.Bytes())
+}
+
+// visibleFriend returns the friend with guid if they are online and p can see them, otherwise nil.
+// Friends in GM mode appear offline to accounts with a lower GM level.
+func (w *World) visibleFriend(p *Player, guid realmd.Guid) *Player {
+	friend := w.players[guid]
+	if friend == nil {
+		return nil
+	}
+
+	if friend.Values.GM() && friend.GMLevel() > p.GMLevel() {
+		return nil
+	}
+
+	return friend
+}
+
+// AddFriend adds target to the player's friend list. Returns the contact that should be saved, or nil if
+// target couldn't be added. target is nil if the character doesn't exist.
+func (w *World) AddFriend(p *Player, target *model.Character, note string, data *dbc.Store) *model.Contact {
+	if target == nil {
+		p.sendFriendStatus(FriendResultNotFound, 0, "", nil)
+		return nil
+	}
+
+	guid := realmd.Guid(target.Id)
+
+	if guid == p.GUID() {
+		p.sendFriendStatus(FriendResultSelf, guid, "", nil)
+		return nil
+	}
+
+	if race, ok := data.ChrRaces[target.Race]; !ok || race.Horde != p.Horde {
+		p.sendFriendStatus(FriendResultEnemy, guid, "", nil)
+		return nil
+	}
+
+	if p.IsFriend(guid) {
+		p.sendFriendStatus(FriendResultAlready, guid, "", nil)
+		return nil
+	}
+
+	if p.countContacts(model.ContactFlagFriend) >= MaxFriends {
+		p.sendFriendStatus(FriendResultListFull, guid, "", nil)
+		return nil
+	}
+
+	c := p.contacts[guid]
+	if c == nil {
+		c = &model.Contact{CharacterId: p.Character.Id, ContactId: target.Id}
+		p.contacts[guid] = c
+	}
+	c.Flags |= model.ContactFlagFriend
+	c.Note = truncateNote(note)
+
+	if friend := w.visibleFriend(p, guid); friend != nil {
+		p.sendFriendStatus(FriendResultAddedOnline, guid, c.Note, friend)
+	} else {
+		p.sendFriendStatus(FriendResultAddedOffline, guid, c.Note, nil)
+	}
+
+	return c
+}
+
+// RemoveFriend removes guid from the player's friend list. Returns the updated contact, or nil if guid
+// wasn't a friend. If the contact's flags are empty it should be deleted.
+func (w *World) RemoveFriend(p *Player, guid realmd.Guid) *model.Contact {
+	return p.removeContact(guid, model.ContactFlagFriend, FriendResultRemoved, FriendResultNotFound)
+}
+
+// AddIgnore adds target to the player's ignore list. Returns the contact that should be saved, or nil if
+// target couldn't be ignored. target is nil if the character doesn't exist.
+func (w *World) AddIgnore(p *Player, target *model.Character) *model.Contact {
+	if target == nil {
+		p.sendFriendStatus(FriendResultIgnoreNotFound, 0, "", nil)
+		return nil
+	}
+
+	guid := realmd.Guid(target.Id)
+
+	if guid == p.GUID() {
+		p.sendFriendStatus(FriendResultIgnoreSelf, guid, "", nil)
+		return nil
+	}
+
+	c := p.contacts[guid]
+	if c != nil && c.Flags&model.ContactFlagIgnored != 0 {
+		p.sendFriendStatus(FriendResultIgnoreAlready, guid, "", nil)
+		return nil
+	}
+
+	if p.countContacts(model.ContactFlagIgnored) >= MaxIgnored {
+		p.sendFriendStatus(FriendResultIgnoreFull, guid, "", nil)
+		return nil
+	}
+
+	if c == nil {
+		c = &model.Contact{CharacterId: p.Character.Id, ContactId: target.Id}
+		p.contacts[guid] = c
+	}
+	c.Flags |= model.ContactFlagIgnored
+
+	p.sendFriendStatus(FriendResultIgnoreAdded, guid, "", nil)
+
+	return c
+}
+
+// RemoveIgnore removes guid from the player's ignore list. Returns the updated contact, or nil if guid
+// wasn't ignored. If the contact's flags are empty it should be deleted.
+func (w *World) RemoveIgnore(p *Player, guid realmd.Guid) *model.Contact {
+	return p.removeContact(guid, model.ContactFlagIgnored, FriendResultIgnoreRemoved, FriendResultIgnoreNotFound)
+}
+
+func (p *Player) removeContact(guid realmd.Guid, flag model.ContactFlags, removed, notFound FriendResult) *model.Contact {
+	c := p.contacts[guid]
+	if c == nil || c.Flags&flag == 0 {
+		p.sendFriendStatus(notFound, guid, "", nil)
+		return nil
+	}
+
+	c.Flags &^= flag
+	if c.Flags == 0 {
+		delete(p.contacts, guid)
+	}
+
+	p.sendFriendStatus(removed, guid, "", nil)
+
+	return c
+}
+
+// SetContactNote changes the note for one of the player's friends. Returns the contact that should be
+// saved, or nil if guid isn't a contact.
+func (w *World) SetContactNote(p *Player, guid realmd.Guid, note string) *model.Contact {
+	c := p.contacts[guid]
+	if c == nil {
+		return nil
+	}
+
+	c.Note = truncateNote(note)
+	return c
+}
+
+// truncateNote cuts note off at MaxContactNoteLength characters.
+func truncateNote(note string) string {
+	runes := []rune(note)
+	if len(runes) > MaxContactNoteLength {
+		return string(runes[:MaxContactNoteLength])
+	}
+	return note
+}
+
+// notifyFriends tells everyone who has p as a friend that p came online or went offline.
+func (w *World) notifyFriends(p *Player, online bool) {
+	for _, other := range w.players {
+		if other == p || !other.IsFriend(p.GUID()) {
+			continue
+		}
+
+		if p.Values.GM() && p.GMLevel() > other.GMLevel() {
+			continue
+		}
+
+		if online {
+			other.sendFriendStatus(FriendResultOnline, p.GUID(), "", p)
+		} else {
+			other.sendFriendStatus(FriendResultOffline, p.GUID(), "", nil)
+		}
+	}
+}
+
+// sendIgnored tells p that other is ignoring them.
+func (p *Player) sendIgnored(other *Player) error {
+	msg := &chat.Message{
+		Type:     chat.MessageTypeIgnored,
+		Language: chat.LanguageUniversal,
+		Sender:   other.GUID(),
+		Target:   other.GUID(),
+	}
+
+	return p.Client.SendPacketBytes(realmd.OpServerMessageChat, msg.Bytes())
+}
@@ -0,0 +1,114 @@
+package world
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/kangaroux/gomaggus/model"
+	"github.com/kangaroux/gomaggus/realmd/chat"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAddFriend(t *testing.T) {
+	w := New(nil)
+	p, conn := newTestPlayer(1, 0, 0)
+	other, _ := newTestPlayer(2, 1000, 1000)
+	assert.NoError(t, w.AddPlayer(p))
+
+	result := func() FriendResult {
+		return FriendResult(conn.last[4])
+	}
+
+	tests := []struct {
+		name     string
+		target   *model.Character
+		expected FriendResult
+	}{
+		{"not found", nil, FriendResultNotFound},
+		{"self", p.Character, FriendResultSelf},
+		{"enemy", &model.Character{Id: 3, Race: model.RaceOrc}, FriendResultEnemy},
+		{"offline", &model.Character{Id: 4, Race: model.RaceDwarf}, FriendResultAddedOffline},
+		{"already", &model.Character{Id: 4, Race: model.RaceDwarf}, FriendResultAlready},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := w.AddFriend(p, tt.target, "note", testData)
+			assert.Equal(t, tt.expected, result())
+			assert.Equal(t, tt.expected == FriendResultAddedOffline, c != nil)
+		})
+	}
+
+	t.Run("online", func(t *testing.T) {
+		assert.NoError(t, w.AddPlayer(other))
+		c := w.AddFriend(p, other.Character, "", testData)
+		assert.Equal(t, FriendResultAddedOnline, result())
+		assert.Equal(t, model.ContactFlagFriend, c.Flags)
+	})
+
+	t.Run("notified when friend logs off", func(t *testing.T) {
+		w.RemovePlayer(other)
+		assert.Equal(t, FriendResultOffline, result())
+
+		assert.NoError(t, w.AddPlayer(other))
+		assert.Equal(t, FriendResultOnline, result())
+	})
+
+	t.Run("remove", func(t *testing.T) {
+		c := w.RemoveFriend(p, other.GUID())
+		assert.Equal(t, FriendResultRemoved, result())
+		assert.Zero(t, c.Flags)
+		assert.Nil(t, p.Contact(other.GUID()))
+
+		assert.Nil(t, w.RemoveFriend(p, other.GUID()))
+		assert.Equal(t, FriendResultNotFound, result())
+	})
+
+	t.Run("list full", func(t *testing.T) {
+		for i := uint32(0); i < MaxFriends; i++ {
+			w.AddFriend(p, &model.Character{Id: 100 + i, Race: model.RaceHuman}, "", testData)
+		}
+		assert.Nil(t, w.AddFriend(p, other.Character, "", testData))
+		assert.Equal(t, FriendResultListFull, result())
+	})
+}
+
+func TestIgnore(t *testing.T) {
+	w := New(nil)
+	p, _ := newTestPlayer(1, 0, 0)
+	other, otherConn := newTestPlayer(2, 0, 0)
+	p.Character.Name = "Alice"
+	other.Character.Name = "Bob"
+	assert.NoError(t, w.AddPlayer(p))
+	assert.NoError(t, w.AddPlayer(other))
+
+	c := w.AddIgnore(p, other.Character)
+	if !assert.NotNil(t, c) {
+		return
+	}
+	assert.Equal(t, model.ContactFlagIgnored, c.Flags)
+	assert.True(t, p.Ignores(other))
+	assert.False(t, other.Ignores(p))
+
+	assert.Nil(t, w.AddIgnore(p, other.Character), "already ignored")
+	assert.Nil(t, w.AddIgnore(p, p.Character), "can't ignore self")
+
+	t.Run("whisper", func(t *testing.T) {
+		assert.NoError(t, w.Whisper(other, p, chat.LanguageCommon, "hello"))
+		assert.Equal(t, byte(chat.MessageTypeIgnored), otherConn.last[4])
+	})
+
+	t.Run("channel", func(t *testing.T) {
+		w.JoinChannel(p, "Club", "")
+		w.JoinChannel(other, "Club", "")
+
+		w.ChannelChat(other, "Club", chat.LanguageCommon, "hello")
+		assert.True(t, bytes.Contains(otherConn.last, []byte("hello")))
+		assert.False(t, bytes.Contains(p.Client.Conn.(*testConn).last, []byte("hello")))
+	})
+
+	t.Run("remove", func(t *testing.T) {
+		assert.NotNil(t, w.RemoveIgnore(p, other.GUID()))
+		assert.False(t, p.Ignores(other))
+	})
+}
@@ -0,0 +1,646 @@
+package world
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/kangaroux/gomaggus/model"
+	"github.com/kangaroux/gomaggus/realmd"
+	"github.com/kangaroux/gomaggus/realmd/dbc"
+	"github.com/kangaroux/gomaggus/realmd/values"
+)
+
+// https://gtker.com/wow_messages/docs/spellcastresult.html#client-version-335
+type SpellCastResult uint8
+
+const (
+	SpellCastResultBadTargets      SpellCastResult = 11
+	SpellCastResultCasterDead      SpellCastResult = 22
+	SpellCastResultInterrupted     SpellCastResult = 40
+	SpellCastResultMoving          SpellCastResult = 51
+	SpellCastResultNotKnown        SpellCastResult = 63
+	SpellCastResultNotReady        SpellCastResult = 67
+	SpellCastResultNoPower         SpellCastResult = 85
+	SpellCastResultOutOfRange      SpellCastResult = 97
+	SpellCastResultSpellInProgress SpellCastResult = 105
+	SpellCastResultTargetsDead     SpellCastResult = 109
+	SpellCastResultTargetEnemy     SpellCastResult = 113
+	SpellCastResultTargetFriendly  SpellCastResult = 115
+	SpellCastResultTooClose        SpellCastResult = 128
+	SpellCastResultInvalidGlyph    SpellCastResult = 176
+	SpellCastResultUniqueGlyph     SpellCastResult = 177
+	SpellCastResultGlyphLocked     SpellCastResult = 178
+	SpellCastResultOK              SpellCastResult = 255
+)
+
+// https://gtker.com/wow_messages/docs/spellcasttargetflags.html#client-version-335
+const (
+	SpellTargetFlagSelf           = 0x0
+	SpellTargetFlagUnit           = 0x2
+	SpellTargetFlagUnitRaid       = 0x4
+	SpellTargetFlagUnitParty      = 0x8
+	SpellTargetFlagItem           = 0x10
+	SpellTargetFlagSourceLocation = 0x20
+	SpellTargetFlagDestLocation   = 0x40
+	SpellTargetFlagUnitEnemy      = 0x80
+	SpellTargetFlagUnitAlly       = 0x100
+	SpellTargetFlagCorpseEnemy    = 0x200
+	SpellTargetFlagUnitDead       = 0x400
+	SpellTargetFlagGameObject     = 0x800
+	SpellTargetFlagTradeItem      = 0x1000
+	SpellTargetFlagString         = 0x2000
+	SpellTargetFlagCorpseAlly     = 0x8000
+	SpellTargetFlagUnitMinipet    = 0x10000
+	SpellTargetFlagUnitPassenger  = 0x100000
+
+	// spellTargetFlagObject is any of the flags that are followed by the guid of a unit or object.
+	spellTargetFlagObject = SpellTargetFlagUnit | SpellTargetFlagUnitRaid | SpellTargetFlagUnitParty |
+		SpellTargetFlagUnitEnemy | SpellTargetFlagUnitAlly | SpellTargetFlagCorpseEnemy |
+		SpellTargetFlagUnitDead | SpellTargetFlagGameObject | SpellTargetFlagCorpseAlly |
+		SpellTargetFlagUnitMinipet | SpellTargetFlagUnitPassenger
+)
+
+// The implicit targets that decide whether a spell must be cast on an enemy or a friend.
+// https://wowdev.wiki/SpellImplicitTargets
+const (
+	implicitTargetEnemy = 6
+	implicitTargetAlly  = 21
+)
+
+// movingFlags are the movement flags that mean the player is moving.
+const movingFlags = values.LivingMovementFlagForward | values.LivingMovementFlagBackward |
+	values.LivingMovementFlagStrafeLeft | values.LivingMovementFlagStrafeRight |
+	values.LivingMovementFlagFalling | values.LivingMovementFlagAscending | values.LivingMovementFlagDescending
+
+var ErrBadSpellTargets = errors.New("spell targets are malformed")
+
+// SpellTargets are what a spell is being cast on. Locations are sent relative to the world, transports
+// aren't supported.
+// https://gtker.com/wow_messages/docs/spellcasttargets.html#client-version-335
+type SpellTargets struct {
+	Flags  uint32
+	Unit   realmd.Guid
+	Item   realmd.Guid
+	Source realmd.Vector3
+	Dest   realmd.Vector3
+	String string
+}
+
+// ReadSpellTargets reads the targets of a spell from r.
+func ReadSpellTargets(r *bytes.Reader) (SpellTargets, error) {
+	t := SpellTargets{}
+
+	if err := binary.Read(r, binary.LittleEndian, &t.Flags); err != nil {
+		return t, ErrBadSpellTargets
+	}
+
+	var err error
+
+	if t.Flags&spellTargetFlagObject > 0 {
+		if t.Unit, err = realmd.ReadPackedGuid(r); err != nil {
+			return t, ErrBadSpellTargets
+		}
+	}
+
+	if t.Flags&(SpellTargetFlagItem|SpellTargetFlagTradeItem) > 0 {
+		if t.Item, err = realmd.ReadPackedGuid(r); err != nil {
+			return t, ErrBadSpellTargets
+		}
+	}
+
+	for _, loc := range []struct {
+		flag uint32
+		pos  *realmd.Vector3
+	}{{SpellTargetFlagSourceLocation, &t.Source}, {SpellTargetFlagDestLocation, &t.Dest}} {
+		if t.Flags&loc.flag == 0 {
+			continue
+		}
+		if _, err := realmd.ReadPackedGuid(r); err != nil {
+			return t, ErrBadSpellTargets
+		}
+		if err := binary.Read(r, binary.LittleEndian, loc.pos); err != nil {
+			return t, ErrBadSpellTargets
+		}
+	}
+
+	if t.Flags&SpellTargetFlagString > 0 {
+		s := []byte{}
+		for {
+			b, err := r.ReadByte()
+			if err != nil {
+				return t, ErrBadSpellTargets
+			}
+			if b == 0 {
+				break
+			}
+			s = append(s, b)
+		}
+		t.String = string(s)
+	}
+
+	return t, nil
+}
+
+func (t *SpellTargets) write(buf *bytes.Buffer) {
+	binary.Write(buf, binary.LittleEndian, t.Flags)
+
+	if t.Flags&spellTargetFlagObject > 0 {
+		buf.Write(realmd.PackGuid(uint64(t.Unit)))
+	}
+	if t.Flags&(SpellTargetFlagItem|SpellTargetFlagTradeItem) > 0 {
+		buf.Write(realmd.PackGuid(uint64(t.Item)))
+	}
+	if t.Flags&SpellTargetFlagSourceLocation > 0 {
+		buf.Write(realmd.PackGuid(0))
+		binary.Write(buf, binary.LittleEndian, t.Source)
+	}
+	if t.Flags&SpellTargetFlagDestLocation > 0 {
+		buf.Write(realmd.PackGuid(0))
+		binary.Write(buf, binary.LittleEndian, t.Dest)
+	}
+	if t.Flags&SpellTargetFlagString > 0 {
+		buf.WriteString(t.String)
+		buf.WriteByte(0)
+	}
+}
+
+// Cast is a spell that a player is casting or channeling.
+type Cast struct {
+	Spell   *dbc.Spell
+	Count   uint8
+	Targets SpellTargets
+
+	// CastTime is how long it takes to cast the spell. Duration is how long the spell is channeled for
+	// after it's cast, or -1 if it's channeled until it's cancelled.
+	CastTime time.Duration
+	Duration time.Duration
+
+	// Range is how close the target must be, or nil if the spell doesn't have a range.
+	Range *dbc.SpellRange
+
+	// Cost is how much of the spell's power type is taken when the spell is cast.
+	Cost uint32
+
+	// Ability is the skill line ability for recipes and other spells that can raise a skill when they're
+	// cast, or nil.
+	Ability *dbc.SkillLineAbility
+
+	// Item is the item the spell is cast from, or nil. Players don't have to know the spells on their items.
+	Item *Item
+
+	// Glyph is the glyph the spell puts in GlyphSlot of the player's active spec, or nil if the spell
+	// doesn't apply a glyph.
+	Glyph     *dbc.GlyphProperties
+	GlyphSlot uint8
+
+	// elapsed is how long the spell has been cast or channeled for.
+	elapsed    time.Duration
+	channeling bool
+}
+
+// SetSpells replaces the spells the player knows.
+func (p *Player) SetSpells(ids []uint32) {
+	p.spells = make(map[uint32]bool, len(ids))
+	for _, id := range ids {
+		p.spells[id] = true
+	}
+}
+
+// Spells returns the ids of the spells the player knows, in ascending order.
+func (p *Player) Spells() []uint32 {
+	result := make([]uint32, 0, len(p.spells))
+	for id := range p.spells {
+		result = append(result, id)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i] < result[j] })
+	return result
+}
+
+// KnowsSpell reports whether the player knows the spell.
+func (p *Player) KnowsSpell(id uint32) bool {
+	return p.spells[id]
+}
+
+type learnedSpell struct {
+	SpellID uint32
+	Unknown uint16
+}
+
+// LearnSpell teaches the player a spell. Returns false if they already know it.
+// https://gtker.com/wow_messages/docs/smsg_learned_spell.html#client-version-335
+func (p *Player) LearnSpell(id uint32) bool {
+	if p.spells[id] {
+		return false
+	}
+
+	p.spells[id] = true
+
+	if err := p.Client.SendPacket(realmd.OpServerLearnedSpell, &learnedSpell{SpellID: id}); err != nil {
+		p.Client.Log.Error().Err(err).Msg("error sending learned spell")
+	}
+
+	return true
+}
+
+// SetCooldowns replaces the player's spell cooldowns.
+func (p *Player) SetCooldowns(cooldowns []*model.SpellCooldown) {
+	p.cooldowns = make(map[uint32]*model.SpellCooldown, len(cooldowns))
+	for _, cd := range cooldowns {
+		p.cooldowns[cd.SpellId] = cd
+	}
+}
+
+// Cooldowns returns the player's spell cooldowns that haven't expired, in order of spell id.
+func (p *Player) Cooldowns() []*model.SpellCooldown {
+	now := time.Now()
+
+	var result []*model.SpellCooldown
+	for _, cd := range p.cooldowns {
+		if cd.ExpiresAt.After(now) || cd.CategoryExpiresAt.After(now) {
+			result = append(result, cd)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].SpellId < result[j].SpellId })
+	return result
+}
+
+// onCooldown reports whether the spell can't be cast yet because it, a spell in its category or the
+// global cooldown is recovering.
+func (p *Player) onCooldown(spell *dbc.Spell, now time.Time) bool {
+	if cd, ok := p.cooldowns[spell.ID]; ok && cd.ExpiresAt.After(now) {
+		return true
+	}
+
+	if spell.Category != 0 {
+		for _, cd := range p.cooldowns {
+			if cd.Category == spell.Category && cd.CategoryExpiresAt.After(now) {
+				return true
+			}
+		}
+	}
+
+	if spell.StartRecoveryCategory != 0 {
+		if gcd, ok := p.globalCooldowns[spell.StartRecoveryCategory]; ok && gcd.After(now) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// startCooldown puts the spell on cooldown after it's cast.
+func (p *Player) startCooldown(spell *dbc.Spell, now time.Time) {
+	if spell.RecoveryTime == 0 && spell.CategoryRecoveryTime == 0 {
+		return
+	}
+
+	cd := &model.SpellCooldown{
+		CharacterId: p.Character.Id,
+		SpellId:     spell.ID,
+		ExpiresAt:   now.Add(time.Duration(spell.RecoveryTime) * time.Millisecond),
+	}
+	cd.CategoryExpiresAt = cd.ExpiresAt
+
+	if spell.Category != 0 {
+		cd.Category = spell.Category
+		cd.CategoryExpiresAt = now.Add(time.Duration(spell.CategoryRecoveryTime) * time.Millisecond)
+	}
+
+	p.cooldowns[spell.ID] = cd
+}
+
+// hostileTo reports whether other is an enemy of the player.
+func (p *Player) hostileTo(other *Player) bool {
+	return p.Horde != other.Horde
+}
+
+// moving reports whether the player is moving.
+func (p *Player) moving() bool {
+	return p.Movement.Flags&movingFlags > 0
+}
+
+// Casting returns the spell the player is casting or channeling, or nil if they aren't casting anything.
+func (p *Player) Casting() *Cast {
+	return p.cast
+}
+
+// CastSpell starts casting a spell. If the spell can't be cast, the player is told why and the reason is
+// returned. Spells without a cast time are cast immediately, otherwise they are cast once the cast time
+// has passed.
+func (w *World) CastSpell(p *Player, c *Cast) SpellCastResult {
+	now := time.Now()
+
+	result := w.checkCast(p, c, now)
+	if result != SpellCastResultOK {
+		p.sendCastFailed(c, result)
+		return result
+	}
+
+	if c.Spell.StartRecoveryCategory != 0 {
+		p.globalCooldowns[c.Spell.StartRecoveryCategory] = now.Add(
+			time.Duration(c.Spell.StartRecoveryTime) * time.Millisecond)
+	}
+
+	p.cast = c
+	w.Broadcast(p, false, realmd.OpServerSpellStart, c.startPacket(p))
+
+	if c.CastTime <= 0 {
+		w.finishCast(p)
+	}
+
+	return SpellCastResultOK
+}
+
+// checkCast returns whether the player can start casting the spell.
+func (w *World) checkCast(p *Player, c *Cast, now time.Time) SpellCastResult {
+	if !p.Alive() {
+		return SpellCastResultCasterDead
+	}
+	if p.cast != nil {
+		return SpellCastResultSpellInProgress
+	}
+	if c.Item == nil && !p.KnowsSpell(c.Spell.ID) || c.Spell.Passive() {
+		return SpellCastResultNotKnown
+	}
+	if p.onCooldown(c.Spell, now) {
+		return SpellCastResultNotReady
+	}
+	if c.CastTime > 0 && c.Spell.InterruptFlags&dbc.SpellInterruptMovement > 0 && p.moving() {
+		return SpellCastResultMoving
+	}
+
+	if result := p.checkPower(c); result != SpellCastResultOK {
+		return result
+	}
+	if c.Glyph != nil {
+		if result := p.checkGlyph(c.Glyph, c.GlyphSlot); result != SpellCastResultOK {
+			return result
+		}
+	}
+
+	return w.checkTarget(p, c)
+}
+
+// checkPower returns whether the player has enough power to pay for the spell.
+func (p *Player) checkPower(c *Cast) SpellCastResult {
+	if c.Cost == 0 {
+		return SpellCastResultOK
+	}
+	if realmd.PowerType(c.Spell.PowerType) != p.Values.PowerType() || p.Power() < c.Cost {
+		return SpellCastResultNoPower
+	}
+	return SpellCastResultOK
+}
+
+// checkTarget returns whether the spell's target is valid and in range. The target is checked again when
+// the spell finishes casting, since it may have moved or died.
+func (w *World) checkTarget(p *Player, c *Cast) SpellCastResult {
+	target := w.castTarget(p, c)
+	if target == nil || !p.CanSee(target) {
+		return SpellCastResultBadTargets
+	}
+
+	hostile := target.hostileTo(p)
+
+	for _, effect := range c.Spell.Effects {
+		switch effect.ImplicitTargetA {
+		case implicitTargetEnemy:
+			if target == p {
+				return SpellCastResultBadTargets
+			}
+			if !hostile {
+				return SpellCastResultTargetFriendly
+			}
+
+		case implicitTargetAlly:
+			if hostile {
+				return SpellCastResultTargetEnemy
+			}
+		}
+	}
+
+	if !target.Alive() {
+		return SpellCastResultTargetsDead
+	}
+
+	if c.Range == nil || target == p {
+		return SpellCastResultOK
+	}
+
+	minRange, maxRange := c.Range.MinRangeFriend, c.Range.MaxRangeFriend
+	if hostile {
+		minRange, maxRange = c.Range.MinRangeHostile, c.Range.MaxRangeHostile
+	}
+
+	dist := distance(p.Position(), target.Position())
+	if dist > maxRange {
+		return SpellCastResultOutOfRange
+	}
+	if dist < minRange {
+		return SpellCastResultTooClose
+	}
+
+	return SpellCastResultOK
+}
+
+// castTarget returns the unit the spell is being cast on, or nil if they aren't in the world.
+func (w *World) castTarget(p *Player, c *Cast) *Player {
+	if c.Targets.Flags&spellTargetFlagObject == 0 || c.Targets.Unit == p.GUID() {
+		return p
+	}
+	return w.players[c.Targets.Unit]
+}
+
+// updateCast finishes the player's cast once the cast time has passed, and ends their channel once the
+// duration has passed.
+func (w *World) updateCast(p *Player, diff time.Duration) {
+	c := p.cast
+	c.elapsed += diff
+
+	if !c.channeling {
+		if c.elapsed >= c.CastTime {
+			w.finishCast(p)
+		}
+		return
+	}
+
+	if c.Duration >= 0 && c.elapsed >= c.Duration {
+		w.endChannel(p)
+	}
+}
+
+// finishCast casts the spell the player was casting. The power cost is taken and the spell goes on
+// cooldown. Channeled spells start channeling.
+func (w *World) finishCast(p *Player) {
+	c := p.cast
+
+	result := p.checkPower(c)
+	if result == SpellCastResultOK {
+		result = w.checkTarget(p, c)
+	}
+	if result != SpellCastResultOK {
+		w.InterruptCast(p, result)
+		return
+	}
+
+	if c.Cost > 0 {
+		p.SetPower(p.Power() - c.Cost)
+	}
+	p.startCooldown(c.Spell, time.Now())
+
+	w.Broadcast(p, false, realmd.OpServerSpellGo, c.goPacket(p))
+	w.applyEffects(p, c)
+
+	if c.Ability != nil {
+		p.updateCastSkill(c.Ability)
+	}
+
+	if !c.Spell.Channeled() || c.Duration == 0 {
+		p.cast = nil
+		return
+	}
+
+	c.channeling = true
+	c.elapsed = 0
+
+	target := p.GUID()
+	if c.Targets.Flags&spellTargetFlagObject > 0 {
+		target = c.Targets.Unit
+	}
+	p.Values.SetChannelSpell(c.Spell.ID)
+	p.Values.SetChannelObject(target)
+
+	data := bytes.Buffer{}
+	data.Write(realmd.PackGuid(uint64(p.GUID())))
+	binary.Write(&data, binary.LittleEndian, c.Spell.ID)
+	binary.Write(&data, binary.LittleEndian, durationMillis(c.Duration))
+	w.Broadcast(p, false, realmd.OpServerChannelStart, data.Bytes())
+}
+
+// endChannel stops the player's channel and tells the player and anyone nearby that it ended.
+// https://gtker.com/wow_messages/docs/msg_channel_update.html#client-version-335
+func (w *World) endChannel(p *Player) {
+	p.cast = nil
+	p.Values.SetChannelSpell(0)
+	p.Values.SetChannelObject(0)
+
+	data := bytes.Buffer{}
+	data.Write(realmd.PackGuid(uint64(p.GUID())))
+	binary.Write(&data, binary.LittleEndian, uint32(0))
+	w.Broadcast(p, false, realmd.OpServerChannelUpdate, data.Bytes())
+}
+
+// InterruptCast stops the spell the player is casting or channeling, and tells the player and anyone
+// nearby that it failed. Does nothing if the player isn't casting anything.
+func (w *World) InterruptCast(p *Player, result SpellCastResult) {
+	c := p.cast
+	if c == nil {
+		return
+	}
+
+	if c.channeling {
+		w.endChannel(p)
+	}
+	p.cast = nil
+
+	// https://gtker.com/wow_messages/docs/smsg_spell_failure.html#client-version-335
+	// https://gtker.com/wow_messages/docs/smsg_spell_failed_other.html#client-version-335
+	data := bytes.Buffer{}
+	data.Write(realmd.PackGuid(uint64(p.GUID())))
+	data.WriteByte(c.Count)
+	binary.Write(&data, binary.LittleEndian, c.Spell.ID)
+	data.WriteByte(uint8(result))
+
+	w.Broadcast(p, false, realmd.OpServerSpellFailure, data.Bytes())
+	w.Broadcast(p, true, realmd.OpServerSpellFailedOther, data.Bytes())
+	p.sendCastFailed(c, result)
+}
+
+// CancelCast stops the player from casting the spell. Does nothing if they aren't casting it.
+func (w *World) CancelCast(p *Player, spellId uint32) {
+	if c := p.cast; c != nil && !c.channeling && c.Spell.ID == spellId {
+		w.InterruptCast(p, SpellCastResultInterrupted)
+	}
+}
+
+// CancelChannel stops the player from channeling the spell. Does nothing if they aren't channeling it.
+func (w *World) CancelChannel(p *Player, spellId uint32) {
+	if c := p.cast; c != nil && c.channeling && c.Spell.ID == spellId {
+		w.InterruptCast(p, SpellCastResultInterrupted)
+	}
+}
+
+// interruptMovedCast interrupts the player's cast if they moved from prev and the spell can't be cast
+// or channeled while moving.
+func (w *World) interruptMovedCast(p *Player, prev realmd.Vector4) {
+	c := p.cast
+	if c == nil {
+		return
+	}
+
+	pos := p.Position()
+	if pos.X == prev.X && pos.Y == prev.Y && pos.Z == prev.Z {
+		return
+	}
+
+	if c.channeling && c.Spell.ChannelInterruptFlags&dbc.SpellChannelInterruptMove > 0 ||
+		!c.channeling && c.Spell.InterruptFlags&dbc.SpellInterruptMovement > 0 {
+		w.InterruptCast(p, SpellCastResultInterrupted)
+	}
+}
+
+// https://gtker.com/wow_messages/docs/smsg_cast_failed.html#client-version-335
+func (p *Player) sendCastFailed(c *Cast, result SpellCastResult) {
+	data := bytes.Buffer{}
+	data.WriteByte(c.Count)
+	binary.Write(&data, binary.LittleEndian, c.Spell.ID)
+	data.WriteByte(uint8(result))
+	p.sendPacket(realmd.OpServerCastFailed, data.Bytes())
+}
+
+// https://gtker.com/wow_messages/docs/smsg_spell_start.html#client-version-335
+func (c *Cast) startPacket(caster *Player) []byte {
+	data := bytes.Buffer{}
+	// The cast item is the caster when the spell isn't cast from an item
+	data.Write(realmd.PackGuid(uint64(caster.GUID())))
+	data.Write(realmd.PackGuid(uint64(caster.GUID())))
+	data.WriteByte(c.Count)
+	binary.Write(&data, binary.LittleEndian, c.Spell.ID)
+	binary.Write(&data, binary.LittleEndian, uint32(0)) // Cast flags
+	binary.Write(&data, binary.LittleEndian, uint32(c.CastTime.Milliseconds()))
+	c.Targets.write(&data)
+	return data.Bytes()
+}
+
+// https://gtker.com/wow_messages/docs/smsg_spell_go.html#client-version-335
+func (c *Cast) goPacket(caster *Player) []byte {
+	data := bytes.Buffer{}
+	data.Write(realmd.PackGuid(uint64(caster.GUID()))) // Cast item
+	data.Write(realmd.PackGuid(uint64(caster.GUID())))
+	data.WriteByte(c.Count)
+	binary.Write(&data, binary.LittleEndian, c.Spell.ID)
+	binary.Write(&data, binary.LittleEndian, uint32(0)) // Cast flags
+	binary.Write(&data, binary.LittleEndian, uint32(time.Now().UnixMilli()))
+
+	target := caster.GUID()
+	if c.Targets.Flags&spellTargetFlagObject > 0 {
+		target = c.Targets.Unit
+	}
+	data.WriteByte(1) // Hit count
+	binary.Write(&data, binary.LittleEndian, target)
+	data.WriteByte(0) // Miss count
+
+	c.Targets.write(&data)
+	return data.Bytes()
+}
+
+// durationMillis returns d in milliseconds, or -1 if d is negative.
+func durationMillis(d time.Duration) int32 {
+	if d < 0 {
+		return -1
+	}
+	return int32(d.Milliseconds())
+}
@@ -0,0 +1,139 @@
+package world
+
+import (
+	"encoding/binary"
+	"testing"
+	"time"
+
+	"github.com/kangaroux/gomaggus/model"
+	"github.com/kangaroux/gomaggus/realmd"
+	"github.com/kangaroux/gomaggus/realmd/dbc"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	testFireball = &dbc.Spell{ID: 133, InterruptFlags: dbc.SpellInterruptMovement, StartRecoveryCategory: 133,
+		StartRecoveryTime: 1500, Effects: [dbc.NumSpellEffects]dbc.SpellEffect{{ImplicitTargetA: implicitTargetEnemy}}}
+	testFrostNova = &dbc.Spell{ID: 122, RecoveryTime: 25000}
+	testRange     = &dbc.SpellRange{MaxRangeHostile: 30, MaxRangeFriend: 30}
+)
+
+// castFailed returns the result of the last SMSG_CAST_FAILED sent on conn, or SpellCastResultOK if the
+// last packet was something else.
+func castFailed(conn *testConn) SpellCastResult {
+	if realmd.ServerOpcode(binary.LittleEndian.Uint16(conn.last[2:])) != realmd.OpServerCastFailed {
+		return SpellCastResultOK
+	}
+	return SpellCastResult(conn.last[9])
+}
+
+func TestCastSpell(t *testing.T) {
+	w := New(nil)
+	p, conn := newTestPlayer(1, 0, 0)
+	enemy, _ := newTestPlayer(2, 10, 0)
+	enemy.Horde = true
+	far, _ := newTestPlayer(3, 50, 0)
+	far.Horde = true
+	friend, _ := newTestPlayer(4, 5, 0)
+
+	assert.NoError(t, w.AddPlayer(p))
+	assert.NoError(t, w.AddPlayer(friend))
+	assert.NoError(t, w.AddPlayer(enemy))
+	assert.NoError(t, w.AddPlayer(far))
+
+	fireball := func(target *Player) *Cast {
+		return &Cast{
+			Spell:    testFireball,
+			Targets:  SpellTargets{Flags: SpellTargetFlagUnit, Unit: target.GUID()},
+			CastTime: 1500 * time.Millisecond,
+			Range:    testRange,
+			Cost:     30,
+		}
+	}
+
+	t.Run("unknown spell", func(t *testing.T) {
+		assert.Equal(t, SpellCastResultNotKnown, w.CastSpell(p, fireball(enemy)))
+		assert.Equal(t, SpellCastResultNotKnown, castFailed(conn))
+	})
+
+	p.SetSpells([]uint32{testFireball.ID, testFrostNova.ID})
+
+	t.Run("bad targets", func(t *testing.T) {
+		assert.Equal(t, SpellCastResultBadTargets, w.CastSpell(p, fireball(p)))
+		assert.Equal(t, SpellCastResultTargetFriendly, w.CastSpell(p, fireball(friend)))
+		assert.Equal(t, SpellCastResultOutOfRange, w.CastSpell(p, fireball(far)))
+		assert.Nil(t, p.Casting())
+	})
+
+	t.Run("not enough power", func(t *testing.T) {
+		c := fireball(enemy)
+		c.Cost = p.Power() + 1
+		assert.Equal(t, SpellCastResultNoPower, w.CastSpell(p, c))
+	})
+
+	t.Run("cast time", func(t *testing.T) {
+		mana := p.Power()
+
+		assert.Equal(t, SpellCastResultOK, w.CastSpell(p, fireball(enemy)))
+		assert.NotNil(t, p.Casting())
+		assert.Equal(t, SpellCastResultSpellInProgress, w.CastSpell(p, fireball(enemy)))
+
+		w.update(time.Second)
+		assert.NotNil(t, p.Casting())
+		assert.Equal(t, mana, p.Power())
+
+		w.update(time.Second)
+		assert.Nil(t, p.Casting())
+		assert.Equal(t, mana-30, p.Power())
+	})
+
+	t.Run("global cooldown", func(t *testing.T) {
+		assert.Equal(t, SpellCastResultNotReady, w.CastSpell(p, fireball(enemy)))
+		p.globalCooldowns = make(map[uint32]time.Time)
+	})
+
+	t.Run("interrupted by movement", func(t *testing.T) {
+		assert.Equal(t, SpellCastResultOK, w.CastSpell(p, fireball(enemy)))
+
+		prev := p.Position()
+		p.Movement.Position.X += 1
+		w.interruptMovedCast(p, prev)
+
+		assert.Nil(t, p.Casting())
+		assert.Equal(t, SpellCastResultInterrupted, castFailed(conn))
+		p.globalCooldowns = make(map[uint32]time.Time)
+	})
+
+	t.Run("cooldown", func(t *testing.T) {
+		nova := &Cast{Spell: testFrostNova}
+
+		assert.Equal(t, SpellCastResultOK, w.CastSpell(p, nova))
+		assert.Nil(t, p.Casting())
+		assert.Equal(t, SpellCastResultNotReady, w.CastSpell(p, nova))
+
+		cooldowns := p.Cooldowns()
+		if assert.Len(t, cooldowns, 1) {
+			assert.Equal(t, testFrostNova.ID, cooldowns[0].SpellId)
+		}
+	})
+}
+
+func TestSetCooldowns(t *testing.T) {
+	p, _ := newTestPlayer(1, 0, 0)
+	now := time.Now()
+
+	p.SetCooldowns([]*model.SpellCooldown{
+		{SpellId: 2, ExpiresAt: now.Add(time.Minute), CategoryExpiresAt: now.Add(time.Minute)},
+		{SpellId: 1, ExpiresAt: now.Add(-time.Minute), CategoryExpiresAt: now.Add(-time.Minute)},
+		{SpellId: 3, Category: 7, ExpiresAt: now, CategoryExpiresAt: now.Add(time.Minute)},
+	})
+
+	cooldowns := p.Cooldowns()
+	if assert.Len(t, cooldowns, 2) {
+		assert.Equal(t, uint32(2), cooldowns[0].SpellId)
+		assert.Equal(t, uint32(3), cooldowns[1].SpellId)
+	}
+
+	assert.True(t, p.onCooldown(&dbc.Spell{ID: 4, Category: 7}, now))
+	assert.False(t, p.onCooldown(&dbc.Spell{ID: 1}, now))
+}
@@ -0,0 +1,532 @@
+package world
+
+import (
+	"database/sql"
+	"encoding/binary"
+	"sort"
+	"time"
+
+	"github.com/kangaroux/gomaggus/realmd"
+	"github.com/kangaroux/gomaggus/realmd/dbc"
+	"github.com/kangaroux/gomaggus/realmd/player"
+)
+
+const (
+	// MaxTalentSpecs is how many talent specs a player can have with dual specialization.
+	MaxTalentSpecs = 2
+
+	// MaxGlyphSlots is how many glyphs each spec can have.
+	MaxGlyphSlots = 6
+
+	// ActionButtonCount is how many buttons there are across all of the action bars.
+	ActionButtonCount = 144
+
+	// Players get their first talent point at talentStartLevel and another point every level after.
+	talentStartLevel = 10
+
+	// MaxTalentPoints is how many talent points a player has at the highest level.
+	MaxTalentPoints = player.MaxLevel - talentStartLevel + 1
+
+	// Each tier of a talent tree needs talentsPerTier more points spent in the tree than the tier above it.
+	talentsPerTier = 5
+
+	// Talent resets cost more each time, up to maxTalentResetCost. The cost goes back down by
+	// talentResetStep for each month since the last reset.
+	talentResetStep    = 5 * 10000
+	maxTalentResetCost = 50 * 10000
+	talentResetDecay   = 30 * 24 * time.Hour
+
+	SpellEffectApplyGlyph       = 74
+	SpellEffectTalentSpecCount  = 161
+	SpellEffectTalentSpecSelect = 162
+
+	// Players with two specs know these spells, which switch to each spec.
+	SpellActivatePrimarySpec   uint32 = 63645
+	SpellActivateSecondarySpec uint32 = 63644
+)
+
+// glyphSlotIDs are the GlyphSlot.dbc ids of each glyph slot.
+var glyphSlotIDs = [MaxGlyphSlots]uint32{21, 22, 23, 24, 25, 26}
+
+// glyphSlotMinor is whether each glyph slot holds a minor glyph. The other slots hold major glyphs.
+var glyphSlotMinor = [MaxGlyphSlots]bool{false, true, false, true, true, false}
+
+// LearnedTalent is a talent in a spec and the rank the player has learned, starting at 0 for the first rank.
+type LearnedTalent struct {
+	Talent *dbc.Talent
+	Rank   uint8
+}
+
+// TalentSpec is one of a player's talent specializations. Each spec has its own talents, glyphs and action
+// buttons.
+type TalentSpec struct {
+	Talents map[uint32]*LearnedTalent
+	Glyphs  [MaxGlyphSlots]uint32
+
+	// Actions are the packed action on each button, with the action type in the upper 8 bits.
+	Actions [ActionButtonCount]uint32
+}
+
+func NewTalentSpec() *TalentSpec {
+	return &TalentSpec{Talents: make(map[uint32]*LearnedTalent)}
+}
+
+// PointsSpent returns how many talent points have been spent in the spec.
+func (s *TalentSpec) PointsSpent() uint32 {
+	var total uint32
+	for _, t := range s.Talents {
+		total += uint32(t.Rank) + 1
+	}
+	return total
+}
+
+// pointsInTab returns how many talent points have been spent in one of the spec's talent trees.
+func (s *TalentSpec) pointsInTab(tab uint32) uint32 {
+	var total uint32
+	for _, t := range s.Talents {
+		if t.Talent.Tab == tab {
+			total += uint32(t.Rank) + 1
+		}
+	}
+	return total
+}
+
+// Spells returns the spells for the rank of each talent in the spec, in ascending order.
+func (s *TalentSpec) Spells() []uint32 {
+	result := make([]uint32, 0, len(s.Talents))
+	for _, t := range s.Talents {
+		result = append(result, t.Talent.SpellRank[t.Rank])
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i] < result[j] })
+	return result
+}
+
+// TalentSpells returns the spells a player knows because of their talents in the active spec, and the
+// spells for switching specs if they have more than one.
+func TalentSpells(specs []*TalentSpec, active uint8) []uint32 {
+	var result []uint32
+	if int(active) < len(specs) {
+		result = specs[active].Spells()
+	}
+	if len(specs) > 1 {
+		result = append(result, SpellActivatePrimarySpec, SpellActivateSecondarySpec)
+	}
+	return result
+}
+
+// TalentPointsForLevel returns how many talent points a player has at level.
+func TalentPointsForLevel(level uint32) uint32 {
+	if level < talentStartLevel {
+		return 0
+	}
+	return level - talentStartLevel + 1
+}
+
+// SetSpecs sets the player's talent specs and which one is active. The player learns the spells from the
+// active spec's talents. It should be called after SetSpells.
+func (p *Player) SetSpecs(specs []*TalentSpec, active uint8) {
+	if len(specs) == 0 {
+		specs = []*TalentSpec{NewTalentSpec()}
+	}
+	if int(active) >= len(specs) {
+		active = 0
+	}
+
+	p.specs = specs
+	p.activeSpec = active
+
+	for _, id := range TalentSpells(specs, active) {
+		p.spells[id] = true
+	}
+
+	p.Values.SetGlyphs(p.Spec().Glyphs)
+	p.updateTalentPoints()
+}
+
+// Specs returns the player's talent specs.
+func (p *Player) Specs() []*TalentSpec {
+	return p.specs
+}
+
+// ActiveSpec returns the index of the spec the player is using.
+func (p *Player) ActiveSpec() uint8 {
+	return p.activeSpec
+}
+
+// Spec returns the spec the player is using.
+func (p *Player) Spec() *TalentSpec {
+	return p.specs[p.activeSpec]
+}
+
+// UnspentTalentPoints returns how many talent points the player can still spend in their active spec.
+func (p *Player) UnspentTalentPoints() uint32 {
+	total := TalentPointsForLevel(p.Values.Level())
+	spent := p.Spec().PointsSpent()
+	if spent >= total {
+		return 0
+	}
+	return total - spent
+}
+
+// updateTalentPoints sets the player's unspent talent points and which glyph slots they can use at their
+// level.
+func (p *Player) updateTalentPoints() {
+	v := p.Values
+	points := v.CharacterPoints()
+	points[0] = p.UnspentTalentPoints()
+	v.SetCharacterPoints(points)
+
+	level := v.Level()
+	var enabled uint32
+	if level >= 15 {
+		enabled |= 0x01 | 0x02
+	}
+	if level >= 30 {
+		enabled |= 0x08
+	}
+	if level >= 50 {
+		enabled |= 0x04
+	}
+	if level >= 70 {
+		enabled |= 0x10
+	}
+	if level >= 80 {
+		enabled |= 0x20
+	}
+	v.SetGlyphSlots(glyphSlotIDs)
+	v.SetGlyphsEnabled(enabled)
+}
+
+// unlearnSpell removes a spell from the player's spellbook.
+// https://gtker.com/wow_messages/docs/smsg_removed_spell.html#client-version-335
+func (p *Player) unlearnSpell(id uint32) {
+	if !p.spells[id] {
+		return
+	}
+
+	delete(p.spells, id)
+
+	buf := make([]byte, 4)
+	binary.LittleEndian.PutUint32(buf, id)
+	p.sendPacket(realmd.OpServerRemovedSpell, buf)
+}
+
+// LearnTalent learns rank of a talent in the player's active spec, starting at 0 for the first rank. Ranks
+// can be skipped as long as the player has the points for them. Returns false if the player can't learn
+// it. The caller must check that the talent is in one of the player's class's trees.
+func (w *World) LearnTalent(p *Player, t *dbc.Talent, rank uint8) bool {
+	spec := p.Spec()
+
+	if int(rank) >= t.Ranks() {
+		return false
+	}
+
+	cost := uint32(rank) + 1
+	current, ok := spec.Talents[t.ID]
+	if ok {
+		if current.Rank >= rank {
+			return false
+		}
+		cost -= uint32(current.Rank) + 1
+	}
+
+	if p.UnspentTalentPoints() < cost {
+		return false
+	}
+	if spec.pointsInTab(t.Tab) < t.Tier*talentsPerTier {
+		return false
+	}
+
+	for i, prereq := range t.PrereqTalent {
+		if prereq == 0 {
+			continue
+		}
+		learned, found := spec.Talents[prereq]
+		if !found || uint32(learned.Rank) < t.PrereqRank[i] {
+			return false
+		}
+	}
+
+	if ok {
+		p.unlearnSpell(t.SpellRank[current.Rank])
+		current.Rank = rank
+	} else {
+		spec.Talents[t.ID] = &LearnedTalent{Talent: t, Rank: rank}
+	}
+	p.LearnSpell(t.SpellRank[rank])
+
+	p.updateTalentPoints()
+	p.SendTalents()
+
+	return true
+}
+
+// TalentResetCost returns what the player has to pay to reset their talents.
+func (p *Player) TalentResetCost(now time.Time) uint32 {
+	char := p.Character
+	last := char.TalentResetCost
+
+	switch {
+	case last < 1*10000:
+		return 1 * 10000
+	case last < 5*10000:
+		return 5 * 10000
+	case last < 10*10000:
+		return 10 * 10000
+	}
+
+	months := uint32(0)
+	if char.TalentResetAt.Valid {
+		months = uint32(now.Sub(char.TalentResetAt.Time) / talentResetDecay)
+	}
+
+	if months > 0 {
+		decay := months * talentResetStep
+		if decay >= last || last-decay < 10*10000 {
+			return 10 * 10000
+		}
+		return last - decay
+	}
+
+	if last+talentResetStep > maxTalentResetCost {
+		return maxTalentResetCost
+	}
+	return last + talentResetStep
+}
+
+type talentWipeConfirm struct {
+	Guid realmd.Guid
+	Cost uint32
+}
+
+// ConfirmTalentReset asks the player if they want to pay to reset their talents. trainer is who is
+// offering the reset, and the player's answer comes back with it.
+// https://gtker.com/wow_messages/docs/msg_talent_wipe_confirm.html#client-version-335
+func (w *World) ConfirmTalentReset(p *Player, trainer realmd.Guid) {
+	resp := talentWipeConfirm{Guid: trainer, Cost: p.TalentResetCost(time.Now())}
+	if err := p.Client.SendPacket(realmd.OpServerTalentWipeConfirm, &resp); err != nil {
+		p.Client.Log.Error().Err(err).Msg("error sending talent wipe confirm")
+	}
+}
+
+// ResetTalents removes all of the talents in the player's active spec and charges them for it. Returns
+// false if they don't have any talents or can't afford the reset.
+func (w *World) ResetTalents(p *Player) bool {
+	spec := p.Spec()
+	now := time.Now()
+	cost := p.TalentResetCost(now)
+
+	if len(spec.Talents) == 0 || !p.AddMoney(-int64(cost)) {
+		// Closes the confirmation
+		if err := p.Client.SendPacket(realmd.OpServerTalentWipeConfirm, &talentWipeConfirm{}); err != nil {
+			p.Client.Log.Error().Err(err).Msg("error sending talent wipe confirm")
+		}
+		return false
+	}
+
+	for _, id := range spec.Spells() {
+		p.unlearnSpell(id)
+	}
+	spec.Talents = make(map[uint32]*LearnedTalent)
+
+	p.Character.TalentResetCost = cost
+	p.Character.TalentResetAt = sql.NullTime{Time: now, Valid: true}
+
+	p.updateTalentPoints()
+	p.SendTalents()
+
+	return true
+}
+
+// setSpecCount gives the player more specs, or takes away the specs they have past count.
+func (w *World) setSpecCount(p *Player, count uint8) {
+	if count < 1 || count > MaxTalentSpecs || int(count) == len(p.specs) {
+		return
+	}
+
+	if int(p.activeSpec) >= int(count) {
+		w.ActivateSpec(p, 0)
+	}
+
+	for len(p.specs) < int(count) {
+		p.specs = append(p.specs, NewTalentSpec())
+	}
+	p.specs = p.specs[:count]
+
+	if count > 1 {
+		p.LearnSpell(SpellActivatePrimarySpec)
+		p.LearnSpell(SpellActivateSecondarySpec)
+	} else {
+		p.unlearnSpell(SpellActivatePrimarySpec)
+		p.unlearnSpell(SpellActivateSecondarySpec)
+	}
+
+	p.SendTalents()
+}
+
+// ActivateSpec switches the player to another one of their specs. The spells from the old spec's talents
+// are replaced with the new spec's, along with their glyphs and action buttons.
+func (w *World) ActivateSpec(p *Player, spec uint8) {
+	if int(spec) >= len(p.specs) || spec == p.activeSpec {
+		return
+	}
+
+	for _, id := range p.Spec().Spells() {
+		p.unlearnSpell(id)
+	}
+
+	p.activeSpec = spec
+
+	for _, id := range p.Spec().Spells() {
+		p.LearnSpell(id)
+	}
+
+	p.Values.SetGlyphs(p.Spec().Glyphs)
+	p.updateTalentPoints()
+	p.SendTalents()
+
+	if err := SendActionButtons(p.Client, p.Spec()); err != nil {
+		p.Client.Log.Error().Err(err).Msg("error sending action buttons")
+	}
+}
+
+// checkGlyph returns whether the glyph can go in one of the glyph slots of the player's active spec. The slot
+// has to be unlocked at the player's level and be the right kind of slot for the glyph, and the spec can only
+// have one of each glyph.
+func (p *Player) checkGlyph(glyph *dbc.GlyphProperties, slot uint8) SpellCastResult {
+	if slot >= MaxGlyphSlots || p.Values.GlyphsEnabled()&(1<<slot) == 0 {
+		return SpellCastResultGlyphLocked
+	}
+	if glyph.Minor() != glyphSlotMinor[slot] {
+		return SpellCastResultInvalidGlyph
+	}
+
+	for i, id := range p.Spec().Glyphs {
+		if id == glyph.ID && i != int(slot) {
+			return SpellCastResultUniqueGlyph
+		}
+	}
+
+	return SpellCastResultOK
+}
+
+// ApplyGlyph puts a glyph in one of the glyph slots of the player's active spec, replacing whatever was there.
+// Returns false if the glyph can't go in the slot.
+func (w *World) ApplyGlyph(p *Player, glyph *dbc.GlyphProperties, slot uint8) bool {
+	if p.checkGlyph(glyph, slot) != SpellCastResultOK {
+		return false
+	}
+
+	spec := p.Spec()
+	spec.Glyphs[slot] = glyph.ID
+	p.Values.SetGlyphs(spec.Glyphs)
+	p.SendTalents()
+
+	return true
+}
+
+// RemoveGlyph clears one of the glyph slots in the player's active spec. Returns false if there wasn't a
+// glyph in the slot.
+func (w *World) RemoveGlyph(p *Player, slot uint8) bool {
+	spec := p.Spec()
+	if slot >= MaxGlyphSlots || spec.Glyphs[slot] == 0 {
+		return false
+	}
+
+	spec.Glyphs[slot] = 0
+	p.Values.SetGlyphs(spec.Glyphs)
+	p.SendTalents()
+
+	return true
+}
+
+type talentInfo struct {
+	ID   uint32
+	Rank uint8
+}
+
+type talentSpecInfo struct {
+	TalentCount uint8
+	Talents     []talentInfo `binary:"[TalentCount]Any"`
+	GlyphCount  uint8
+	Glyphs      []uint16 `binary:"[GlyphCount]uint16"`
+}
+
+type talentsInfo struct {
+	TalentType    uint8 // player=0, pet=1
+	UnspentPoints uint32
+	SpecCount     uint8
+	ActiveSpec    uint8
+	Specs         []talentSpecInfo `binary:"[SpecCount]Any"`
+}
+
+// SendTalentsInfo sends the talents and glyphs in each spec, and how many points are unspent in the
+// active spec at level. It's sent before the player is added to the world, so it doesn't need one.
+// https://gtker.com/wow_messages/docs/smsg_talents_info.html#client-version-335
+func SendTalentsInfo(client *realmd.Client, specs []*TalentSpec, active uint8, level uint32) error {
+	resp := talentsInfo{
+		SpecCount:  uint8(len(specs)),
+		ActiveSpec: active,
+	}
+
+	for i, spec := range specs {
+		info := talentSpecInfo{GlyphCount: MaxGlyphSlots}
+
+		ids := make([]uint32, 0, len(spec.Talents))
+		for id := range spec.Talents {
+			ids = append(ids, id)
+		}
+		sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+		for _, id := range ids {
+			info.Talents = append(info.Talents, talentInfo{ID: id, Rank: spec.Talents[id].Rank})
+		}
+		info.TalentCount = uint8(len(info.Talents))
+
+		for _, glyph := range spec.Glyphs {
+			info.Glyphs = append(info.Glyphs, uint16(glyph))
+		}
+
+		if i == int(active) {
+			if total, spent := TalentPointsForLevel(level), spec.PointsSpent(); spent < total {
+				resp.UnspentPoints = total - spent
+			}
+		}
+
+		resp.Specs = append(resp.Specs, info)
+	}
+
+	return client.SendPacket(realmd.OpServerPlayerTalents, &resp)
+}
+
+// SendTalents sends the player their talents in each spec. It should be sent after their level changes.
+func (p *Player) SendTalents() {
+	if err := SendTalentsInfo(p.Client, p.specs, p.activeSpec, p.Values.Level()); err != nil {
+		p.Client.Log.Error().Err(err).Msg("error sending talents")
+	}
+}
+
+type actionButtonsType uint8
+
+const (
+	actionButtonsInitial actionButtonsType = 0 // Unused
+	actionButtonsSet     actionButtonsType = 1
+	actionButtonsClear   actionButtonsType = 2
+)
+
+// actionButtons overwrites all of the player's action bars.
+type actionButtons struct {
+	Type    actionButtonsType
+	Buttons [ActionButtonCount]uint32
+}
+
+// SendActionButtons sends the action buttons in spec.
+// https://gtker.com/wow_messages/docs/smsg_action_buttons.html#client-version-335
+func SendActionButtons(client *realmd.Client, spec *TalentSpec) error {
+	resp := actionButtons{
+		// Trinity says there were issues using Initial, so Set is used instead
+		Type:    actionButtonsSet,
+		Buttons: spec.Actions,
+	}
+	return client.SendPacket(realmd.OpServerActionButtons, &resp)
+}
@@ -0,0 +1,152 @@
+package world
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/kangaroux/gomaggus/realmd/dbc"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	testImprovedFireball = &dbc.Talent{ID: 26, Tab: 41, SpellRank: [dbc.MaxTalentRanks]uint32{11069, 12338, 12339}}
+	testIgnite           = &dbc.Talent{ID: 34, Tab: 41, Tier: 1, SpellRank: [dbc.MaxTalentRanks]uint32{11119, 11120}}
+	testPyroblast        = &dbc.Talent{ID: 29, Tab: 41, Tier: 1, SpellRank: [dbc.MaxTalentRanks]uint32{11366},
+		PrereqTalent: [3]uint32{34}, PrereqRank: [3]uint32{1}}
+)
+
+func TestLearnTalent(t *testing.T) {
+	w := New(nil)
+	p, _ := newTestPlayer(1, 0, 0)
+	assert.NoError(t, w.AddPlayer(p))
+
+	assert.False(t, w.LearnTalent(p, testImprovedFireball, 0), "no points below level 10")
+
+	assert.NoError(t, p.SetLevel(16))
+	assert.Equal(t, uint32(7), p.UnspentTalentPoints())
+
+	assert.False(t, w.LearnTalent(p, testImprovedFireball, 5), "rank the talent doesn't have")
+	assert.False(t, w.LearnTalent(p, testIgnite, 0), "tier needs 5 points in the tree")
+
+	assert.True(t, w.LearnTalent(p, testImprovedFireball, 1))
+	assert.True(t, p.KnowsSpell(12338))
+	assert.False(t, p.KnowsSpell(11069))
+	assert.False(t, w.LearnTalent(p, testImprovedFireball, 1), "already learned")
+
+	assert.True(t, w.LearnTalent(p, testImprovedFireball, 2))
+	assert.False(t, p.KnowsSpell(12338))
+	assert.Equal(t, uint32(4), p.UnspentTalentPoints())
+
+	assert.False(t, w.LearnTalent(p, testIgnite, 0), "still needs 5 points in the tree")
+
+	p.Spec().Talents[99] = &LearnedTalent{Talent: &dbc.Talent{ID: 99, Tab: 41, SpellRank: [dbc.MaxTalentRanks]uint32{1, 2}}, Rank: 1}
+	assert.True(t, w.LearnTalent(p, testIgnite, 0))
+	assert.False(t, w.LearnTalent(p, testPyroblast, 0), "needs both ranks of ignite")
+	assert.True(t, w.LearnTalent(p, testIgnite, 1))
+	assert.Equal(t, uint32(0), p.UnspentTalentPoints())
+	assert.False(t, w.LearnTalent(p, testPyroblast, 0), "no points left")
+}
+
+func TestResetTalents(t *testing.T) {
+	w := New(nil)
+	p, _ := newTestPlayer(1, 0, 0)
+	assert.NoError(t, w.AddPlayer(p))
+	assert.NoError(t, p.SetLevel(20))
+
+	assert.False(t, w.ResetTalents(p), "nothing to reset")
+
+	assert.True(t, w.LearnTalent(p, testImprovedFireball, 2))
+	assert.False(t, w.ResetTalents(p), "can't afford it")
+
+	p.AddMoney(10000)
+	assert.True(t, w.ResetTalents(p))
+	assert.Equal(t, uint32(0), p.Money())
+	assert.Empty(t, p.Spec().Talents)
+	assert.False(t, p.KnowsSpell(12339))
+	assert.Equal(t, TalentPointsForLevel(20), p.UnspentTalentPoints())
+	assert.Equal(t, uint32(5*10000), p.TalentResetCost(time.Now()))
+}
+
+func TestTalentResetCost(t *testing.T) {
+	p, _ := newTestPlayer(1, 0, 0)
+	now := time.Now()
+	char := p.Character
+
+	char.TalentResetCost = 10 * 10000
+	char.TalentResetAt = sql.NullTime{Time: now, Valid: true}
+	assert.Equal(t, uint32(15*10000), p.TalentResetCost(now))
+
+	char.TalentResetCost = maxTalentResetCost
+	assert.Equal(t, uint32(maxTalentResetCost), p.TalentResetCost(now))
+
+	char.TalentResetAt.Time = now.Add(-2 * talentResetDecay)
+	assert.Equal(t, uint32(40*10000), p.TalentResetCost(now))
+
+	char.TalentResetAt.Time = now.Add(-20 * talentResetDecay)
+	assert.Equal(t, uint32(10*10000), p.TalentResetCost(now))
+}
+
+func TestActivateSpec(t *testing.T) {
+	w := New(nil)
+	p, _ := newTestPlayer(1, 0, 0)
+	assert.NoError(t, w.AddPlayer(p))
+	assert.NoError(t, p.SetLevel(20))
+
+	w.ActivateSpec(p, 1)
+	assert.Equal(t, uint8(0), p.ActiveSpec(), "only has one spec")
+
+	w.setSpecCount(p, 2)
+	assert.Len(t, p.Specs(), 2)
+	assert.True(t, p.KnowsSpell(SpellActivateSecondarySpec))
+
+	assert.True(t, w.LearnTalent(p, testImprovedFireball, 0))
+	p.Spec().Glyphs[0] = 42
+	p.Spec().Actions[0] = 133
+
+	w.ActivateSpec(p, 1)
+	assert.Equal(t, uint8(1), p.ActiveSpec())
+	assert.False(t, p.KnowsSpell(11069))
+	assert.Equal(t, TalentPointsForLevel(20), p.UnspentTalentPoints())
+	assert.Equal(t, uint32(0), p.Values.Glyphs()[0])
+
+	w.ActivateSpec(p, 0)
+	assert.True(t, p.KnowsSpell(11069))
+	assert.Equal(t, uint32(42), p.Values.Glyphs()[0])
+	assert.Equal(t, uint32(133), p.Spec().Actions[0])
+
+	w.setSpecCount(p, 1)
+	assert.Len(t, p.Specs(), 1)
+	assert.False(t, p.KnowsSpell(SpellActivatePrimarySpec))
+}
+
+func TestApplyGlyph(t *testing.T) {
+	w := New(nil)
+	p, conn := newTestPlayer(1, 0, 0)
+	assert.NoError(t, w.AddPlayer(p))
+	assert.NoError(t, p.SetLevel(20))
+
+	major := &dbc.GlyphProperties{ID: 311, Spell: 56368}
+	minor := &dbc.GlyphProperties{ID: 445, Spell: 57924, Flags: dbc.GlyphFlagMinor}
+	item := newTestItem(p, testBread, InventorySlotBag0, BackpackSlotStart, 1)
+
+	inscribe := func(glyph *dbc.GlyphProperties, slot uint8) SpellCastResult {
+		spell := &dbc.Spell{ID: 1000 + glyph.ID, Effects: [dbc.NumSpellEffects]dbc.SpellEffect{
+			{Effect: SpellEffectApplyGlyph, MiscValue: int32(glyph.ID)}}}
+		return w.CastSpell(p, &Cast{Spell: spell, Item: item, Glyph: glyph, GlyphSlot: slot})
+	}
+
+	assert.Equal(t, SpellCastResultGlyphLocked, inscribe(major, 2), "unlocked at level 50")
+	assert.Equal(t, SpellCastResultGlyphLocked, castFailed(conn))
+	assert.Equal(t, SpellCastResultInvalidGlyph, inscribe(minor, 0))
+
+	assert.Equal(t, SpellCastResultOK, inscribe(major, 0))
+	assert.Equal(t, uint32(311), p.Spec().Glyphs[0])
+	assert.Equal(t, uint32(311), p.Values.Glyphs()[0])
+
+	assert.NoError(t, p.SetLevel(50))
+	assert.Equal(t, SpellCastResultUniqueGlyph, inscribe(major, 2))
+
+	assert.Equal(t, SpellCastResultOK, inscribe(minor, 1))
+	assert.Equal(t, [MaxGlyphSlots]uint32{311, 445}, p.Spec().Glyphs)
+}
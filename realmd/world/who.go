@@ -0,0 +1,158 @@
+package world
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/kangaroux/gomaggus/model"
+	"github.com/kangaroux/gomaggus/realmd/dbc"
+)
+
+const (
+	// MaxWhoResults is the most players the client shows in the who list.
+	MaxWhoResults = 50
+
+	// The client never sends more zones or strings than this.
+	MaxWhoZones   = 10
+	MaxWhoStrings = 4
+)
+
+// WhoQuery is a search for online players. Empty fields match everyone.
+type WhoQuery struct {
+	MinLevel uint32
+	MaxLevel uint32
+
+	// Name and Guild match if they are part of the player's name or guild name.
+	Name  string
+	Guild string
+
+	// The masks have a bit set for each race or class that matches, starting with bit 0 for race/class 1.
+	RaceMask  uint32
+	ClassMask uint32
+
+	Zones []uint32
+
+	// Strings are words that the player typed in the search box. A player matches if any of the words
+	// are part of their name, guild name or zone name.
+	Strings []string
+}
+
+// WhoEntry is an online player that matched a who search.
+type WhoEntry struct {
+	Name   string
+	Guild  string
+	Level  uint32
+	Class  model.Class
+	Race   model.Race
+	Gender model.Gender
+	Zone   uint32
+}
+
+// Who searches the online players that searcher can see. Players can only see players on their own team,
+// and players in GM mode are hidden from accounts with a lower GM level. Returns up to MaxWhoResults
+// entries sorted by name, and the total number of players that matched.
+func (w *World) Who(searcher *Player, q *WhoQuery, data *dbc.Store) ([]WhoEntry, int) {
+	var result []WhoEntry
+
+	for _, p := range w.players {
+		if !searcher.canSeeInWho(p) {
+			continue
+		}
+
+		entry := WhoEntry{
+			Name:   p.Character.Name,
+			Level:  p.Values.Level(),
+			Class:  p.Character.Class,
+			Race:   p.Character.Race,
+			Gender: p.Character.Gender,
+			Zone:   p.Zone,
+		}
+		if p.guild != nil {
+			entry.Guild = p.guild.Name
+		}
+
+		zoneName := ""
+		if area, ok := data.Areas[p.Zone]; ok {
+			zoneName = area.Name
+		}
+
+		if q.matches(&entry, zoneName) {
+			result = append(result, entry)
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Name < result[j].Name
+	})
+
+	total := len(result)
+	if total > MaxWhoResults {
+		result = result[:MaxWhoResults]
+	}
+
+	return result, total
+}
+
+// canSeeInWho reports whether other shows up in the player's who searches.
+func (p *Player) canSeeInWho(other *Player) bool {
+	if p.GMLevel() > model.GMLevelPlayer {
+		return true
+	}
+
+	if other.Values.GM() && other.GMLevel() > p.GMLevel() {
+		return false
+	}
+
+	return other.Horde == p.Horde
+}
+
+func (q *WhoQuery) matches(e *WhoEntry, zoneName string) bool {
+	if e.Level < q.MinLevel || (q.MaxLevel != 0 && e.Level > q.MaxLevel) {
+		return false
+	}
+
+	if q.RaceMask != 0 && q.RaceMask&(1<<(e.Race-1)) == 0 {
+		return false
+	}
+
+	if q.ClassMask != 0 && q.ClassMask&(1<<(e.Class-1)) == 0 {
+		return false
+	}
+
+	if q.Name != "" && !containsFold(e.Name, q.Name) {
+		return false
+	}
+
+	if q.Guild != "" && !containsFold(e.Guild, q.Guild) {
+		return false
+	}
+
+	if len(q.Zones) > 0 {
+		found := false
+		for _, zone := range q.Zones {
+			if zone == e.Zone {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	if len(q.Strings) > 0 {
+		for _, s := range q.Strings {
+			if containsFold(e.Name, s) || containsFold(e.Guild, s) || containsFold(zoneName, s) {
+				return true
+			}
+		}
+		return false
+	}
+
+	return true
+}
+
+// containsFold reports whether substr is within s, ignoring case.
+func containsFold(s, substr string) bool {
+	return strings.Contains(strings.ToLower(s), strings.ToLower(substr))
+}
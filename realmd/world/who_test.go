@@ -0,0 +1,87 @@
+package world
+
+import (
+	"testing"
+
+	"github.com/kangaroux/gomaggus/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWho(t *testing.T) {
+	w := New(nil)
+
+	alice, _ := newTestPlayer(1, 0, 0)
+	bob, _ := newTestPlayer(2, 0, 0)
+	carol, _ := newTestPlayer(3, 0, 0)
+	orc, _ := newTestPlayer(4, 0, 0)
+
+	alice.Character.Name = "Alice"
+	bob.Character.Name = "Bob"
+	carol.Character.Name = "Carol"
+	orc.Character.Name = "Grunt"
+	orc.Horde = true
+
+	bob.Zone = 12
+	carol.Zone = 1519
+	assert.NoError(t, bob.SetLevel(10))
+
+	for _, p := range []*Player{alice, bob, carol, orc} {
+		assert.NoError(t, w.AddPlayer(p))
+	}
+
+	names := func(entries []WhoEntry) []string {
+		var result []string
+		for _, e := range entries {
+			result = append(result, e.Name)
+		}
+		return result
+	}
+
+	tests := []struct {
+		name     string
+		query    WhoQuery
+		expected []string
+	}{
+		{"everyone", WhoQuery{}, []string{"Alice", "Bob", "Carol"}},
+		{"name", WhoQuery{Name: "ca"}, []string{"Carol"}},
+		{"level", WhoQuery{MinLevel: 5, MaxLevel: 80}, []string{"Bob"}},
+		{"race", WhoQuery{RaceMask: 1 << (model.RaceOrc - 1)}, nil},
+		{"class", WhoQuery{ClassMask: 1 << (model.ClassMage - 1)}, []string{"Alice", "Bob", "Carol"}},
+		{"zone", WhoQuery{Zones: []uint32{12, 1519}}, []string{"Bob", "Carol"}},
+		{"zone name", WhoQuery{Strings: []string{"stormwind"}}, []string{"Carol"}},
+		{"strings", WhoQuery{Strings: []string{"nobody", "bo"}}, []string{"Bob"}},
+		{"guild", WhoQuery{Guild: "guild"}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entries, total := w.Who(alice, &tt.query, testData)
+			assert.Equal(t, tt.expected, names(entries))
+			assert.Equal(t, len(tt.expected), total)
+		})
+	}
+
+	t.Run("gm mode", func(t *testing.T) {
+		carol.Client.Account = &model.Account{GMLevel: model.GMLevelGameMaster}
+		carol.Values.SetGM(true)
+		defer carol.Values.SetGM(false)
+
+		entries, _ := w.Who(alice, &WhoQuery{}, testData)
+		assert.Equal(t, []string{"Alice", "Bob"}, names(entries))
+
+		entries, _ = w.Who(carol, &WhoQuery{}, testData)
+		assert.Equal(t, []string{"Alice", "Bob", "Carol", "Grunt"}, names(entries), "GMs see everyone")
+	})
+
+	t.Run("limit", func(t *testing.T) {
+		w := New(nil)
+		for i := 0; i < MaxWhoResults+5; i++ {
+			p, _ := newTestPlayer(uint32(100+i), 0, 0)
+			assert.NoError(t, w.AddPlayer(p))
+		}
+
+		entries, total := w.Who(alice, &WhoQuery{}, testData)
+		assert.Len(t, entries, MaxWhoResults)
+		assert.Equal(t, MaxWhoResults+5, total)
+	})
+}
@@ -0,0 +1,431 @@
+package world
+
+import (
+	"database/sql"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/kangaroux/gomaggus/model"
+	"github.com/kangaroux/gomaggus/realmd"
+	"github.com/kangaroux/gomaggus/realmd/values"
+	"github.com/phuslu/log"
+)
+
+const (
+	// tickInterval is how often the world is updated.
+	tickInterval = 100 * time.Millisecond
+
+	// saveInterval is how often players are saved while they are in the world.
+	saveInterval = 5 * time.Minute
+)
+
+// World contains all the maps and the players that are logged in. The world must be locked before
+// accessing anything inside of it, including the players and their values.
+type World struct {
+	sync.Mutex
+
+	maps    map[uint32]*Map
+	players map[realmd.Guid]*Player
+
+	channels map[channelKey]*Channel
+
+	// groups maps each group member's guid to their group, including members that are offline.
+	groups      map[realmd.Guid]*Group
+	nextGroupID uint32
+
+	// guilds are the guilds that have been loaded, by id.
+	guilds map[uint32]*Guild
+
+	// nextCorpseID is the id of the last corpse that was created.
+	nextCorpseID uint32
+
+	// characters is used to save players. Players aren't saved if it's nil.
+	characters model.CharacterService
+
+	// saveData saves everything about a player that isn't in their character, like their spells and skills.
+	// It's set with SetDataSaver and may be nil.
+	saveData func(*Player) error
+
+	// sinceSave is how long it's been since the players were last saved.
+	sinceSave time.Duration
+
+	// sinceGroupStats is how long it's been since group members were sent each other's stats.
+	sinceGroupStats time.Duration
+}
+
+func New(characters model.CharacterService) *World {
+	return &World{
+		maps:       make(map[uint32]*Map),
+		players:    make(map[realmd.Guid]*Player),
+		channels:   make(map[channelKey]*Channel),
+		groups:     make(map[realmd.Guid]*Group),
+		guilds:     make(map[uint32]*Guild),
+		characters: characters,
+	}
+}
+
+// Run updates the world every tick. Run blocks forever and should be called in its own goroutine.
+func (w *World) Run() {
+	ticker := time.NewTicker(tickInterval)
+	defer ticker.Stop()
+
+	last := time.Now()
+
+	for now := range ticker.C {
+		w.Lock()
+		w.update(now.Sub(last))
+		w.Unlock()
+
+		last = now
+	}
+}
+
+func (w *World) update(diff time.Duration) {
+	for _, p := range w.players {
+		if p.cast != nil {
+			w.updateCast(p, diff)
+		}
+		if len(p.auras) > 0 {
+			w.updateAuras(p, diff)
+		}
+		w.updateRest(p, diff)
+		w.updateCombat(p, diff)
+	}
+
+	for _, p := range w.players {
+		w.flushValues(p)
+		p.flushItems()
+	}
+
+	w.sinceGroupStats += diff
+	if w.sinceGroupStats >= groupStatsInterval {
+		w.sinceGroupStats = 0
+		w.updateGroupStats()
+	}
+
+	w.sinceSave += diff
+	if w.sinceSave >= saveInterval {
+		w.sinceSave = 0
+		for _, p := range w.players {
+			w.SavePlayerData(p)
+		}
+	}
+}
+
+// SavePlayer saves the player's character to the database.
+func (w *World) SavePlayer(p *Player) {
+	if w.characters == nil {
+		return
+	}
+
+	p.SyncCharacter()
+
+	if _, err := w.characters.Update(p.Character); err != nil {
+		p.Client.Log.Error().Err(err).Str("char", p.Character.String()).Msg("error saving character")
+	}
+}
+
+// SetDataSaver sets the function that saves everything about a player that isn't in their character, like
+// their spells and skills.
+func (w *World) SetDataSaver(save func(*Player) error) {
+	w.saveData = save
+}
+
+// SavePlayerData saves the player's character and everything else about them. Players are saved this way
+// periodically and when they leave the world, so a crash loses as little as possible.
+func (w *World) SavePlayerData(p *Player) {
+	w.SavePlayer(p)
+
+	if w.saveData == nil {
+		return
+	}
+
+	if err := w.saveData(p); err != nil {
+		p.Client.Log.Error().Err(err).Str("char", p.Character.String()).Msg("error saving player data")
+	}
+}
+
+// Map returns the map with the given id. The map is created if it doesn't exist yet.
+func (w *World) Map(id uint32) *Map {
+	m, ok := w.maps[id]
+	if !ok {
+		m = newMap(id)
+		w.maps[id] = m
+	}
+	return m
+}
+
+// Player returns the logged in player with the given guid, or nil if they aren't in the world.
+func (w *World) Player(guid realmd.Guid) *Player {
+	return w.players[guid]
+}
+
+// PlayerFor returns the player the client is logged in as, or nil if the client isn't in the world.
+func (w *World) PlayerFor(client *realmd.Client) *Player {
+	if client.Character == nil {
+		return nil
+	}
+
+	p := w.players[realmd.Guid(client.Character.Id)]
+	if p == nil || p.Client != client {
+		return nil
+	}
+
+	return p
+}
+
+// Players returns all the players in the world.
+func (w *World) Players() []*Player {
+	result := make([]*Player, 0, len(w.players))
+	for _, p := range w.players {
+		result = append(result, p)
+	}
+	return result
+}
+
+// ErrPlayerInWorld is returned when adding a player whose character is already in the world.
+var ErrPlayerInWorld = errors.New("character is already in the world")
+
+// AddPlayer adds p to the world. The player is spawned for themselves and any nearby players. If the
+// player was in a group before they went offline, they are put back in it, and if they logged out as a
+// ghost their corpse is put back too.
+func (w *World) AddPlayer(p *Player) error {
+	// A character can only be in the world once
+	if other := w.players[p.GUID()]; other != nil && other != p {
+		return ErrPlayerInWorld
+	}
+
+	w.players[p.GUID()] = p
+	w.notifyFriends(p, true)
+	w.restoreCorpse(p)
+
+	if err := w.spawn(p); err != nil {
+		return err
+	}
+
+	w.reconnectGroupMember(p)
+
+	return nil
+}
+
+// spawn adds p to their map and spawns them for themselves and any nearby players.
+func (w *World) spawn(p *Player) error {
+	w.Map(p.MapID).Add(p)
+
+	// The player's items are created first since the player's values refer to them
+	u := values.UpdateBuilder{}
+	p.writeItems(&u)
+	p.WriteCreate(&u, p)
+	p.visible[p.GUID()] = p
+
+	if err := p.SendUpdate(&u); err != nil {
+		return err
+	}
+	p.sendAuras(p)
+
+	w.UpdateVisibility(p)
+
+	return nil
+}
+
+// KickPlayer removes p from the world and closes their connection. Closing the connection stops the client's
+// read loop, which cleans up the rest of the client.
+func (w *World) KickPlayer(p *Player) {
+	w.RemovePlayer(p)
+	p.Client.Conn.Close()
+}
+
+// RemovePlayer saves everything about p and removes them from the world and any channels they are in. They are despawned
+// for any players that could see them. The player stays in their group while they are offline, and their
+// guild is told they signed off.
+func (w *World) RemovePlayer(p *Player) {
+	if w.players[p.GUID()] != p {
+		return
+	}
+
+	p.Character.LogoutAt = sql.NullTime{Time: time.Now(), Valid: true}
+	w.SavePlayerData(p)
+	w.detach(p)
+}
+
+// DetachPlayer removes p from the world like RemovePlayer, but doesn't save them. Nothing in the world refers
+// to p afterwards, so they can be saved with SavePlayerData without holding the world lock. Returns false if
+// p wasn't in the world.
+func (w *World) DetachPlayer(p *Player) bool {
+	if w.players[p.GUID()] != p {
+		return false
+	}
+
+	p.Character.LogoutAt = sql.NullTime{Time: time.Now(), Valid: true}
+	p.SyncCharacter()
+	w.detach(p)
+
+	return true
+}
+
+// detach removes p from the world once they've been saved.
+func (w *World) detach(p *Player) {
+	w.InterruptCast(p, SpellCastResultInterrupted)
+	w.StopAttack(p)
+	w.removeCorpse(p)
+	w.leaveAllChannels(p)
+	w.notifyFriends(p, false)
+	w.disconnectGroupMember(p)
+	w.guildSignOff(p)
+
+	for _, other := range p.VisiblePlayers() {
+		if other != p {
+			w.forget(other, p)
+		}
+	}
+
+	w.Map(p.MapID).Remove(p)
+	delete(w.players, p.GUID())
+	p.visible = make(map[realmd.Guid]Object)
+}
+
+// UpdateVisibility spawns and despawns objects for p based on what is in range of p. If any of the
+// objects are players, p is also spawned or despawned for those players.
+func (w *World) UpdateVisibility(p *Player) {
+	// Any pending value changes need to be sent before creating p for other players, otherwise the
+	// players who already see p would miss the changes
+	w.flushValues(p)
+
+	inRange := make(map[realmd.Guid]Object)
+	for _, obj := range w.Map(p.MapID).ObjectsInRange(p.Position(), visibilityDistance) {
+		inRange[obj.GUID()] = obj
+	}
+
+	u := values.UpdateBuilder{}
+	var outOfRange []realmd.Guid
+
+	for guid, obj := range p.visible {
+		if _, ok := inRange[guid]; ok || obj == Object(p) {
+			continue
+		}
+
+		delete(p.visible, guid)
+		outOfRange = append(outOfRange, guid)
+
+		if other, ok := obj.(*Player); ok {
+			w.forget(other, p)
+		}
+	}
+
+	u.OutOfRange(outOfRange...)
+
+	for guid, obj := range inRange {
+		if obj == Object(p) {
+			continue
+		}
+
+		if _, ok := p.visible[guid]; !ok {
+			w.flushValues(obj)
+			obj.WriteCreate(&u, p)
+			p.visible[guid] = obj
+		}
+
+		if other, ok := obj.(*Player); ok && !other.CanSee(p) {
+			w.see(other, p)
+		}
+	}
+
+	if err := p.SendUpdate(&u); err != nil {
+		p.Client.Log.Error().Err(err).Msg("error sending visibility update")
+	}
+}
+
+// addObject puts obj on a map and spawns it for the players close enough to see it.
+func (w *World) addObject(mapID uint32, obj Object) {
+	m := w.Map(mapID)
+	m.Add(obj)
+
+	for _, p := range m.PlayersInRange(obj.Position(), visibilityDistance) {
+		w.see(p, obj)
+	}
+}
+
+// removeObject takes obj off of a map and despawns it for the players that can see it.
+func (w *World) removeObject(mapID uint32, obj Object) {
+	for _, p := range w.Observers(obj) {
+		w.forget(p, obj)
+	}
+
+	w.Map(mapID).Remove(obj)
+}
+
+// see spawns obj for p.
+func (w *World) see(p *Player, obj Object) {
+	u := values.UpdateBuilder{}
+	obj.WriteCreate(&u, p)
+	p.visible[obj.GUID()] = obj
+
+	if err := p.SendUpdate(&u); err != nil {
+		p.Client.Log.Error().Err(err).Msg("error sending create object")
+	}
+
+	if other, ok := obj.(*Player); ok {
+		other.sendAuras(p)
+	}
+}
+
+// forget despawns obj for p.
+func (w *World) forget(p *Player, obj Object) {
+	if !p.CanSee(obj) {
+		return
+	}
+
+	u := values.UpdateBuilder{}
+	u.OutOfRange(obj.GUID())
+	delete(p.visible, obj.GUID())
+
+	if err := p.SendUpdate(&u); err != nil {
+		p.Client.Log.Error().Err(err).Msg("error sending out of range objects")
+	}
+}
+
+// Observers returns the players that can see obj. If obj is a player, they are included in the result.
+func (w *World) Observers(obj Object) []*Player {
+	var result []*Player
+
+	if p, ok := obj.(*Player); ok {
+		return p.VisiblePlayers()
+	}
+
+	for _, p := range w.players {
+		if p.CanSee(obj) {
+			result = append(result, p)
+		}
+	}
+
+	return result
+}
+
+// flushValues sends any of obj's changed values to the players that can see it.
+func (w *World) flushValues(obj Object) {
+	u := values.UpdateBuilder{}
+	if !obj.WriteValues(&u) {
+		return
+	}
+
+	for _, p := range w.Observers(obj) {
+		if err := p.SendUpdate(&u); err != nil {
+			log.Error().Err(err).Int64("cid", p.Client.ID).Msg("error sending values update")
+		}
+	}
+}
+
+// Broadcast sends a packet to every player that can see obj. If obj is a player, they will also
+// receive the packet unless excludeSelf is true.
+func (w *World) Broadcast(obj Object, excludeSelf bool, opcode realmd.ServerOpcode, data []byte) {
+	for _, p := range w.Observers(obj) {
+		if excludeSelf && Object(p) == obj {
+			continue
+		}
+
+		if err := p.Client.SendPacketBytes(opcode, data); err != nil {
+			p.Client.Log.Error().Err(err).Str("op", opcode.String()).Msg("error sending broadcast")
+		}
+	}
+}
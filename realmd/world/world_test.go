@@ -0,0 +1,158 @@
+package world
+
+import (
+	"net"
+	"testing"
+
+	"github.com/kangaroux/gomaggus/model"
+	"github.com/kangaroux/gomaggus/realmd"
+	"github.com/kangaroux/gomaggus/realmd/dbc"
+	"github.com/phuslu/log"
+	"github.com/stretchr/testify/assert"
+)
+
+// testConn is a connection which records the number of writes and the last write.
+type testConn struct {
+	net.Conn
+	writes int
+	last   []byte
+	sent   [][]byte
+}
+
+func (c *testConn) Close() error {
+	return nil
+}
+
+func (c *testConn) Write(p []byte) (int, error) {
+	c.writes++
+	c.last = p
+	c.sent = append(c.sent, p)
+	return len(p), nil
+}
+
+// testData is the client data from the DBC test fixtures.
+var testData = mustLoadTestData()
+
+func mustLoadTestData() *dbc.Store {
+	data, err := dbc.Load("../dbc/testdata")
+	if err != nil {
+		panic(err)
+	}
+	return data
+}
+
+func newTestPlayer(id uint32, x, y float32) (*Player, *testConn) {
+	conn := &testConn{}
+	client, _ := realmd.NewClient(conn)
+	client.Log = &log.Logger{Writer: log.WriterFunc(func(e *log.Entry) (int, error) { return 0, nil })}
+	client.Character = &model.Character{Id: id, Race: model.RaceHuman, Class: model.ClassMage}
+
+	p, err := NewPlayer(client, client.Character, testData)
+	if err != nil {
+		panic(err)
+	}
+	p.Movement.Position.X = x
+	p.Movement.Position.Y = y
+
+	return p, conn
+}
+
+func TestWorldVisibility(t *testing.T) {
+	w := New(nil)
+	a, aConn := newTestPlayer(1, 0, 0)
+	b, bConn := newTestPlayer(2, 10, 10)
+	c, _ := newTestPlayer(3, 1000, 1000)
+
+	assert.NoError(t, w.AddPlayer(a))
+	assert.NoError(t, w.AddPlayer(b))
+	assert.NoError(t, w.AddPlayer(c))
+
+	t.Run("nearby players see each other", func(t *testing.T) {
+		assert.True(t, a.CanSee(b))
+		assert.True(t, b.CanSee(a))
+		assert.Greater(t, aConn.writes, 1)
+		assert.Greater(t, bConn.writes, 0)
+	})
+
+	t.Run("far players are not visible", func(t *testing.T) {
+		assert.False(t, a.CanSee(c))
+		assert.False(t, c.CanSee(a))
+	})
+
+	t.Run("moving out of range", func(t *testing.T) {
+		b.Movement.Position.X = 500
+		w.Map(b.MapID).Relocate(b)
+		w.UpdateVisibility(b)
+
+		assert.False(t, a.CanSee(b))
+		assert.False(t, b.CanSee(a))
+	})
+
+	t.Run("moving into range", func(t *testing.T) {
+		b.Movement.Position.X = 950
+		b.Movement.Position.Y = 1000
+		w.Map(b.MapID).Relocate(b)
+		w.UpdateVisibility(b)
+
+		assert.True(t, b.CanSee(c))
+		assert.True(t, c.CanSee(b))
+	})
+
+	t.Run("removed players are despawned", func(t *testing.T) {
+		w.RemovePlayer(c)
+
+		assert.False(t, b.CanSee(c))
+		assert.Nil(t, w.Player(c.GUID()))
+		assert.Equal(t, 2, w.Map(0).Len())
+	})
+}
+
+func TestSavePlayerData(t *testing.T) {
+	w := New(nil)
+	p, _ := newTestPlayer(1, 0, 0)
+	assert.NoError(t, w.AddPlayer(p))
+
+	var saved []*Player
+	w.SetDataSaver(func(p *Player) error {
+		saved = append(saved, p)
+		return nil
+	})
+
+	w.update(saveInterval)
+	assert.Equal(t, []*Player{p}, saved, "autosave")
+
+	w.RemovePlayer(p)
+	assert.Len(t, saved, 2, "leaving the world")
+}
+
+func TestAddPlayerTwice(t *testing.T) {
+	w := New(nil)
+	p, _ := newTestPlayer(1, 0, 0)
+	assert.NoError(t, w.AddPlayer(p))
+
+	again, _ := newTestPlayer(1, 0, 0)
+	assert.Equal(t, ErrPlayerInWorld, w.AddPlayer(again))
+	assert.Equal(t, p, w.Player(p.GUID()))
+}
+
+func TestDetachPlayer(t *testing.T) {
+	w := New(nil)
+	p, _ := newTestPlayer(1, 0, 0)
+	assert.NoError(t, w.AddPlayer(p))
+
+	var saved []*Player
+	w.SetDataSaver(func(p *Player) error {
+		saved = append(saved, p)
+		return nil
+	})
+
+	assert.True(t, w.DetachPlayer(p))
+	assert.Empty(t, saved, "the caller saves the player")
+	assert.True(t, p.Character.LogoutAt.Valid)
+	assert.Nil(t, w.Player(p.GUID()))
+	assert.Zero(t, w.Map(0).Len())
+	assert.False(t, w.DetachPlayer(p))
+
+	again, _ := newTestPlayer(1, 0, 0)
+	assert.NoError(t, w.AddPlayer(again))
+}